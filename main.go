@@ -4,11 +4,13 @@ import (
 	"fmt"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/joho/godotenv"
-	"gitlab.com/open-soft/go-crypto-bot/src/client"
 	"gitlab.com/open-soft/go-crypto-bot/src/config"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
@@ -21,17 +23,21 @@ func main() {
 		}
 	}
 
+	logger.Init(os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
 	container := config.InitServiceContainer()
 	container.PingDB()
 
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
 	defer container.Db.Close()
 	defer container.DbSwap.Close()
-	container.PythonMLBridge.Initialize()
-	defer container.PythonMLBridge.Finalize()
 	container.StartHttpServer()
 	log.Printf("Bot [%s] is initialized successfully", container.CurrentBot.BotUuid)
 
-	usdtBalance, err := container.BalanceService.GetAssetBalance("USDT", false)
+	reportingCurrency := container.CurrentBot.GetReportingCurrency()
+	balance, err := container.BalanceService.GetAssetBalance(reportingCurrency, false)
 	if err != nil {
 		log.Printf("Balance check error: %s", err.Error())
 
@@ -48,17 +54,28 @@ func main() {
 
 		os.Exit(0)
 	}
-	log.Printf("API Key permission check passed, balance is: %.2f", usdtBalance)
-	container.PythonMLBridge.StartAutoLearn()
+	log.Printf("API Key permission check passed, balance is: %.2f %s", balance, reportingCurrency)
+	for _, quoteAsset := range container.ExchangeRepository.GetQuoteAssets() {
+		if quoteAsset == reportingCurrency {
+			continue
+		}
 
-	if binance, ok := container.Binance.(*client.Binance); ok {
-		binance.APIKeyCheckCompleted = true
+		quoteBalance, err := container.BalanceService.GetAssetBalance(quoteAsset, false)
+		if err == nil {
+			log.Printf("Quote asset balance is: %.2f %s", quoteBalance, quoteAsset)
+		}
 	}
-	if binance, ok := container.Binance.(*client.ByBit); ok {
-		binance.APIKeyCheckCompleted = true
+	container.MLService.StartAutoLearn()
+
+	container.Binance.SetAPIKeyCheckCompleted(true)
+
+	if container.UserDataStream != nil {
+		go container.UserDataStream.StartListening()
 	}
 
 	container.MakerService.RecoverOrders()
+	go container.SwapRecovery.StartWatching()
+	go container.DailySummary.StartWatching()
 
 	if container.IsMasterBot {
 		container.MakerService.UpdateSwapPairs()
@@ -73,5 +90,9 @@ func main() {
 
 	container.TimeService.WaitSeconds(10)
 	container.MakerService.StartTrade()
-	container.MarketTradeListener.ListenAll()
+	go container.MarketTradeListener.ListenAll()
+
+	received := <-signals
+	log.Printf("Signal %s is received", received)
+	container.Shutdown()
 }
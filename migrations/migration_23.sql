@@ -0,0 +1,2 @@
+ALTER TABLE trade_limit ADD COLUMN strategies JSON;
+UPDATE trade_limit SET strategies = json_array() WHERE id > 0;
@@ -0,0 +1,2 @@
+ALTER TABLE trade_limit ADD COLUMN min_strategy_agreement INT NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN explanation JSON DEFAULT NULL;
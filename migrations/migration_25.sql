@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN close_reason VARCHAR(32) DEFAULT NULL;
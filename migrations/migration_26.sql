@@ -0,0 +1,2 @@
+ALTER TABLE bots ADD COLUMN risk_config JSON;
+UPDATE bots b SET b.risk_config = CAST('{"maxExposure": 0, "maxPositions": 0, "maxAssetPercent": 0, "dailyLossLimit": 0}' as JSON) WHERE b.id > 0;
@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN protection JSON DEFAULT NULL;
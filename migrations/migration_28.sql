@@ -0,0 +1,22 @@
+ALTER TABLE trade_limit ADD COLUMN base_asset VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE trade_limit ADD COLUMN quote_asset VARCHAR(16) NOT NULL DEFAULT '';
+UPDATE trade_limit SET quote_asset = CASE
+    WHEN symbol LIKE '%FDUSD' AND CHAR_LENGTH(symbol) > 5 THEN 'FDUSD'
+    WHEN symbol LIKE '%USDT' AND CHAR_LENGTH(symbol) > 4 THEN 'USDT'
+    WHEN symbol LIKE '%USDC' AND CHAR_LENGTH(symbol) > 4 THEN 'USDC'
+    WHEN symbol LIKE '%TUSD' AND CHAR_LENGTH(symbol) > 4 THEN 'TUSD'
+    WHEN symbol LIKE '%EUR' AND CHAR_LENGTH(symbol) > 3 THEN 'EUR'
+    WHEN symbol LIKE '%TRY' AND CHAR_LENGTH(symbol) > 3 THEN 'TRY'
+    WHEN symbol LIKE '%BTC' AND CHAR_LENGTH(symbol) > 3 THEN 'BTC'
+    WHEN symbol LIKE '%ETH' AND CHAR_LENGTH(symbol) > 3 THEN 'ETH'
+    WHEN symbol LIKE '%BNB' AND CHAR_LENGTH(symbol) > 3 THEN 'BNB'
+    WHEN symbol LIKE '%DAI' AND CHAR_LENGTH(symbol) > 3 THEN 'DAI'
+    WHEN symbol LIKE '%OKB' AND CHAR_LENGTH(symbol) > 3 THEN 'OKB'
+    ELSE 'USDT'
+END WHERE id > 0;
+UPDATE trade_limit SET base_asset = IF(
+    symbol LIKE CONCAT('%', quote_asset),
+    LEFT(symbol, CHAR_LENGTH(symbol) - CHAR_LENGTH(quote_asset)),
+    symbol
+) WHERE id > 0;
+ALTER TABLE bots ADD COLUMN reporting_currency VARCHAR(16) NOT NULL DEFAULT 'USDT';
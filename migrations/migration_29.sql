@@ -0,0 +1,19 @@
+create table `ml_models`
+(
+    id               int auto_increment primary key,
+    bot_id           int unsigned not null,
+    symbol           VARCHAR(20)  not null,
+    model_type       VARCHAR(32)  not null,
+    format_version   int          not null,
+    features         JSON         not null,
+    metrics          JSON         not null,
+    dataset_from     bigint       not null,
+    dataset_to       bigint       not null,
+    dataset_length   int          not null,
+    is_active        tinyint(1)   not null default 0,
+    promotion_reason VARCHAR(255) not null default '',
+    payload          LONGTEXT     not null,
+    created_at       datetime     not null,
+    index ml_models_symbol_idx (bot_id, symbol, is_active),
+    constraint ml_models_bot_id_fk foreign key (bot_id) references `bots` (id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE trade_limit ADD COLUMN ml_config JSON DEFAULT NULL;
+ALTER TABLE ml_models ADD COLUMN horizon int NOT NULL DEFAULT 0;
@@ -10,9 +10,12 @@ import (
 	uuid2 "github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/redis/go-redis/v9"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/metrics"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
-	"log"
+	"log/slog"
 	"net/http"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -21,14 +24,23 @@ import (
 )
 
 type ExchangeOrderAPIInterface interface {
-	LimitOrder(symbol string, quantity float64, price float64, operation string, timeInForce string) (model.BinanceOrder, error)
-	QueryOrder(symbol string, orderId string) (model.BinanceOrder, error)
-	CancelOrder(symbol string, orderId string) (model.BinanceOrder, error)
-	GetOpenedOrders() ([]model.BinanceOrder, error)
+	LimitOrder(symbol string, quantity float64, price float64, operation string, timeInForce string) (model.ExchangeOrder, error)
+	MarketOrder(symbol string, quantity float64, operation string) (model.ExchangeOrder, error)
+	QuoteMarketOrder(symbol string, quoteQuantity float64, operation string) (model.ExchangeOrder, error)
+	PostOnlyOrder(symbol string, quantity float64, price float64, operation string) (model.ExchangeOrder, error)
+	QueryOrder(symbol string, orderId string) (model.ExchangeOrder, error)
+	CancelOrder(symbol string, orderId string) (model.ExchangeOrder, error)
+	GetOpenedOrders() ([]model.ExchangeOrder, error)
+}
+
+// ExchangeProtectionAPIInterface places take profit and stop loss SELL pair on the exchange side
+type ExchangeProtectionAPIInterface interface {
+	ProtectionOrder(symbol string, quantity float64, takeProfitPrice float64, stopPrice float64) (model.ProtectionOrder, error)
+	CancelProtectionOrder(symbol string, protection model.ProtectionOrder) error
 }
 
 type ExchangePriceAPIInterface interface {
-	GetOpenedOrders() ([]model.BinanceOrder, error)
+	GetOpenedOrders() ([]model.ExchangeOrder, error)
 	GetDepth(symbol string, limit int64) *model.OrderBook
 	GetKLines(symbol string, interval string, limit int64) []model.KLineHistory
 	GetKLinesCached(symbol string, interval string, limit int64) []model.KLine
@@ -36,20 +48,24 @@ type ExchangePriceAPIInterface interface {
 }
 
 type ExchangeAPIInterface interface {
-	QueryOrder(symbol string, orderId string) (model.BinanceOrder, error)
-	CancelOrder(symbol string, orderId string) (model.BinanceOrder, error)
+	QueryOrder(symbol string, orderId string) (model.ExchangeOrder, error)
+	CancelOrder(symbol string, orderId string) (model.ExchangeOrder, error)
 	GetDepth(symbol string, limit int64) *model.OrderBook
-	GetOpenedOrders() ([]model.BinanceOrder, error)
+	GetOpenedOrders() ([]model.ExchangeOrder, error)
 	GetKLines(symbol string, interval string, limit int64) []model.KLineHistory
 	TradesAggregate(symbol string, limit int64, startTime int64, endTime int64) []model.Trade
 	GetKLinesCached(symbol string, interval string, limit int64) []model.KLine
 	GetExchangeData(symbols []string) (*model.ExchangeInfo, error)
 	GetAccountStatus() (*model.AccountStatus, error)
-	GetTickers(symbols []string) []model.WSTickerPrice
-	LimitOrder(symbol string, quantity float64, price float64, operation string, timeInForce string) (model.BinanceOrder, error)
+	GetTickers(symbols []string) []model.ExchangeTicker
+	LimitOrder(symbol string, quantity float64, price float64, operation string, timeInForce string) (model.ExchangeOrder, error)
+	MarketOrder(symbol string, quantity float64, operation string) (model.ExchangeOrder, error)
+	QuoteMarketOrder(symbol string, quoteQuantity float64, operation string) (model.ExchangeOrder, error)
+	PostOnlyOrder(symbol string, quantity float64, price float64, operation string) (model.ExchangeOrder, error)
 	IsConnected() bool
 	IsWaitMode() bool
 	IsAPIKeyCheckCompleted() bool
+	SetAPIKeyCheckCompleted(completed bool)
 }
 
 type Binance struct {
@@ -64,6 +80,8 @@ type Binance struct {
 	RDB          *redis.Client
 	Ctx          *context.Context
 
+	RateLimitGovernor *RateLimitGovernor
+
 	WaitMode             bool
 	Connected            bool
 	APIKeyCheckCompleted bool
@@ -82,6 +100,10 @@ func (b *Binance) IsAPIKeyCheckCompleted() bool {
 	return b.APIKeyCheckCompleted
 }
 
+func (b *Binance) SetAPIKeyCheckCompleted(completed bool) {
+	b.APIKeyCheckCompleted = completed
+}
+
 func (b *Binance) IsWaitingMode() bool {
 	b.Lock.Lock()
 	isWaiting := b.WaitMode
@@ -108,7 +130,8 @@ func (b *Binance) Connect(address string) {
 	connection, _, err := websocket.DefaultDialer.Dial(address, nil)
 	if err != nil {
 		b.Connected = false
-		log.Printf("Binance WS [%s]: %s, wait and reconnect...", address, err.Error())
+		slog.Error("Connection error, wait and reconnect", logger.KeyExchange, "binance", logger.KeyStream, "api", logger.Error(err))
+		metrics.WSReconnects.Inc("binance", "api")
 		time.Sleep(time.Second * 10)
 		b.Connect(address)
 		return
@@ -124,11 +147,10 @@ func (b *Binance) Connect(address string) {
 		for {
 			_, message, err := connection.ReadMessage()
 			if err != nil {
-				log.Println("read: ", err)
-
 				_ = connection.Close()
 				b.Connected = false
-				log.Printf("Binance WS, wait and reconnect...")
+				slog.Warn("Stream is disconnected, wait and reconnect", logger.KeyExchange, "binance", logger.KeyStream, "api", logger.Error(err))
+				metrics.WSReconnects.Inc("binance", "api")
 				time.Sleep(time.Second * 10)
 				b.Connect(address)
 				return
@@ -154,6 +176,10 @@ func (b *Binance) Connect(address string) {
 func (b *Binance) socketRequest(req model.SocketRequest, channel chan []byte) {
 	b.CheckWait()
 
+	if b.RateLimitGovernor != nil {
+		b.RateLimitGovernor.Acquire(GetBinanceRequestClass(req))
+	}
+
 	go func(req model.SocketRequest) {
 		for {
 			msg := <-b.Channel
@@ -161,11 +187,12 @@ func (b *Binance) socketRequest(req model.SocketRequest, channel chan []byte) {
 			if strings.Contains(string(msg), "Too much request weight used; current limit is 6000 request weight per 1 MINUTE") {
 				b.SetWaitingMode(true)
 
-				log.Printf(
-					"[%s] Socket error [%s]: %s, wait 1 min and retry...",
-					req.Method,
-					req.Id,
-					string(msg),
+				slog.Warn(
+					"Request weight limit is reached, wait 1 minute and retry",
+					logger.KeyExchange, "binance",
+					"method", req.Method,
+					"requestId", req.Id,
+					logger.KeyError, string(msg),
 				)
 
 				time.Sleep(time.Minute)
@@ -173,13 +200,14 @@ func (b *Binance) socketRequest(req model.SocketRequest, channel chan []byte) {
 				b.SetWaitingMode(false)
 
 				b.SocketWriter <- serialized
-				log.Printf("[%s] retried...", req.Id)
+				slog.Info("Request is retried", logger.KeyExchange, "binance", "method", req.Method, "requestId", req.Id)
 
 				continue
 			}
 
 			if strings.Contains(string(msg), req.Id) {
-				//log.Printf("[%s], %s", req.Method, string(msg))
+				b.updateRateLimits(msg)
+				countApiError("binance", msg)
 				channel <- msg
 				return
 			}
@@ -200,7 +228,7 @@ func (b *Binance) socketRequest(req model.SocketRequest, channel chan []byte) {
 	b.SocketWriter <- serialized
 }
 
-func (b *Binance) QueryOrder(symbol string, orderId string) (model.BinanceOrder, error) {
+func (b *Binance) QueryOrder(symbol string, orderId string) (model.ExchangeOrder, error) {
 	b.CheckWait()
 
 	channel := make(chan []byte)
@@ -223,13 +251,13 @@ func (b *Binance) QueryOrder(symbol string, orderId string) (model.BinanceOrder,
 	json.Unmarshal(message, &response)
 
 	if response.Error != nil {
-		return model.BinanceOrder{}, errors.New(response.Error.GetMessage())
+		return model.ExchangeOrder{}, errors.New(response.Error.GetMessage())
 	}
 
-	return response.Result.ToModern(), nil
+	return response.Result.ToModern()
 }
 
-func (b *Binance) CancelOrder(symbol string, orderId string) (model.BinanceOrder, error) {
+func (b *Binance) CancelOrder(symbol string, orderId string) (model.ExchangeOrder, error) {
 	b.CheckWait()
 
 	channel := make(chan []byte)
@@ -252,10 +280,10 @@ func (b *Binance) CancelOrder(symbol string, orderId string) (model.BinanceOrder
 	json.Unmarshal(message, &response)
 
 	if response.Error != nil {
-		return model.BinanceOrder{}, errors.New(response.Error.GetMessage())
+		return model.ExchangeOrder{}, errors.New(response.Error.GetMessage())
 	}
 
-	return response.Result.ToModern(), nil
+	return response.Result.ToModern()
 }
 
 func (b *Binance) UserDataStreamStart() (model.UserDataStreamStart, error) {
@@ -283,6 +311,33 @@ func (b *Binance) UserDataStreamStart() (model.UserDataStreamStart, error) {
 	return response.Result, nil
 }
 
+// UserDataStreamPing extends listen key validity for 60 minutes
+func (b *Binance) UserDataStreamPing(listenKey string) error {
+	b.CheckWait()
+
+	channel := make(chan []byte)
+	defer close(channel)
+
+	socketRequest := model.SocketRequest{
+		Id:     uuid2.New().String(),
+		Method: "userDataStream.ping",
+		Params: make(map[string]any),
+	}
+	socketRequest.Params["apiKey"] = b.ApiKey
+	socketRequest.Params["listenKey"] = listenKey
+	b.socketRequest(socketRequest, channel)
+	message := <-channel
+
+	var response model.UserDataStreamStartResponse
+	json.Unmarshal(message, &response)
+
+	if response.Error != nil {
+		return errors.New(response.Error.GetMessage())
+	}
+
+	return nil
+}
+
 func (b *Binance) GetDepth(symbol string, limit int64) *model.OrderBook {
 	b.CheckWait()
 
@@ -309,7 +364,7 @@ func (b *Binance) GetDepth(symbol string, limit int64) *model.OrderBook {
 	return &response.Result
 }
 
-func (b *Binance) GetOpenedOrders() ([]model.BinanceOrder, error) {
+func (b *Binance) GetOpenedOrders() ([]model.ExchangeOrder, error) {
 	b.CheckWait()
 
 	channel := make(chan []byte)
@@ -330,14 +385,19 @@ func (b *Binance) GetOpenedOrders() ([]model.BinanceOrder, error) {
 	json.Unmarshal(message, &response)
 
 	if response.Error != nil {
-		log.Println(socketRequest)
-		list := make([]model.BinanceOrder, 0)
+		slog.Error("GetOpenedOrders", logger.KeyExchange, "binance", "requestId", socketRequest.Id, logger.KeyError, response.Error.GetMessage())
+		list := make([]model.ExchangeOrder, 0)
 		return list, errors.New(response.Error.GetMessage())
 	}
 
-	list := make([]model.BinanceOrder, 0)
+	list := make([]model.ExchangeOrder, 0)
 	for _, orderLegacy := range response.Result {
-		list = append(list, orderLegacy.ToModern())
+		exchangeOrder, err := orderLegacy.ToModern()
+		if err != nil {
+			slog.Error("GetOpenedOrders", logger.KeyExchange, "binance", logger.KeySymbol, orderLegacy.Symbol, logger.Error(err))
+			continue
+		}
+		list = append(list, exchangeOrder)
 	}
 
 	return list, nil
@@ -365,7 +425,7 @@ func (b *Binance) GetKLines(symbol string, interval string, limit int64) []model
 	json.Unmarshal(message, &response)
 
 	if response.Error != nil {
-		log.Println(socketRequest)
+		logger.ForSymbol("binance", symbol).Error("GetKLines", "requestId", socketRequest.Id, logger.KeyError, response.Error.GetMessage())
 		list := make([]model.KLineHistory, 0)
 		return list
 	}
@@ -400,7 +460,7 @@ func (b *Binance) TradesAggregate(symbol string, limit int64, startTime int64, e
 	json.Unmarshal(message, &response)
 
 	if response.Error != nil {
-		log.Println(socketRequest)
+		logger.ForSymbol("binance", symbol).Error("TradesAggregate", "requestId", socketRequest.Id, logger.KeyError, response.Error.GetMessage())
 		list := make([]model.Trade, 0)
 		return list
 	}
@@ -419,7 +479,7 @@ func (b *Binance) GetKLinesCached(symbol string, interval string, limit int64) [
 		if err == nil {
 			return batch.Items
 		}
-		log.Printf("[%s] kline[%s] history cache invalid", symbol, interval)
+		slog.Warn("KLine history cache is invalid", logger.KeyExchange, "binance", logger.KeySymbol, symbol, "interval", interval)
 	}
 
 	historyKLines := b.GetKLines(symbol, interval, limit)
@@ -460,11 +520,13 @@ func (b *Binance) GetExchangeData(symbols []string) (*model.ExchangeInfo, error)
 	json.Unmarshal(message, &response)
 
 	if response.Error != nil {
-		log.Println(socketRequest)
+		slog.Error("GetExchangeData", logger.KeyExchange, "binance", "requestId", socketRequest.Id, logger.KeyError, response.Error.GetMessage())
 		return &model.ExchangeInfo{}, errors.New(response.Error.GetMessage())
 	}
 
-	return &response.Result, nil
+	exchangeInfo := response.Result.ToExchangeInfo()
+
+	return &exchangeInfo, nil
 }
 
 func (b *Binance) GetAccountStatus() (*model.AccountStatus, error) {
@@ -489,7 +551,7 @@ func (b *Binance) GetAccountStatus() (*model.AccountStatus, error) {
 	json.Unmarshal(message, &response)
 
 	if response.Error != nil {
-		log.Println(socketRequest)
+		slog.Error("GetAccountStatus", logger.KeyExchange, "binance", "requestId", socketRequest.Id, logger.KeyError, response.Error.GetMessage())
 
 		return nil, errors.New(response.Error.GetMessage())
 	}
@@ -520,7 +582,7 @@ func (b *Binance) GetTrades(order model.Order) ([]model.MyTrade, error) {
 	json.Unmarshal(message, &response)
 
 	if response.Error != nil {
-		log.Println(socketRequest)
+		logger.ForSymbol("binance", order.Symbol).Error("GetTrades", "requestId", socketRequest.Id, logger.KeyError, response.Error.GetMessage())
 		list := make([]model.MyTrade, 0)
 		return list, errors.New(response.Error.GetMessage())
 	}
@@ -528,7 +590,7 @@ func (b *Binance) GetTrades(order model.Order) ([]model.MyTrade, error) {
 	return response.Result, nil
 }
 
-func (b *Binance) GetTickers(symbols []string) []model.WSTickerPrice {
+func (b *Binance) GetTickers(symbols []string) []model.ExchangeTicker {
 	b.CheckWait()
 
 	channel := make(chan []byte)
@@ -548,29 +610,19 @@ func (b *Binance) GetTickers(symbols []string) []model.WSTickerPrice {
 	json.Unmarshal(message, &response)
 
 	if response.Error != nil {
-		log.Println(response.Error)
-		list := make([]model.WSTickerPrice, 0)
+		slog.Error("GetTickers", logger.KeyExchange, "binance", logger.KeyError, response.Error.GetMessage())
+		list := make([]model.ExchangeTicker, 0)
 		return list
 	}
 
 	return response.Result
 }
 
-func (b *Binance) LimitOrder(symbol string, quantity float64, price float64, operation string, timeInForce string) (model.BinanceOrder, error) {
-	b.CheckWait()
-
-	channel := make(chan []byte)
-	defer close(channel)
-
-	socketRequest := model.SocketRequest{
-		Id:     uuid2.New().String(),
-		Method: "order.place",
-		Params: make(map[string]any),
-	}
-	socketRequest.Params["symbol"] = symbol
-	socketRequest.Params["side"] = operation
-	socketRequest.Params["type"] = "LIMIT"
-	socketRequest.Params["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+func (b *Binance) LimitOrder(symbol string, quantity float64, price float64, operation string, timeInForce string) (model.ExchangeOrder, error) {
+	params := make(map[string]any)
+	params["side"] = operation
+	params["type"] = model.OrderTypeLimit
+	params["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
 	// [FOK] - Fill or kill (FOK) is a conditional type of time-in-force order used in
 	// securities trading that instructs a brokerage to execute a
 	// transaction immediately and completely or not at all.
@@ -585,8 +637,130 @@ func (b *Binance) LimitOrder(symbol string, quantity float64, price float64, ope
 	// [GTC] - Good ’til canceled (GTC) describes a type of order that an investor may place to buy or sell
 	// a security that remains active until either the order is filled or the investor cancels it.
 	// Brokerages will typically limit the maximum time you can keep a GTC order open (active) to 90 days.
-	socketRequest.Params["timeInForce"] = timeInForce
-	socketRequest.Params["price"] = strconv.FormatFloat(price, 'f', -1, 64)
+	params["timeInForce"] = timeInForce
+	params["price"] = strconv.FormatFloat(price, 'f', -1, 64)
+
+	return b.placeOrder(symbol, params)
+}
+
+func (b *Binance) MarketOrder(symbol string, quantity float64, operation string) (model.ExchangeOrder, error) {
+	params := make(map[string]any)
+	params["side"] = operation
+	params["type"] = model.OrderTypeMarket
+	params["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+
+	return b.placeOrder(symbol, params)
+}
+
+func (b *Binance) QuoteMarketOrder(symbol string, quoteQuantity float64, operation string) (model.ExchangeOrder, error) {
+	params := make(map[string]any)
+	params["side"] = operation
+	params["type"] = model.OrderTypeMarket
+	params["quoteOrderQty"] = strconv.FormatFloat(quoteQuantity, 'f', -1, 64)
+
+	return b.placeOrder(symbol, params)
+}
+
+// PostOnlyOrder is rejected by exchange if it would immediately match and trade as a taker
+func (b *Binance) PostOnlyOrder(symbol string, quantity float64, price float64, operation string) (model.ExchangeOrder, error) {
+	params := make(map[string]any)
+	params["side"] = operation
+	params["type"] = model.OrderTypeLimitMaker
+	params["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	params["price"] = strconv.FormatFloat(price, 'f', -1, 64)
+
+	return b.placeOrder(symbol, params)
+}
+
+// ProtectionOrder places OCO order: LIMIT_MAKER take profit above the price and STOP_LOSS (market) below
+func (b *Binance) ProtectionOrder(symbol string, quantity float64, takeProfitPrice float64, stopPrice float64) (model.ProtectionOrder, error) {
+	b.CheckWait()
+
+	channel := make(chan []byte)
+	defer close(channel)
+
+	socketRequest := model.SocketRequest{
+		Id:     uuid2.New().String(),
+		Method: "orderList.place.oco",
+		Params: make(map[string]any),
+	}
+	socketRequest.Params["symbol"] = symbol
+	socketRequest.Params["side"] = "SELL"
+	socketRequest.Params["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	socketRequest.Params["aboveType"] = model.OrderTypeLimitMaker
+	socketRequest.Params["abovePrice"] = strconv.FormatFloat(takeProfitPrice, 'f', -1, 64)
+	socketRequest.Params["belowType"] = "STOP_LOSS"
+	socketRequest.Params["belowStopPrice"] = strconv.FormatFloat(stopPrice, 'f', -1, 64)
+	socketRequest.Params["apiKey"] = b.ApiKey
+	socketRequest.Params["timestamp"] = time.Now().Unix() * 1000
+	socketRequest.Params["signature"] = b.signature(socketRequest.Params)
+	b.socketRequest(socketRequest, channel)
+	message := <-channel
+
+	var response model.BinanceOcoResponse
+	json.Unmarshal(message, &response)
+
+	if response.Error != nil {
+		logger.ForSymbol("binance", symbol).Error("OCO Order", "requestId", socketRequest.Id, logger.KeyError, response.Error.GetMessage())
+
+		return model.ProtectionOrder{}, errors.New(response.Error.GetMessage())
+	}
+
+	orderIds := make([]string, 0)
+	for _, item := range response.Result.Orders {
+		orderIds = append(orderIds, strconv.FormatInt(item.OrderId, 10))
+	}
+
+	return model.ProtectionOrder{
+		OrderListId:     strconv.FormatInt(response.Result.OrderListId, 10),
+		OrderIds:        orderIds,
+		Quantity:        quantity,
+		TakeProfitPrice: takeProfitPrice,
+		StopPrice:       stopPrice,
+	}, nil
+}
+
+func (b *Binance) CancelProtectionOrder(symbol string, protection model.ProtectionOrder) error {
+	b.CheckWait()
+
+	channel := make(chan []byte)
+	defer close(channel)
+
+	socketRequest := model.SocketRequest{
+		Id:     uuid2.New().String(),
+		Method: "orderList.cancel",
+		Params: make(map[string]any),
+	}
+	socketRequest.Params["apiKey"] = b.ApiKey
+	socketRequest.Params["orderListId"] = protection.OrderListId
+	socketRequest.Params["symbol"] = symbol
+	socketRequest.Params["timestamp"] = time.Now().Unix() * 1000
+	socketRequest.Params["signature"] = b.signature(socketRequest.Params)
+	b.socketRequest(socketRequest, channel)
+	message := <-channel
+
+	var response model.BinanceOcoResponse
+	json.Unmarshal(message, &response)
+
+	if response.Error != nil {
+		return errors.New(response.Error.GetMessage())
+	}
+
+	return nil
+}
+
+func (b *Binance) placeOrder(symbol string, params map[string]any) (model.ExchangeOrder, error) {
+	b.CheckWait()
+
+	channel := make(chan []byte)
+	defer close(channel)
+
+	socketRequest := model.SocketRequest{
+		Id:     uuid2.New().String(),
+		Method: "order.place",
+		Params: params,
+	}
+	socketRequest.Params["symbol"] = symbol
 	socketRequest.Params["apiKey"] = b.ApiKey
 	socketRequest.Params["timestamp"] = time.Now().Unix() * 1000
 	socketRequest.Params["signature"] = b.signature(socketRequest.Params)
@@ -597,17 +771,18 @@ func (b *Binance) LimitOrder(symbol string, quantity float64, price float64, ope
 	json.Unmarshal(message, &response)
 
 	if response.Error != nil {
-		log.Printf("[%s] Limit Order: %s -> %s", symbol, response.Error.GetMessage(), socketRequest)
+		orderLog := logger.ForSymbol("binance", symbol)
+		orderLog.Error("Order is not placed", "side", params["side"], "type", params["type"], "quantity", params["quantity"], "price", params["price"], "requestId", socketRequest.Id, logger.KeyError, response.Error.GetMessage())
 
 		if response.Error.IsNotional() {
-			log.Printf("[%s] Sleep 1 minute", symbol)
+			orderLog.Warn("Sleep 1 minute")
 			time.Sleep(time.Minute) // wait one minute
 		}
 
-		return model.BinanceOrder{}, errors.New(response.Error.GetMessage())
+		return model.ExchangeOrder{}, errors.New(response.Error.GetMessage())
 	}
 
-	return response.Result.ToModern(), nil
+	return response.Result.ToModern()
 }
 
 func (b *Binance) signature(params map[string]any) string {
@@ -638,3 +813,66 @@ func (b *Binance) sign(url string) string {
 
 	return signingKey
 }
+
+func (b *Binance) updateRateLimits(message []byte) {
+	if b.RateLimitGovernor == nil {
+		return
+	}
+
+	var response model.BinanceRateLimitResponse
+	err := json.Unmarshal(message, &response)
+	if err == nil {
+		b.RateLimitGovernor.UpdateBinanceRateLimits(response.RateLimits)
+	}
+
+	// Way too much request weight used; IP banned until 1702275878212.
+	banned := regexp.MustCompile(`IP banned until (\d+)`).FindStringSubmatch(string(message))
+	if len(banned) == 2 {
+		bannedUntil, err := strconv.ParseInt(banned[1], 10, 64)
+		if err == nil {
+			b.RateLimitGovernor.BlockUntil(time.UnixMilli(bannedUntil))
+		}
+	}
+}
+
+// GetBinanceRequestClass weights of WS-API methods: https://developers.binance.com/docs/binance-spot-api-docs/web-socket-api
+func GetBinanceRequestClass(req model.SocketRequest) (string, int64) {
+	switch req.Method {
+	case "order.place", "order.cancel", "orderList.place.oco", "orderList.cancel":
+		return model.RateLimitClassOrder, 1
+	case "order.status":
+		return model.RateLimitClassAccount, 4
+	case "openOrders.status":
+		if _, ok := req.Params["symbol"]; ok {
+			return model.RateLimitClassAccount, 6
+		}
+
+		return model.RateLimitClassAccount, 80
+	case "account.status", "myTrades":
+		return model.RateLimitClassAccount, 20
+	case "userDataStream.start", "userDataStream.ping":
+		return model.RateLimitClassAccount, 2
+	case "depth":
+		limit, _ := req.Params["limit"].(int64)
+		switch {
+		case limit <= 100:
+			return model.RateLimitClassMarket, 5
+		case limit <= 500:
+			return model.RateLimitClassMarket, 25
+		case limit <= 1000:
+			return model.RateLimitClassMarket, 50
+		default:
+			return model.RateLimitClassMarket, 250
+		}
+	case "exchangeInfo":
+		return model.RateLimitClassMarket, 20
+	case "ticker.price":
+		if _, ok := req.Params["symbols"]; ok {
+			return model.RateLimitClassMarket, 4
+		}
+
+		return model.RateLimitClassMarket, 2
+	default:
+		return model.RateLimitClassMarket, 2
+	}
+}
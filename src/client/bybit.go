@@ -9,11 +9,13 @@ import (
 	"errors"
 	"fmt"
 	"github.com/redis/go-redis/v9"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/utils"
-	"log"
+	"log/slog"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -43,8 +45,12 @@ func (b *ByBit) IsAPIKeyCheckCompleted() bool {
 	return b.APIKeyCheckCompleted == true
 }
 
-func (b *ByBit) QueryOrder(symbol string, orderId string) (model.BinanceOrder, error) {
-	var order model.BinanceOrder
+func (b *ByBit) SetAPIKeyCheckCompleted(completed bool) {
+	b.APIKeyCheckCompleted = completed
+}
+
+func (b *ByBit) QueryOrder(symbol string, orderId string) (model.ExchangeOrder, error) {
+	var order model.ExchangeOrder
 	queryString := fmt.Sprintf("category=spot&limit=1&orderId=%s&symbol=%s&openOnly=0", orderId, symbol)
 	url := fmt.Sprintf("%s/v5/order/realtime?%s", b.DSN, queryString)
 	result, err := b.HttpClient.Get(url, b.GetHeaders(queryString))
@@ -56,25 +62,25 @@ func (b *ByBit) QueryOrder(symbol string, orderId string) (model.BinanceOrder, e
 	var orderHistoryResponse model.ByBitOrderListResponse
 	err = json.Unmarshal(result, &orderHistoryResponse)
 	if err != nil {
-		log.Printf("[%s] QueryOrder: %s", symbol, err.Error())
+		logger.ForSymbol("bybit", symbol).Error("QueryOrder", logger.Error(err))
 		return order, err
 	}
 
 	if orderHistoryResponse.Message != "OK" {
-		log.Printf("[%s] QueryOrder: %s", symbol, orderHistoryResponse.Message)
+		logger.ForSymbol("bybit", symbol).Error("QueryOrder", logger.KeyError, orderHistoryResponse.Message)
 		return order, errors.New(orderHistoryResponse.Message)
 	}
 
 	for _, byBitOrder := range orderHistoryResponse.Result.List {
 		if byBitOrder.OrderId == orderId {
-			return b.Formatter.ByBitOrderToBinanceOrder(orderHistoryResponse.Result.List[0]), nil
+			return b.Formatter.ByBitOrderToExchangeOrder(byBitOrder)
 		}
 	}
 
 	return order, errors.New(fmt.Sprintf("[%s] order %s is not found", symbol, orderId))
 }
 
-func (b *ByBit) CancelOrder(symbol string, orderId string) (model.BinanceOrder, error) {
+func (b *ByBit) CancelOrder(symbol string, orderId string) (model.ExchangeOrder, error) {
 	requestBody := map[string]string{
 		"category": "spot",
 		"symbol":   symbol,
@@ -82,7 +88,7 @@ func (b *ByBit) CancelOrder(symbol string, orderId string) (model.BinanceOrder,
 	}
 	encoded, err := json.Marshal(requestBody)
 
-	var order model.BinanceOrder
+	var order model.ExchangeOrder
 
 	if err != nil {
 		return order, err
@@ -96,12 +102,12 @@ func (b *ByBit) CancelOrder(symbol string, orderId string) (model.BinanceOrder,
 	var byBitResult model.ByBitKeyValueResult
 	err = json.Unmarshal(result, &byBitResult)
 	if err != nil {
-		log.Printf("[%s] CancelOrder: %s", symbol, err.Error())
+		logger.ForSymbol("bybit", symbol).Error("CancelOrder", logger.Error(err))
 		return order, err
 	}
 
 	if byBitResult.Message != "OK" {
-		log.Printf("[%s] CancelOrder: %s", symbol, byBitResult.Message)
+		logger.ForSymbol("bybit", symbol).Error("CancelOrder", logger.KeyError, byBitResult.Message)
 		return order, errors.New(byBitResult.Message)
 	}
 
@@ -125,23 +131,24 @@ func (b *ByBit) GetDepth(symbol string, limit int64) *model.OrderBook {
 	var orderBookResult model.ByBitOrderBookResponse
 	err = json.Unmarshal(result, &orderBookResult)
 	if err != nil {
-		log.Printf("[%s] GetDepth: %s", symbol, err.Error())
+		logger.ForSymbol("bybit", symbol).Error("GetDepth", logger.Error(err))
 		return nil
 	}
 
 	if orderBookResult.Message != "OK" {
-		log.Printf("[%s] GetDepth: %s", symbol, orderBookResult.Message)
+		logger.ForSymbol("bybit", symbol).Error("GetDepth", logger.KeyError, orderBookResult.Message)
 		return nil
 	}
 
 	return &model.OrderBook{
-		Bids: orderBookResult.Result.Bids,
-		Asks: orderBookResult.Result.Asks,
+		LastUpdateId: int64(orderBookResult.Result.UpdateId),
+		Bids:         orderBookResult.Result.Bids,
+		Asks:         orderBookResult.Result.Asks,
 	}
 }
 
-func (b *ByBit) GetOpenedOrders() ([]model.BinanceOrder, error) {
-	orders := make([]model.BinanceOrder, 0)
+func (b *ByBit) GetOpenedOrders() ([]model.ExchangeOrder, error) {
+	orders := make([]model.ExchangeOrder, 0)
 	queryString := "category=spot&openOnly=1"
 	result, err := b.HttpClient.Get(fmt.Sprintf("%s/v5/order/realtime?%s", b.DSN, queryString), b.GetHeaders(queryString))
 	if err != nil {
@@ -151,17 +158,21 @@ func (b *ByBit) GetOpenedOrders() ([]model.BinanceOrder, error) {
 	var openedOrdersResponse model.ByBitOrderListResponse
 	err = json.Unmarshal(result, &openedOrdersResponse)
 	if err != nil {
-		log.Printf("GetOpenedOrders: %s", err.Error())
+		slog.Error("GetOpenedOrders", logger.KeyExchange, "bybit", logger.Error(err))
 		return orders, err
 	}
 
 	if openedOrdersResponse.Message != "OK" {
-		log.Printf("GetOpenedOrders: %s", openedOrdersResponse.Message)
+		slog.Error("GetOpenedOrders", logger.KeyExchange, "bybit", logger.KeyError, openedOrdersResponse.Message)
 		return orders, errors.New(openedOrdersResponse.Message)
 	}
 
 	for _, byBitOrder := range openedOrdersResponse.Result.List {
-		order := b.Formatter.ByBitOrderToBinanceOrder(byBitOrder)
+		order, err := b.Formatter.ByBitOrderToExchangeOrder(byBitOrder)
+		if err != nil {
+			slog.Error("GetOpenedOrders", logger.KeyExchange, "bybit", logger.KeySymbol, byBitOrder.Symbol, logger.Error(err))
+			continue
+		}
 		if order.IsNew() || order.IsPartiallyFilled() {
 			orders = append(orders, order)
 		}
@@ -190,12 +201,12 @@ func (b *ByBit) GetKLines(symbol string, interval string, limit int64) []model.K
 	var klineHistoryResponse model.ByBitKLineHistoryResponse
 	err = json.Unmarshal(result, &klineHistoryResponse)
 	if err != nil {
-		log.Printf("[%s] GetKLines: %s", symbol, err.Error())
+		logger.ForSymbol("bybit", symbol).Error("GetKLines", logger.Error(err))
 		return kLines
 	}
 
 	if klineHistoryResponse.Message != "OK" {
-		log.Printf("[%s] GetKLines: %s", symbol, klineHistoryResponse.Message)
+		logger.ForSymbol("bybit", symbol).Error("GetKLines", logger.KeyError, klineHistoryResponse.Message)
 		return kLines
 	}
 
@@ -223,12 +234,12 @@ func (b *ByBit) TradesAggregate(symbol string, limit int64, startTime int64, end
 	var tradesHistory model.ByBitTradeHistoryResponse
 	err = json.Unmarshal(result, &tradesHistory)
 	if err != nil {
-		log.Printf("[%s] TradesAggregate: %s", symbol, err.Error())
+		logger.ForSymbol("bybit", symbol).Error("TradesAggregate", logger.Error(err))
 		return trades
 	}
 
 	if tradesHistory.Message != "OK" {
-		log.Printf("[%s] TradesAggregate: %s", symbol, tradesHistory.Message)
+		logger.ForSymbol("bybit", symbol).Error("TradesAggregate", logger.KeyError, tradesHistory.Message)
 		return trades
 	}
 
@@ -252,7 +263,7 @@ func (b *ByBit) GetKLinesCached(symbol string, interval string, limit int64) []m
 		if err == nil {
 			return batch.Items
 		}
-		log.Printf("[%s] kline[%s] history cache invalid", symbol, interval)
+		slog.Warn("KLine history cache is invalid", logger.KeyExchange, "bybit", logger.KeySymbol, symbol, "interval", interval)
 	}
 
 	historyKLines := b.GetKLines(symbol, interval, limit)
@@ -285,26 +296,25 @@ func (b *ByBit) GetExchangeData(symbols []string) (*model.ExchangeInfo, error) {
 	var exchangeInfoResponse model.ByBitExchangeInfoResponse
 	err = json.Unmarshal(result, &exchangeInfoResponse)
 	if err != nil {
-		log.Printf("GetExchangeData: %s", err.Error())
+		slog.Error("GetExchangeData", logger.KeyExchange, "bybit", logger.Error(err))
 		return nil, err
 	}
 
 	if exchangeInfoResponse.Message != "OK" {
-		log.Printf("GetExchangeData: %s", exchangeInfoResponse.Message)
+		slog.Error("GetExchangeData", logger.KeyExchange, "bybit", logger.KeyError, exchangeInfoResponse.Message)
 		return nil, errors.New(exchangeInfoResponse.Message)
 	}
 
 	exchangeSymbols := make([]model.ExchangeSymbol, 0)
 	for _, byBitSymbol := range exchangeInfoResponse.Result.List {
 		if len(symbols) == 0 || slices.Contains(symbols, byBitSymbol.Symbol) {
-			exchangeSymbols = append(exchangeSymbols, b.Formatter.ByBitExchangeSymbolToBinanceExchangeSymbol(byBitSymbol))
+			exchangeSymbols = append(exchangeSymbols, b.Formatter.ByBitSymbolToExchangeSymbol(byBitSymbol))
 		}
 	}
 
 	return &model.ExchangeInfo{
 		Symbols:    exchangeSymbols,
 		Timezone:   "UTC",
-		RateLimits: make([]model.RateLimit, 0), // unused
 		ServerTime: time.Now().UnixMilli(),
 	}, nil
 }
@@ -323,11 +333,11 @@ func (b *ByBit) GetAccountStatus() (*model.AccountStatus, error) {
 	var balanceResponse model.ByBitBalanceResponse
 	err = json.Unmarshal(result, &balanceResponse)
 	if err != nil {
-		log.Printf("GetAccountStatus: %s", err.Error())
+		slog.Error("GetAccountStatus", logger.KeyExchange, "bybit", logger.Error(err))
 		return nil, err
 	}
 	if balanceResponse.Message != "OK" {
-		log.Printf("GetAccountStatus: %s", balanceResponse.Message)
+		slog.Error("GetAccountStatus", logger.KeyExchange, "bybit", logger.KeyError, balanceResponse.Message)
 		return nil, errors.New(balanceResponse.Message)
 	}
 
@@ -349,8 +359,8 @@ func (b *ByBit) GetAccountStatus() (*model.AccountStatus, error) {
 		Balances: balances,
 	}, nil
 }
-func (b *ByBit) GetTickers(symbols []string) []model.WSTickerPrice {
-	tickers := make([]model.WSTickerPrice, 0)
+func (b *ByBit) GetTickers(symbols []string) []model.ExchangeTicker {
+	tickers := make([]model.ExchangeTicker, 0)
 	queryString := "category=spot"
 
 	result, err := b.HttpClient.Get(fmt.Sprintf(
@@ -364,23 +374,23 @@ func (b *ByBit) GetTickers(symbols []string) []model.WSTickerPrice {
 	var tickerResponse model.ByBitTickerResponse
 	err = json.Unmarshal(result, &tickerResponse)
 	if err != nil {
-		log.Printf("GetTickers: %s", err.Error())
+		slog.Error("GetTickers", logger.KeyExchange, "bybit", logger.Error(err))
 		return tickers
 	}
 	if tickerResponse.Message != "OK" {
-		log.Printf("GetTickers: %s", tickerResponse.Message)
+		slog.Error("GetTickers", logger.KeyExchange, "bybit", logger.KeyError, tickerResponse.Message)
 		return tickers
 	}
 
 	for _, byBitTicker := range tickerResponse.Result.List {
 		if len(symbols) == 0 || slices.Contains(symbols, byBitTicker.Symbol) {
-			tickers = append(tickers, b.Formatter.ByBitTickerToBinanceTicker(byBitTicker))
+			tickers = append(tickers, b.Formatter.ByBitTickerToExchangeTicker(byBitTicker))
 		}
 	}
 
 	return tickers
 }
-func (b *ByBit) LimitOrder(symbol string, quantity float64, price float64, operation string, timeInForce string) (model.BinanceOrder, error) {
+func (b *ByBit) LimitOrder(symbol string, quantity float64, price float64, operation string, timeInForce string) (model.ExchangeOrder, error) {
 	requestBody := map[string]any{
 		"category":    "spot",
 		"symbol":      symbol,
@@ -392,29 +402,89 @@ func (b *ByBit) LimitOrder(symbol string, quantity float64, price float64, opera
 		"isLeverage":  0,
 		"orderFilter": "Order",
 	}
+
+	return b.createOrder(symbol, requestBody, model.ExchangeOrder{
+		Symbol:              symbol,
+		Price:               price,
+		OrigQty:             quantity,
+		CummulativeQuoteQty: price * quantity,
+		Status:              model.ExchangeOrderStatusNew,
+		Type:                model.OrderTypeLimit,
+		Side:                operation,
+	})
+}
+
+func (b *ByBit) MarketOrder(symbol string, quantity float64, operation string) (model.ExchangeOrder, error) {
+	requestBody := map[string]any{
+		"category":    "spot",
+		"symbol":      symbol,
+		"side":        b.Formatter.BinanceSideToByBitSide(operation),
+		"orderType":   "Market",
+		"qty":         strconv.FormatFloat(quantity, 'f', -1, 64),
+		"marketUnit":  "baseCoin",
+		"isLeverage":  0,
+		"orderFilter": "Order",
+	}
+
+	return b.createOrder(symbol, requestBody, model.ExchangeOrder{
+		Symbol:  symbol,
+		OrigQty: quantity,
+		Status:  model.ExchangeOrderStatusNew,
+		Type:    model.OrderTypeMarket,
+		Side:    operation,
+	})
+}
+
+func (b *ByBit) QuoteMarketOrder(symbol string, quoteQuantity float64, operation string) (model.ExchangeOrder, error) {
+	requestBody := map[string]any{
+		"category":    "spot",
+		"symbol":      symbol,
+		"side":        b.Formatter.BinanceSideToByBitSide(operation),
+		"orderType":   "Market",
+		"qty":         strconv.FormatFloat(quoteQuantity, 'f', -1, 64),
+		"marketUnit":  "quoteCoin",
+		"isLeverage":  0,
+		"orderFilter": "Order",
+	}
+
+	return b.createOrder(symbol, requestBody, model.ExchangeOrder{
+		Symbol:              symbol,
+		CummulativeQuoteQty: quoteQuantity,
+		Status:              model.ExchangeOrderStatusNew,
+		Type:                model.OrderTypeMarket,
+		Side:                operation,
+	})
+}
+
+func (b *ByBit) PostOnlyOrder(symbol string, quantity float64, price float64, operation string) (model.ExchangeOrder, error) {
+	return b.LimitOrder(symbol, quantity, price, operation, "PostOnly")
+}
+
+// createOrder returns the order from exchange, placed order is returned if exchange can't find it yet
+func (b *ByBit) createOrder(symbol string, requestBody map[string]any, placed model.ExchangeOrder) (model.ExchangeOrder, error) {
 	encoded, err := json.Marshal(requestBody)
 	if err != nil {
-		return model.BinanceOrder{}, err
+		return model.ExchangeOrder{}, err
 	}
 	result, err := b.HttpClient.Post(fmt.Sprintf("%s/v5/order/create", b.DSN), encoded, b.GetHeaders(string(encoded)))
 	if err != nil {
-		return model.BinanceOrder{}, err
+		return model.ExchangeOrder{}, err
 	}
 	var byBitResult model.ByBitKeyValueResult
 	err = json.Unmarshal(result, &byBitResult)
 	if err != nil {
-		log.Printf("[%s] LimitOrder: %s", symbol, err.Error())
-		return model.BinanceOrder{}, err
+		logger.ForSymbol("bybit", symbol).Error("Order is not placed", "side", placed.Side, "type", placed.Type, "quantity", placed.OrigQty, "price", placed.Price, logger.Error(err))
+		return model.ExchangeOrder{}, err
 	}
 
 	if byBitResult.Message != "OK" {
-		log.Printf("[%s] LimitOrder: %s", symbol, byBitResult.Message)
-		return model.BinanceOrder{}, errors.New(byBitResult.Message)
+		logger.ForSymbol("bybit", symbol).Error("Order is not placed", "side", placed.Side, "type", placed.Type, "quantity", placed.OrigQty, "price", placed.Price, logger.KeyError, byBitResult.Message)
+		return model.ExchangeOrder{}, errors.New(byBitResult.Message)
 	}
 
 	orderIdRaw, ok := byBitResult.Result["orderId"]
 	if !ok {
-		return model.BinanceOrder{}, errors.New("can't get orderId")
+		return model.ExchangeOrder{}, errors.New("can't get orderId")
 	}
 
 	if orderId, ok := orderIdRaw.(string); ok {
@@ -423,23 +493,25 @@ func (b *ByBit) LimitOrder(symbol string, quantity float64, price float64, opera
 			return exchangeOrder, nil
 		}
 
-		return model.BinanceOrder{
-			OrderId:             orderId,
-			Symbol:              symbol,
-			TransactTime:        0,
-			Price:               price,
-			OrigQty:             quantity,
-			ExecutedQty:         0.00,
-			CummulativeQuoteQty: price * quantity,
-			Status:              model.ExchangeOrderStatusNew,
-			Type:                "LIMIT",
-			Side:                operation,
-			WorkingTime:         0,
-			Timestamp:           time.Now().UnixMilli(),
-		}, nil
-	}
-
-	return model.BinanceOrder{}, errors.New("orderId is not string")
+		placed.OrderId = orderId
+		placed.Timestamp = time.Now().UnixMilli()
+
+		return placed, nil
+	}
+
+	return model.ExchangeOrder{}, errors.New("orderId is not string")
+}
+
+// GetWsAuthRequest signs private websocket connection, signature is valid for 10 seconds
+func (b *ByBit) GetWsAuthRequest() model.ByBitWsAuthRequest {
+	expires := time.Now().Add(time.Second * 10).UnixMilli()
+	h := hmac.New(sha256.New, []byte(b.ApiSecret))
+	h.Write([]byte(fmt.Sprintf("GET/realtime%d", expires)))
+
+	return model.ByBitWsAuthRequest{
+		Operation: "auth",
+		Arguments: []any{b.ApiKey, expires, hex.EncodeToString(h.Sum(nil))},
+	}
 }
 
 func (b *ByBit) GetHeaders(payload string) map[string]string {
@@ -455,3 +527,15 @@ func (b *ByBit) GetHeaders(payload string) map[string]string {
 		"X-BAPI-SIGN":      hex.EncodeToString(h.Sum(nil)),
 	}
 }
+
+// GetByBitRequestClass every request has weight 1, ByBit limits requests per endpoint and per IP
+func GetByBitRequestClass(method string, url string) (string, int64) {
+	switch {
+	case strings.Contains(url, "/v5/order/create"), strings.Contains(url, "/v5/order/cancel"):
+		return model.RateLimitClassOrder, 1
+	case strings.Contains(url, "/v5/market/"):
+		return model.RateLimitClassMarket, 1
+	default:
+		return model.RateLimitClassAccount, 1
+	}
+}
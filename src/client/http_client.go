@@ -2,11 +2,16 @@ package client
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/metrics"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"io"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,6 +21,9 @@ type HttpClientInterface interface {
 }
 
 type HttpClient struct {
+	RateLimiter HttpRateLimiterInterface
+	// Exchange is used as metrics label, errors are not counted if empty
+	Exchange string
 }
 
 func (h *HttpClient) Post(url string, message []byte, headers map[string]string) ([]byte, error) {
@@ -32,23 +40,35 @@ func (h *HttpClient) Post(url string, message []byte, headers map[string]string)
 		Timeout: 20 * time.Second,
 	}
 
+	if h.RateLimiter != nil {
+		h.RateLimiter.AcquireRequest(req.Method, url)
+	}
+
 	res, err := client.Do(req)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if res.StatusCode >= 400 {
-		return nil, errors.New(fmt.Sprintf("Request [%s] failed with error code: %d", url, res.StatusCode))
+	if h.RateLimiter != nil {
+		h.RateLimiter.ReadHeaders(req.Method, url, res.Header)
 	}
 
 	responseBody, err := io.ReadAll(res.Body)
 	defer res.Body.Close()
 
+	if res.StatusCode >= 400 {
+		h.countError(responseBody, res.StatusCode)
+
+		return nil, errors.New(fmt.Sprintf("Request [%s] failed with error code: %d", url, res.StatusCode))
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
+	h.countError(responseBody, res.StatusCode)
+
 	return responseBody, nil
 }
 
@@ -66,22 +86,77 @@ func (h *HttpClient) Get(url string, headers map[string]string) ([]byte, error)
 		Timeout: 20 * time.Second,
 	}
 
+	if h.RateLimiter != nil {
+		h.RateLimiter.AcquireRequest(req.Method, url)
+	}
+
 	res, err := client.Do(req)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if res.StatusCode >= 400 {
-		return nil, errors.New(fmt.Sprintf("Request [%s] failed with error code: %d", url, res.StatusCode))
+	if h.RateLimiter != nil {
+		h.RateLimiter.ReadHeaders(req.Method, url, res.Header)
 	}
 
 	responseBody, err := io.ReadAll(res.Body)
 	defer res.Body.Close()
 
+	if res.StatusCode >= 400 {
+		h.countError(responseBody, res.StatusCode)
+
+		return nil, errors.New(fmt.Sprintf("Request [%s] failed with error code: %d", url, res.StatusCode))
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
+	h.countError(responseBody, res.StatusCode)
+
 	return responseBody, nil
 }
+
+func (h *HttpClient) countError(body []byte, statusCode int) {
+	if h.Exchange == "" {
+		return
+	}
+
+	if !countApiError(h.Exchange, body) && statusCode >= 400 {
+		metrics.ApiErrors.Inc(h.Exchange, fmt.Sprintf("http_%d", statusCode))
+	}
+}
+
+type apiErrorResponse struct {
+	Code    json.RawMessage `json:"code"`
+	RetCode *int64          `json:"retCode"`
+	Error   *model.Error    `json:"error"`
+}
+
+// countApiError reads error code from Binance ({"code": -1121} or {"error": {"code": -2010}}),
+// ByBit ({"retCode": 10001}) or OKX ({"code": "51000"}) response, zero code means success
+func countApiError(exchange string, body []byte) bool {
+	var response apiErrorResponse
+	if json.Unmarshal(body, &response) != nil {
+		return false
+	}
+
+	code := ""
+	switch {
+	case response.Error != nil:
+		code = strconv.FormatInt(response.Error.Code, 10)
+	case response.RetCode != nil:
+		code = strconv.FormatInt(*response.RetCode, 10)
+	case len(response.Code) > 0:
+		code = strings.Trim(string(response.Code), `"`)
+	}
+
+	if code == "" || code == "0" {
+		return false
+	}
+
+	metrics.ApiErrors.Inc(exchange, code)
+
+	return true
+}
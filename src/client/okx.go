@@ -0,0 +1,504 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Okx spot client, OKX instruments (BTC-USDT) are converted to bot symbols (BTCUSDT) and Binance models
+type Okx struct {
+	CurrentBot *model.Bot
+	HttpClient HttpClientInterface
+	DSN        string
+	ApiKey     string
+	ApiSecret  string
+	Passphrase string
+	Formatter  *utils.Formatter
+
+	RDB *redis.Client
+	Ctx *context.Context
+
+	APIKeyCheckCompleted bool
+}
+
+func (o *Okx) IsConnected() bool {
+	return true
+}
+
+func (o *Okx) IsWaitMode() bool {
+	return false
+}
+
+func (o *Okx) IsAPIKeyCheckCompleted() bool {
+	return o.APIKeyCheckCompleted == true
+}
+
+func (o *Okx) SetAPIKeyCheckCompleted(completed bool) {
+	o.APIKeyCheckCompleted = completed
+}
+
+func (o *Okx) QueryOrder(symbol string, orderId string) (model.ExchangeOrder, error) {
+	var order model.ExchangeOrder
+	path := fmt.Sprintf("/api/v5/trade/order?instId=%s&ordId=%s", o.Formatter.BinanceSymbolToOkxInstId(symbol), orderId)
+	result, err := o.HttpClient.Get(o.DSN+path, o.GetHeaders("GET", path, ""))
+
+	if err != nil {
+		return order, err
+	}
+
+	var orderResponse model.OkxOrderListResponse
+	err = json.Unmarshal(result, &orderResponse)
+	if err != nil {
+		logger.ForSymbol("okx", symbol).Error("QueryOrder", logger.Error(err))
+		return order, err
+	}
+
+	if orderResponse.Code != model.OkxResponseCodeOk {
+		logger.ForSymbol("okx", symbol).Error("QueryOrder", logger.KeyError, orderResponse.Message)
+		return order, errors.New(orderResponse.Message)
+	}
+
+	for _, okxOrder := range orderResponse.Data {
+		if okxOrder.OrderId == orderId {
+			return o.Formatter.OkxOrderToExchangeOrder(okxOrder)
+		}
+	}
+
+	return order, errors.New(fmt.Sprintf("[%s] order %s is not found", symbol, orderId))
+}
+
+func (o *Okx) CancelOrder(symbol string, orderId string) (model.ExchangeOrder, error) {
+	requestBody := map[string]string{
+		"instId": o.Formatter.BinanceSymbolToOkxInstId(symbol),
+		"ordId":  orderId,
+	}
+
+	var order model.ExchangeOrder
+
+	_, err := o.post("/api/v5/trade/cancel-order", requestBody)
+	if err != nil {
+		logger.ForSymbol("okx", symbol).Error("CancelOrder", logger.Error(err))
+		return order, err
+	}
+
+	return o.QueryOrder(symbol, orderId)
+}
+
+func (o *Okx) GetDepth(symbol string, limit int64) *model.OrderBook {
+	if limit > 400 {
+		limit = 400
+	}
+	path := fmt.Sprintf("/api/v5/market/books?instId=%s&sz=%d", o.Formatter.BinanceSymbolToOkxInstId(symbol), limit)
+	result, err := o.HttpClient.Get(o.DSN+path, o.GetHeaders("GET", path, ""))
+
+	if err != nil {
+		return nil
+	}
+	var orderBookResponse model.OkxOrderBookResponse
+	err = json.Unmarshal(result, &orderBookResponse)
+	if err != nil {
+		logger.ForSymbol("okx", symbol).Error("GetDepth", logger.Error(err))
+		return nil
+	}
+
+	if orderBookResponse.Code != model.OkxResponseCodeOk || len(orderBookResponse.Data) == 0 {
+		logger.ForSymbol("okx", symbol).Error("GetDepth", logger.KeyError, orderBookResponse.Message)
+		return nil
+	}
+
+	depth := orderBookResponse.Data[0].ToOrderBookModel(symbol)
+
+	return &model.OrderBook{
+		Bids: depth.Bids,
+		Asks: depth.Asks,
+	}
+}
+
+func (o *Okx) GetOpenedOrders() ([]model.ExchangeOrder, error) {
+	orders := make([]model.ExchangeOrder, 0)
+	path := "/api/v5/trade/orders-pending?instType=SPOT"
+	result, err := o.HttpClient.Get(o.DSN+path, o.GetHeaders("GET", path, ""))
+	if err != nil {
+		return orders, err
+	}
+
+	var openedOrdersResponse model.OkxOrderListResponse
+	err = json.Unmarshal(result, &openedOrdersResponse)
+	if err != nil {
+		slog.Error("GetOpenedOrders", logger.KeyExchange, "okx", logger.Error(err))
+		return orders, err
+	}
+
+	if openedOrdersResponse.Code != model.OkxResponseCodeOk {
+		slog.Error("GetOpenedOrders", logger.KeyExchange, "okx", logger.KeyError, openedOrdersResponse.Message)
+		return orders, errors.New(openedOrdersResponse.Message)
+	}
+
+	for _, okxOrder := range openedOrdersResponse.Data {
+		order, err := o.Formatter.OkxOrderToExchangeOrder(okxOrder)
+		if err != nil {
+			slog.Error("GetOpenedOrders", logger.KeyExchange, "okx", logger.KeySymbol, okxOrder.InstId, logger.Error(err))
+			continue
+		}
+		if order.IsNew() || order.IsPartiallyFilled() {
+			orders = append(orders, order)
+		}
+	}
+
+	return orders, nil
+}
+
+func (o *Okx) GetKLines(symbol string, interval string, limit int64) []model.KLineHistory {
+	kLines := make([]model.KLineHistory, 0)
+	if limit > 300 {
+		limit = 300
+	}
+	path := fmt.Sprintf(
+		"/api/v5/market/candles?instId=%s&bar=%s&limit=%d",
+		o.Formatter.BinanceSymbolToOkxInstId(symbol),
+		o.Formatter.BinanceIntervalToOkxInterval(interval),
+		limit,
+	)
+	result, err := o.HttpClient.Get(o.DSN+path, o.GetHeaders("GET", path, ""))
+
+	if err != nil {
+		return kLines
+	}
+	var kLineResponse model.OkxKLineResponse
+	err = json.Unmarshal(result, &kLineResponse)
+	if err != nil {
+		logger.ForSymbol("okx", symbol).Error("GetKLines", logger.Error(err))
+		return kLines
+	}
+
+	if kLineResponse.Code != model.OkxResponseCodeOk {
+		logger.ForSymbol("okx", symbol).Error("GetKLines", logger.KeyError, kLineResponse.Message)
+		return kLines
+	}
+
+	for _, okxKLine := range kLineResponse.Data {
+		kLines = append(kLines, o.Formatter.OkxKLineToBinanceHistoryKline(okxKLine))
+	}
+
+	// Reverse list (Doc: newest candle is the first one)
+	slices.Reverse(kLines)
+
+	return kLines
+}
+
+func (o *Okx) TradesAggregate(symbol string, limit int64, startTime int64, endTime int64) []model.Trade {
+	trades := make([]model.Trade, 0)
+	if limit > 500 {
+		limit = 500
+	}
+	path := fmt.Sprintf("/api/v5/market/trades?instId=%s&limit=%d", o.Formatter.BinanceSymbolToOkxInstId(symbol), limit)
+	result, err := o.HttpClient.Get(o.DSN+path, o.GetHeaders("GET", path, ""))
+	if err != nil {
+		return trades
+	}
+	var tradeResponse model.OkxTradeResponse
+	err = json.Unmarshal(result, &tradeResponse)
+	if err != nil {
+		logger.ForSymbol("okx", symbol).Error("TradesAggregate", logger.Error(err))
+		return trades
+	}
+
+	if tradeResponse.Code != model.OkxResponseCodeOk {
+		logger.ForSymbol("okx", symbol).Error("TradesAggregate", logger.KeyError, tradeResponse.Message)
+		return trades
+	}
+
+	for _, okxTrade := range tradeResponse.Data {
+		if okxTrade.Timestamp.Gt(model.TimestampMilli(startTime)) && okxTrade.Timestamp.Lte(model.TimestampMilli(endTime)) {
+			trades = append(trades, o.Formatter.OkxTradeToBinanceTrade(okxTrade))
+		}
+	}
+
+	slices.Reverse(trades)
+
+	return trades
+}
+
+func (o *Okx) GetKLinesCached(symbol string, interval string, limit int64) []model.KLine {
+	cacheKey := fmt.Sprintf("interval-klines-history-%s-%s-%d-%d", symbol, interval, limit, o.CurrentBot.Id)
+
+	res := o.RDB.Get(*o.Ctx, cacheKey).Val()
+	if len(res) > 0 {
+		var batch model.KlineBatch
+
+		err := json.Unmarshal([]byte(res), &batch)
+		if err == nil {
+			return batch.Items
+		}
+		slog.Warn("KLine history cache is invalid", logger.KeyExchange, "okx", logger.KeySymbol, symbol, "interval", interval)
+	}
+
+	historyKLines := o.GetKLines(symbol, interval, limit)
+	kLines := make([]model.KLine, 0)
+	for _, historyKLine := range historyKLines {
+		kLines = append(kLines, historyKLine.ToKLine(symbol))
+	}
+
+	batch := model.KlineBatch{
+		Items: kLines,
+	}
+	encoded, err := json.Marshal(batch)
+	if err == nil {
+		o.RDB.Set(*o.Ctx, cacheKey, string(encoded), time.Second*15)
+	}
+
+	return batch.Items
+}
+
+func (o *Okx) GetExchangeData(symbols []string) (*model.ExchangeInfo, error) {
+	path := "/api/v5/public/instruments?instType=SPOT"
+	result, err := o.HttpClient.Get(o.DSN+path, o.GetHeaders("GET", path, ""))
+	if err != nil {
+		return nil, err
+	}
+	var instrumentResponse model.OkxInstrumentResponse
+	err = json.Unmarshal(result, &instrumentResponse)
+	if err != nil {
+		slog.Error("GetExchangeData", logger.KeyExchange, "okx", logger.Error(err))
+		return nil, err
+	}
+
+	if instrumentResponse.Code != model.OkxResponseCodeOk {
+		slog.Error("GetExchangeData", logger.KeyExchange, "okx", logger.KeyError, instrumentResponse.Message)
+		return nil, errors.New(instrumentResponse.Message)
+	}
+
+	exchangeSymbols := make([]model.ExchangeSymbol, 0)
+	for _, instrument := range instrumentResponse.Data {
+		exchangeSymbol := o.Formatter.OkxInstrumentToExchangeSymbol(instrument)
+		if len(symbols) == 0 || slices.Contains(symbols, exchangeSymbol.Symbol) {
+			exchangeSymbols = append(exchangeSymbols, exchangeSymbol)
+		}
+	}
+
+	return &model.ExchangeInfo{
+		Symbols:    exchangeSymbols,
+		Timezone:   "UTC",
+		ServerTime: time.Now().UnixMilli(),
+	}, nil
+}
+
+func (o *Okx) GetAccountStatus() (*model.AccountStatus, error) {
+	path := "/api/v5/account/balance"
+	result, err := o.HttpClient.Get(o.DSN+path, o.GetHeaders("GET", path, ""))
+	if err != nil {
+		return nil, err
+	}
+	var balanceResponse model.OkxBalanceResponse
+	err = json.Unmarshal(result, &balanceResponse)
+	if err != nil {
+		slog.Error("GetAccountStatus", logger.KeyExchange, "okx", logger.Error(err))
+		return nil, err
+	}
+	if balanceResponse.Code != model.OkxResponseCodeOk {
+		slog.Error("GetAccountStatus", logger.KeyExchange, "okx", logger.KeyError, balanceResponse.Message)
+		return nil, errors.New(balanceResponse.Message)
+	}
+
+	balances := make([]model.Balance, 0)
+
+	for _, okxBalance := range balanceResponse.Data {
+		for _, detail := range okxBalance.Details {
+			balances = append(balances, model.Balance{
+				Asset:  detail.Currency,
+				Free:   detail.AvailableBalance,
+				Locked: detail.FrozenBalance,
+			})
+		}
+	}
+
+	return &model.AccountStatus{
+		Balances: balances,
+	}, nil
+}
+
+func (o *Okx) GetTickers(symbols []string) []model.ExchangeTicker {
+	tickers := make([]model.ExchangeTicker, 0)
+	path := "/api/v5/market/tickers?instType=SPOT"
+
+	result, err := o.HttpClient.Get(o.DSN+path, o.GetHeaders("GET", path, ""))
+	if err != nil {
+		return tickers
+	}
+	var tickerResponse model.OkxTickerResponse
+	err = json.Unmarshal(result, &tickerResponse)
+	if err != nil {
+		slog.Error("GetTickers", logger.KeyExchange, "okx", logger.Error(err))
+		return tickers
+	}
+	if tickerResponse.Code != model.OkxResponseCodeOk {
+		slog.Error("GetTickers", logger.KeyExchange, "okx", logger.KeyError, tickerResponse.Message)
+		return tickers
+	}
+
+	for _, okxTicker := range tickerResponse.Data {
+		symbol := o.Formatter.OkxInstIdToBinanceSymbol(okxTicker.InstId)
+		if len(symbols) == 0 || slices.Contains(symbols, symbol) {
+			tickers = append(tickers, model.ExchangeTicker{
+				Symbol: symbol,
+				Price:  float64(okxTicker.Last),
+			})
+		}
+	}
+
+	return tickers
+}
+
+func (o *Okx) LimitOrder(symbol string, quantity float64, price float64, operation string, timeInForce string) (model.ExchangeOrder, error) {
+	orderType := "limit"
+	switch timeInForce {
+	case "IOC":
+		orderType = "ioc"
+	case "FOK":
+		orderType = "fok"
+	}
+
+	return o.createOrder(symbol, map[string]string{
+		"instId":  o.Formatter.BinanceSymbolToOkxInstId(symbol),
+		"tdMode":  "cash",
+		"side":    o.Formatter.BinanceSideToOkxSide(operation),
+		"ordType": orderType,
+		"sz":      strconv.FormatFloat(quantity, 'f', -1, 64),
+		"px":      strconv.FormatFloat(price, 'f', -1, 64),
+	}, model.ExchangeOrder{
+		Symbol:              symbol,
+		Price:               price,
+		OrigQty:             quantity,
+		CummulativeQuoteQty: price * quantity,
+		Status:              model.ExchangeOrderStatusNew,
+		Type:                model.OrderTypeLimit,
+		Side:                operation,
+	})
+}
+
+func (o *Okx) MarketOrder(symbol string, quantity float64, operation string) (model.ExchangeOrder, error) {
+	return o.createOrder(symbol, map[string]string{
+		"instId":  o.Formatter.BinanceSymbolToOkxInstId(symbol),
+		"tdMode":  "cash",
+		"side":    o.Formatter.BinanceSideToOkxSide(operation),
+		"ordType": "market",
+		"sz":      strconv.FormatFloat(quantity, 'f', -1, 64),
+		"tgtCcy":  "base_ccy",
+	}, model.ExchangeOrder{
+		Symbol:  symbol,
+		OrigQty: quantity,
+		Status:  model.ExchangeOrderStatusNew,
+		Type:    model.OrderTypeMarket,
+		Side:    operation,
+	})
+}
+
+func (o *Okx) QuoteMarketOrder(symbol string, quoteQuantity float64, operation string) (model.ExchangeOrder, error) {
+	return o.createOrder(symbol, map[string]string{
+		"instId":  o.Formatter.BinanceSymbolToOkxInstId(symbol),
+		"tdMode":  "cash",
+		"side":    o.Formatter.BinanceSideToOkxSide(operation),
+		"ordType": "market",
+		"sz":      strconv.FormatFloat(quoteQuantity, 'f', -1, 64),
+		"tgtCcy":  "quote_ccy",
+	}, model.ExchangeOrder{
+		Symbol:              symbol,
+		CummulativeQuoteQty: quoteQuantity,
+		Status:              model.ExchangeOrderStatusNew,
+		Type:                model.OrderTypeMarket,
+		Side:                operation,
+	})
+}
+
+func (o *Okx) PostOnlyOrder(symbol string, quantity float64, price float64, operation string) (model.ExchangeOrder, error) {
+	return o.createOrder(symbol, map[string]string{
+		"instId":  o.Formatter.BinanceSymbolToOkxInstId(symbol),
+		"tdMode":  "cash",
+		"side":    o.Formatter.BinanceSideToOkxSide(operation),
+		"ordType": "post_only",
+		"sz":      strconv.FormatFloat(quantity, 'f', -1, 64),
+		"px":      strconv.FormatFloat(price, 'f', -1, 64),
+	}, model.ExchangeOrder{
+		Symbol:              symbol,
+		Price:               price,
+		OrigQty:             quantity,
+		CummulativeQuoteQty: price * quantity,
+		Status:              model.ExchangeOrderStatusNew,
+		Type:                model.OrderTypeLimitMaker,
+		Side:                operation,
+	})
+}
+
+// createOrder returns the order from exchange, placed order is returned if exchange can't find it yet
+func (o *Okx) createOrder(symbol string, requestBody map[string]string, placed model.ExchangeOrder) (model.ExchangeOrder, error) {
+	orderResult, err := o.post("/api/v5/trade/order", requestBody)
+	if err != nil {
+		logger.ForSymbol("okx", symbol).Error("Order is not placed", "side", placed.Side, "type", placed.Type, "quantity", placed.OrigQty, "price", placed.Price, logger.Error(err))
+		return model.ExchangeOrder{}, err
+	}
+
+	exchangeOrder, err := o.QueryOrder(symbol, orderResult.OrderId)
+	if err == nil {
+		return exchangeOrder, nil
+	}
+
+	placed.OrderId = orderResult.OrderId
+	placed.Timestamp = time.Now().UnixMilli()
+
+	return placed, nil
+}
+
+// post sends trade request, OKX returns result code per order in data list
+func (o *Okx) post(path string, requestBody map[string]string) (model.OkxOrderResult, error) {
+	encoded, err := json.Marshal(requestBody)
+	if err != nil {
+		return model.OkxOrderResult{}, err
+	}
+
+	result, err := o.HttpClient.Post(o.DSN+path, encoded, o.GetHeaders("POST", path, string(encoded)))
+	if err != nil {
+		return model.OkxOrderResult{}, err
+	}
+
+	var response model.OkxOrderResultResponse
+	err = json.Unmarshal(result, &response)
+	if err != nil {
+		return model.OkxOrderResult{}, err
+	}
+
+	if len(response.Data) > 0 && response.Data[0].StatusCode != model.OkxResponseCodeOk {
+		return model.OkxOrderResult{}, errors.New(response.Data[0].StatusMsg)
+	}
+
+	if response.Code != model.OkxResponseCodeOk || len(response.Data) == 0 {
+		return model.OkxOrderResult{}, errors.New(response.Message)
+	}
+
+	return response.Data[0], nil
+}
+
+func (o *Okx) GetHeaders(method string, path string, body string) map[string]string {
+	timestamp := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	h := hmac.New(sha256.New, []byte(o.ApiSecret))
+	h.Write([]byte(timestamp + method + path + body))
+
+	return map[string]string{
+		"OK-ACCESS-KEY":        o.ApiKey,
+		"OK-ACCESS-SIGN":       base64.StdEncoding.EncodeToString(h.Sum(nil)),
+		"OK-ACCESS-TIMESTAMP":  timestamp,
+		"OK-ACCESS-PASSPHRASE": o.Passphrase,
+	}
+}
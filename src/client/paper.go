@@ -0,0 +1,419 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"log"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Paper uses public market data of the wrapped exchange, orders are matched in memory against
+// its order book, balances are virtual.
+type Paper struct {
+	Exchange   ExchangeAPIInterface
+	FeePercent float64
+	Balances   map[string]float64
+	Orders     map[string]model.ExchangeOrder
+	Symbols    map[string]model.ExchangeSymbol
+	Sequence   int64
+	Lock       *sync.Mutex
+}
+
+func (p *Paper) getSymbol(symbol string) (model.ExchangeSymbol, error) {
+	p.Lock.Lock()
+	exchangeSymbol, ok := p.Symbols[symbol]
+	p.Lock.Unlock()
+
+	if ok {
+		return exchangeSymbol, nil
+	}
+
+	exchangeInfo, err := p.Exchange.GetExchangeData([]string{symbol})
+	if err != nil {
+		return model.ExchangeSymbol{}, err
+	}
+
+	for _, item := range exchangeInfo.Symbols {
+		if item.Symbol == symbol {
+			p.Lock.Lock()
+			p.Symbols[symbol] = item
+			p.Lock.Unlock()
+
+			return item, nil
+		}
+	}
+
+	return model.ExchangeSymbol{}, errors.New(fmt.Sprintf("Paper: symbol %s is not found", symbol))
+}
+
+func (p *Paper) LimitOrder(symbol string, quantity float64, price float64, operation string, timeInForce string) (model.ExchangeOrder, error) {
+	if quantity <= 0.00 || price <= 0.00 {
+		return model.ExchangeOrder{}, errors.New(fmt.Sprintf("Invalid order quantity %f or price %f", quantity, price))
+	}
+
+	exchangeSymbol, err := p.getSymbol(symbol)
+	if err != nil {
+		return model.ExchangeOrder{}, err
+	}
+
+	// exchange request is not made under the lock
+	depth := p.Exchange.GetDepth(symbol, 5)
+
+	p.Lock.Lock()
+	defer p.Lock.Unlock()
+
+	switch operation {
+	case "BUY":
+		if p.Balances[exchangeSymbol.QuoteAsset] < quantity*price {
+			return model.ExchangeOrder{}, errors.New("Account has insufficient balance for requested action.")
+		}
+		p.Balances[exchangeSymbol.QuoteAsset] -= quantity * price
+		break
+	case "SELL":
+		if p.Balances[exchangeSymbol.BaseAsset] < quantity {
+			return model.ExchangeOrder{}, errors.New("Account has insufficient balance for requested action.")
+		}
+		p.Balances[exchangeSymbol.BaseAsset] -= quantity
+		break
+	default:
+		return model.ExchangeOrder{}, errors.New(fmt.Sprintf("Unsupported operation %s", operation))
+	}
+
+	p.Sequence++
+	now := time.Now().UnixMilli()
+	order := model.ExchangeOrder{
+		OrderId:      strconv.FormatInt(p.Sequence, 10),
+		Symbol:       symbol,
+		TransactTime: now,
+		Price:        price,
+		OrigQty:      quantity,
+		ExecutedQty:  0.00,
+		Status:       model.ExchangeOrderStatusNew,
+		Type:         "LIMIT",
+		Side:         operation,
+		WorkingTime:  now,
+		Timestamp:    now,
+	}
+
+	p.match(&order, exchangeSymbol, depth)
+
+	if order.IsNew() && timeInForce != "GTC" {
+		p.release(&order, exchangeSymbol)
+		order.Status = model.ExchangeOrderStatusExpired
+	}
+
+	p.Orders[order.OrderId] = order
+	log.Printf("[%s] Paper %s order %s %s: %f x %f", symbol, operation, order.OrderId, order.Status, order.OrigQty, order.Price)
+
+	return order, nil
+}
+
+func (p *Paper) MarketOrder(symbol string, quantity float64, operation string) (model.ExchangeOrder, error) {
+	return p.marketOrder(symbol, quantity, 0.00, operation)
+}
+
+func (p *Paper) QuoteMarketOrder(symbol string, quoteQuantity float64, operation string) (model.ExchangeOrder, error) {
+	return p.marketOrder(symbol, 0.00, quoteQuantity, operation)
+}
+
+// marketOrder fills the whole order by the best price of the opposite side
+func (p *Paper) marketOrder(symbol string, quantity float64, quoteQuantity float64, operation string) (model.ExchangeOrder, error) {
+	exchangeSymbol, err := p.getSymbol(symbol)
+	if err != nil {
+		return model.ExchangeOrder{}, err
+	}
+
+	depth := p.Exchange.GetDepth(symbol, 5)
+	if depth == nil || len(depth.Asks) == 0 || len(depth.Bids) == 0 {
+		return model.ExchangeOrder{}, errors.New(fmt.Sprintf("Paper: order book %s is empty", symbol))
+	}
+
+	price := depth.Asks[0][0].Value
+	if operation == "SELL" {
+		price = depth.Bids[0][0].Value
+	}
+
+	if quantity == 0.00 && price > 0.00 {
+		quantity = quoteQuantity / price
+	}
+
+	if quantity <= 0.00 || price <= 0.00 {
+		return model.ExchangeOrder{}, errors.New(fmt.Sprintf("Invalid order quantity %f or price %f", quantity, price))
+	}
+
+	p.Lock.Lock()
+	defer p.Lock.Unlock()
+
+	fee := p.FeePercent / 100
+
+	switch operation {
+	case "BUY":
+		if p.Balances[exchangeSymbol.QuoteAsset] < quantity*price {
+			return model.ExchangeOrder{}, errors.New("Account has insufficient balance for requested action.")
+		}
+		p.Balances[exchangeSymbol.QuoteAsset] -= quantity * price
+		p.Balances[exchangeSymbol.BaseAsset] += quantity * (1 - fee)
+		break
+	case "SELL":
+		if p.Balances[exchangeSymbol.BaseAsset] < quantity {
+			return model.ExchangeOrder{}, errors.New("Account has insufficient balance for requested action.")
+		}
+		p.Balances[exchangeSymbol.BaseAsset] -= quantity
+		p.Balances[exchangeSymbol.QuoteAsset] += quantity * price * (1 - fee)
+		break
+	default:
+		return model.ExchangeOrder{}, errors.New(fmt.Sprintf("Unsupported operation %s", operation))
+	}
+
+	p.Sequence++
+	now := time.Now().UnixMilli()
+	order := model.ExchangeOrder{
+		OrderId:      strconv.FormatInt(p.Sequence, 10),
+		Symbol:       symbol,
+		TransactTime: now,
+		OrigQty:      quantity,
+		Type:         model.OrderTypeMarket,
+		Side:         operation,
+		Timestamp:    now,
+	}
+	p.fill(&order, price)
+
+	p.Orders[order.OrderId] = order
+	log.Printf("[%s] Paper %s market order %s %s: %f x %f", symbol, operation, order.OrderId, order.Status, order.OrigQty, order.Price)
+
+	return order, nil
+}
+
+func (p *Paper) PostOnlyOrder(symbol string, quantity float64, price float64, operation string) (model.ExchangeOrder, error) {
+	depth := p.Exchange.GetDepth(symbol, 5)
+
+	if depth != nil {
+		if operation == "BUY" && len(depth.Asks) > 0 && depth.Asks[0][0].Value > 0.00 && depth.Asks[0][0].Value <= price {
+			return model.ExchangeOrder{}, errors.New("Order would immediately match and take.")
+		}
+		if operation == "SELL" && len(depth.Bids) > 0 && depth.Bids[0][0].Value >= price {
+			return model.ExchangeOrder{}, errors.New("Order would immediately match and take.")
+		}
+	}
+
+	order, err := p.LimitOrder(symbol, quantity, price, operation, "GTC")
+	if err != nil {
+		return order, err
+	}
+
+	p.Lock.Lock()
+	order = p.Orders[order.OrderId]
+	order.Type = model.OrderTypeLimitMaker
+	p.Orders[order.OrderId] = order
+	p.Lock.Unlock()
+
+	return order, nil
+}
+
+// match fills the whole order if the best price of the opposite side reaches the order price, it is called under the lock
+func (p *Paper) match(order *model.ExchangeOrder, exchangeSymbol model.ExchangeSymbol, depth *model.OrderBook) {
+	if depth == nil {
+		return
+	}
+
+	fee := p.FeePercent / 100
+
+	if order.IsBuy() && len(depth.Asks) > 0 && depth.Asks[0][0].Value > 0.00 && depth.Asks[0][0].Value <= order.Price {
+		fillPrice := math.Min(order.Price, depth.Asks[0][0].Value)
+		// unused part of the reserved amount goes back
+		p.Balances[exchangeSymbol.QuoteAsset] += (order.Price - fillPrice) * order.OrigQty
+		p.Balances[exchangeSymbol.BaseAsset] += order.OrigQty * (1 - fee)
+		p.fill(order, fillPrice)
+	}
+
+	if order.IsSell() && len(depth.Bids) > 0 && depth.Bids[0][0].Value >= order.Price {
+		fillPrice := math.Max(order.Price, depth.Bids[0][0].Value)
+		p.Balances[exchangeSymbol.QuoteAsset] += order.OrigQty * fillPrice * (1 - fee)
+		p.fill(order, fillPrice)
+	}
+}
+
+func (p *Paper) fill(order *model.ExchangeOrder, price float64) {
+	order.Price = price
+	order.ExecutedQty = order.OrigQty
+	order.CummulativeQuoteQty = order.OrigQty * price
+	order.Status = model.ExchangeOrderStatusFilled
+	order.WorkingTime = time.Now().UnixMilli()
+}
+
+func (p *Paper) release(order *model.ExchangeOrder, exchangeSymbol model.ExchangeSymbol) {
+	if order.IsBuy() {
+		p.Balances[exchangeSymbol.QuoteAsset] += order.OrigQty * order.Price
+	} else {
+		p.Balances[exchangeSymbol.BaseAsset] += order.OrigQty
+	}
+}
+
+func (p *Paper) matchOpened() {
+	p.Lock.Lock()
+	opened := make([]model.ExchangeOrder, 0)
+	for _, order := range p.Orders {
+		if order.IsNew() {
+			opened = append(opened, order)
+		}
+	}
+	p.Lock.Unlock()
+
+	depths := make(map[string]*model.OrderBook)
+	for _, order := range opened {
+		exchangeSymbol, err := p.getSymbol(order.Symbol)
+		if err != nil {
+			continue
+		}
+
+		depth, ok := depths[order.Symbol]
+		if !ok {
+			depth = p.Exchange.GetDepth(order.Symbol, 5)
+			depths[order.Symbol] = depth
+		}
+
+		p.Lock.Lock()
+		current := p.Orders[order.OrderId]
+		if current.IsNew() {
+			p.match(&current, exchangeSymbol, depth)
+			p.Orders[current.OrderId] = current
+		}
+		p.Lock.Unlock()
+	}
+}
+
+func (p *Paper) QueryOrder(symbol string, orderId string) (model.ExchangeOrder, error) {
+	p.matchOpened()
+
+	p.Lock.Lock()
+	defer p.Lock.Unlock()
+
+	order, ok := p.Orders[orderId]
+	if !ok || order.Symbol != symbol {
+		return model.ExchangeOrder{}, errors.New("Order does not exist.")
+	}
+
+	return order, nil
+}
+
+func (p *Paper) CancelOrder(symbol string, orderId string) (model.ExchangeOrder, error) {
+	exchangeSymbol, err := p.getSymbol(symbol)
+	if err != nil {
+		return model.ExchangeOrder{}, err
+	}
+
+	p.Lock.Lock()
+	defer p.Lock.Unlock()
+
+	order, ok := p.Orders[orderId]
+	if !ok || order.Symbol != symbol {
+		return model.ExchangeOrder{}, errors.New("Order does not exist.")
+	}
+
+	if !order.IsNew() {
+		return order, errors.New("Order was not canceled due to cancel restrictions.")
+	}
+
+	p.release(&order, exchangeSymbol)
+	order.Status = model.ExchangeOrderStatusCanceled
+	p.Orders[orderId] = order
+
+	return order, nil
+}
+
+func (p *Paper) GetOpenedOrders() ([]model.ExchangeOrder, error) {
+	p.matchOpened()
+
+	p.Lock.Lock()
+	defer p.Lock.Unlock()
+
+	opened := make([]model.ExchangeOrder, 0)
+	for _, order := range p.Orders {
+		if order.IsNew() {
+			opened = append(opened, order)
+		}
+	}
+
+	return opened, nil
+}
+
+func (p *Paper) GetAccountStatus() (*model.AccountStatus, error) {
+	p.matchOpened()
+
+	p.Lock.Lock()
+	defer p.Lock.Unlock()
+
+	locked := make(map[string]float64)
+	for _, order := range p.Orders {
+		if !order.IsNew() {
+			continue
+		}
+
+		exchangeSymbol, ok := p.Symbols[order.Symbol]
+		if !ok {
+			continue
+		}
+
+		if order.IsBuy() {
+			locked[exchangeSymbol.QuoteAsset] += order.OrigQty * order.Price
+		} else {
+			locked[exchangeSymbol.BaseAsset] += order.OrigQty
+		}
+	}
+
+	balances := make([]model.Balance, 0)
+	for asset, free := range p.Balances {
+		balances = append(balances, model.Balance{
+			Asset:  asset,
+			Free:   free,
+			Locked: locked[asset],
+		})
+	}
+
+	return &model.AccountStatus{
+		Balances: balances,
+	}, nil
+}
+
+func (p *Paper) GetDepth(symbol string, limit int64) *model.OrderBook {
+	return p.Exchange.GetDepth(symbol, limit)
+}
+
+func (p *Paper) GetKLines(symbol string, interval string, limit int64) []model.KLineHistory {
+	return p.Exchange.GetKLines(symbol, interval, limit)
+}
+
+func (p *Paper) TradesAggregate(symbol string, limit int64, startTime int64, endTime int64) []model.Trade {
+	return p.Exchange.TradesAggregate(symbol, limit, startTime, endTime)
+}
+
+func (p *Paper) GetKLinesCached(symbol string, interval string, limit int64) []model.KLine {
+	return p.Exchange.GetKLinesCached(symbol, interval, limit)
+}
+
+func (p *Paper) GetExchangeData(symbols []string) (*model.ExchangeInfo, error) {
+	return p.Exchange.GetExchangeData(symbols)
+}
+
+func (p *Paper) GetTickers(symbols []string) []model.ExchangeTicker {
+	return p.Exchange.GetTickers(symbols)
+}
+
+func (p *Paper) IsConnected() bool {
+	return p.Exchange.IsConnected()
+}
+
+func (p *Paper) IsWaitMode() bool {
+	return p.Exchange.IsWaitMode()
+}
+
+func (p *Paper) IsAPIKeyCheckCompleted() bool {
+	return true
+}
+
+func (p *Paper) SetAPIKeyCheckCompleted(completed bool) {
+}
@@ -0,0 +1,274 @@
+package client
+
+import (
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const RateLimitPollInterval = time.Millisecond * 50
+
+// RateLimitClassBudget share of the weight limit available for the endpoint class,
+// order placement may use the whole limit, market data refresh leaves room for orders
+var RateLimitClassBudget = map[string]float64{
+	model.RateLimitClassOrder:   1.00,
+	model.RateLimitClassAccount: 0.90,
+	model.RateLimitClassMarket:  0.70,
+}
+
+var rateLimitClassPriority = map[string]int{
+	model.RateLimitClassOrder:   0,
+	model.RateLimitClassAccount: 1,
+	model.RateLimitClassMarket:  2,
+}
+
+type HttpRateLimiterInterface interface {
+	AcquireRequest(method string, url string)
+	ReadHeaders(method string, url string, headers http.Header)
+}
+
+// RateLimitGovernor budgets requests of the exchange client, the usage is counted locally
+// and corrected by the values the exchange returns (headers or WS-API rateLimits)
+type RateLimitGovernor struct {
+	Name          string
+	Interval      time.Duration
+	WeightLimit   int64
+	OrderInterval time.Duration
+	OrderLimit    int64
+	Classify      func(method string, url string) (string, int64)
+
+	usedWeight        int64
+	weightWindow      time.Time
+	orderCount        int64
+	orderWindow       time.Time
+	blockedUntil      time.Time
+	classBlockedUntil map[string]time.Time
+	queued            map[string]int64
+	mutex             sync.Mutex
+}
+
+func (g *RateLimitGovernor) Acquire(class string, weight int64) {
+	isQueued := false
+	for {
+		if g.TryAcquire(class, weight) {
+			if isQueued {
+				g.mutex.Lock()
+				g.queued[class]--
+				g.mutex.Unlock()
+			}
+
+			return
+		}
+
+		if !isQueued {
+			g.mutex.Lock()
+			g.init()
+			g.queued[class]++
+			g.mutex.Unlock()
+			isQueued = true
+		}
+
+		time.Sleep(RateLimitPollInterval)
+	}
+}
+
+func (g *RateLimitGovernor) TryAcquire(class string, weight int64) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	now := time.Now()
+	g.init()
+	g.rotate(now)
+
+	if now.Before(g.blockedUntil) || now.Before(g.classBlockedUntil[class]) {
+		return false
+	}
+
+	// requests of higher priority are served first
+	for queuedClass, queued := range g.queued {
+		if queued > 0 && rateLimitClassPriority[queuedClass] < rateLimitClassPriority[class] {
+			return false
+		}
+	}
+
+	budget, ok := RateLimitClassBudget[class]
+	if !ok {
+		budget = RateLimitClassBudget[model.RateLimitClassMarket]
+	}
+
+	if g.WeightLimit > 0 && float64(g.usedWeight+weight) > float64(g.WeightLimit)*budget {
+		return false
+	}
+
+	if class == model.RateLimitClassOrder && g.OrderLimit > 0 && g.orderCount >= g.OrderLimit {
+		return false
+	}
+
+	g.usedWeight += weight
+	if class == model.RateLimitClassOrder {
+		g.orderCount++
+	}
+
+	return true
+}
+
+func (g *RateLimitGovernor) AcquireRequest(method string, url string) {
+	class, weight := model.RateLimitClassMarket, int64(1)
+	if g.Classify != nil {
+		class, weight = g.Classify(method, url)
+	}
+
+	g.Acquire(class, weight)
+}
+
+func (g *RateLimitGovernor) UpdateWeight(used int64, limit int64) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.init()
+	g.rotate(time.Now())
+
+	// local counter includes requests which are not counted by exchange yet
+	if used > g.usedWeight {
+		g.usedWeight = used
+	}
+	if limit > 0 {
+		g.WeightLimit = limit
+	}
+}
+
+func (g *RateLimitGovernor) UpdateOrderCount(count int64, limit int64) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.init()
+	g.rotate(time.Now())
+
+	if count > g.orderCount {
+		g.orderCount = count
+	}
+	if limit > 0 {
+		g.OrderLimit = limit
+	}
+}
+
+func (g *RateLimitGovernor) BlockUntil(until time.Time) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if until.After(g.blockedUntil) {
+		g.blockedUntil = until
+		log.Printf("%s rate limit: requests are blocked until %s", g.Name, until.Format("2006-01-02 15:04:05"))
+	}
+}
+
+func (g *RateLimitGovernor) BlockClassUntil(class string, until time.Time) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.init()
+	if until.After(g.classBlockedUntil[class]) {
+		g.classBlockedUntil[class] = until
+	}
+}
+
+func (g *RateLimitGovernor) UpdateBinanceRateLimits(rateLimits []model.BinanceRateLimit) {
+	for _, rateLimit := range rateLimits {
+		switch rateLimit.RateLimitType {
+		case model.BinanceRateLimitTypeRequestWeight:
+			g.UpdateWeight(rateLimit.Count, rateLimit.Limit)
+			break
+		case model.BinanceRateLimitTypeOrders:
+			// daily order limit is not budgeted
+			if rateLimit.Interval == "SECOND" {
+				g.UpdateOrderCount(rateLimit.Count, rateLimit.Limit)
+			}
+			break
+		}
+	}
+}
+
+func (g *RateLimitGovernor) ReadHeaders(method string, url string, headers http.Header) {
+	// Binance REST API
+	if used, err := strconv.ParseInt(headers.Get("X-MBX-USED-WEIGHT-1M"), 10, 64); err == nil {
+		g.UpdateWeight(used, 0)
+	}
+	if count, err := strconv.ParseInt(headers.Get("X-MBX-ORDER-COUNT-10S"), 10, 64); err == nil {
+		g.UpdateOrderCount(count, 0)
+	}
+
+	// ByBit limits are per endpoint, the endpoint class is paused until reset when nothing remains
+	remaining, err := strconv.ParseInt(headers.Get("X-Bapi-Limit-Status"), 10, 64)
+	if err != nil || remaining > 0 {
+		return
+	}
+
+	resetAt, err := strconv.ParseInt(headers.Get("X-Bapi-Limit-Reset-Timestamp"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	class := model.RateLimitClassMarket
+	if g.Classify != nil {
+		class, _ = g.Classify(method, url)
+	}
+
+	log.Printf("%s rate limit: %s limit %s is reached", g.Name, class, headers.Get("X-Bapi-Limit"))
+	g.BlockClassUntil(class, time.UnixMilli(resetAt))
+}
+
+func (g *RateLimitGovernor) GetUsage() model.RateLimitUsage {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.init()
+	g.rotate(time.Now())
+
+	queued := make(map[string]int64)
+	for class, count := range g.queued {
+		queued[class] = count
+	}
+
+	blockedUntil := int64(0)
+	if time.Now().Before(g.blockedUntil) {
+		blockedUntil = g.blockedUntil.Unix()
+	}
+
+	return model.RateLimitUsage{
+		UsedWeight:   g.usedWeight,
+		WeightLimit:  g.WeightLimit,
+		OrderCount:   g.orderCount,
+		OrderLimit:   g.OrderLimit,
+		Queued:       queued,
+		BlockedUntil: blockedUntil,
+	}
+}
+
+func (g *RateLimitGovernor) init() {
+	if g.queued == nil {
+		g.queued = make(map[string]int64)
+		g.classBlockedUntil = make(map[string]time.Time)
+	}
+}
+
+// rotate exchange counters are reset at the start of the interval (e.g. every minute)
+func (g *RateLimitGovernor) rotate(now time.Time) {
+	if g.Interval > 0 {
+		window := now.Truncate(g.Interval)
+		if !window.Equal(g.weightWindow) {
+			g.weightWindow = window
+			g.usedWeight = 0
+		}
+	}
+
+	if g.OrderInterval > 0 {
+		window := now.Truncate(g.OrderInterval)
+		if !window.Equal(g.orderWindow) {
+			g.orderWindow = window
+			g.orderCount = 0
+		}
+	}
+}
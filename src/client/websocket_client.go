@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/gorilla/websocket"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/metrics"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
-	"log"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"log/slog"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -61,7 +65,8 @@ func GetStreamBatchByBit(tradeLimits []model.SymbolInterface, events []string) [
 func Listen(address string, tradeChannel chan<- []byte, streams []string, connectionId int64) *websocket.Conn {
 	connection, _, err := websocket.DefaultDialer.Dial(address, nil)
 	if err != nil {
-		log.Printf("Binance [err_1] WS Events [%s]: %s, wait and reconnect...", address, err.Error())
+		slog.Error("Connection error, wait and reconnect", logger.KeyExchange, "binance", logger.KeyStream, "market", "connectionId", connectionId, logger.Error(err))
+		metrics.WSReconnects.Inc("binance", "market")
 		time.Sleep(time.Second * 3)
 		connectionId++
 
@@ -72,10 +77,10 @@ func Listen(address string, tradeChannel chan<- []byte, streams []string, connec
 		for {
 			_, message, err := connection.ReadMessage()
 			if err != nil {
-				log.Printf("Binance [err_2] WS Events, read [%s]: %s", address, err.Error())
+				slog.Warn("Stream is disconnected, wait and reconnect", logger.KeyExchange, "binance", logger.KeyStream, "market", "connectionId", connectionId, logger.Error(err))
 
 				_ = connection.Close()
-				log.Printf("Binance [err_2] WS Events, wait and reconnect...")
+				metrics.WSReconnects.Inc("binance", "market")
 				time.Sleep(time.Second * 3)
 				connectionId++
 				Listen(address, tradeChannel, streams, connectionId)
@@ -103,7 +108,8 @@ func Listen(address string, tradeChannel chan<- []byte, streams []string, connec
 func ListenByBit(address string, tradeChannel chan<- []byte, streams []string, connectionId int64) *websocket.Conn {
 	connection, _, err := websocket.DefaultDialer.Dial(address, nil)
 	if err != nil {
-		log.Printf("ByBit [err_1] WS Events [%s]: %s, wait and reconnect...", address, err.Error())
+		slog.Error("Connection error, wait and reconnect", logger.KeyExchange, "bybit", logger.KeyStream, "market", "connectionId", connectionId, logger.Error(err))
+		metrics.WSReconnects.Inc("bybit", "market")
 		time.Sleep(time.Second * 3)
 		connectionId++
 
@@ -114,10 +120,10 @@ func ListenByBit(address string, tradeChannel chan<- []byte, streams []string, c
 		for {
 			_, message, err := connection.ReadMessage()
 			if err != nil {
-				log.Printf("ByBit [err_2] WS Events, read [%s]: %s", address, err.Error())
+				slog.Warn("Stream is disconnected, wait and reconnect", logger.KeyExchange, "bybit", logger.KeyStream, "market", "connectionId", connectionId, logger.Error(err))
 
 				_ = connection.Close()
-				log.Printf("ByBit [err_2] WS Events, wait and reconnect...")
+				metrics.WSReconnects.Inc("bybit", "market")
 				time.Sleep(time.Second * 3)
 				connectionId++
 				ListenByBit(address, tradeChannel, streams, connectionId)
@@ -140,3 +146,143 @@ func ListenByBit(address string, tradeChannel chan<- []byte, streams []string, c
 
 	return connection
 }
+
+func GetStreamBatchOkx(tradeLimits []model.SymbolInterface, channels []string, formatter *utils.Formatter) [][]model.OkxWsArgument {
+	streamBatch := make([][]model.OkxWsArgument, 0)
+
+	streams := make([]model.OkxWsArgument, 0)
+
+	for _, tradeLimit := range tradeLimits {
+		for i := 0; i < len(channels); i++ {
+			streams = append(streams, model.OkxWsArgument{
+				Channel: channels[i],
+				InstId:  formatter.BinanceSymbolToOkxInstId(tradeLimit.GetSymbol()),
+			})
+		}
+
+		if len(streams) >= 24 {
+			streamBatch = append(streamBatch, streams)
+			streams = make([]model.OkxWsArgument, 0)
+		}
+	}
+
+	if len(streams) > 0 {
+		streamBatch = append(streamBatch, streams)
+	}
+
+	return streamBatch
+}
+
+func ListenOkx(address string, tradeChannel chan<- []byte, streams []model.OkxWsArgument, connectionId int64) *websocket.Conn {
+	connection, _, err := websocket.DefaultDialer.Dial(address, nil)
+	if err != nil {
+		slog.Error("Connection error, wait and reconnect", logger.KeyExchange, "okx", logger.KeyStream, "market", "connectionId", connectionId, logger.Error(err))
+		metrics.WSReconnects.Inc("okx", "market")
+		time.Sleep(time.Second * 3)
+		connectionId++
+
+		return ListenOkx(address, tradeChannel, streams, connectionId)
+	}
+
+	lock := sync.Mutex{}
+	closed := make(chan bool)
+
+	go func() {
+		for {
+			_, message, err := connection.ReadMessage()
+			if err != nil {
+				slog.Warn("Stream is disconnected, wait and reconnect", logger.KeyExchange, "okx", logger.KeyStream, "market", "connectionId", connectionId, logger.Error(err))
+
+				close(closed)
+				_ = connection.Close()
+				metrics.WSReconnects.Inc("okx", "market")
+				time.Sleep(time.Second * 3)
+				connectionId++
+				ListenOkx(address, tradeChannel, streams, connectionId)
+				return
+			}
+
+			tradeChannel <- message
+		}
+	}()
+
+	// OKX closes connection if there is no data within 30 seconds
+	go func() {
+		for {
+			select {
+			case <-closed:
+				return
+			case <-time.After(time.Second * 20):
+				lock.Lock()
+				_ = connection.WriteMessage(websocket.TextMessage, []byte("ping"))
+				lock.Unlock()
+			}
+		}
+	}()
+
+	if len(streams) > 0 {
+		socketRequest := model.OkxSocketStreamsRequest{
+			Operation: "subscribe",
+			Arguments: streams,
+		}
+		serialized, _ := json.Marshal(socketRequest)
+		lock.Lock()
+		_ = connection.WriteMessage(websocket.TextMessage, serialized)
+		lock.Unlock()
+	}
+
+	return connection
+}
+
+// ListenPrivate connects to user data stream without reconnect, the stream is re-authorized by the caller
+// when done channel is closed. Requests are sent once connection is established, ping is sent every 20 seconds.
+func ListenPrivate(address string, eventChannel chan<- []byte, requests [][]byte, ping []byte) (*websocket.Conn, <-chan bool, error) {
+	connection, _, err := websocket.DefaultDialer.Dial(address, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lock := sync.Mutex{}
+	done := make(chan bool)
+
+	go func() {
+		for {
+			_, message, err := connection.ReadMessage()
+			if err != nil {
+				slog.Warn("Stream is disconnected", logger.KeyStream, "user_data", logger.Error(err))
+				close(done)
+				_ = connection.Close()
+				return
+			}
+
+			eventChannel <- message
+		}
+	}()
+
+	if ping != nil {
+		go func() {
+			for {
+				select {
+				case <-done:
+					return
+				case <-time.After(time.Second * 20):
+					lock.Lock()
+					_ = connection.WriteMessage(websocket.TextMessage, ping)
+					lock.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, request := range requests {
+		lock.Lock()
+		err = connection.WriteMessage(websocket.TextMessage, request)
+		lock.Unlock()
+		if err != nil {
+			_ = connection.Close()
+			return nil, nil, err
+		}
+	}
+
+	return connection, done, nil
+}
@@ -9,24 +9,32 @@ import (
 	"gitlab.com/open-soft/go-crypto-bot/src/client"
 	"gitlab.com/open-soft/go-crypto-bot/src/controller"
 	"gitlab.com/open-soft/go-crypto-bot/src/event_subscriber"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/metrics"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
 	"gitlab.com/open-soft/go-crypto-bot/src/service"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/backtest"
 	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/indicator"
 	"gitlab.com/open-soft/go-crypto-bot/src/service/ml"
 	"gitlab.com/open-soft/go-crypto-bot/src/service/strategy"
 	"gitlab.com/open-soft/go-crypto-bot/src/utils"
 	"gitlab.com/open-soft/go-crypto-bot/src/validator"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"runtime"
+	"strconv"
 	"sync"
 	"time"
 )
 
 const BotExchangeBinance = "binance"
 const BotExchangeByBit = "bybit"
+const BotExchangeOkx = "okx"
+const BotExchangePaper = "paper"
 
 func InitServiceContainer() Container {
 	if runtime.GOMAXPROCS(0) < 2 {
@@ -108,6 +116,7 @@ func InitServiceContainer() Container {
 			IsMasterBot:       false,
 			IsSwapEnabled:     false,
 			TradeStackSorting: model.TradeStackSortingLessPriceDiff,
+			ReportingCurrency: model.DefaultQuoteAsset,
 			SwapConfig: model.SwapConfig{
 				MinValidPercent:    2.00,
 				FallPercentTrigger: -5.00,
@@ -132,8 +141,26 @@ func InitServiceContainer() Container {
 	var exchangeApi client.ExchangeAPIInterface
 	var exchangeWSStreamer strategy.ExchangeWSStreamer
 
-	switch botExchange {
+	// paper trading uses market data of the real exchange
+	marketExchange := botExchange
+	if botExchange == BotExchangePaper {
+		marketExchange = os.Getenv("PAPER_EXCHANGE")
+		if marketExchange == "" {
+			marketExchange = BotExchangeBinance
+		}
+	}
+
+	var rateLimitGovernor *client.RateLimitGovernor
+
+	switch marketExchange {
 	case BotExchangeBinance:
+		rateLimitGovernor = &client.RateLimitGovernor{
+			Name:          "Binance",
+			Interval:      time.Minute,
+			WeightLimit:   6000,
+			OrderInterval: time.Second * 10,
+			OrderLimit:    50,
+		}
 		binanceExchange := client.Binance{
 			CurrentBot:           currentBot,
 			ApiKey:               os.Getenv("BINANCE_API_KEY"),
@@ -142,6 +169,7 @@ func InitServiceContainer() Container {
 			SocketWriter:         make(chan []byte, 500),
 			RDB:                  rdb,
 			Ctx:                  &ctx,
+			RateLimitGovernor:    rateLimitGovernor,
 			WaitMode:             false,
 			APIKeyCheckCompleted: false,
 			Connected:            false,
@@ -151,9 +179,17 @@ func InitServiceContainer() Container {
 		exchangeApi = &binanceExchange
 		break
 	case BotExchangeByBit:
+		rateLimitGovernor = &client.RateLimitGovernor{
+			Name:          "ByBit",
+			Interval:      time.Second * 5,
+			WeightLimit:   600,
+			OrderInterval: time.Second,
+			OrderLimit:    20,
+			Classify:      client.GetByBitRequestClass,
+		}
 		exchangeApi = &client.ByBit{
 			CurrentBot:           currentBot,
-			HttpClient:           &client.HttpClient{},
+			HttpClient:           &client.HttpClient{RateLimiter: rateLimitGovernor, Exchange: BotExchangeByBit},
 			ApiKey:               os.Getenv("BYBIT_API_KEY"),
 			ApiSecret:            os.Getenv("BYBIT_API_SECRET"),
 			DSN:                  os.Getenv("BYBIT_API_DSN"),
@@ -163,10 +199,40 @@ func InitServiceContainer() Container {
 			APIKeyCheckCompleted: false,
 		}
 		break
+	case BotExchangeOkx:
+		exchangeApi = &client.Okx{
+			CurrentBot:           currentBot,
+			HttpClient:           &client.HttpClient{Exchange: BotExchangeOkx},
+			ApiKey:               os.Getenv("OKX_API_KEY"),
+			ApiSecret:            os.Getenv("OKX_API_SECRET"),
+			Passphrase:           os.Getenv("OKX_API_PASSPHRASE"),
+			DSN:                  os.Getenv("OKX_API_DSN"),
+			Formatter:            &formatter,
+			RDB:                  rdb,
+			Ctx:                  &ctx,
+			APIKeyCheckCompleted: false,
+		}
+		break
 	default:
 		log.Panic(fmt.Sprintf("Unsupported exchange: %s", botExchange))
 	}
 
+	if botExchange == BotExchangePaper {
+		paperBalance, err := strconv.ParseFloat(os.Getenv("PAPER_BALANCE"), 64)
+		if err != nil {
+			paperBalance = 1000.00
+		}
+
+		exchangeApi = &client.Paper{
+			Exchange:   exchangeApi,
+			FeePercent: 0.1,
+			Balances:   map[string]float64{currentBot.GetReportingCurrency(): paperBalance},
+			Orders:     make(map[string]model.ExchangeOrder),
+			Symbols:    make(map[string]model.ExchangeSymbol),
+			Lock:       &sync.Mutex{},
+		}
+	}
+
 	objectRepository := repository.ObjectRepository{
 		DB:         db,
 		CurrentBot: currentBot,
@@ -187,6 +253,7 @@ func InitServiceContainer() Container {
 	smaStrategy := strategy.SmaTradeStrategy{
 		ExchangeRepository: &exchangeRepository,
 	}
+	strategyRegistry := exchange.StrategyRegistry{}
 
 	var swapStreamListener exchange.SwapStreamListenerInterface
 	swapUpdater := exchange.SwapUpdater{
@@ -229,12 +296,16 @@ func InitServiceContainer() Container {
 		},
 	}
 
-	switch botExchange {
+	orderBookKeeper := exchange.OrderBookKeeper{
+		Binance: exchangeApi,
+	}
+
+	switch marketExchange {
 	case BotExchangeBinance:
 		exchangeWSStreamer = &strategy.BinanceWSStreamer{
-			SmaTradeStrategy:    &smaStrategy,
-			MarketDepthStrategy: &marketDepthStrategy,
-			ExchangeRepository:  &exchangeRepository,
+			ExchangeRepository: &exchangeRepository,
+			StrategyRegistry:   &strategyRegistry,
+			OrderBookKeeper:    &orderBookKeeper,
 		}
 		swapStreamListener = &exchange.BinanceSwapStreamListener{
 			ExchangeRepository: &exchangeRepository,
@@ -244,11 +315,13 @@ func InitServiceContainer() Container {
 		}
 		break
 	case BotExchangeByBit:
+		// orderbook.50 stream, REST snapshot is from another update sequence
+		orderBookKeeper.StreamDepth = 50
 		exchangeWSStreamer = &strategy.ByBitWsStreamer{
-			ExchangeRepository:  &exchangeRepository,
-			SmaTradeStrategy:    &smaStrategy,
-			MarketDepthStrategy: &marketDepthStrategy,
-			Formatter:           &formatter,
+			ExchangeRepository: &exchangeRepository,
+			StrategyRegistry:   &strategyRegistry,
+			Formatter:          &formatter,
+			OrderBookKeeper:    &orderBookKeeper,
 		}
 		swapStreamListener = &exchange.BybitSwapStreamListener{
 			ExchangeRepository: &exchangeRepository,
@@ -258,6 +331,20 @@ func InitServiceContainer() Container {
 			Formatter:          &formatter,
 		}
 		break
+	case BotExchangeOkx:
+		exchangeWSStreamer = &strategy.OkxWsStreamer{
+			ExchangeRepository: &exchangeRepository,
+			StrategyRegistry:   &strategyRegistry,
+			Formatter:          &formatter,
+		}
+		swapStreamListener = &exchange.OkxSwapStreamListener{
+			ExchangeRepository: &exchangeRepository,
+			SwapUpdater:        &swapUpdater,
+			SwapRepository:     &swapRepository,
+			SwapManager:        &swapManager,
+			Formatter:          &formatter,
+		}
+		break
 	default:
 		log.Panic(fmt.Sprintf("Unsupported exchange: %s", botExchange))
 	}
@@ -267,6 +354,11 @@ func InitServiceContainer() Container {
 		CurrentBot: currentBot,
 	}
 
+	indicatorService := indicator.IndicatorService{
+		ExchangeRepository: &exchangeRepository,
+		StatRepository:     &statRepository,
+	}
+
 	frameService := exchange.FrameService{
 		CurrentBot: currentBot,
 		RDB:        rdb,
@@ -281,9 +373,7 @@ func InitServiceContainer() Container {
 		CurrentBot: currentBot,
 	}
 
-	callbackManager := service.CallbackManager{
-		AutoTradeHost: "https://api.autotrade.cloud",
-	}
+	notificationManager := InitNotificationManager()
 	orderRepository := repository.OrderRepository{
 		DB:               db,
 		RDB:              rdb,
@@ -310,6 +400,51 @@ func InitServiceContainer() Container {
 		Binance:    exchangeApi,
 		BotService: &botService,
 	}
+	stopLossService := exchange.StopLossService{
+		BotService: &botService,
+	}
+	currencyConverter := exchange.CurrencyConverter{
+		Binance:            exchangeApi,
+		ExchangeRepository: &exchangeRepository,
+		BotService:         &botService,
+	}
+	riskManager := exchange.RiskManager{
+		OrderRepository:    &orderRepository,
+		ProfitRepository:   &orderRepository,
+		ExchangeRepository: &exchangeRepository,
+		BalanceService:     &balanceService,
+		CurrencyConverter:  &currencyConverter,
+		BotService:         &botService,
+		CallbackManager:    notificationManager,
+		TimeService:        &timeService,
+		Location:           GetRiskLocation(),
+	}
+	dailySummaryService := exchange.DailySummaryService{
+		RiskManager:     &riskManager,
+		BotService:      &botService,
+		CallbackManager: notificationManager,
+		TimeService:     &timeService,
+		Hour:            GetDailySummaryHour(),
+	}
+	// only Binance has exchange side protection (OCO), ByBit and OKX spot can't cancel one leg by another
+	protectionApi, _ := exchangeApi.(client.ExchangeProtectionAPIInterface)
+	if protectionApi == nil && currentBot.RiskConfig.ExchangeProtection {
+		slog.Warn("Exchange protection is enabled, but the exchange can't place it, positions are protected by the bot only", logger.KeyExchange, currentBot.Exchange)
+	}
+	protectionService := exchange.ProtectionService{
+		Binance:            exchangeApi,
+		ProtectionApi:      protectionApi,
+		OrderRepository:    &orderRepository,
+		ExchangeRepository: &exchangeRepository,
+		ProfitService:      &profitService,
+		BalanceService:     &balanceService,
+		RiskManager:        &riskManager,
+		BotService:         &botService,
+		CallbackManager:    notificationManager,
+		Formatter:          &formatter,
+		TimeService:        &timeService,
+		CurrentBot:         currentBot,
+	}
 
 	lossSecurity := exchange.LossSecurity{
 		MlEnabled:            true,
@@ -336,17 +471,9 @@ func InitServiceContainer() Container {
 		SignalStorage:      &signalRepository,
 	}
 
-	pythonMLBridge := ml.PythonMLBridge{
-		DataSetBuilder: &ml.DataSetBuilder{
-			StatRepository: &statRepository,
-		},
-		ExchangeRepository: &exchangeRepository,
-		SwapRepository:     &swapRepository,
-		TimeService:        &timeService,
-		CurrentBot:         currentBot,
-		RDB:                rdb,
-		Ctx:                &ctx,
-		Learning:           true,
+	mlModelRepository := repository.MLModelRepository{
+		DB:         db,
+		CurrentBot: currentBot,
 	}
 
 	statService := service.StatService{
@@ -354,6 +481,17 @@ func InitServiceContainer() Container {
 		ExchangeRepository: &exchangeRepository,
 	}
 
+	mlService := ml.MLService{
+		DataSetBuilder: &ml.DataSetBuilder{
+			StatRepository:     &statRepository,
+			ExchangeRepository: &exchangeRepository,
+		},
+		ExchangeRepository: &exchangeRepository,
+		MLModelRepository:  &mlModelRepository,
+		StatService:        &statService,
+		TimeService:        &timeService,
+	}
+
 	chartService := service.ChartService{
 		ExchangeRepository: &exchangeRepository,
 		OrderRepository:    &orderRepository,
@@ -362,6 +500,34 @@ func InitServiceContainer() Container {
 		StatService:        &statService,
 	}
 
+	shutdownManager := service.ShutdownManager{}
+
+	swapExecutor := exchange.SwapExecutor{
+		BalanceService:           &balanceService,
+		SwapRepository:           &swapRepository,
+		OrderRepository:          &orderRepository,
+		Binance:                  exchangeApi,
+		Formatter:                &formatter,
+		TimeService:              &timeService,
+		CurrentBot:               currentBot,
+		SwapFirstAmendmentSteps:  exchange.SwapFirstAmendmentSteps,
+		SwapSecondAmendmentSteps: exchange.SwapSecondAmendmentSteps,
+		SwapThirdAmendmentSteps:  exchange.SwapThirdAmendmentSteps,
+		ShutdownManager:          &shutdownManager,
+		CallbackManager:          notificationManager,
+	}
+
+	swapRecoveryService := exchange.SwapRecoveryService{
+		SwapRepository:  &swapRepository,
+		OrderRepository: &orderRepository,
+		SwapExecutor:    &swapExecutor,
+		Binance:         exchangeApi,
+		BalanceService:  &balanceService,
+		TimeService:     &timeService,
+		StuckMinutes:    exchange.SwapRecoveryStuckMinutes,
+		ShutdownManager: &shutdownManager,
+	}
+
 	exchangeController := controller.ExchangeController{
 		SwapRepository:     &swapRepository,
 		ExchangeRepository: &exchangeRepository,
@@ -372,6 +538,8 @@ func InitServiceContainer() Container {
 		BotService:         &botService,
 		BalanceService:     &balanceService,
 		Exchange:           exchangeApi,
+		IndicatorService:   &indicatorService,
+		SwapRecovery:       &swapRecoveryService,
 	}
 
 	tradeFilterService := exchange.TradeFilterService{
@@ -380,6 +548,7 @@ func InitServiceContainer() Container {
 		ExchangePriceAPI:  exchangeApi,
 		Formatter:         &formatter,
 		SignalStorage:     &signalRepository,
+		IndicatorService:  &indicatorService,
 	}
 
 	tradeStack := exchange.TradeStack{
@@ -396,31 +565,57 @@ func InitServiceContainer() Container {
 		SignalStorage:      &signalRepository,
 	}
 
+	userDataProcessor := exchange.UserDataProcessor{
+		OrderRepository: &orderRepository,
+		BalanceService:  &balanceService,
+	}
+
+	// paper trading has no user data, orders are polled as before if the stream is not configured
+	var userDataStream exchange.UserDataStreamListenerInterface
+	switch exchangeApi.(type) {
+	case *client.Binance:
+		userDataStreamDSN := os.Getenv("BINANCE_USER_STREAM_DSN")
+		if userDataStreamDSN == "" {
+			userDataStreamDSN = os.Getenv("BINANCE_STREAM_DSN")
+		}
+		userDataStream = &exchange.BinanceUserDataStream{
+			Binance:   exchangeApi.(*client.Binance),
+			Processor: &userDataProcessor,
+			DSN:       userDataStreamDSN,
+		}
+		break
+	case *client.ByBit:
+		if os.Getenv("BYBIT_PRIVATE_STREAM_DSN") != "" {
+			userDataStream = &exchange.ByBitUserDataStream{
+				ByBit:     exchangeApi.(*client.ByBit),
+				Processor: &userDataProcessor,
+				Formatter: &formatter,
+				DSN:       os.Getenv("BYBIT_PRIVATE_STREAM_DSN"),
+			}
+		}
+		break
+	}
+
 	orderExecutor := exchange.OrderExecutor{
-		TradeStack:         &tradeStack,
-		LossSecurity:       &lossSecurity,
-		CurrentBot:         currentBot,
-		TimeService:        &timeService,
-		BalanceService:     &balanceService,
-		Binance:            exchangeApi,
-		OrderRepository:    &orderRepository,
-		ExchangeRepository: &exchangeRepository,
-		PriceCalculator:    &priceCalculator,
-		ProfitService:      &profitService,
-		CallbackManager:    &callbackManager,
-		SwapRepository:     &swapRepository,
-		SwapExecutor: &exchange.SwapExecutor{
-			BalanceService:           &balanceService,
-			SwapRepository:           &swapRepository,
-			OrderRepository:          &orderRepository,
-			Binance:                  exchangeApi,
-			Formatter:                &formatter,
-			TimeService:              &timeService,
-			CurrentBot:               currentBot,
-			SwapFirstAmendmentSteps:  exchange.SwapFirstAmendmentSteps,
-			SwapSecondAmendmentSteps: exchange.SwapSecondAmendmentSteps,
-			SwapThirdAmendmentSteps:  exchange.SwapThirdAmendmentSteps,
-		},
+		TradeStack:             &tradeStack,
+		LossSecurity:           &lossSecurity,
+		CurrentBot:             currentBot,
+		TimeService:            &timeService,
+		BalanceService:         &balanceService,
+		Binance:                exchangeApi,
+		OrderRepository:        &orderRepository,
+		ExchangeRepository:     &exchangeRepository,
+		PriceCalculator:        &priceCalculator,
+		ProfitService:          &profitService,
+		StopLossService:        &stopLossService,
+		ProtectionService:      &protectionService,
+		RiskManager:            &riskManager,
+		UserDataStream:         &userDataProcessor,
+		LifecycleRepository:    &orderRepository,
+		ShutdownManager:        &shutdownManager,
+		CallbackManager:        notificationManager,
+		SwapRepository:         &swapRepository,
+		SwapExecutor:           &swapExecutor,
 		SwapValidator:          &swapValidator,
 		Formatter:              &formatter,
 		BotService:             &botService,
@@ -444,12 +639,18 @@ func InitServiceContainer() Container {
 		CurrentBot:         currentBot,
 		PriceCalculator:    &priceCalculator,
 		BotService:         &botService,
+		TimeService:        &timeService,
+		StopLossService:    &stopLossService,
+		RiskManager:        &riskManager,
+		ProtectionService:  &protectionService,
+		ShutdownManager:    &shutdownManager,
 		StrategyFacade: &exchange.StrategyFacade{
 			MinDecisions:        3.00,
 			OrderRepository:     &orderRepository,
 			DecisionReadStorage: &exchangeRepository,
 			ExchangeRepository:  &exchangeRepository,
 			BotService:          &botService,
+			StrategyRegistry:    &strategyRegistry,
 		},
 	}
 
@@ -472,6 +673,7 @@ func InitServiceContainer() Container {
 		BotService:             &botService,
 		ProfitService:          &profitService,
 		TradeFilterService:     &tradeFilterService,
+		ProtectionService:      &protectionService,
 		ExchangeAPI:            exchangeApi,
 	}
 
@@ -481,6 +683,11 @@ func InitServiceContainer() Container {
 		TradeStack:          &tradeStack,
 		TradeLimitValidator: &tradeLimitValidator,
 		SignalRepository:    &signalRepository,
+		Backtester: &backtest.Backtester{
+			StatRepository: &statRepository,
+			Formatter:      &formatter,
+			CurrentBot:     currentBot,
+		},
 	}
 
 	baseKLineStrategy := strategy.BaseKLineStrategy{
@@ -488,16 +695,25 @@ func InitServiceContainer() Container {
 		TradeStack:         &tradeStack,
 		ExchangeRepository: &exchangeRepository,
 		Formatter:          &formatter,
+		IndicatorService:   &indicatorService,
 		MlEnabled:          true,
 	}
 	orderBasedStrategy := strategy.OrderBasedStrategy{
 		ExchangeRepository: &exchangeRepository,
 		OrderRepository:    &orderRepository,
 		ProfitService:      &profitService,
+		StopLossService:    &stopLossService,
 		BotService:         &botService,
 		SignalStorage:      &signalRepository,
 	}
 
+	for _, registered := range []exchange.StrategyInterface{&baseKLineStrategy, &orderBasedStrategy, &smaStrategy, &marketDepthStrategy} {
+		err := strategyRegistry.Register(registered)
+		if err != nil {
+			log.Panic(err)
+		}
+	}
+
 	go func() {
 		for {
 			lock := <-lockTradeChannel
@@ -510,7 +726,7 @@ func InitServiceContainer() Container {
 	healthService := service.HealthService{
 		BotRepository:      &botRepository,
 		ExchangeRepository: &exchangeRepository,
-		PythonMLBridge:     &pythonMLBridge,
+		MLService:          &mlService,
 		Binance:            exchangeApi,
 		CurrentBot:         currentBot,
 		DB:                 db,
@@ -518,12 +734,22 @@ func InitServiceContainer() Container {
 		RDB:                rdb,
 		Ctx:                &ctx,
 		TimeService:        &timeService,
+		RateLimitGovernor:  rateLimitGovernor,
 	}
+	metrics.Default.OnCollect(healthService.UpdateMetrics)
 
 	botController := controller.BotController{
-		HealthService: &healthService,
-		CurrentBot:    currentBot,
-		BotRepository: &botRepository,
+		HealthService:       &healthService,
+		CurrentBot:          currentBot,
+		BotRepository:       &botRepository,
+		RiskManager:         &riskManager,
+		ProtectionSupported: protectionApi != nil,
+	}
+
+	mlController := controller.MLController{
+		MLModelRepository: &mlModelRepository,
+		MLService:         &mlService,
+		CurrentBot:        currentBot,
 	}
 
 	mcGatewayAddress := "" //os.Getenv("MC_DSN")
@@ -541,49 +767,56 @@ func InitServiceContainer() Container {
 				BotService:         &botService,
 				StatService:        &statService,
 			},
+			&indicator.KLineEventSubscriber{
+				IndicatorService: &indicatorService,
+			},
 		},
 		Enabled: false,
 	}
 
 	return Container{
-		PriceCalculator:     &priceCalculator,
-		BotController:       &botController,
-		HealthService:       &healthService,
-		Db:                  db,
-		DbSwap:              swapDb,
-		CurrentBot:          currentBot,
-		CallbackManager:     &callbackManager,
-		BalanceService:      &balanceService,
-		TimeService:         &timeService,
-		Binance:             exchangeApi,
-		PythonMLBridge:      &pythonMLBridge,
-		SwapRepository:      &swapRepository,
-		ExchangeRepository:  &exchangeRepository,
-		OrderRepository:     &orderRepository,
-		ExchangeController:  &exchangeController,
-		TradeController:     &tradeController,
-		OrderController:     &orderController,
-		MakerService:        &makerService,
-		OrderExecutor:       &orderExecutor,
-		SwapManager:         &swapManager,
-		SwapUpdater:         &swapUpdater,
-		SmaTradeStrategy:    &smaStrategy,
-		MarketDepthStrategy: &marketDepthStrategy,
-		OrderBasedStrategy:  &orderBasedStrategy,
-		BaseKLineStrategy:   &baseKLineStrategy,
-		IsMasterBot:         botService.IsMasterBot(),
+		PriceCalculator:    &priceCalculator,
+		BotController:      &botController,
+		HealthService:      &healthService,
+		Db:                 db,
+		DbSwap:             swapDb,
+		CurrentBot:         currentBot,
+		CallbackManager:    notificationManager,
+		BalanceService:     &balanceService,
+		TimeService:        &timeService,
+		Binance:            exchangeApi,
+		MLService:          &mlService,
+		SwapRepository:     &swapRepository,
+		ExchangeRepository: &exchangeRepository,
+		OrderRepository:    &orderRepository,
+		ExchangeController: &exchangeController,
+		TradeController:    &tradeController,
+		OrderController:    &orderController,
+		MLController:       &mlController,
+		MakerService:       &makerService,
+		OrderExecutor:      &orderExecutor,
+		UserDataStream:     userDataStream,
+		ShutdownManager:    &shutdownManager,
+		SwapManager:        &swapManager,
+		SwapUpdater:        &swapUpdater,
+		SwapRecovery:       &swapRecoveryService,
+		DailySummary:       &dailySummaryService,
+		StrategyRegistry:   &strategyRegistry,
+		IsMasterBot:        botService.IsMasterBot(),
+		ApiAuthenticator: &controller.ApiAuthenticator{
+			Tokens:         controller.ParseApiTokens(os.Getenv("API_TOKENS")),
+			AllowedOrigins: controller.ParseAllowedOrigins(os.Getenv("CORS_ALLOWED_ORIGINS")),
+		},
 		MarketTradeListener: &strategy.MarketTradeListener{
-			SmaTradeStrategy:    &smaStrategy,
-			MarketDepthStrategy: &marketDepthStrategy,
-			OrderBasedStrategy:  &orderBasedStrategy,
-			BaseKLineStrategy:   &baseKLineStrategy,
-			ExchangeRepository:  &exchangeRepository,
-			TimeService:         &timeService,
-			Binance:             exchangeApi,
-			PythonMLBridge:      &pythonMLBridge,
-			PriceCalculator:     &priceCalculator,
-			EventDispatcher:     &eventDispatcher,
-			ExchangeWSStreamer:  exchangeWSStreamer,
+			StrategyRegistry:   &strategyRegistry,
+			ExchangeRepository: &exchangeRepository,
+			TimeService:        &timeService,
+			Binance:            exchangeApi,
+			MLService:          &mlService,
+			PriceCalculator:    &priceCalculator,
+			EventDispatcher:    &eventDispatcher,
+			ExchangeWSStreamer: exchangeWSStreamer,
+			CurrentBot:         currentBot,
 		},
 		MarketSwapListener: &exchange.MarketSwapListener{
 			ExchangeRepository: &exchangeRepository,
@@ -605,63 +838,49 @@ type Container struct {
 	Db                  *sql.DB
 	DbSwap              *sql.DB
 	CurrentBot          *model.Bot
-	CallbackManager     *service.CallbackManager
+	CallbackManager     *service.NotificationManager
 	BalanceService      *exchange.BalanceService
 	TimeService         *utils.TimeHelper
 	Binance             client.ExchangeAPIInterface
-	PythonMLBridge      *ml.PythonMLBridge
+	MLService           *ml.MLService
 	SwapRepository      *repository.SwapRepository
 	ExchangeRepository  *repository.ExchangeRepository
 	OrderRepository     *repository.OrderRepository
 	ExchangeController  *controller.ExchangeController
 	TradeController     *controller.TradeController
 	OrderController     *controller.OrderController
+	MLController        *controller.MLController
 	MakerService        *exchange.MakerService
 	OrderExecutor       *exchange.OrderExecutor
+	UserDataStream      exchange.UserDataStreamListenerInterface
+	ShutdownManager     *service.ShutdownManager
 	SwapManager         *exchange.SwapManager
 	SwapUpdater         *exchange.SwapUpdater
-	SmaTradeStrategy    *strategy.SmaTradeStrategy
-	MarketDepthStrategy *strategy.MarketDepthStrategy
-	BaseKLineStrategy   *strategy.BaseKLineStrategy
-	OrderBasedStrategy  *strategy.OrderBasedStrategy
+	SwapRecovery        *exchange.SwapRecoveryService
+	DailySummary        *exchange.DailySummaryService
+	StrategyRegistry    *exchange.StrategyRegistry
 	MarketTradeListener *strategy.MarketTradeListener
 	MarketSwapListener  *exchange.MarketSwapListener
 	IsMasterBot         bool
+	ApiAuthenticator    *controller.ApiAuthenticator
 }
 
 func (c *Container) StartHttpServer() {
-	// todo: use GIN http server
-	// configure controllers
-	http.HandleFunc("/kline/list/", c.ExchangeController.GetKlineListAction)
-	http.HandleFunc("/depth/", c.ExchangeController.GetDepthAction)
-	http.HandleFunc("/trade/list/", c.ExchangeController.GetTradeListAction)
-	http.HandleFunc("/swap/list", c.ExchangeController.GetSwapListAction)
-	http.HandleFunc("/swap/action/list", c.ExchangeController.GetSwapActionListAction)
-	http.HandleFunc("/account", c.ExchangeController.GetAccountAction)
-	http.HandleFunc("/exchange/order/", c.ExchangeController.GetExchangeOrderAction)
-	http.HandleFunc("/chart/list", c.ExchangeController.GetChartListAction)
-	http.HandleFunc("/order/list", c.OrderController.GetOrderListAction)
-	http.HandleFunc("/order/extra/charge/update", c.OrderController.UpdateExtraChargeAction)
-	http.HandleFunc("/order/profit/options/update", c.OrderController.UpdateProfitOptionsAction)
-	http.HandleFunc("/order/pending/list", c.OrderController.GetPendingOrderListAction)
-	http.HandleFunc("/order/position/list", c.OrderController.GetPositionListAction)
-	http.HandleFunc("/order", c.OrderController.PostManualOrderAction)
-	http.HandleFunc("/order/", c.OrderController.DeleteManualOrderAction)
-	http.HandleFunc("/order/cancel/", c.OrderController.DeleteCancelExchangeOrderAction)
-	http.HandleFunc("/order/trade/list", c.OrderController.GetOrderTradeListAction)
-	http.HandleFunc("/trade/limit/list", c.TradeController.GetTradeLimitsAction)
-	http.HandleFunc("/trade/stack", c.TradeController.GetTradeStackAction)
-	http.HandleFunc("/trade/signal", c.TradeController.PostSignalAction)
-	http.HandleFunc("/trade/limit/create", c.TradeController.CreateTradeLimitAction)
-	http.HandleFunc("/trade/limit/update", c.TradeController.UpdateTradeLimitAction)
-	http.HandleFunc("/trade/limit/switch/", c.TradeController.SwitchTradeLimitAction)
-	http.HandleFunc("/trade/limit/sentiment/", c.TradeController.PatchSentimentAction)
-	http.HandleFunc("/health/check", c.BotController.GetHealthCheckAction)
-	http.HandleFunc("/bot/update", c.BotController.PutConfigAction)
+	if !c.ApiAuthenticator.IsEnabled() {
+		log.Printf("WARNING: API_TOKENS is not set, API accepts requests from loopback address only (127.0.0.1, ::1)")
+	}
+
+	router := &controller.Router{
+		Authenticator: c.ApiAuthenticator,
+		CurrentBot:    c.CurrentBot,
+		Title:         "Go Crypto Bot API",
+		Version:       "1.0.0",
+	}
+	c.RegisterRoutes(router)
 
 	// Start HTTP server!
 	go func() {
-		_ = http.ListenAndServe(":8080", nil)
+		_ = http.ListenAndServe(":8080", router)
 	}()
 }
 
@@ -682,3 +901,15 @@ func (c *Container) PingDB() {
 		}
 	}()
 }
+
+// Shutdown stops new trade decisions and waits in-progress order and swap operations
+func (c *Container) Shutdown() {
+	timeout, err := strconv.ParseInt(os.Getenv("SHUTDOWN_TIMEOUT"), 10, 64)
+	if err != nil || timeout <= 0 {
+		timeout = 60
+	}
+
+	log.Printf("Bot [%s] is stopping, wait up to %d seconds...", c.CurrentBot.BotUuid, timeout)
+	c.ShutdownManager.Shutdown(time.Second * time.Duration(timeout))
+	c.CallbackManager.Stop(time.Second * 10)
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/notifier"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// autotrade.cloud callback was the only channel before, it keeps the same events by default
+var autoTradeEvents = []string{
+	model.NotificationEventBuy,
+	model.NotificationEventSell,
+	model.NotificationEventExtraCharge,
+	model.NotificationEventError,
+	model.NotificationEventRiskLimit,
+}
+
+func InitNotificationManager() *service.NotificationManager {
+	subscriptions := make([]notifier.Subscription, 0)
+
+	add := func(channel string, channelNotifier notifier.Notifier, defaults []string) {
+		events := notifier.ParseEvents(os.Getenv("NOTIFY_"+strings.ToUpper(channel)+"_EVENTS"), defaults)
+		if len(events) == 0 {
+			return
+		}
+
+		subscriptions = append(subscriptions, notifier.Subscription{
+			Channel:  channel,
+			Notifier: channelNotifier,
+			Events:   events,
+		})
+	}
+
+	add("autotrade", &service.CallbackManager{
+		AutoTradeHost: "https://api.autotrade.cloud",
+	}, autoTradeEvents)
+
+	if os.Getenv("NOTIFY_TELEGRAM_BOT_TOKEN") != "" && os.Getenv("NOTIFY_TELEGRAM_CHAT_ID") != "" {
+		add("telegram", &notifier.TelegramNotifier{
+			BotToken: os.Getenv("NOTIFY_TELEGRAM_BOT_TOKEN"),
+			ChatId:   os.Getenv("NOTIFY_TELEGRAM_CHAT_ID"),
+		}, model.NotificationEvents)
+	}
+
+	if os.Getenv("NOTIFY_SLACK_WEBHOOK_URL") != "" {
+		add("slack", &notifier.SlackNotifier{
+			WebhookUrl: os.Getenv("NOTIFY_SLACK_WEBHOOK_URL"),
+		}, model.NotificationEvents)
+	}
+
+	if os.Getenv("NOTIFY_DISCORD_WEBHOOK_URL") != "" {
+		add("discord", &notifier.DiscordNotifier{
+			WebhookUrl: os.Getenv("NOTIFY_DISCORD_WEBHOOK_URL"),
+		}, model.NotificationEvents)
+	}
+
+	if os.Getenv("NOTIFY_SMTP_HOST") != "" && os.Getenv("NOTIFY_EMAIL_TO") != "" {
+		port := os.Getenv("NOTIFY_SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+
+		recipients := make([]string, 0)
+		for _, recipient := range strings.Split(os.Getenv("NOTIFY_EMAIL_TO"), ",") {
+			if strings.TrimSpace(recipient) != "" {
+				recipients = append(recipients, strings.TrimSpace(recipient))
+			}
+		}
+
+		add("email", &notifier.EmailNotifier{
+			Host:     os.Getenv("NOTIFY_SMTP_HOST"),
+			Port:     port,
+			Username: os.Getenv("NOTIFY_SMTP_USERNAME"),
+			Password: os.Getenv("NOTIFY_SMTP_PASSWORD"),
+			From:     os.Getenv("NOTIFY_EMAIL_FROM"),
+			To:       recipients,
+		}, model.NotificationEvents)
+	}
+
+	if os.Getenv("NOTIFY_WEBHOOK_URL") != "" {
+		add("webhook", &notifier.WebhookNotifier{
+			Url:    os.Getenv("NOTIFY_WEBHOOK_URL"),
+			Secret: os.Getenv("NOTIFY_WEBHOOK_SECRET"),
+		}, model.NotificationEvents)
+	}
+
+	notificationManager := service.NotificationManager{
+		Subscriptions: subscriptions,
+	}
+	slog.Info("Notification channels", "channels", strings.Join(notificationManager.GetChannels(), ","))
+	notificationManager.Start()
+
+	return &notificationManager
+}
+
+// GetDailySummaryHour returns -1 if summary is disabled with "off"
+func GetDailySummaryHour() int64 {
+	value := strings.TrimSpace(os.Getenv("NOTIFY_DAILY_SUMMARY_HOUR"))
+	if strings.ToLower(value) == "off" {
+		return -1
+	}
+
+	hour, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || hour < 0 || hour > 23 {
+		return exchange.DailySummaryDefaultHour
+	}
+
+	return hour
+}
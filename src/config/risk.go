@@ -0,0 +1,26 @@
+package config
+
+import (
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// GetRiskLocation returns timezone of the risk manager day boundary (daily loss limit), UTC by default
+func GetRiskLocation() *time.Location {
+	value := strings.TrimSpace(os.Getenv("RISK_TIMEZONE"))
+	if value == "" {
+		return time.UTC
+	}
+
+	location, err := time.LoadLocation(value)
+	if err != nil {
+		slog.Warn("Risk timezone is invalid, UTC is used", "timezone", value, logger.Error(err))
+
+		return time.UTC
+	}
+
+	return location
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"gitlab.com/open-soft/go-crypto-bot/src/controller"
+	"gitlab.com/open-soft/go-crypto-bot/src/metrics"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+)
+
+func (c *Container) RegisterRoutes(router *controller.Router) {
+	// exchange
+	router.Handle(controller.Route{Method: "GET", Path: "/kline/list/{symbol}", Scope: model.ApiScopeRead, Tag: "exchange", Summary: "Latest klines", Response: []model.KLine{}, Handler: c.ExchangeController.GetKlineListAction})
+	router.Handle(controller.Route{Method: "GET", Path: "/indicator/list/{symbol}", Scope: model.ApiScopeRead, Tag: "exchange", Summary: "Technical indicator values", Response: model.IndicatorValues{}, Handler: c.ExchangeController.GetIndicatorListAction})
+	router.Handle(controller.Route{Method: "GET", Path: "/depth/{symbol}", Scope: model.ApiScopeRead, Tag: "exchange", Summary: "Order book depth", Response: model.OrderBookModel{}, Handler: c.ExchangeController.GetDepthAction})
+	router.Handle(controller.Route{Method: "GET", Path: "/trade/list/{symbol}", Scope: model.ApiScopeRead, Tag: "exchange", Summary: "Latest market trades", Response: []model.Trade{}, Handler: c.ExchangeController.GetTradeListAction})
+	router.Handle(controller.Route{Method: "GET", Path: "/account", Scope: model.ApiScopeRead, Tag: "exchange", Summary: "Account balances", Query: []string{"hideZero"}, Response: map[string]model.Balance{}, Handler: c.ExchangeController.GetAccountAction})
+	router.Handle(controller.Route{Method: "GET", Path: "/exchange/order/{symbol}", Scope: model.ApiScopeRead, Tag: "exchange", Summary: "Query order on exchange", Query: []string{"orderId"}, Response: model.ExchangeOrder{}, Handler: c.ExchangeController.GetExchangeOrderAction})
+	router.Handle(controller.Route{Method: "GET", Path: "/chart/list", Scope: model.ApiScopeRead, Tag: "exchange", Summary: "Chart data", Query: []string{"symbol"}, Response: []map[string][]any{}, Handler: c.ExchangeController.GetChartListAction})
+
+	// swap
+	router.Handle(controller.Route{Method: "GET", Path: "/swap/list", Scope: model.ApiScopeRead, Tag: "swap", Summary: "Available swap chains", Response: []model.SwapChainEntity{}, Handler: c.ExchangeController.GetSwapListAction})
+	router.Handle(controller.Route{Method: "GET", Path: "/swap/action/list", Scope: model.ApiScopeRead, Tag: "swap", Summary: "Swap actions with balances", Response: []model.SwapContainer{}, Handler: c.ExchangeController.GetSwapActionListAction})
+	router.Handle(controller.Route{Method: "GET", Path: "/swap/recovery/list", Scope: model.ApiScopeRead, Tag: "swap", Summary: "Stuck swap actions", Response: []model.SwapRecoveryState{}, Handler: c.ExchangeController.GetSwapRecoveryListAction})
+	router.Handle(controller.Route{Method: "POST", Path: "/swap/recovery/{action}/{swapActionId}", Scope: model.ApiScopeTrading, Tag: "swap", Summary: "Retry, force or rollback stuck swap", Response: model.SwapRecoveryState{}, Handler: c.ExchangeController.PostSwapRecoveryAction})
+
+	// order
+	router.Handle(controller.Route{Method: "GET", Path: "/order/list", Scope: model.ApiScopeRead, Tag: "order", Summary: "Order history", Response: []model.Order{}, Handler: c.OrderController.GetOrderListAction})
+	router.Handle(controller.Route{Method: "GET", Path: "/order/trade/list", Scope: model.ApiScopeRead, Tag: "order", Summary: "Closed trades", Response: []model.OrderTrade{}, Handler: c.OrderController.GetOrderTradeListAction})
+	router.Handle(controller.Route{Method: "GET", Path: "/order/pending/list", Scope: model.ApiScopeRead, Tag: "order", Summary: "Pending BUY orders", Response: []model.PendingOrder{}, Handler: c.OrderController.GetPendingOrderListAction})
+	router.Handle(controller.Route{Method: "GET", Path: "/order/position/list", Scope: model.ApiScopeRead, Tag: "order", Summary: "Opened positions", Response: []model.Position{}, Handler: c.OrderController.GetPositionListAction})
+	router.Handle(controller.Route{Method: "PUT", Path: "/order/extra/charge/update", Scope: model.ApiScopeTrading, Tag: "order", Summary: "Update extra charge options", Request: model.UpdateOrderExtraChargeOptions{}, Response: model.Order{}, Handler: c.OrderController.UpdateExtraChargeAction})
+	router.Handle(controller.Route{Method: "PUT", Path: "/order/profit/options/update", Scope: model.ApiScopeTrading, Tag: "order", Summary: "Update profit options", Request: model.UpdateOrderProfitOptions{}, Response: model.Order{}, Handler: c.OrderController.UpdateProfitOptionsAction})
+	router.Handle(controller.Route{Method: "POST", Path: "/order", Scope: model.ApiScopeTrading, Tag: "order", Summary: "Create manual order", Request: model.ManualOrder{}, Response: model.ManualOrder{}, Handler: c.OrderController.PostManualOrderAction})
+	router.Handle(controller.Route{Method: "DELETE", Path: "/order/{symbol}", Scope: model.ApiScopeTrading, Tag: "order", Summary: "Delete manual order", Handler: c.OrderController.DeleteManualOrderAction})
+	router.Handle(controller.Route{Method: "DELETE", Path: "/order/cancel/{operation}/{symbol}", Scope: model.ApiScopeTrading, Tag: "order", Summary: "Cancel exchange order, operation is buy or sell", Handler: c.OrderController.DeleteCancelExchangeOrderAction})
+
+	// trade
+	router.Handle(controller.Route{Method: "GET", Path: "/trade/limit/list", Scope: model.ApiScopeRead, Tag: "trade", Summary: "Trade limits", Response: []model.TradeLimit{}, Handler: c.TradeController.GetTradeLimitsAction})
+	router.Handle(controller.Route{Method: "GET", Path: "/trade/stack", Scope: model.ApiScopeRead, Tag: "trade", Summary: "Trade stack with decisions", Response: []model.TradeStackItem{}, Handler: c.TradeController.GetTradeStackAction})
+	router.Handle(controller.Route{Method: "POST", Path: "/trade/signal", Scope: model.ApiScopeTrading, Tag: "trade", Summary: "Send trade signal", Request: model.Signal{}, Handler: c.TradeController.PostSignalAction})
+	router.Handle(controller.Route{Method: "POST", Path: "/trade/limit/create", Scope: model.ApiScopeAdmin, Tag: "trade", Summary: "Create trade limit", Request: model.TradeLimit{}, Response: model.TradeLimit{}, Handler: c.TradeController.CreateTradeLimitAction})
+	router.Handle(controller.Route{Method: "PUT", Path: "/trade/limit/update", Scope: model.ApiScopeAdmin, Tag: "trade", Summary: "Update trade limit", Request: model.TradeLimit{}, Response: model.TradeLimit{}, Handler: c.TradeController.UpdateTradeLimitAction})
+	router.Handle(controller.Route{Method: "POST", Path: "/trade/limit/backtest", Scope: model.ApiScopeTrading, Tag: "trade", Summary: "Backtest trade limit", Request: model.BacktestRequest{}, Response: model.BacktestReport{}, Handler: c.TradeController.BacktestTradeLimitAction})
+	router.Handle(controller.Route{Method: "PUT", Path: "/trade/limit/switch/{symbol}", Scope: model.ApiScopeAdmin, Tag: "trade", Summary: "Enable or disable trade limit", Response: model.TradeLimit{}, Handler: c.TradeController.SwitchTradeLimitAction})
+	router.Handle(controller.Route{Method: "PATCH", Path: "/trade/limit/sentiment/{symbol}", Scope: model.ApiScopeTrading, Tag: "trade", Summary: "Update trade limit sentiment", Request: model.SentimentData{}, Response: model.TradeLimit{}, Handler: c.TradeController.PatchSentimentAction})
+
+	// bot
+	router.Handle(controller.Route{Method: "GET", Path: "/health/check", Scope: model.ApiScopeRead, Tag: "bot", Summary: "Bot health", Response: model.BotHealth{}, Handler: c.BotController.GetHealthCheckAction})
+	router.Handle(controller.Route{Method: "PUT", Path: "/bot/update", Scope: model.ApiScopeAdmin, Tag: "bot", Summary: "Update bot config", Request: model.BotConfigUpdate{}, Handler: c.BotController.PutConfigAction})
+	router.Handle(controller.Route{Method: "GET", Path: "/metrics", Unversioned: true, SkipBotUuid: true, Scope: model.ApiScopeRead, Tag: "bot", Summary: "Prometheus metrics", Handler: metrics.Handler(metrics.Default)})
+	router.Handle(controller.Route{Method: "GET", Path: "/bot/risk", Scope: model.ApiScopeRead, Tag: "bot", Summary: "Portfolio risk status", Response: model.RiskStatus{}, Handler: c.BotController.GetRiskStatusAction})
+
+	// ml
+	router.Handle(controller.Route{Method: "GET", Path: "/ml/model/list", Scope: model.ApiScopeRead, Tag: "ml", Summary: "ML models", Query: []string{"symbol"}, Response: []model.MLModel{}, Handler: c.MLController.GetModelListAction})
+	router.Handle(controller.Route{Method: "PUT", Path: "/ml/model/activate/{id}", Scope: model.ApiScopeAdmin, Tag: "ml", Summary: "Activate ML model", Response: model.MLModel{}, Handler: c.MLController.PutActivateModelAction})
+}
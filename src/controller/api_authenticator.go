@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"log"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ApiAuthenticator checks API token scope and sets CORS headers for every registered route,
+// only loopback requests are allowed if there are no tokens
+type ApiAuthenticator struct {
+	Tokens         []model.ApiToken
+	AllowedOrigins []string
+}
+
+// ParseApiTokens reads tokens in format "scope:token,scope:token", e.g. "admin:secret1,read:secret2"
+func ParseApiTokens(value string) []model.ApiToken {
+	tokens := make([]model.ApiToken, 0)
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.SplitN(item, ":", 2)
+		if len(parts) != 2 || !model.IsApiScopeValid(parts[0]) || parts[1] == "" {
+			log.Printf("API token is skipped, expected format is scope:token, scopes: read, trading, admin")
+			continue
+		}
+
+		tokens = append(tokens, model.ApiToken{
+			Scope: parts[0],
+			Hash:  sha256.Sum256([]byte(parts[1])),
+		})
+	}
+
+	return tokens
+}
+
+// ParseAllowedOrigins returns empty list if value is empty, CORS headers are not sent then, use "*" to allow any origin
+func ParseAllowedOrigins(value string) []string {
+	origins := make([]string, 0)
+
+	for _, origin := range strings.Split(value, ",") {
+		origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+		if origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+
+	return origins
+}
+
+func (a *ApiAuthenticator) IsEnabled() bool {
+	return len(a.Tokens) > 0
+}
+
+func (a *ApiAuthenticator) Protect(scope string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		a.setCorsHeaders(w, req)
+
+		if req.Method == "OPTIONS" {
+			_, _ = fmt.Fprintf(w, "OK")
+			return
+		}
+
+		if !a.IsEnabled() && scope != model.ApiScopePublic && !isLoopback(req) {
+			log.Printf("API: %s %s is not allowed from %s, API_TOKENS is not set", req.Method, req.URL.Path, req.RemoteAddr)
+			WriteError(w, "Forbidden", http.StatusForbidden)
+
+			return
+		}
+
+		if a.IsEnabled() && scope != model.ApiScopePublic {
+			token := a.findToken(req)
+
+			if token == nil {
+				log.Printf("API: %s %s is not authorized", req.Method, req.URL.Path)
+				WriteError(w, "Unauthorized", http.StatusUnauthorized)
+
+				return
+			}
+
+			if !token.HasScope(scope) {
+				log.Printf("API: %s %s requires %s scope, token scope is %s", req.Method, req.URL.Path, scope, token.Scope)
+				WriteError(w, "Forbidden", http.StatusForbidden)
+
+				return
+			}
+		}
+
+		handler(w, req)
+	}
+}
+
+func (a *ApiAuthenticator) findToken(req *http.Request) *model.ApiToken {
+	value := req.Header.Get("X-Api-Token")
+	if value == "" {
+		value, _ = strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	}
+
+	if value == "" {
+		return nil
+	}
+
+	hash := sha256.Sum256([]byte(value))
+	for _, token := range a.Tokens {
+		if subtle.ConstantTimeCompare(hash[:], token.Hash[:]) == 1 {
+			return &token
+		}
+	}
+
+	return nil
+}
+
+// isLoopback uses connection address only, proxy headers can be set by client
+func isLoopback(req *http.Request) bool {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+func (a *ApiAuthenticator) setCorsHeaders(w http.ResponseWriter, req *http.Request) {
+	if len(a.AllowedOrigins) == 0 {
+		return
+	}
+
+	origin := req.Header.Get("Origin")
+
+	if slices.Contains(a.AllowedOrigins, "*") {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else if origin != "" && slices.Contains(a.AllowedOrigins, origin) {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+	}
+
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Api-Token")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+}
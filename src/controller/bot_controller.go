@@ -6,59 +6,44 @@ import (
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
 	"gitlab.com/open-soft/go-crypto-bot/src/service"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
 	"net/http"
 )
 
 type BotController struct {
-	HealthService *service.HealthService
-	CurrentBot    *model.Bot
-	BotRepository *repository.BotRepository
+	HealthService       *service.HealthService
+	CurrentBot          *model.Bot
+	BotRepository       *repository.BotRepository
+	RiskManager         exchange.RiskManagerInterface
+	ProtectionSupported bool
 }
 
 func (b *BotController) GetHealthCheckAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != b.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
 	health := b.HealthService.HealthCheck()
 
 	encoded, _ := json.Marshal(health)
 	fmt.Fprintf(w, string(encoded))
 }
 
-func (b *BotController) PutConfigAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != b.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	if req.Method != "PUT" {
-		http.Error(w, "Only PUT method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
+func (b *BotController) GetRiskStatusAction(w http.ResponseWriter, req *http.Request) {
+	encoded, _ := json.Marshal(b.RiskManager.GetStatus())
+	fmt.Fprintf(w, string(encoded))
+}
 
+func (b *BotController) PutConfigAction(w http.ResponseWriter, req *http.Request) {
 	var botUpdate model.BotConfigUpdate
 
 	// Try to decode the request body into the struct. If there is an error,
 	// respond to the client with the error message and a 400 status code.
 	err := json.NewDecoder(req.Body).Decode(&botUpdate)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if botUpdate.RiskConfig.ExchangeProtection && !b.ProtectionSupported {
+		WriteError(w, fmt.Sprintf("Exchange protection is not supported by %s.", b.CurrentBot.Exchange), http.StatusBadRequest)
 
 		return
 	}
@@ -68,10 +53,12 @@ func (b *BotController) PutConfigAction(w http.ResponseWriter, req *http.Request
 	bot.IsSwapEnabled = botUpdate.IsSwapEnabled
 	bot.TradeStackSorting = botUpdate.TradeStackSorting
 	bot.SwapConfig = botUpdate.SwapConfig
+	bot.RiskConfig = botUpdate.RiskConfig
+	bot.ReportingCurrency = botUpdate.ReportingCurrency
 	err = b.BotRepository.Update(*bot)
 
 	if err != nil {
-		http.Error(w, "Couldn't update bot config.", http.StatusBadRequest)
+		WriteError(w, "Couldn't update bot config.", http.StatusBadRequest)
 
 		return
 	}
@@ -10,6 +10,7 @@ import (
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
 	"gitlab.com/open-soft/go-crypto-bot/src/service"
 	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/indicator"
 	"net/http"
 	"strconv"
 	"strings"
@@ -26,41 +27,33 @@ type ExchangeController struct {
 	BotService         *service.BotService
 	BalanceService     *exchange.BalanceService
 	Exchange           client.ExchangeAPIInterface
+	IndicatorService   *indicator.IndicatorService
+	SwapRecovery       *exchange.SwapRecoveryService
 }
 
 func (e *ExchangeController) GetKlineListAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != e.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	symbol := strings.TrimPrefix(req.URL.Path, "/kline/list/")
+	symbol := PathParam(req, "symbol")
 
 	list := e.ExchangeRepository.KLineList(symbol, true, 200)
 	encoded, _ := json.Marshal(list)
 	fmt.Fprintf(w, string(encoded))
 }
 
-func (e *ExchangeController) GetSwapActionListAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	botUuid := req.URL.Query().Get("botUuid")
+func (e *ExchangeController) GetIndicatorListAction(w http.ResponseWriter, req *http.Request) {
+	symbol := PathParam(req, "symbol")
 
-	if botUuid != e.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if e.ExchangeRepository.GetTradeLimitCached(symbol) == nil {
+		WriteError(w, "Trade limit is not found", http.StatusNotFound)
 
 		return
 	}
 
+	values := e.IndicatorService.GetValues(symbol)
+	encoded, _ := json.Marshal(values)
+	fmt.Fprintf(w, string(encoded))
+}
+
+func (e *ExchangeController) GetSwapActionListAction(w http.ResponseWriter, req *http.Request) {
 	actions := e.SwapRepository.GetSwapActions()
 	account := e.BalanceService.GetBalance(false)
 	list := make([]model.SwapContainer, 0)
@@ -104,22 +97,51 @@ func (e *ExchangeController) GetSwapActionListAction(w http.ResponseWriter, req
 	_, _ = fmt.Fprintf(w, string(encoded))
 }
 
-func (e *ExchangeController) GetExchangeOrderAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
+func (e *ExchangeController) GetSwapRecoveryListAction(w http.ResponseWriter, req *http.Request) {
+	encoded, _ := json.Marshal(e.SwapRecovery.GetStuckList())
+	_, _ = fmt.Fprintf(w, string(encoded))
+}
 
-	botUuid := req.URL.Query().Get("botUuid")
+func (e *ExchangeController) PostSwapRecoveryAction(w http.ResponseWriter, req *http.Request) {
+	swapActionId, err := strconv.ParseInt(PathParam(req, "swapActionId"), 10, 64)
+	if err != nil {
+		WriteError(w, "Swap action id is invalid", http.StatusBadRequest)
+
+		return
+	}
+
+	var state model.SwapRecoveryState
+
+	switch PathParam(req, "action") {
+	case model.SwapRecoveryActionRetry:
+		state, err = e.SwapRecovery.Retry(swapActionId)
+		break
+	case model.SwapRecoveryActionForce:
+		state, err = e.SwapRecovery.Force(swapActionId)
+		break
+	case model.SwapRecoveryActionRollback:
+		state, err = e.SwapRecovery.Rollback(swapActionId)
+		break
+	default:
+		WriteError(w, "Swap recovery action is not found", http.StatusNotFound)
+
+		return
+	}
 
-	if botUuid != e.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if err != nil {
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
 
-	symbol := strings.TrimSpace(strings.TrimPrefix(req.URL.Path, "/exchange/order/"))
+	encoded, _ := json.Marshal(state)
+	_, _ = fmt.Fprintf(w, string(encoded))
+}
+
+func (e *ExchangeController) GetExchangeOrderAction(w http.ResponseWriter, req *http.Request) {
+	symbol := strings.TrimSpace(PathParam(req, "symbol"))
 	if "" == symbol {
-		http.Error(w, "Symbol should not be empty", http.StatusBadRequest)
+		WriteError(w, "Symbol should not be empty", http.StatusBadRequest)
 
 		return
 	}
@@ -128,7 +150,7 @@ func (e *ExchangeController) GetExchangeOrderAction(w http.ResponseWriter, req *
 	order, err := e.Exchange.QueryOrder(symbol, orderId)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
@@ -138,18 +160,6 @@ func (e *ExchangeController) GetExchangeOrderAction(w http.ResponseWriter, req *
 }
 
 func (e *ExchangeController) GetAccountAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != e.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
 	hideZero, err := strconv.ParseBool(req.URL.Query().Get("hideZero"))
 	if err != nil {
 		hideZero = false
@@ -162,19 +172,7 @@ func (e *ExchangeController) GetAccountAction(w http.ResponseWriter, req *http.R
 }
 
 func (e *ExchangeController) GetDepthAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != e.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	symbol := strings.TrimPrefix(req.URL.Path, "/depth/")
+	symbol := PathParam(req, "symbol")
 
 	list := e.ExchangeRepository.GetDepth(symbol, 20)
 	encoded, _ := json.Marshal(list)
@@ -182,19 +180,7 @@ func (e *ExchangeController) GetDepthAction(w http.ResponseWriter, req *http.Req
 }
 
 func (e *ExchangeController) GetTradeListAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != e.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	symbol := strings.TrimPrefix(req.URL.Path, "/trade/list/")
+	symbol := PathParam(req, "symbol")
 
 	list := e.ExchangeRepository.TradeList(symbol)
 	encoded, _ := json.Marshal(list)
@@ -202,36 +188,12 @@ func (e *ExchangeController) GetTradeListAction(w http.ResponseWriter, req *http
 }
 
 func (e *ExchangeController) GetSwapListAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != e.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
 	list := e.SwapRepository.GetAvailableSwapChains()
 	encoded, _ := json.Marshal(list)
 	fmt.Fprintf(w, string(encoded))
 }
 
 func (e *ExchangeController) GetChartListAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != e.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
 	symbol := req.URL.Query().Get("symbol")
 
 	symbolFilter := make([]string, 0)
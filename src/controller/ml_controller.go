@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/ml"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type MLController struct {
+	MLModelRepository repository.MLModelRepositoryInterface
+	MLService         *ml.MLService
+	CurrentBot        *model.Bot
+}
+
+func (m *MLController) GetModelListAction(w http.ResponseWriter, req *http.Request) {
+	list := m.MLModelRepository.GetList(strings.ToUpper(req.URL.Query().Get("symbol")))
+	encoded, _ := json.Marshal(list)
+	fmt.Fprintf(w, string(encoded))
+}
+
+func (m *MLController) PutActivateModelAction(w http.ResponseWriter, req *http.Request) {
+	id, err := strconv.ParseInt(PathParam(req, "id"), 10, 64)
+	if err != nil {
+		WriteError(w, "Model id is invalid", http.StatusBadRequest)
+
+		return
+	}
+
+	entity, err := m.MLService.ActivateModel(id)
+	if err != nil {
+		WriteError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	encoded, _ := json.Marshal(entity)
+	_, _ = fmt.Fprintf(w, string(encoded))
+}
@@ -0,0 +1,233 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// OpenApi generates OpenAPI 3.0 document from registered routes, schemas are built from route sample values
+func (r *Router) OpenApi() map[string]any {
+	schemas := make(map[string]any)
+	paths := make(map[string]map[string]any)
+
+	for _, route := range r.routes {
+		path := ApiVersionPrefix + route.Path
+		if route.Unversioned {
+			path = route.Path
+		}
+		if _, ok := paths[path]; !ok {
+			paths[path] = make(map[string]any)
+		}
+
+		parameters := make([]map[string]any, 0)
+		for _, segment := range route.segments {
+			if isPathParam(segment) {
+				parameters = append(parameters, map[string]any{
+					"name":     strings.Trim(segment, "{}"),
+					"in":       "path",
+					"required": true,
+					"schema":   map[string]any{"type": "string"},
+				})
+			}
+		}
+		if !route.SkipBotUuid {
+			parameters = append(parameters, map[string]any{
+				"name":     "botUuid",
+				"in":       "query",
+				"required": true,
+				"schema":   map[string]any{"type": "string"},
+			})
+		}
+		for _, name := range route.Query {
+			parameters = append(parameters, map[string]any{
+				"name":     name,
+				"in":       "query",
+				"required": false,
+				"schema":   map[string]any{"type": "string"},
+			})
+		}
+
+		response := map[string]any{"type": "string", "example": "OK"}
+		if route.Response != nil {
+			response = schemaOf(reflect.TypeOf(route.Response), schemas)
+		}
+
+		operation := map[string]any{
+			"summary":     route.Summary,
+			"operationId": operationId(route),
+			"tags":        []string{route.Tag},
+			"parameters":  parameters,
+			"x-scope":     route.Scope,
+			"security": []map[string][]string{
+				{"bearerAuth": {}},
+				{"apiToken": {}},
+			},
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "Successful response",
+					"content": map[string]any{
+						"application/json": map[string]any{"schema": response},
+					},
+				},
+				"default": map[string]any{
+					"description": "Error response",
+					"content": map[string]any{
+						"application/json": map[string]any{
+							"schema": schemaOf(reflect.TypeOf(model.ApiErrorResponse{}), schemas),
+						},
+					},
+				},
+			},
+		}
+
+		if route.Request != nil {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{
+						"schema": schemaOf(reflect.TypeOf(route.Request), schemas),
+					},
+				},
+			}
+		}
+
+		paths[path][strings.ToLower(route.Method)] = operation
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       r.Title,
+			"version":     r.Version,
+			"description": fmt.Sprintf("Token scopes: %s, %s, %s. Unversioned paths are deprecated.", model.ApiScopeRead, model.ApiScopeTrading, model.ApiScopeAdmin),
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+				"apiToken":   map[string]any{"type": "apiKey", "in": "header", "name": "X-Api-Token"},
+			},
+		},
+	}
+}
+
+func operationId(route Route) string {
+	id := strings.ToLower(route.Method)
+	for _, segment := range route.segments {
+		segment = strings.Trim(segment, "{}")
+		if segment == "" {
+			continue
+		}
+		id += strings.ToUpper(segment[:1]) + segment[1:]
+	}
+
+	return id
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+var marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// schemaOf describes type using json tags, named structs are placed to components and referenced
+func schemaOf(t reflect.Type, schemas map[string]any) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	// custom marshalers (Price, Volume, TimestampMilli...) are described by the value they produce
+	if t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+		if encoded, err := reflect.New(t).Interface().(json.Marshaler).MarshalJSON(); err == nil {
+			var value any
+			if json.Unmarshal(encoded, &value) == nil {
+				switch value.(type) {
+				case string:
+					return map[string]any{"type": "string"}
+				case float64:
+					return map[string]any{"type": "number"}
+				case bool:
+					return map[string]any{"type": "boolean"}
+				}
+			}
+		}
+
+		return map[string]any{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaOf(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaOf(t.Elem(), schemas)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return structSchema(t, schemas)
+		}
+
+		if _, ok := schemas[t.Name()]; !ok {
+			// placeholder breaks recursion for self referenced types
+			schemas[t.Name()] = map[string]any{}
+			schemas[t.Name()] = structSchema(t, schemas)
+		}
+
+		return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+	}
+
+	return map[string]any{}
+}
+
+func structSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	properties := make(map[string]any)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+
+		// embedded struct fields are promoted to the parent object
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, value := range structSchema(embedded, schemas)["properties"].(map[string]any) {
+					properties[key] = value
+				}
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = schemaOf(field.Type, schemas)
+	}
+
+	return map[string]any{"type": "object", "properties": properties}
+}
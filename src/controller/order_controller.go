@@ -12,6 +12,7 @@ import (
 	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
 	"gitlab.com/open-soft/go-crypto-bot/src/utils"
 	"gitlab.com/open-soft/go-crypto-bot/src/validator"
+	"log"
 	"net/http"
 	"slices"
 	"strings"
@@ -31,62 +32,17 @@ type OrderController struct {
 	BotService             service.BotServiceInterface
 	ProfitService          exchange.ProfitServiceInterface
 	TradeFilterService     exchange.TradeFilterServiceInterface
+	ProtectionService      exchange.ProtectionServiceInterface
 	ExchangeAPI            client.ExchangeAPIInterface
 }
 
 func (o *OrderController) GetOrderTradeListAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	if req.Method != "GET" {
-		http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != o.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
 	list := o.OrderRepository.GetTrades()
 	encoded, _ := json.Marshal(list)
 	_, _ = fmt.Fprintf(w, string(encoded))
 }
 
 func (o *OrderController) GetPositionListAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != o.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	if req.Method != "GET" {
-		http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
-
 	positions := make([]model.Position, 0)
 
 	for _, limit := range o.ExchangeRepository.GetTradeLimits() {
@@ -103,16 +59,16 @@ func (o *OrderController) GetPositionListAction(w http.ResponseWriter, req *http
 		var sellPrice float64
 
 		// todo: Decomposition is required here, move it to separate service
-		binanceOrder := o.OrderRepository.GetBinanceOrder(openedOrder.Symbol, "SELL")
+		exchangeOrder := o.OrderRepository.GetExchangeOrder(openedOrder.Symbol, "SELL")
 		executedQty := 0.00
 		origQty := openedOrder.GetPositionQuantityWithSwap()
 
 		manualOrder := o.OrderRepository.GetManualOrder(limit.Symbol)
 
-		if binanceOrder != nil {
-			sellPrice = binanceOrder.Price
-			origQty = binanceOrder.OrigQty
-			executedQty = binanceOrder.ExecutedQty
+		if exchangeOrder != nil {
+			sellPrice = exchangeOrder.Price
+			origQty = exchangeOrder.OrigQty
+			executedQty = exchangeOrder.ExecutedQty
 		} else {
 			sellPrice, _ = o.PriceCalculator.CalculateSell(limit, *openedOrder)
 		}
@@ -159,7 +115,7 @@ func (o *OrderController) GetPositionListAction(w http.ResponseWriter, req *http
 				MinClosePrice:    o.ProfitService.GetMinClosePrice(openedOrder, openedOrder.Price),
 			},
 			IsPriceExpired:          kLine.IsPriceExpired(),
-			BinanceOrder:            binanceOrder,
+			ExchangeOrder:           exchangeOrder,
 			ManualOrder:             manualOrder,
 			IsEnabled:               limit.IsEnabled,
 			TradeFiltersBuy:         limit.TradeFiltersBuy,
@@ -177,55 +133,32 @@ func (o *OrderController) GetPositionListAction(w http.ResponseWriter, req *http
 }
 
 func (o *OrderController) UpdateExtraChargeAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != o.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	if req.Method != "PUT" {
-		http.Error(w, "Only PUT method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
-
 	var options model.UpdateOrderExtraChargeOptions
 
 	// Try to decode the request body into the struct. If there is an error,
 	// respond to the client with the error message and a 400 status code.
 	err := json.NewDecoder(req.Body).Decode(&options)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
 
 	entity, err := o.OrderRepository.Find(options.OrderId)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		WriteError(w, err.Error(), http.StatusNotFound)
 
 		return
 	}
 
 	if entity.IsSell() {
-		http.Error(w, "Can not update SELL order", http.StatusBadRequest)
+		WriteError(w, "Can not update SELL order", http.StatusBadRequest)
 
 		return
 	}
 
 	if entity.IsClosed() {
-		http.Error(w, "Can not update closed order", http.StatusBadRequest)
+		WriteError(w, "Can not update closed order", http.StatusBadRequest)
 
 		return
 	}
@@ -234,14 +167,14 @@ func (o *OrderController) UpdateExtraChargeAction(w http.ResponseWriter, req *ht
 	err = o.OrderRepository.Update(entity)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
 
 	entity, err = o.OrderRepository.Find(entity.Id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		WriteError(w, err.Error(), http.StatusServiceUnavailable)
 
 		return
 	}
@@ -254,42 +187,19 @@ func (o *OrderController) UpdateExtraChargeAction(w http.ResponseWriter, req *ht
 }
 
 func (o *OrderController) UpdateProfitOptionsAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != o.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	if req.Method != "PUT" {
-		http.Error(w, "Only PUT method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
-
 	var options model.UpdateOrderProfitOptions
 
 	// Try to decode the request body into the struct. If there is an error,
 	// respond to the client with the error message and a 400 status code.
 	err := json.NewDecoder(req.Body).Decode(&options)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
 
 	if len(options.ProfitOptions) == 0 {
-		http.Error(w, "ProfitOptions length has to be greater than 0", http.StatusBadRequest)
+		WriteError(w, "ProfitOptions length has to be greater than 0", http.StatusBadRequest)
 
 		return
 	}
@@ -297,26 +207,26 @@ func (o *OrderController) UpdateProfitOptionsAction(w http.ResponseWriter, req *
 	violation := o.ProfitOptionsValidator.Validate(options.ProfitOptions)
 
 	if violation != nil {
-		http.Error(w, violation.Error(), http.StatusBadRequest)
+		WriteError(w, violation.Error(), http.StatusBadRequest)
 
 		return
 	}
 
 	entity, err := o.OrderRepository.Find(options.OrderId)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		WriteError(w, err.Error(), http.StatusNotFound)
 
 		return
 	}
 
 	if entity.IsSell() {
-		http.Error(w, "Can not update SELL order", http.StatusBadRequest)
+		WriteError(w, "Can not update SELL order", http.StatusBadRequest)
 
 		return
 	}
 
 	if entity.IsClosed() {
-		http.Error(w, "Can not update closed order", http.StatusBadRequest)
+		WriteError(w, "Can not update closed order", http.StatusBadRequest)
 
 		return
 	}
@@ -325,14 +235,20 @@ func (o *OrderController) UpdateProfitOptionsAction(w http.ResponseWriter, req *
 	err = o.OrderRepository.Update(entity)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
 
+	// stop loss or take profit is changed, exchange side protection has to be replaced
+	err = o.ProtectionService.Protect(entity)
+	if err != nil {
+		log.Printf("[%s] Protection: %s", entity.Symbol, err.Error())
+	}
+
 	entity, err = o.OrderRepository.Find(entity.Id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		WriteError(w, err.Error(), http.StatusServiceUnavailable)
 
 		return
 	}
@@ -345,34 +261,11 @@ func (o *OrderController) UpdateProfitOptionsAction(w http.ResponseWriter, req *
 }
 
 func (o *OrderController) GetPendingOrderListAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != o.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	if req.Method != "GET" {
-		http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
-
 	pending := make([]model.PendingOrder, 0)
 
 	for _, limit := range o.ExchangeRepository.GetTradeLimits() {
-		binanceOrder := o.OrderRepository.GetBinanceOrder(limit.Symbol, "BUY")
-		if binanceOrder == nil {
+		exchangeOrder := o.OrderRepository.GetExchangeOrder(limit.Symbol, "BUY")
+		if exchangeOrder == nil {
 			continue
 		}
 
@@ -389,11 +282,11 @@ func (o *OrderController) GetPendingOrderListAction(w http.ResponseWriter, req *
 		interpolation := o.PriceCalculator.InterpolatePrice(limit)
 		pending = append(pending, model.PendingOrder{
 			Symbol:         limit.Symbol,
-			BinanceOrder:   *binanceOrder,
+			ExchangeOrder:  *exchangeOrder,
 			KLine:          *kLine,
 			PredictedPrice: predictedPrice,
 			Interpolation:  interpolation,
-			IsRisky:        o.LossSecurity.IsRiskyBuy(*binanceOrder, limit),
+			IsRisky:        o.LossSecurity.IsRiskyBuy(*exchangeOrder, limit),
 		})
 	}
 
@@ -402,183 +295,94 @@ func (o *OrderController) GetPendingOrderListAction(w http.ResponseWriter, req *
 }
 
 func (o *OrderController) GetOrderListAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != o.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
 	list := o.OrderRepository.GetList()
 	encoded, _ := json.Marshal(list)
 	_, _ = fmt.Fprintf(w, string(encoded))
 }
 
 func (o *OrderController) DeleteCancelExchangeOrderAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != o.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	if req.Method != "DELETE" {
-		http.Error(w, "Only DELETE method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
-
-	var symbol string
-	var operation string
-	pathSuffix := strings.TrimPrefix(req.URL.Path, "/order/cancel/")
-	switch true {
-	case strings.Contains(pathSuffix, "sell/"):
-		symbol = strings.ToUpper(strings.TrimPrefix(pathSuffix, "sell/"))
-		operation = "SELL"
-		break
-	case strings.Contains(pathSuffix, "buy/"):
-		symbol = strings.ToUpper(strings.TrimPrefix(pathSuffix, "buy/"))
-		operation = "BUY"
-		break
-	default:
-		http.Error(w, "Not found", http.StatusNotFound)
+	symbol := strings.ToUpper(PathParam(req, "symbol"))
+	operation := strings.ToUpper(PathParam(req, "operation"))
+	if operation != "BUY" && operation != "SELL" {
+		WriteError(w, "Not found", http.StatusNotFound)
 
 		return
 	}
 
-	exchangeOrder := o.OrderRepository.GetBinanceOrder(symbol, operation)
+	exchangeOrder := o.OrderRepository.GetExchangeOrder(symbol, operation)
 
 	if exchangeOrder == nil {
-		http.Error(w, "Order is not found", http.StatusNotFound)
+		WriteError(w, "Order is not found", http.StatusNotFound)
 
 		return
 	}
 
 	exchangeOrderApi, err := o.ExchangeAPI.QueryOrder(exchangeOrder.Symbol, exchangeOrder.OrderId)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		WriteError(w, err.Error(), http.StatusInternalServerError)
 
 		return
 	}
 
 	if !exchangeOrderApi.IsNew() {
-		http.Error(w, fmt.Sprintf("Can't cancel order in status: %s", exchangeOrderApi.Status), http.StatusConflict)
+		WriteError(w, fmt.Sprintf("Can't cancel order in status: %s", exchangeOrderApi.Status), http.StatusConflict)
 
 		return
 	}
 
 	canceledOrder, err := o.ExchangeAPI.CancelOrder(exchangeOrderApi.Symbol, exchangeOrderApi.OrderId)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		WriteError(w, err.Error(), http.StatusInternalServerError)
 
 		return
 	}
-	o.OrderRepository.SetBinanceOrder(canceledOrder)
+	o.OrderRepository.SetExchangeOrder(canceledOrder)
 
 	_, _ = fmt.Fprintf(w, "OK")
 }
 
 func (o *OrderController) DeleteManualOrderAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != o.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	if req.Method != "DELETE" {
-		http.Error(w, "Only DELETE method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
-
-	symbol := strings.TrimPrefix(req.URL.Path, "/order/")
+	symbol := PathParam(req, "symbol")
 	o.OrderRepository.DeleteManualOrder(symbol)
 
 	_, _ = fmt.Fprintf(w, "OK")
 }
 
 func (o *OrderController) PostManualOrderAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != o.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	if req.Method != "POST" {
-		http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
-
 	var manual model.ManualOrder
 
 	// Try to decode the request body into the struct. If there is an error,
 	// respond to the client with the error message and a 400 status code.
 	err := json.NewDecoder(req.Body).Decode(&manual)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
 
 	if manual.BotUuid != o.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+		WriteError(w, "Forbidden", http.StatusForbidden)
 
 		return
 	}
 
 	allowedOperations := []string{"BUY", "SELL"}
 	if !slices.Contains(allowedOperations, manual.Operation) {
-		http.Error(w, "Only BUY/SELL operations are supported", http.StatusBadRequest)
+		WriteError(w, "Only BUY/SELL operations are supported", http.StatusBadRequest)
+
+		return
+	}
+
+	allowedOrderTypes := []string{"", model.OrderTypeLimit, model.OrderTypeMarket, model.OrderTypeLimitMaker}
+	if !slices.Contains(allowedOrderTypes, manual.OrderType) {
+		WriteError(w, "Only LIMIT/MARKET/LIMIT_MAKER order types are supported", http.StatusBadRequest)
 
 		return
 	}
 
 	tradeLimit, err := o.ExchangeRepository.GetTradeLimit(manual.Symbol)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("%s не поддерживается", manual.Symbol), http.StatusBadRequest)
+		WriteError(w, fmt.Sprintf("%s не поддерживается", manual.Symbol), http.StatusBadRequest)
 
 		return
 	}
@@ -586,27 +390,27 @@ func (o *OrderController) PostManualOrderAction(w http.ResponseWriter, req *http
 	opened := o.OrderRepository.GetOpenedOrderCached(manual.Symbol, "BUY")
 	if opened != nil && manual.Operation == "SELL" {
 		if opened.Swap {
-			http.Error(w, "Can not sell position when SWAP is processing", http.StatusBadRequest)
+			WriteError(w, "Can not sell position when SWAP is processing", http.StatusBadRequest)
 
 			return
 		}
 
 		minPrice := o.Formatter.FormatPrice(tradeLimit, opened.GetManualMinClosePrice())
 		if minPrice > manual.Price {
-			http.Error(w, fmt.Sprintf("Price can not be less then %.6f", minPrice), http.StatusBadRequest)
+			WriteError(w, fmt.Sprintf("Price can not be less then %.6f", minPrice), http.StatusBadRequest)
 
 			return
 		}
 	}
 
 	if err != nil && manual.Operation == "SELL" {
-		http.Error(w, "There are no opened orders", http.StatusBadRequest)
+		WriteError(w, "There are no opened orders", http.StatusBadRequest)
 
 		return
 	}
 
 	if err == nil && manual.Operation == "BUY" {
-		http.Error(w, "Manual extra buy is temporary prohibited", http.StatusBadRequest)
+		WriteError(w, "Manual extra buy is temporary prohibited", http.StatusBadRequest)
 
 		return
 	}
@@ -614,21 +418,21 @@ func (o *OrderController) PostManualOrderAction(w http.ResponseWriter, req *http
 	priceModel := o.PriceCalculator.CalculateBuy(tradeLimit)
 
 	if priceModel.Error != nil {
-		http.Error(w, fmt.Sprintf("Ошибка: %s", priceModel.Error.Error()), http.StatusBadRequest)
+		WriteError(w, fmt.Sprintf("Ошибка: %s", priceModel.Error.Error()), http.StatusBadRequest)
 
 		return
 	}
 
 	if err != nil && manual.Operation == "BUY" && priceModel.Price < manual.Price {
-		http.Error(w, fmt.Sprintf("Price can not be greather then %f", priceModel.Price), http.StatusBadRequest)
+		WriteError(w, fmt.Sprintf("Price can not be greather then %f", priceModel.Price), http.StatusBadRequest)
 
 		return
 	}
 
-	binanceOrder := o.OrderRepository.GetBinanceOrder(manual.Symbol, manual.Operation)
+	exchangeOrder := o.OrderRepository.GetExchangeOrder(manual.Symbol, manual.Operation)
 
-	if binanceOrder != nil && binanceOrder.Status == "PARTIALLY_FILLED" {
-		http.Error(w, "Order is filling now, please wait until has been filled", http.StatusBadRequest)
+	if exchangeOrder != nil && exchangeOrder.Status == model.ExchangeOrderStatusPartiallyFilled {
+		WriteError(w, "Order is filling now, please wait until has been filled", http.StatusBadRequest)
 
 		return
 	}
@@ -0,0 +1,250 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+)
+
+const ApiVersionPrefix = "/api/v1"
+
+type Middleware func(next http.HandlerFunc) http.HandlerFunc
+
+// Route describes an API action, Request and Response are sample values used to describe the schema in OpenAPI document.
+// Unversioned route is served without /api/v1 prefix only (e.g. /metrics for scrapers),
+// SkipBotUuid route is protected by token scope only and handler sets its own Content-Type
+type Route struct {
+	Method      string
+	Path        string
+	Scope       string
+	Summary     string
+	Tag         string
+	Query       []string
+	Request     any
+	Response    any
+	Handler     http.HandlerFunc
+	Unversioned bool
+	SkipBotUuid bool
+	segments    []string
+}
+
+func (r Route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(r.segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for index, segment := range r.segments {
+		if isPathParam(segment) {
+			if segments[index] == "" {
+				return nil, false
+			}
+			params[strings.Trim(segment, "{}")] = segments[index]
+			continue
+		}
+
+		if segment != segments[index] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// static segments have priority over path params: "/order/list" wins against "/order/{symbol}"
+func (r Route) weight() int {
+	weight := 0
+	for _, segment := range r.segments {
+		weight <<= 1
+		if !isPathParam(segment) {
+			weight |= 1
+		}
+	}
+
+	return weight
+}
+
+// Router serves every route with /api/v1 prefix, unversioned paths are kept for backward compatibility
+type Router struct {
+	Authenticator *ApiAuthenticator
+	CurrentBot    *model.Bot
+	Title         string
+	Version       string
+	routes        []Route
+}
+
+type pathParamsKey struct{}
+
+func PathParam(req *http.Request, name string) string {
+	params, ok := req.Context().Value(pathParamsKey{}).(map[string]string)
+	if !ok {
+		return ""
+	}
+
+	return params[name]
+}
+
+func (r *Router) Handle(route Route) {
+	route.segments = splitPath(route.Path)
+	r.routes = append(r.routes, route)
+	slices.SortStableFunc(r.routes, func(a, b Route) int {
+		return b.weight() - a.weight()
+	})
+}
+
+func (r *Router) GetRoutes() []Route {
+	return r.routes
+}
+
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	Chain(r.dispatch, Recover, RequestLogger)(w, req)
+}
+
+// Chain wraps handler with middlewares, the first middleware is the outermost one
+func Chain(handler http.HandlerFunc, middlewares ...Middleware) http.HandlerFunc {
+	for index := len(middlewares) - 1; index >= 0; index-- {
+		handler = middlewares[index](handler)
+	}
+
+	return handler
+}
+
+func (r *Router) dispatch(w http.ResponseWriter, req *http.Request) {
+	path, isVersioned := strings.CutPrefix(req.URL.Path, ApiVersionPrefix)
+
+	if path == "/openapi.json" && (req.Method == "GET" || req.Method == "OPTIONS") {
+		r.Authenticator.Protect(model.ApiScopePublic, r.GetOpenApiAction)(w, req)
+
+		return
+	}
+
+	segments := splitPath(path)
+	allowed := make([]string, 0)
+
+	for _, route := range r.routes {
+		if route.Unversioned && isVersioned {
+			continue
+		}
+
+		params, ok := route.match(segments)
+		if !ok {
+			continue
+		}
+
+		if route.Method != req.Method && req.Method != "OPTIONS" {
+			if !slices.Contains(allowed, route.Method) {
+				allowed = append(allowed, route.Method)
+			}
+
+			continue
+		}
+
+		if !route.Unversioned && !isVersioned {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", fmt.Sprintf("<%s%s>; rel=\"successor-version\"", ApiVersionPrefix, path))
+		}
+
+		handler := route.Handler
+		if !route.SkipBotUuid {
+			handler = r.checkBotUuid(handler)
+		}
+
+		ctx := context.WithValue(req.Context(), pathParamsKey{}, params)
+		r.Authenticator.Protect(route.Scope, handler)(w, req.WithContext(ctx))
+
+		return
+	}
+
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		WriteError(w, fmt.Sprintf("Only %s method is allowed", strings.Join(allowed, ", ")), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	WriteError(w, "Not found", http.StatusNotFound)
+}
+
+func (r *Router) checkBotUuid(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("botUuid") != r.CurrentBot.BotUuid {
+			WriteError(w, "Forbidden", http.StatusForbidden)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		next(w, req)
+	}
+}
+
+func (r *Router) GetOpenApiAction(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoded, _ := json.Marshal(r.OpenApi())
+	_, _ = w.Write(encoded)
+}
+
+// WriteError sends JSON error envelope: {"error": {"code": 400, "message": "..."}}
+func WriteError(w http.ResponseWriter, message string, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+
+	encoded, _ := json.Marshal(model.ApiErrorResponse{
+		Error: model.ApiError{
+			Code:    code,
+			Message: message,
+		},
+	})
+	_, _ = w.Write(encoded)
+}
+
+func Recover(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		defer func() {
+			if err := recover(); err != nil {
+				log.Printf("API: %s %s panic: %v\n%s", req.Method, req.URL.Path, err, debug.Stack())
+				WriteError(w, "Internal server error", http.StatusInternalServerError)
+			}
+		}()
+
+		next(w, req)
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func RequestLogger(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next(recorder, req)
+
+		if req.Method != "OPTIONS" {
+			log.Printf("API: %s %s %d %dms", req.Method, req.URL.Path, recorder.status, time.Since(start).Milliseconds())
+		}
+	}
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func isPathParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
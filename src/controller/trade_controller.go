@@ -5,11 +5,11 @@ import (
 	"fmt"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/backtest"
 	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
 	"gitlab.com/open-soft/go-crypto-bot/src/validator"
 	"log"
 	"net/http"
-	"strings"
 )
 
 type TradeController struct {
@@ -18,39 +18,17 @@ type TradeController struct {
 	TradeStack          *exchange.TradeStack
 	TradeLimitValidator *validator.TradeLimitValidator
 	SignalRepository    *repository.SignalRepository
+	Backtester          *backtest.Backtester
 }
 
 func (t *TradeController) UpdateTradeLimitAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != t.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	if req.Method != "PUT" {
-		http.Error(w, "Only PUT method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
-
 	var tradeLimit model.TradeLimit
 
 	// Try to decode the request body into the struct. If there is an error,
 	// respond to the client with the error message and a 400 status code.
 	err := json.NewDecoder(req.Body).Decode(&tradeLimit)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
@@ -58,14 +36,14 @@ func (t *TradeController) UpdateTradeLimitAction(w http.ResponseWriter, req *htt
 	violation := t.TradeLimitValidator.Validate(tradeLimit)
 
 	if violation != nil {
-		http.Error(w, violation.Error(), http.StatusBadRequest)
+		WriteError(w, violation.Error(), http.StatusBadRequest)
 
 		return
 	}
 
 	entity, err := t.ExchangeRepository.GetTradeLimit(tradeLimit.Symbol)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
@@ -74,7 +52,7 @@ func (t *TradeController) UpdateTradeLimitAction(w http.ResponseWriter, req *htt
 	err = t.ExchangeRepository.UpdateTradeLimit(tradeLimit)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
@@ -82,7 +60,7 @@ func (t *TradeController) UpdateTradeLimitAction(w http.ResponseWriter, req *htt
 
 	entity, err = t.ExchangeRepository.GetTradeLimit(tradeLimit.Symbol)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		WriteError(w, err.Error(), http.StatusServiceUnavailable)
 
 		return
 	}
@@ -94,36 +72,13 @@ func (t *TradeController) UpdateTradeLimitAction(w http.ResponseWriter, req *htt
 }
 
 func (t *TradeController) CreateTradeLimitAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != t.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	if req.Method != "POST" {
-		http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
-
 	var tradeLimit model.TradeLimit
 
 	// Try to decode the request body into the struct. If there is an error,
 	// respond to the client with the error message and a 400 status code.
 	err := json.NewDecoder(req.Body).Decode(&tradeLimit)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
@@ -131,14 +86,14 @@ func (t *TradeController) CreateTradeLimitAction(w http.ResponseWriter, req *htt
 	violation := t.TradeLimitValidator.Validate(tradeLimit)
 
 	if violation != nil {
-		http.Error(w, violation.Error(), http.StatusBadRequest)
+		WriteError(w, violation.Error(), http.StatusBadRequest)
 
 		return
 	}
 
 	_, err = t.ExchangeRepository.GetTradeLimit(tradeLimit.Symbol)
 	if err == nil {
-		http.Error(w, "Trade limit has already existed", http.StatusBadRequest)
+		WriteError(w, "Trade limit has already existed", http.StatusBadRequest)
 
 		return
 	}
@@ -146,14 +101,14 @@ func (t *TradeController) CreateTradeLimitAction(w http.ResponseWriter, req *htt
 	_, err = t.ExchangeRepository.CreateTradeLimit(tradeLimit)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
 
 	entity, err := t.ExchangeRepository.GetTradeLimit(tradeLimit.Symbol)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		WriteError(w, err.Error(), http.StatusServiceUnavailable)
 
 		return
 	}
@@ -165,29 +120,6 @@ func (t *TradeController) CreateTradeLimitAction(w http.ResponseWriter, req *htt
 }
 
 func (t *TradeController) GetTradeLimitsAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != t.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	if req.Method != "GET" {
-		http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
-
 	limits := t.ExchangeRepository.GetTradeLimits()
 
 	encodedRes, _ := json.Marshal(limits)
@@ -195,42 +127,19 @@ func (t *TradeController) GetTradeLimitsAction(w http.ResponseWriter, req *http.
 }
 
 func (t *TradeController) PostSignalAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != t.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	if req.Method != "POST" {
-		http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
-
 	var signal model.Signal
 
 	// Try to decode the request body into the struct. If there is an error,
 	// respond to the client with the error message and a 400 status code.
 	err := json.NewDecoder(req.Body).Decode(&signal)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
 
 	if t.CurrentBot.Exchange != signal.Exchange {
-		http.Error(w, fmt.Sprintf("Wrong exchange '%s', expected: %s", signal.Exchange, t.CurrentBot.Exchange), http.StatusBadRequest)
+		WriteError(w, fmt.Sprintf("Wrong exchange '%s', expected: %s", signal.Exchange, t.CurrentBot.Exchange), http.StatusBadRequest)
 
 		return
 	}
@@ -241,29 +150,6 @@ func (t *TradeController) PostSignalAction(w http.ResponseWriter, req *http.Requ
 }
 
 func (t *TradeController) GetTradeStackAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != t.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	if req.Method != "GET" {
-		http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
-
 	stack := t.TradeStack.GetTradeStack(exchange.TradeStackParams{
 		SkipFiltered:    false,
 		SkipLocked:      false,
@@ -277,41 +163,18 @@ func (t *TradeController) GetTradeStackAction(w http.ResponseWriter, req *http.R
 	encodedRes, err := json.Marshal(stack)
 	if err != nil {
 		log.Printf("Trade stack marshal error: %s", err.Error())
-		http.Error(w, "Something went wrong", http.StatusServiceUnavailable)
+		WriteError(w, "Something went wrong", http.StatusServiceUnavailable)
 		return
 	}
 	_, _ = fmt.Fprintf(w, string(encodedRes))
 }
 
 func (t *TradeController) SwitchTradeLimitAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != t.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	if req.Method != "PUT" {
-		http.Error(w, "Only PUT method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
-
-	symbol := strings.TrimPrefix(req.URL.Path, "/trade/limit/switch/")
+	symbol := PathParam(req, "symbol")
 
 	entity, err := t.ExchangeRepository.GetTradeLimit(symbol)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
@@ -320,14 +183,14 @@ func (t *TradeController) SwitchTradeLimitAction(w http.ResponseWriter, req *htt
 	err = t.ExchangeRepository.UpdateTradeLimit(entity)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
 
 	entity, err = t.ExchangeRepository.GetTradeLimit(entity.Symbol)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		WriteError(w, err.Error(), http.StatusServiceUnavailable)
 
 		return
 	}
@@ -339,34 +202,11 @@ func (t *TradeController) SwitchTradeLimitAction(w http.ResponseWriter, req *htt
 }
 
 func (t *TradeController) PatchSentimentAction(w http.ResponseWriter, req *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Content-Type", "application/json")
-
-	if req.Method == "OPTIONS" {
-		_, _ = fmt.Fprintf(w, "OK")
-		return
-	}
-
-	botUuid := req.URL.Query().Get("botUuid")
-
-	if botUuid != t.CurrentBot.BotUuid {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-
-		return
-	}
-
-	if req.Method != "PATCH" {
-		http.Error(w, "Only PATCH method is allowed", http.StatusMethodNotAllowed)
-
-		return
-	}
-
-	symbol := strings.TrimPrefix(req.URL.Path, "/trade/limit/sentiment/")
+	symbol := PathParam(req, "symbol")
 
 	entity, err := t.ExchangeRepository.GetTradeLimit(symbol)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
@@ -377,7 +217,7 @@ func (t *TradeController) PatchSentimentAction(w http.ResponseWriter, req *http.
 	// respond to the client with the error message and a 400 status code.
 	err = json.NewDecoder(req.Body).Decode(&sentiment)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
@@ -387,14 +227,14 @@ func (t *TradeController) PatchSentimentAction(w http.ResponseWriter, req *http.
 	err = t.ExchangeRepository.UpdateTradeLimit(entity)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
 
 	entity, err = t.ExchangeRepository.GetTradeLimit(entity.Symbol)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		WriteError(w, err.Error(), http.StatusServiceUnavailable)
 
 		return
 	}
@@ -404,3 +244,32 @@ func (t *TradeController) PatchSentimentAction(w http.ResponseWriter, req *http.
 	encodedRes, _ := json.Marshal(entity)
 	_, _ = fmt.Fprintf(w, string(encodedRes))
 }
+
+func (t *TradeController) BacktestTradeLimitAction(w http.ResponseWriter, req *http.Request) {
+	var request model.BacktestRequest
+
+	err := json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		WriteError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	violation := t.TradeLimitValidator.Validate(request.TradeLimit)
+
+	if violation != nil {
+		WriteError(w, violation.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	report, err := t.Backtester.Run(request)
+	if err != nil {
+		WriteError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	encodedRes, _ := json.Marshal(report)
+	_, _ = fmt.Fprintf(w, string(encodedRes))
+}
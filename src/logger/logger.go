@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// field names shared by all components, to follow one order through decision, exchange and database
+const KeySymbol = "symbol"
+const KeyOperation = "operation"
+const KeyOrderId = "order_id"
+const KeyExchangeOrderId = "exchange_order_id"
+const KeySwapActionId = "swap_action_id"
+const KeyExchange = "exchange"
+const KeyStrategy = "strategy"
+const KeyStream = "stream"
+const KeyCorrelationId = "correlation_id"
+const KeyError = "error"
+
+const FormatJson = "json"
+const FormatText = "text"
+
+// Init replaces default logger, standard log package output is written by the same handler (INFO level)
+func Init(level string, format string) {
+	slog.SetDefault(New(os.Stdout, level, format))
+}
+
+func New(writer io.Writer, level string, format string) *slog.Logger {
+	options := &slog.HandlerOptions{Level: ParseLevel(level)}
+
+	if strings.ToLower(format) == FormatText {
+		return slog.New(slog.NewTextHandler(writer, options))
+	}
+
+	return slog.New(slog.NewJSONHandler(writer, options))
+}
+
+// ParseLevel returns INFO level if value is empty or unknown
+func ParseLevel(value string) slog.Level {
+	var level slog.Level
+	if level.UnmarshalText([]byte(value)) != nil {
+		return slog.LevelInfo
+	}
+
+	return level
+}
+
+// operation loggers by symbol, exchange clients don't know the order operation they are called for
+var operations sync.Map
+
+// BindOperation makes l the logger of exchange calls for the symbol until returned func is called
+func BindOperation(symbol string, l *slog.Logger) func() {
+	operations.Store(symbol, l)
+
+	return func() {
+		operations.CompareAndDelete(symbol, l)
+	}
+}
+
+// ForSymbol returns the logger of the running symbol operation (with correlation id),
+// default logger with exchange and symbol fields is returned if there is no operation
+func ForSymbol(exchange string, symbol string) *slog.Logger {
+	if l, ok := operations.Load(symbol); ok {
+		return l.(*slog.Logger)
+	}
+
+	return slog.With(KeyExchange, exchange, KeySymbol, symbol)
+}
+
+func NewCorrelationId() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+func Error(err error) slog.Attr {
+	if err == nil {
+		return slog.String(KeyError, "")
+	}
+
+	return slog.String(KeyError, err.Error())
+}
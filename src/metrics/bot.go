@@ -0,0 +1,27 @@
+package metrics
+
+var latencyBuckets = []float64{0.5, 1, 5, 15, 30, 60, 120, 300, 600, 1800}
+var predictBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5}
+
+// trading
+var OrdersPlaced = Default.Counter("bot_orders_placed_total", "Orders placed on exchange", "symbol", "operation")
+var OrdersFilled = Default.Counter("bot_orders_filled_total", "Orders filled (fully or partially) on exchange", "symbol", "operation")
+var OrdersCancelled = Default.Counter("bot_orders_cancelled_total", "Orders cancelled or expired without execution", "symbol", "operation")
+var OrderFillLatency = Default.Histogram("bot_order_fill_latency_seconds", "Time between order placement and fill", latencyBuckets, "symbol", "operation")
+var RealizedPnl = Default.Gauge("bot_realized_pnl", "Realized profit and loss of closed positions in quote asset", "symbol", "asset")
+var SwapOutcomes = Default.Counter("bot_swaps_total", "Finished swaps by outcome: success, canceled, rollback, force", "outcome")
+
+// exchange
+var WSReconnects = Default.Counter("bot_ws_reconnects_total", "Websocket reconnects", "exchange", "stream")
+var StalePrices = Default.Counter("bot_stale_price_events_total", "Expired stream prices sent by exchange", "symbol", "source")
+var ApiErrors = Default.Counter("bot_exchange_api_errors_total", "Exchange API errors by error code", "exchange", "code")
+var ExchangeStatus = Default.Gauge("bot_exchange_status", "Exchange connection status, 1 for the current status", "status")
+var PriceAge = Default.Gauge("bot_price_age_seconds", "Seconds since the last price update", "symbol")
+var OrderBookAge = Default.Gauge("bot_order_book_age_seconds", "Seconds since the last order book update", "symbol")
+
+// ml
+var MLPredictDuration = Default.Histogram("bot_ml_predict_duration_seconds", "ML price prediction latency", predictBuckets, "symbol")
+
+// infrastructure
+var StorageUp = Default.Gauge("bot_storage_up", "Storage ping status: 1 is up, 0 is down", "storage")
+var Goroutines = Default.Gauge("bot_goroutines", "Number of goroutines")
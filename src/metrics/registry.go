@@ -0,0 +1,234 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Registry keeps metrics and writes them in Prometheus text exposition format
+type Registry struct {
+	mutex     sync.Mutex
+	vectors   []*vector
+	onCollect []func()
+}
+
+var Default = &Registry{}
+
+// OnCollect callback is called before each scrape, it is used to update gauges from current state
+func (r *Registry) OnCollect(callback func()) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.onCollect = append(r.onCollect, callback)
+}
+
+func (r *Registry) Counter(name string, help string, labels ...string) *CounterVec {
+	return &CounterVec{vector: r.register(name, help, "counter", labels, nil)}
+}
+
+func (r *Registry) Gauge(name string, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{vector: r.register(name, help, "gauge", labels, nil)}
+}
+
+func (r *Registry) Histogram(name string, help string, buckets []float64, labels ...string) *HistogramVec {
+	return &HistogramVec{vector: r.register(name, help, "histogram", labels, buckets)}
+}
+
+func (r *Registry) Write(writer io.Writer) {
+	r.mutex.Lock()
+	callbacks := append([]func(){}, r.onCollect...)
+	vectors := append([]*vector{}, r.vectors...)
+	r.mutex.Unlock()
+
+	for _, callback := range callbacks {
+		callback()
+	}
+
+	for _, vector := range vectors {
+		vector.write(writer)
+	}
+}
+
+func (r *Registry) register(name string, help string, kind string, labels []string, buckets []float64) *vector {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, existing := range r.vectors {
+		if existing.name == name {
+			panic(fmt.Sprintf("Metric %s is already registered", name))
+		}
+	}
+
+	v := &vector{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+	r.vectors = append(r.vectors, v)
+
+	return v
+}
+
+type series struct {
+	labels  []string
+	value   float64
+	sum     float64
+	count   uint64
+	buckets []uint64
+}
+
+type vector struct {
+	mutex   sync.Mutex
+	name    string
+	help    string
+	kind    string
+	labels  []string
+	buckets []float64
+	series  map[string]*series
+}
+
+func (v *vector) update(values []string, callback func(s *series)) {
+	if len(values) != len(v.labels) {
+		panic(fmt.Sprintf("Metric %s expects %d labels, %d given", v.name, len(v.labels), len(values)))
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	key := strings.Join(values, "\xff")
+	s, ok := v.series[key]
+	if !ok {
+		s = &series{
+			labels:  append([]string{}, values...),
+			buckets: make([]uint64, len(v.buckets)),
+		}
+		v.series[key] = s
+	}
+
+	callback(s)
+}
+
+func (v *vector) write(writer io.Writer) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	_, _ = fmt.Fprintf(writer, "# HELP %s %s\n", v.name, v.help)
+	_, _ = fmt.Fprintf(writer, "# TYPE %s %s\n", v.name, v.kind)
+
+	keys := make([]string, 0, len(v.series))
+	for key := range v.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := v.series[key]
+
+		if v.kind != "histogram" {
+			_, _ = fmt.Fprintf(writer, "%s%s %s\n", v.name, v.formatLabels(s.labels, ""), formatValue(s.value))
+			continue
+		}
+
+		for index, bound := range v.buckets {
+			_, _ = fmt.Fprintf(writer, "%s_bucket%s %d\n", v.name, v.formatLabels(s.labels, formatValue(bound)), s.buckets[index])
+		}
+		_, _ = fmt.Fprintf(writer, "%s_bucket%s %d\n", v.name, v.formatLabels(s.labels, "+Inf"), s.count)
+		_, _ = fmt.Fprintf(writer, "%s_sum%s %s\n", v.name, v.formatLabels(s.labels, ""), formatValue(s.sum))
+		_, _ = fmt.Fprintf(writer, "%s_count%s %d\n", v.name, v.formatLabels(s.labels, ""), s.count)
+	}
+}
+
+func (v *vector) formatLabels(values []string, le string) string {
+	pairs := make([]string, 0, len(values)+1)
+	for index, value := range values {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", v.labels[index], escapeLabel(value)))
+	}
+	if le != "" {
+		pairs = append(pairs, fmt.Sprintf("le=\"%s\"", le))
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+type CounterVec struct {
+	vector *vector
+}
+
+func (c *CounterVec) Inc(labels ...string) {
+	c.Add(1, labels...)
+}
+
+// Add increases counter, negative value is ignored, counter can not go down
+func (c *CounterVec) Add(value float64, labels ...string) {
+	if value < 0 {
+		return
+	}
+
+	c.vector.update(labels, func(s *series) {
+		s.value += value
+	})
+}
+
+type GaugeVec struct {
+	vector *vector
+}
+
+func (g *GaugeVec) Set(value float64, labels ...string) {
+	g.vector.update(labels, func(s *series) {
+		s.value = value
+	})
+}
+
+func (g *GaugeVec) Add(value float64, labels ...string) {
+	g.vector.update(labels, func(s *series) {
+		s.value += value
+	})
+}
+
+type HistogramVec struct {
+	vector *vector
+}
+
+func (h *HistogramVec) Observe(value float64, labels ...string) {
+	h.vector.update(labels, func(s *series) {
+		s.sum += value
+		s.count++
+		for index, bound := range h.vector.buckets {
+			if value <= bound {
+				s.buckets[index]++
+			}
+		}
+	})
+}
+
+func (h *HistogramVec) ObserveSince(start time.Time, labels ...string) {
+	h.Observe(time.Since(start).Seconds(), labels...)
+}
+
+func Handler(registry *Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		registry.Write(w)
+	}
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
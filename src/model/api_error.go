@@ -0,0 +1,10 @@
+package model
+
+type ApiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type ApiErrorResponse struct {
+	Error ApiError `json:"error"`
+}
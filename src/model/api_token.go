@@ -0,0 +1,30 @@
+package model
+
+const ApiScopeRead = "read"
+const ApiScopeTrading = "trading"
+const ApiScopeAdmin = "admin"
+
+// ApiScopePublic is used for API documentation, no token is required
+const ApiScopePublic = ""
+
+// admin token can do everything trading token can, trading token can read
+var apiScopeLevel = map[string]int{
+	ApiScopeRead:    1,
+	ApiScopeTrading: 2,
+	ApiScopeAdmin:   3,
+}
+
+type ApiToken struct {
+	Scope string
+	Hash  [32]byte
+}
+
+func (t ApiToken) HasScope(scope string) bool {
+	return apiScopeLevel[t.Scope] >= apiScopeLevel[scope]
+}
+
+func IsApiScopeValid(scope string) bool {
+	_, ok := apiScopeLevel[scope]
+
+	return ok
+}
@@ -0,0 +1,49 @@
+package model
+
+const BacktestDefaultBalance = 1000.00
+const BacktestDefaultFeePercent = 0.1
+const BacktestDefaultPeriodMinutes = 1440
+
+type BacktestRequest struct {
+	TradeLimit     TradeLimit     `json:"tradeLimit"`
+	From           TimestampMilli `json:"from"`
+	To             TimestampMilli `json:"to"`
+	InitialBalance float64        `json:"initialBalance"`
+	FeePercent     float64        `json:"feePercent"`
+}
+
+type BacktestOrder struct {
+	Id          int64          `json:"id"`
+	Symbol      string         `json:"symbol"`
+	Operation   string         `json:"operation"`
+	Price       float64        `json:"price"`
+	Quantity    float64        `json:"quantity"`
+	QuoteAmount float64        `json:"quoteAmount"`
+	Commission  float64        `json:"commission"`
+	ClosesOrder *int64         `json:"closesOrder"`
+	Timestamp   TimestampMilli `json:"timestamp"`
+	Balance     float64        `json:"balance"`
+	CloseReason *string        `json:"closeReason"`
+}
+
+type BacktestReport struct {
+	Symbol             string          `json:"symbol"`
+	From               TimestampMilli  `json:"from"`
+	To                 TimestampMilli  `json:"to"`
+	KLineCount         int64           `json:"klineCount"`
+	InitialBalance     float64         `json:"initialBalance"`
+	FinalBalance       float64         `json:"finalBalance"`
+	FinalEquity        float64         `json:"finalEquity"`
+	RealizedProfit     float64         `json:"realizedProfit"`
+	UnrealizedProfit   float64         `json:"unrealizedProfit"`
+	ProfitPercent      float64         `json:"profitPercent"`
+	MaxDrawdown        float64         `json:"maxDrawdown"`
+	MaxDrawdownPercent float64         `json:"maxDrawdownPercent"`
+	BuyCount           int64           `json:"buyCount"`
+	ExtraBuyCount      int64           `json:"extraBuyCount"`
+	SellCount          int64           `json:"sellCount"`
+	ClosedPositions    int64           `json:"closedPositions"`
+	HasOpenedPosition  bool            `json:"hasOpenedPosition"`
+	SkippedStrategies  []string        `json:"skippedStrategies"` // enabled, but not supported in backtest
+	Orders             []BacktestOrder `json:"orders"`
+}
@@ -1,20 +1,22 @@
 package model
 
 import (
-	"math"
 	"strconv"
 )
 
 type ByBitOrder struct {
-	OrderId     string  `json:"orderId"`
-	Symbol      string  `json:"symbol"`
-	Price       float64 `json:"price,string"`
-	OrigQty     float64 `json:"qty,string"`
-	ExecutedQty float64 `json:"cumExecQty,string"`
-	Status      string  `json:"orderStatus"`
-	Type        string  `json:"orderType"`
-	Side        string  `json:"side"`
-	Timestamp   int64   `json:"createdTime,string"`
+	OrderId      string  `json:"orderId"`
+	Symbol       string  `json:"symbol"`
+	Price        float64 `json:"price,string"`
+	OrigQty      float64 `json:"qty,string"`
+	ExecutedQty  float64 `json:"cumExecQty,string"`
+	Status       string  `json:"orderStatus"`
+	Type         string  `json:"orderType"`
+	Side         string  `json:"side"`
+	Timestamp    int64   `json:"createdTime,string"`
+	AvgPrice     string  `json:"avgPrice"`
+	CumExecQuote string  `json:"cumExecValue"`
+	Category     string  `json:"category"`
 }
 
 func (b ByBitOrder) GetOrderId() string {
@@ -38,92 +40,42 @@ type BinanceOrderLegacy struct {
 	Side                string  `json:"side"`
 	WorkingTime         int64   `json:"workingTime"`
 	Timestamp           int64   `json:"time"`
+	OrderListId         *int64  `json:"orderListId"`
 }
 
-func (b *BinanceOrderLegacy) ToModern() BinanceOrder {
+func (b *BinanceOrderLegacy) ToModern() (ExchangeOrder, error) {
+	status, err := ParseExchangeOrderStatus(b.Status)
+	if err != nil {
+		return ExchangeOrder{}, err
+	}
+
 	orderIdString := strconv.FormatInt(b.OrderId, 10)
 
-	return BinanceOrder{
+	price := b.Price
+	// market orders have no price, average execution price is used
+	if price == 0.00 && b.ExecutedQty > 0.00 {
+		price = b.CummulativeQuoteQty / b.ExecutedQty
+	}
+
+	orderListId := ""
+	// -1 is returned for the order which is not a part of order list (OCO)
+	if b.OrderListId != nil && *b.OrderListId >= 0 {
+		orderListId = strconv.FormatInt(*b.OrderListId, 10)
+	}
+
+	return ExchangeOrder{
 		OrderId:             orderIdString,
 		Symbol:              b.Symbol,
 		TransactTime:        b.TransactTime,
-		Price:               b.Price,
+		Price:               price,
 		OrigQty:             b.OrigQty,
 		ExecutedQty:         b.ExecutedQty,
 		CummulativeQuoteQty: b.CummulativeQuoteQty,
-		Status:              b.Status,
+		Status:              status,
 		Type:                b.Type,
 		Side:                b.Side,
 		WorkingTime:         b.WorkingTime,
 		Timestamp:           b.Timestamp,
-	}
-}
-
-const ExchangeOrderStatusNew = "NEW"
-
-type BinanceOrder struct {
-	OrderId             string  `json:"orderId"`
-	Symbol              string  `json:"symbol"`
-	TransactTime        int64   `json:"transactTime"`
-	Price               float64 `json:"price,string"`
-	OrigQty             float64 `json:"origQty,string"`
-	ExecutedQty         float64 `json:"executedQty,string"`
-	CummulativeQuoteQty float64 `json:"cummulativeQuoteQty,string"`
-	Status              string  `json:"status"`
-	Type                string  `json:"type"`
-	Side                string  `json:"side"`
-	WorkingTime         int64   `json:"workingTime"`
-	Timestamp           int64   `json:"time"`
-}
-
-func (b *BinanceOrder) IsBuy() bool {
-	return b.Side == "BUY"
-}
-
-func (b *BinanceOrder) IsSell() bool {
-	return b.Side == "SELL"
-}
-
-func (b *BinanceOrder) GetProfitPercent(currentPrice float64) Percent {
-	return Percent(math.Round((currentPrice-b.Price)*100/b.Price*100) / 100)
-}
-
-func (b *BinanceOrder) IsNew() bool {
-	return b.Status == ExchangeOrderStatusNew
-}
-
-func (b *BinanceOrder) IsExpired() bool {
-	return b.Status == "EXPIRED" || b.Status == "EXPIRED_IN_MATCH"
-}
-
-func (b *BinanceOrder) IsFilled() bool {
-	return b.Status == "FILLED"
-}
-
-func (b *BinanceOrder) IsCanceled() bool {
-	return b.Status == "CANCELED"
-}
-
-func (b *BinanceOrder) IsPartiallyFilled() bool {
-	return b.Status == "PARTIALLY_FILLED"
-}
-
-func (b *BinanceOrder) IsNearlyFilled() bool {
-	if b.IsFilled() {
-		return true
-	}
-
-	if !b.IsPartiallyFilled() {
-		return false
-	}
-
-	return (b.ExecutedQty * 100 / b.OrigQty) >= 99.5
-}
-
-func (b *BinanceOrder) HasExecutedQuantity() bool {
-	return b.ExecutedQty > 0
-}
-
-func (b *BinanceOrder) GetExecutedQuantity() float64 {
-	return b.ExecutedQty
+		OrderListId:         orderListId,
+	}, nil
 }
@@ -16,6 +16,17 @@ type Bot struct {
 	IsSwapEnabled     bool       `json:"isSwapEnabled"`
 	SwapConfig        SwapConfig `json:"swapConfig"`
 	TradeStackSorting string     `json:"tradeStackSorting"`
+	RiskConfig        RiskConfig `json:"riskConfig"`
+	ReportingCurrency string     `json:"reportingCurrency"`
+}
+
+// GetReportingCurrency portfolio value, risk limits and PnL of all quote assets are converted to reporting currency
+func (b *Bot) GetReportingCurrency() string {
+	if b.ReportingCurrency == "" {
+		return DefaultQuoteAsset
+	}
+
+	return b.ReportingCurrency
 }
 
 func (b *Bot) IsPercentSorting() bool {
@@ -48,4 +59,6 @@ type BotConfigUpdate struct {
 	IsSwapEnabled     bool       `json:"isSwapEnabled"`
 	SwapConfig        SwapConfig `json:"swapConfig"`
 	TradeStackSorting string     `json:"tradeStackSorting"`
+	RiskConfig        RiskConfig `json:"riskConfig"`
+	ReportingCurrency string     `json:"reportingCurrency"`
 }
@@ -10,6 +10,7 @@ const RedisStatusOk = "ok"
 const RedisStatusFail = "fail"
 const BinanceStatusOk = "ok"
 const BinanceStatusBan = "ban"
+const BinanceStatusThrottled = "throttled"
 const BinanceStatusDisconnected = "disconnected"
 const BinanceStatusApiKeyCheck = "api_key_checking"
 
@@ -28,4 +29,5 @@ type BotHealth struct {
 	GOMAXPROCS    int                 `json:"GOMAXPROCS"`
 	NumGoroutine  int                 `json:"numGoroutine"`
 	DateTimeNow   string              `json:"dateTimeNow"`
+	RateLimit     *RateLimitUsage     `json:"rateLimit"`
 }
@@ -330,3 +330,15 @@ type ByBitWsOrderBookEvent struct {
 	Data  ByBitWsOrderBook `json:"data"`
 	Cts   int64            `json:"cts"`
 }
+
+// ToOrderBookDiff update id 1 means bybit service restart, the message is a snapshot
+func (e *ByBitWsOrderBookEvent) ToOrderBookDiff() OrderBookDiff {
+	return OrderBookDiff{
+		Symbol:        e.Data.Symbol,
+		FirstUpdateId: int64(e.Data.U),
+		LastUpdateId:  int64(e.Data.U),
+		IsSnapshot:    e.Type == "snapshot" || e.Data.U == 1,
+		Bids:          e.Data.Bids,
+		Asks:          e.Data.Asks,
+	}
+}
@@ -1,22 +1,74 @@
 package model
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+)
+
 const OrderBasedStrategyName = "order_based_strategy"
 const MarketDepthStrategyName = "market_depth_strategy"
 const BaseKlineStrategyName = "base_kline_strategy"
 const SmaTradeStrategyName = "sma_trade_strategy"
 const DecisionHighestPriorityScore = 999.99
 
+type DecisionParams map[string]float64
+
 type Decision struct {
-	Operation    string     `json:"operation"`
-	Timestamp    int64      `json:"timestamp"`
-	StrategyName string     `json:"strategyName"`
-	Score        float64    `json:"score"`
-	Price        float64    `json:"price"`
-	Params       [3]float64 `json:"params"`
+	Operation    string         `json:"operation"`
+	Timestamp    int64          `json:"timestamp"`
+	StrategyName string         `json:"strategyName"`
+	Score        float64        `json:"score"`
+	Price        float64        `json:"price"`
+	Reason       string         `json:"reason"`
+	Params       DecisionParams `json:"params"`
 }
 
 type FacadeResponse struct {
-	Hold float64
-	Buy  float64
-	Sell float64
+	Hold        float64
+	Buy         float64
+	Sell        float64
+	Explanation DecisionExplanation
+}
+
+type StrategyExplanation struct {
+	StrategyName string         `json:"strategyName"`
+	Operation    string         `json:"operation"`
+	Score        float64        `json:"score"`
+	Weight       float64        `json:"weight"`
+	Price        float64        `json:"price"`
+	Reason       string         `json:"reason"`
+	Params       DecisionParams `json:"params"`
+}
+
+type DecisionExplanation struct {
+	Buy          float64               `json:"buy"`
+	Sell         float64               `json:"sell"`
+	Hold         float64               `json:"hold"`
+	BuyVotes     int64                 `json:"buyVotes"`
+	SellVotes    int64                 `json:"sellVotes"`
+	MinAgreement int64                 `json:"minAgreement"`
+	Strategies   []StrategyExplanation `json:"strategies"`
+	// CorrelationId is set by decision maker and logged with every exchange call and database update of the operation
+	CorrelationId string `json:"correlationId,omitempty"`
+}
+
+// GetStrategyNames returns strategies voted for the operation
+func (e DecisionExplanation) GetStrategyNames(operation string) []string {
+	names := make([]string, 0)
+	for _, strategy := range e.Strategies {
+		if strategy.Operation == operation {
+			names = append(names, strategy.StrategyName)
+		}
+	}
+
+	return names
+}
+
+func (e *DecisionExplanation) Scan(src interface{}) error {
+	return json.Unmarshal(src.([]byte), &e)
+}
+
+func (e DecisionExplanation) Value() (driver.Value, error) {
+	jsonV, err := json.Marshal(e)
+	return string(jsonV), err
 }
@@ -38,6 +38,19 @@ func (d *OrderBookModel) IsEmpty() bool {
 	return len(d.Asks) == 0 && len(d.Bids) == 0
 }
 
+// Top returns copy of the book limited by levels amount
+func (d *OrderBookModel) Top(limit int) OrderBookModel {
+	top := *d
+	if len(top.Bids) > limit {
+		top.Bids = top.Bids[:limit]
+	}
+	if len(top.Asks) > limit {
+		top.Asks = top.Asks[:limit]
+	}
+
+	return top
+}
+
 func (d *OrderBookModel) GetFirstBuyQty() float64 {
 	bids := d.GetBids()
 
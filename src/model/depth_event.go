@@ -4,3 +4,28 @@ type DepthEvent struct {
 	Stream string         `json:"stream"`
 	Depth  OrderBookModel `json:"data"`
 }
+
+type DiffDepth struct {
+	EventType     string      `json:"e"`
+	EventTime     int64       `json:"E"`
+	Symbol        string      `json:"s"`
+	FirstUpdateId int64       `json:"U"`
+	LastUpdateId  int64       `json:"u"`
+	Bids          [][2]Number `json:"b"`
+	Asks          [][2]Number `json:"a"`
+}
+
+type DiffDepthEvent struct {
+	Stream string    `json:"stream"`
+	Data   DiffDepth `json:"data"`
+}
+
+func (d DiffDepth) ToOrderBookDiff() OrderBookDiff {
+	return OrderBookDiff{
+		Symbol:        d.Symbol,
+		FirstUpdateId: d.FirstUpdateId,
+		LastUpdateId:  d.LastUpdateId,
+		Bids:          d.Bids,
+		Asks:          d.Asks,
+	}
+}
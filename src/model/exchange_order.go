@@ -0,0 +1,155 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ExchangeOrderStatus is exchange independent order status, every adapter maps own statuses into it
+type ExchangeOrderStatus string
+
+const ExchangeOrderStatusNew ExchangeOrderStatus = "NEW"
+const ExchangeOrderStatusPartiallyFilled ExchangeOrderStatus = "PARTIALLY_FILLED"
+const ExchangeOrderStatusFilled ExchangeOrderStatus = "FILLED"
+const ExchangeOrderStatusCanceled ExchangeOrderStatus = "CANCELED"
+const ExchangeOrderStatusPendingCancel ExchangeOrderStatus = "PENDING_CANCEL"
+const ExchangeOrderStatusRejected ExchangeOrderStatus = "REJECTED"
+const ExchangeOrderStatusExpired ExchangeOrderStatus = "EXPIRED"
+const ExchangeOrderStatusExpiredInMatch ExchangeOrderStatus = "EXPIRED_IN_MATCH"
+
+func ParseExchangeOrderStatus(status string) (ExchangeOrderStatus, error) {
+	switch ExchangeOrderStatus(status) {
+	case ExchangeOrderStatusNew,
+		ExchangeOrderStatusPartiallyFilled,
+		ExchangeOrderStatusFilled,
+		ExchangeOrderStatusCanceled,
+		ExchangeOrderStatusPendingCancel,
+		ExchangeOrderStatusRejected,
+		ExchangeOrderStatusExpired,
+		ExchangeOrderStatusExpiredInMatch:
+		return ExchangeOrderStatus(status), nil
+	}
+
+	return "", errors.New(fmt.Sprintf("Unknown exchange order status: %s", status))
+}
+
+const ExchangeOrderSideBuy = "BUY"
+const ExchangeOrderSideSell = "SELL"
+
+const OrderTypeLimit = "LIMIT"
+const OrderTypeMarket = "MARKET"
+const OrderTypeLimitMaker = "LIMIT_MAKER"
+
+type ExchangeOrder struct {
+	OrderId             string              `json:"orderId"`
+	Symbol              string              `json:"symbol"`
+	TransactTime        int64               `json:"transactTime"`
+	Price               float64             `json:"price,string"`
+	OrigQty             float64             `json:"origQty,string"`
+	ExecutedQty         float64             `json:"executedQty,string"`
+	CummulativeQuoteQty float64             `json:"cummulativeQuoteQty,string"`
+	Status              ExchangeOrderStatus `json:"status"`
+	Type                string              `json:"type"`
+	Side                string              `json:"side"`
+	WorkingTime         int64               `json:"workingTime"`
+	Timestamp           int64               `json:"time"`
+	OrderListId         string              `json:"orderListId,omitempty"`
+}
+
+// ExchangeFill is executed part of the order
+type ExchangeFill struct {
+	Price         float64
+	Quantity      float64
+	QuoteQuantity float64
+}
+
+func (b *ExchangeOrder) GetFill() ExchangeFill {
+	quoteQuantity := b.CummulativeQuoteQty
+	if quoteQuantity == 0.00 {
+		quoteQuantity = b.ExecutedQty * b.Price
+	}
+
+	return ExchangeFill{
+		Price:         b.Price,
+		Quantity:      b.ExecutedQty,
+		QuoteQuantity: quoteQuantity,
+	}
+}
+
+// GetExternalStatus swap action keeps exchange status as plain string (together with own rollback statuses)
+// GetPlacedAt returns order creation time in milliseconds, 0 if exchange didn't send it
+func (b *ExchangeOrder) GetPlacedAt() int64 {
+	if b.Timestamp > 0 {
+		return b.Timestamp
+	}
+
+	return b.TransactTime
+}
+
+func (b *ExchangeOrder) GetExternalStatus() *string {
+	status := string(b.Status)
+
+	return &status
+}
+
+func (b *ExchangeOrder) IsBuy() bool {
+	return b.Side == ExchangeOrderSideBuy
+}
+
+func (b *ExchangeOrder) IsSell() bool {
+	return b.Side == ExchangeOrderSideSell
+}
+
+func (b *ExchangeOrder) GetProfitPercent(currentPrice float64) Percent {
+	return Percent(math.Round((currentPrice-b.Price)*100/b.Price*100) / 100)
+}
+
+// IsProtection returns true for the order which is a part of exchange side take profit and stop loss pair
+func (b *ExchangeOrder) IsProtection() bool {
+	return b.OrderListId != ""
+}
+
+func (b *ExchangeOrder) IsMarket() bool {
+	return b.Type == OrderTypeMarket
+}
+
+func (b *ExchangeOrder) IsNew() bool {
+	return b.Status == ExchangeOrderStatusNew
+}
+
+func (b *ExchangeOrder) IsExpired() bool {
+	return b.Status == ExchangeOrderStatusExpired || b.Status == ExchangeOrderStatusExpiredInMatch
+}
+
+func (b *ExchangeOrder) IsFilled() bool {
+	return b.Status == ExchangeOrderStatusFilled
+}
+
+func (b *ExchangeOrder) IsCanceled() bool {
+	return b.Status == ExchangeOrderStatusCanceled
+}
+
+func (b *ExchangeOrder) IsPartiallyFilled() bool {
+	return b.Status == ExchangeOrderStatusPartiallyFilled
+}
+
+func (b *ExchangeOrder) IsNearlyFilled() bool {
+	if b.IsFilled() {
+		return true
+	}
+
+	if !b.IsPartiallyFilled() {
+		return false
+	}
+
+	return (b.ExecutedQty * 100 / b.OrigQty) >= 99.5
+}
+
+func (b *ExchangeOrder) HasExecutedQuantity() bool {
+	return b.ExecutedQty > 0
+}
+
+func (b *ExchangeOrder) GetExecutedQuantity() float64 {
+	return b.ExecutedQty
+}
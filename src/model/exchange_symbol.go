@@ -0,0 +1,29 @@
+package model
+
+// ExchangeSymbol is exchange independent symbol info, every adapter maps exchange filters into it
+type ExchangeSymbol struct {
+	Symbol             string  `json:"symbol"`
+	IsTrading          bool    `json:"isTrading"`
+	BaseAsset          string  `json:"baseAsset"`
+	QuoteAsset         string  `json:"quoteAsset"`
+	BaseAssetPrecision float64 `json:"baseAssetPrecision"`
+	QuotePrecision     float64 `json:"quotePrecision"`
+	MinPrice           float64 `json:"minPrice"`
+	TickSize           float64 `json:"tickSize"`
+	MinQuantity        float64 `json:"minQuantity"`
+	MaxQuantity        float64 `json:"maxQuantity"`
+	StepSize           float64 `json:"stepSize"`
+	MinNotional        float64 `json:"minNotional"`
+	MaxNotional        float64 `json:"maxNotional"`
+}
+
+type ExchangeInfo struct {
+	Timezone   string           `json:"timezone"`
+	ServerTime int64            `json:"serverTime"`
+	Symbols    []ExchangeSymbol `json:"symbols"`
+}
+
+type ExchangeTicker struct {
+	Symbol string  `json:"symbol"`
+	Price  float64 `json:"price,string"`
+}
@@ -0,0 +1,24 @@
+package model
+
+const IndicatorEma9 = "ema_9"
+const IndicatorEma21 = "ema_21"
+const IndicatorEmaCross = "ema_cross"
+const IndicatorRsi14 = "rsi_14"
+const IndicatorMacd = "macd"
+const IndicatorMacdSignal = "macd_signal"
+const IndicatorMacdHistogram = "macd_histogram"
+const IndicatorBollingerUpper = "bb_upper"
+const IndicatorBollingerMiddle = "bb_middle"
+const IndicatorBollingerLower = "bb_lower"
+const IndicatorBollingerPercent = "bb_percent"
+const IndicatorAtr14 = "atr_14"
+const IndicatorVwap = "vwap"
+
+// IndicatorValues contains only values of indicators which have enough history
+type IndicatorValues map[string]float64
+
+func (i IndicatorValues) Get(name string) (float64, bool) {
+	value, ok := i[name]
+
+	return value, ok
+}
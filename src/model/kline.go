@@ -8,6 +8,7 @@ import (
 const KLineSourceTickerStream = "ticker_stream"
 const KLineSourceKLineStream = "kline_stream"
 const KLineSourceKLineFetch = "kline_fetch"
+const KLineSourceBacktest = "backtest"
 
 type PriceChange struct {
 	CloseTime       TimestampMilli `json:"closeTime"`
@@ -100,6 +101,12 @@ func (k *KLine) GetPriceChangeSpeedAvg() float64 {
 	return avgValue
 }
 
+func (k *KLine) GetBaseAsset() string {
+	baseAsset, _ := SplitSymbol(k.Symbol)
+
+	return baseAsset
+}
+
 func (k *KLine) IsNegative() bool {
 	return k.Close.Value() < k.Open.Value()
 }
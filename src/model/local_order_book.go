@@ -0,0 +1,120 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// OrderBookDiff is exchange agnostic order book update: Binance diff-depth event (U..u) or ByBit delta/snapshot (u)
+type OrderBookDiff struct {
+	Symbol        string
+	FirstUpdateId int64
+	LastUpdateId  int64
+	IsSnapshot    bool
+	Bids          [][2]Number
+	Asks          [][2]Number
+}
+
+type LocalOrderBook struct {
+	Symbol       string
+	LastUpdateId int64
+	Synced       bool
+	bids         map[float64]float64
+	asks         map[float64]float64
+}
+
+func NewLocalOrderBook(symbol string) *LocalOrderBook {
+	return &LocalOrderBook{
+		Symbol: symbol,
+		bids:   make(map[float64]float64),
+		asks:   make(map[float64]float64),
+	}
+}
+
+func (b *LocalOrderBook) ApplySnapshot(lastUpdateId int64, bids [][2]Number, asks [][2]Number) {
+	b.bids = make(map[float64]float64)
+	b.asks = make(map[float64]float64)
+	applyOrderBookLevels(b.bids, bids)
+	applyOrderBookLevels(b.asks, asks)
+	b.LastUpdateId = lastUpdateId
+	b.Synced = true
+}
+
+// Apply validates update sequence, stale updates are ignored, gap makes book out of sync and must be resynced from snapshot
+func (b *LocalOrderBook) Apply(diff OrderBookDiff) error {
+	if diff.IsSnapshot {
+		b.ApplySnapshot(diff.LastUpdateId, diff.Bids, diff.Asks)
+
+		return nil
+	}
+
+	if !b.Synced {
+		return errors.New("Order book is not synced")
+	}
+
+	if diff.LastUpdateId <= b.LastUpdateId {
+		return nil
+	}
+
+	if diff.FirstUpdateId > b.LastUpdateId+1 {
+		b.Synced = false
+
+		return errors.New(fmt.Sprintf(
+			"Order book sequence gap: expected %d, got %d..%d",
+			b.LastUpdateId+1,
+			diff.FirstUpdateId,
+			diff.LastUpdateId,
+		))
+	}
+
+	applyOrderBookLevels(b.bids, diff.Bids)
+	applyOrderBookLevels(b.asks, diff.Asks)
+	b.LastUpdateId = diff.LastUpdateId
+
+	return nil
+}
+
+func (b *LocalOrderBook) ToOrderBookModel(limit int, timestamp int64) OrderBookModel {
+	return OrderBookModel{
+		Symbol:    b.Symbol,
+		Timestamp: timestamp,
+		Bids:      sortOrderBookLevels(b.bids, limit, true),
+		Asks:      sortOrderBookLevels(b.asks, limit, false),
+	}
+}
+
+func applyOrderBookLevels(book map[float64]float64, levels [][2]Number) {
+	for _, level := range levels {
+		if level[1].Value == 0.00 {
+			delete(book, level[0].Value)
+			continue
+		}
+
+		book[level[0].Value] = level[1].Value
+	}
+}
+
+func sortOrderBookLevels(book map[float64]float64, limit int, desc bool) [][2]Number {
+	prices := make([]float64, 0, len(book))
+	for price := range book {
+		prices = append(prices, price)
+	}
+
+	if desc {
+		sort.Sort(sort.Reverse(sort.Float64Slice(prices)))
+	} else {
+		sort.Float64s(prices)
+	}
+
+	if limit > 0 && len(prices) > limit {
+		prices = prices[:limit]
+	}
+
+	levels := make([][2]Number, 0, len(prices))
+	for _, price := range prices {
+		levels = append(levels, [2]Number{{Value: price}, {Value: book[price]}})
+	}
+
+	return levels
+}
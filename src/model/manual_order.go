@@ -8,6 +8,7 @@ type ManualOrder struct {
 	Symbol    string  `json:"symbol"`
 	BotUuid   string  `json:"botUuid"`
 	Ttl       int64   `json:"ttl"`
+	OrderType string  `json:"orderType"`
 }
 
 func (m *ManualOrder) IsBuy() bool {
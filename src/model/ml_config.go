@@ -0,0 +1,93 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+)
+
+const MLFeatureOrderBookBuyFirstQty = "order_book_buy_first_qty"
+const MLFeatureOrderBookSellFirstQty = "order_book_sell_first_qty"
+const MLFeatureOrderBookBuyQtySum = "order_book_buy_qty_sum"
+const MLFeatureOrderBookSellQtySum = "order_book_sell_qty_sum"
+const MLFeatureOrderBookBuyVolumeSum = "order_book_buy_volume_sum"
+const MLFeatureOrderBookSellVolumeSum = "order_book_sell_volume_sum"
+const MLFeatureSecondaryPrice = "secondary_price"
+const MLFeatureReturn1m = "return_1m"
+const MLFeatureReturn5m = "return_5m"
+const MLFeatureReturn15m = "return_15m"
+const MLFeatureTradeFlowImbalance = "trade_flow_imbalance"
+const MLFeatureMaxPriceChangeSpeed = "max_price_change_speed"
+const MLFeatureMinPriceChangeSpeed = "min_price_change_speed"
+const MLFeatureBuyIcebergQty = "buy_iceberg_qty"
+const MLFeatureSellIcebergQty = "sell_iceberg_qty"
+const MLFeatureBtcCapitalization = "btc_capitalization"
+const MLFeatureBtcInterpolation = "btc_interpolation"
+const MLFeatureEthInterpolation = "eth_interpolation"
+
+const MLMaxHorizon = 60
+
+// DefaultMLFeatures order book aggregates and secondary price, used when feature set is not configured
+var DefaultMLFeatures = []string{
+	MLFeatureOrderBookBuyFirstQty,
+	MLFeatureOrderBookSellFirstQty,
+	MLFeatureOrderBookBuyQtySum,
+	MLFeatureOrderBookSellQtySum,
+	MLFeatureOrderBookBuyVolumeSum,
+	MLFeatureOrderBookSellVolumeSum,
+	MLFeatureSecondaryPrice,
+}
+
+var MLFeatures = []string{
+	MLFeatureOrderBookBuyFirstQty,
+	MLFeatureOrderBookSellFirstQty,
+	MLFeatureOrderBookBuyQtySum,
+	MLFeatureOrderBookSellQtySum,
+	MLFeatureOrderBookBuyVolumeSum,
+	MLFeatureOrderBookSellVolumeSum,
+	MLFeatureSecondaryPrice,
+	MLFeatureReturn1m,
+	MLFeatureReturn5m,
+	MLFeatureReturn15m,
+	MLFeatureTradeFlowImbalance,
+	MLFeatureMaxPriceChangeSpeed,
+	MLFeatureMinPriceChangeSpeed,
+	MLFeatureBuyIcebergQty,
+	MLFeatureSellIcebergQty,
+	MLFeatureBtcCapitalization,
+	MLFeatureBtcInterpolation,
+	MLFeatureEthInterpolation,
+}
+
+// MLConfig Horizon is amount of minutes the close price is predicted for, 0 means the close price of the current minute
+type MLConfig struct {
+	Features []string `json:"features"`
+	Horizon  int64    `json:"horizon"`
+}
+
+func (m *MLConfig) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+
+	return json.Unmarshal(src.([]byte), &m)
+}
+func (m MLConfig) Value() (driver.Value, error) {
+	jsonV, err := json.Marshal(m)
+	return string(jsonV), err
+}
+
+func (m MLConfig) GetFeatures() []string {
+	if len(m.Features) == 0 {
+		return DefaultMLFeatures
+	}
+
+	return m.Features
+}
+
+func (m MLConfig) GetHorizon() int64 {
+	if m.Horizon < 0 {
+		return 0
+	}
+
+	return m.Horizon
+}
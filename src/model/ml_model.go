@@ -0,0 +1,66 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"slices"
+)
+
+type MLModelMetrics struct {
+	TrainRMSE            float64 `json:"trainRmse"`
+	TrainR2              float64 `json:"trainR2"`
+	WalkForwardRMSE      float64 `json:"walkForwardRmse"`
+	WalkForwardR2        float64 `json:"walkForwardR2"`
+	WalkForwardFolds     int64   `json:"walkForwardFolds"`
+	ValidationLength     int64   `json:"validationLength"`
+	ValidationRMSE       float64 `json:"validationRmse"`
+	ActiveValidationRMSE float64 `json:"activeValidationRmse"`
+}
+
+func (m *MLModelMetrics) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+
+	return json.Unmarshal(src.([]byte), &m)
+}
+func (m MLModelMetrics) Value() (driver.Value, error) {
+	jsonV, err := json.Marshal(m)
+	return string(jsonV), err
+}
+
+type MLFeatureSet []string
+
+func (f *MLFeatureSet) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+
+	return json.Unmarshal(src.([]byte), &f)
+}
+func (f MLFeatureSet) Value() (driver.Value, error) {
+	jsonV, err := json.Marshal(f)
+	return string(jsonV), err
+}
+
+func (f MLFeatureSet) IsSame(features []string) bool {
+	return slices.Equal(f, features)
+}
+
+// MLModel is trained model registry entry, only one model of the symbol is active and used for prediction
+type MLModel struct {
+	Id              int64          `json:"id"`
+	Symbol          string         `json:"symbol"`
+	ModelType       string         `json:"modelType"`
+	FormatVersion   int64          `json:"formatVersion"`
+	Features        MLFeatureSet   `json:"features"`
+	Horizon         int64          `json:"horizon"`
+	Metrics         MLModelMetrics `json:"metrics"`
+	DatasetFrom     TimestampMilli `json:"datasetFrom"`
+	DatasetTo       TimestampMilli `json:"datasetTo"`
+	DatasetLength   int64          `json:"datasetLength"`
+	IsActive        bool           `json:"isActive"`
+	PromotionReason string         `json:"promotionReason"`
+	CreatedAt       string         `json:"createdAt"`
+	Payload         string         `json:"-"`
+}
@@ -0,0 +1,166 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+const NotificationEventBuy = "buy"
+const NotificationEventSell = "sell"
+const NotificationEventExtraCharge = "extra_charge"
+const NotificationEventSwapStarted = "swap_started"
+const NotificationEventSwapFinished = "swap_finished"
+const NotificationEventSwapFailed = "swap_failed"
+const NotificationEventError = "error"
+const NotificationEventRiskLimit = "risk_limit"
+const NotificationEventDailySummary = "daily_summary"
+
+var NotificationEvents = []string{
+	NotificationEventBuy,
+	NotificationEventSell,
+	NotificationEventExtraCharge,
+	NotificationEventSwapStarted,
+	NotificationEventSwapFinished,
+	NotificationEventSwapFailed,
+	NotificationEventError,
+	NotificationEventRiskLimit,
+	NotificationEventDailySummary,
+}
+
+// Notification is rendered as text by chat channels, webhook receives it as is
+type Notification struct {
+	Event      string      `json:"event"`
+	BotUuid    string      `json:"bot"`
+	Exchange   string      `json:"exchange"`
+	Symbol     string      `json:"symbol,omitempty"`
+	Title      string      `json:"title"`
+	Message    string      `json:"message"`
+	Details    string      `json:"details,omitempty"`
+	Code       string      `json:"code,omitempty"`
+	Stop       bool        `json:"stop,omitempty"`
+	DateTime   string      `json:"dateTime"`
+	Order      *Order      `json:"order,omitempty"`
+	SwapAction *SwapAction `json:"swapAction,omitempty"`
+	RiskStatus *RiskStatus `json:"riskStatus,omitempty"`
+}
+
+func (n Notification) GetText() string {
+	if n.Message == "" {
+		return n.Title
+	}
+
+	return fmt.Sprintf("%s\n%s", n.Title, n.Message)
+}
+
+func NewOrderNotification(event string, order Order, bot Bot, details string) Notification {
+	title := strings.ToUpper(order.Operation)
+	if event == NotificationEventExtraCharge {
+		title = "EXTRA CHARGE"
+	}
+
+	return Notification{
+		Event:    event,
+		BotUuid:  bot.BotUuid,
+		Exchange: bot.Exchange,
+		Symbol:   order.Symbol,
+		Title:    fmt.Sprintf("%s %s", title, order.Symbol),
+		Message: fmt.Sprintf(
+			"Price: %f %s\nQuantity: %f %s\n%s",
+			order.Price,
+			order.GetQuoteAsset(),
+			order.Quantity,
+			order.GetBaseAsset(),
+			details,
+		),
+		Details:  details,
+		DateTime: order.CreatedAt,
+		Order:    &order,
+	}
+}
+
+func NewErrorNotification(bot Bot, code string, message string, stop bool) Notification {
+	return Notification{
+		Event:    NotificationEventError,
+		BotUuid:  bot.BotUuid,
+		Exchange: bot.Exchange,
+		Title:    fmt.Sprintf("Error: %s", code),
+		Message:  message,
+		Code:     code,
+		Stop:     stop,
+	}
+}
+
+func NewRiskLimitNotification(bot Bot, code string, message string) Notification {
+	return Notification{
+		Event:    NotificationEventRiskLimit,
+		BotUuid:  bot.BotUuid,
+		Exchange: bot.Exchange,
+		Title:    fmt.Sprintf("Risk limit: %s", code),
+		Message:  message,
+		Code:     code,
+	}
+}
+
+func NewSwapNotification(event string, swapAction SwapAction, bot Bot, details string) Notification {
+	title := "Swap started"
+	switch event {
+	case NotificationEventSwapFinished:
+		title = "Swap finished"
+	case NotificationEventSwapFailed:
+		title = "Swap failed"
+	}
+
+	return Notification{
+		Event:    event,
+		BotUuid:  bot.BotUuid,
+		Exchange: bot.Exchange,
+		Symbol:   swapAction.SwapOneSymbol,
+		Title:    fmt.Sprintf("%s #%d %s", title, swapAction.Id, swapAction.Asset),
+		Message: fmt.Sprintf(
+			"Chain: %s -> %s -> %s\nStart quantity: %f %s\n%s",
+			swapAction.SwapOneSymbol,
+			swapAction.SwapTwoSymbol,
+			swapAction.SwapThreeSymbol,
+			swapAction.StartQuantity,
+			swapAction.Asset,
+			details,
+		),
+		Details:    details,
+		SwapAction: &swapAction,
+	}
+}
+
+func NewDailySummaryNotification(bot Bot, date string, status RiskStatus) Notification {
+	currency := status.ReportingCurrency
+	message := fmt.Sprintf(
+		"Realized PnL: %.2f %s\nUnrealized PnL: %.2f %s\nDaily PnL: %.2f %s\nOpened positions: %d\nExposure: %.2f %s\nBalance: %.2f %s",
+		status.RealizedPnl,
+		currency,
+		status.UnrealizedPnl,
+		currency,
+		status.DailyPnl,
+		currency,
+		status.Positions,
+		status.Exposure,
+		currency,
+		status.Balance,
+		currency,
+	)
+
+	if status.IsBuyPaused {
+		message = fmt.Sprintf("%s\nBuying is paused by daily loss limit", message)
+	}
+
+	if !status.IsComplete() {
+		message = fmt.Sprintf("%s\nNot converted to %s (not counted): %s", message, currency, strings.Join(status.UnconvertedAssets, ", "))
+	}
+
+	return Notification{
+		Event:      NotificationEventDailySummary,
+		BotUuid:    bot.BotUuid,
+		Exchange:   bot.Exchange,
+		Title:      fmt.Sprintf("Daily summary %s", date),
+		Message:    message,
+		RiskStatus: &status,
+	}
+}
@@ -0,0 +1,242 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const OkxResponseCodeOk = "0"
+
+type OkxOrder struct {
+	InstId         string `json:"instId"`
+	OrderId        string `json:"ordId"`
+	Price          string `json:"px"`
+	Size           string `json:"sz"`
+	AccFillSize    string `json:"accFillSz"`
+	AvgPrice       string `json:"avgPx"`
+	State          string `json:"state"`
+	OrderType      string `json:"ordType"`
+	Side           string `json:"side"`
+	TargetCurrency string `json:"tgtCcy"`
+	CreatedTime    int64  `json:"cTime,string"`
+	UpdatedTime    int64  `json:"uTime,string"`
+}
+
+type OkxOrderListResponse struct {
+	Code    string     `json:"code"`
+	Message string     `json:"msg"`
+	Data    []OkxOrder `json:"data"`
+}
+
+type OkxOrderResult struct {
+	OrderId    string `json:"ordId"`
+	ClOrderId  string `json:"clOrdId"`
+	StatusCode string `json:"sCode"`
+	StatusMsg  string `json:"sMsg"`
+}
+
+type OkxOrderResultResponse struct {
+	Code    string           `json:"code"`
+	Message string           `json:"msg"`
+	Data    []OkxOrderResult `json:"data"`
+}
+
+type OkxOrderBook struct {
+	Asks      [][]Number     `json:"asks"`
+	Bids      [][]Number     `json:"bids"`
+	InstId    string         `json:"instId"`
+	Timestamp TimestampMilli `json:"ts,string"`
+}
+
+// ToOrderBookModel okx level is [price, size, deprecated, orders count]
+func (o *OkxOrderBook) ToOrderBookModel(symbol string) OrderBookModel {
+	return OrderBookModel{
+		Symbol:    symbol,
+		Timestamp: time.Now().UnixMilli(),
+		Bids:      okxLevelsToDepth(o.Bids),
+		Asks:      okxLevelsToDepth(o.Asks),
+	}
+}
+
+func okxLevelsToDepth(levels [][]Number) [][2]Number {
+	depth := make([][2]Number, 0)
+	for _, level := range levels {
+		if len(level) < 2 {
+			continue
+		}
+		depth = append(depth, [2]Number{level[0], level[1]})
+	}
+
+	return depth
+}
+
+type OkxOrderBookResponse struct {
+	Code    string         `json:"code"`
+	Message string         `json:"msg"`
+	Data    []OkxOrderBook `json:"data"`
+}
+
+// OkxKLine is [ts, o, h, l, c, vol, volCcy, volCcyQuote, confirm]
+type OkxKLine struct {
+	OpenTime    string
+	Open        string
+	High        string
+	Low         string
+	Close       string
+	Volume      string
+	VolumeCcy   string
+	VolumeQuote string
+	Confirm     string
+}
+
+func (k *OkxKLine) UnmarshalJSON(data []byte) error {
+	var s []json.RawMessage
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	dest := []interface{}{
+		&k.OpenTime,
+		&k.Open,
+		&k.High,
+		&k.Low,
+		&k.Close,
+		&k.Volume,
+		&k.VolumeCcy,
+		&k.VolumeQuote,
+		&k.Confirm,
+	}
+
+	for i := 0; i < len(s) && i < len(dest); i++ {
+		if err := json.Unmarshal(s[i], dest[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type OkxKLineResponse struct {
+	Code    string     `json:"code"`
+	Message string     `json:"msg"`
+	Data    []OkxKLine `json:"data"`
+}
+
+const OkxTradeSideBuy = "buy"
+const OkxTradeSideSell = "sell"
+
+type OkxTrade struct {
+	InstId    string         `json:"instId"`
+	TradeId   int64          `json:"tradeId,string"`
+	Price     float64        `json:"px,string"`
+	Size      float64        `json:"sz,string"`
+	Side      string         `json:"side"`
+	Timestamp TimestampMilli `json:"ts,string"`
+}
+
+type OkxTradeResponse struct {
+	Code    string     `json:"code"`
+	Message string     `json:"msg"`
+	Data    []OkxTrade `json:"data"`
+}
+
+type OkxInstrument struct {
+	InstId      string  `json:"instId"`
+	BaseCcy     string  `json:"baseCcy"`
+	QuoteCcy    string  `json:"quoteCcy"`
+	TickSize    float64 `json:"tickSz,string"`
+	LotSize     float64 `json:"lotSz,string"`
+	MinSize     float64 `json:"minSz,string"`
+	MaxLimitSz  float64 `json:"maxLmtSz,string"`
+	MaxMarketSz float64 `json:"maxMktSz,string"`
+	State       string  `json:"state"`
+}
+
+type OkxInstrumentResponse struct {
+	Code    string          `json:"code"`
+	Message string          `json:"msg"`
+	Data    []OkxInstrument `json:"data"`
+}
+
+type OkxBalanceDetail struct {
+	Currency         string  `json:"ccy"`
+	AvailableBalance float64 `json:"availBal,string"`
+	FrozenBalance    float64 `json:"frozenBal,string"`
+}
+
+type OkxBalance struct {
+	Details []OkxBalanceDetail `json:"details"`
+}
+
+type OkxBalanceResponse struct {
+	Code    string       `json:"code"`
+	Message string       `json:"msg"`
+	Data    []OkxBalance `json:"data"`
+}
+
+type OkxTicker struct {
+	InstId    string         `json:"instId"`
+	Last      Price          `json:"last"`
+	Open24h   Price          `json:"open24h"`
+	High24h   Price          `json:"high24h"`
+	Low24h    Price          `json:"low24h"`
+	Vol24h    Volume         `json:"vol24h"`
+	VolCcy24h Volume         `json:"volCcy24h"`
+	Timestamp TimestampMilli `json:"ts,string"`
+}
+
+func (t *OkxTicker) ToBinanceMiniTicker(symbol string) MiniTicker {
+	return MiniTicker{
+		EventTime:        t.Timestamp,
+		Symbol:           symbol,
+		Close:            t.Last,
+		TotalVolumeAsset: t.Vol24h,
+		TotalVolumeQuote: t.VolCcy24h,
+	}
+}
+
+type OkxTickerResponse struct {
+	Code    string      `json:"code"`
+	Message string      `json:"msg"`
+	Data    []OkxTicker `json:"data"`
+}
+
+type OkxWsArgument struct {
+	Channel string `json:"channel"`
+	InstId  string `json:"instId"`
+}
+
+type OkxSocketStreamsRequest struct {
+	Operation string          `json:"op"`
+	Arguments []OkxWsArgument `json:"args"`
+}
+
+type OkxWsTickerEvent struct {
+	Argument OkxWsArgument `json:"arg"`
+	Data     []OkxTicker   `json:"data"`
+}
+
+type OkxWsTradeEvent struct {
+	Argument OkxWsArgument `json:"arg"`
+	Data     []OkxTrade    `json:"data"`
+}
+
+type OkxWsKLineEvent struct {
+	Argument OkxWsArgument `json:"arg"`
+	Data     []OkxKLine    `json:"data"`
+}
+
+type OkxWsOrderBookEvent struct {
+	Argument OkxWsArgument  `json:"arg"`
+	Data     []OkxOrderBook `json:"data"`
+}
+
+const OkxChannelTickers = "tickers"
+const OkxChannelTrades = "trades"
+const OkxChannelCandle = "candle1m"
+const OkxChannelBooks = "books5"
+
+type OkxWsEvent struct {
+	Event    string        `json:"event"`
+	Argument OkxWsArgument `json:"arg"`
+}
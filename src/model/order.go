@@ -6,12 +6,16 @@ import (
 	"errors"
 	"math"
 	"sort"
-	"strings"
 	"time"
 )
 
 const MinProfitPercent = 0.50
 
+const OrderCloseReasonTakeProfit = "take_profit"
+const OrderCloseReasonManual = "manual"
+const OrderCloseReasonStopLoss = "stop_loss"
+const OrderCloseReasonTrailingStop = "trailing_stop"
+
 type Percent float64
 
 func (p Percent) IsPositive() bool {
@@ -68,29 +72,34 @@ type ProfitPositionInterface interface {
 }
 
 type Order struct {
-	Id                 int64              `json:"id"`
-	Symbol             string             `json:"symbol"`
-	Price              float64            `json:"price"`
-	Quantity           float64            `json:"quantity"`
-	ExecutedQuantity   float64            `json:"executedQuantity"`
-	CreatedAt          string             `json:"createdAt"`
-	SellVolume         float64            `json:"sellVolume"`
-	BuyVolume          float64            `json:"buyVolume"`
-	SmaValue           float64            `json:"smaValue"`
-	Operation          string             `json:"operation"`
-	Status             string             `json:"status"`
-	ExternalId         *string            `json:"externalId"`
-	Exchange           string             `json:"exchange"`
-	ClosesOrder        *int64             `json:"closesOrder"` // sell order here
-	UsedExtraBudget    float64            `json:"usedExtraBudget"`
-	Commission         *float64           `json:"commission"`
-	CommissionAsset    *string            `json:"commissionAsset"`
-	SoldQuantity       *float64           `json:"soldQuantity"`
-	Swap               bool               `json:"swap"`
-	ProfitOptions      ProfitOptions      `json:"profitOptions"`
-	ExtraChargeOptions ExtraChargeOptions `json:"extraChargeOptions"`
-	SwapQuantity       *float64           `json:"swapQuantity"`
-	ExtraOrdersCount   *int64             `json:"extraOrdersCount"`
+	Id                 int64                `json:"id"`
+	Symbol             string               `json:"symbol"`
+	BaseAsset          string               `json:"baseAsset"`  // resolved from trade limit
+	QuoteAsset         string               `json:"quoteAsset"` // resolved from trade limit
+	Price              float64              `json:"price"`
+	Quantity           float64              `json:"quantity"`
+	ExecutedQuantity   float64              `json:"executedQuantity"`
+	CreatedAt          string               `json:"createdAt"`
+	SellVolume         float64              `json:"sellVolume"`
+	BuyVolume          float64              `json:"buyVolume"`
+	SmaValue           float64              `json:"smaValue"`
+	Operation          string               `json:"operation"`
+	Status             string               `json:"status"`
+	ExternalId         *string              `json:"externalId"`
+	Exchange           string               `json:"exchange"`
+	ClosesOrder        *int64               `json:"closesOrder"` // sell order here
+	UsedExtraBudget    float64              `json:"usedExtraBudget"`
+	Commission         *float64             `json:"commission"`
+	CommissionAsset    *string              `json:"commissionAsset"`
+	SoldQuantity       *float64             `json:"soldQuantity"`
+	Swap               bool                 `json:"swap"`
+	ProfitOptions      ProfitOptions        `json:"profitOptions"`
+	ExtraChargeOptions ExtraChargeOptions   `json:"extraChargeOptions"`
+	SwapQuantity       *float64             `json:"swapQuantity"`
+	ExtraOrdersCount   *int64               `json:"extraOrdersCount"`
+	Explanation        *DecisionExplanation `json:"explanation"`
+	CloseReason        *string              `json:"closeReason"`
+	Protection         *ProtectionOrder     `json:"protection"`
 }
 
 func (o *Order) CanExtraBuy(kLine KLine, withSwap bool) bool {
@@ -137,8 +146,33 @@ func (o *Order) GetAvailableExtraBudget(kLine KLine, withSwap bool) float64 {
 	return availableExtraBudget
 }
 
+func IsStopCloseReason(closeReason string) bool {
+	return closeReason == OrderCloseReasonStopLoss || closeReason == OrderCloseReasonTrailingStop
+}
+
+func (o *Order) IsStopClose() bool {
+	return o.CloseReason != nil && IsStopCloseReason(*o.CloseReason)
+}
+
+// GetBaseAsset falls back to the symbol if trade limit assets are unknown (cached orders, deleted trade limit)
 func (o *Order) GetBaseAsset() string {
-	return strings.ReplaceAll(o.Symbol, "USDT", "")
+	if o.BaseAsset != "" {
+		return o.BaseAsset
+	}
+
+	baseAsset, _ := SplitSymbol(o.Symbol)
+
+	return baseAsset
+}
+
+func (o *Order) GetQuoteAsset() string {
+	if o.QuoteAsset != "" {
+		return o.QuoteAsset
+	}
+
+	_, quoteAsset := SplitSymbol(o.Symbol)
+
+	return quoteAsset
 }
 
 func (o Order) GetPositionTime() PositionTime {
@@ -223,7 +257,7 @@ type Position struct {
 	ManualOrderConfig       ManualOrderConfig     `json:"manualOrderConfig"`
 	PositionTime            PositionTime          `json:"positionTime"`
 	CloseStrategy           PositionCloseStrategy `json:"closeStrategy"`
-	BinanceOrder            *BinanceOrder         `json:"binanceOrder"`
+	ExchangeOrder           *ExchangeOrder        `json:"exchangeOrder"`
 	ManualOrder             *ManualOrder          `json:"manualOrder"`
 	IsEnabled               bool                  `json:"isEnabled"`
 	TradeFiltersBuy         TradeFilters          `json:"tradeFiltersBuy"`
@@ -247,7 +281,7 @@ type ManualOrderConfig struct {
 type PendingOrder struct {
 	Symbol         string        `json:"symbol"`
 	KLine          KLine         `json:"kLine"`
-	BinanceOrder   BinanceOrder  `json:"binanceOrder"`
+	ExchangeOrder  ExchangeOrder `json:"exchangeOrder"`
 	PredictedPrice float64       `json:"predictedPrice"`
 	Interpolation  Interpolation `json:"interpolation"`
 	IsRisky        bool          `json:"isRisky"`
@@ -277,12 +311,21 @@ const ProfitOptionUnitHour = "h"
 const ProfitOptionUnitDay = "d"
 const ProfitOptionUnitMonth = "m"
 
+const ProfitOptionTypeTakeProfit = "take_profit"
+const ProfitOptionTypeStopLoss = "stop_loss"
+const ProfitOptionTypeTrailingStop = "trailing_stop"
+
+// ProfitOption take profit option is time based, stop loss option closes position when profit is less or equal
+// OptionPercent (negative), trailing stop closes position when price falls by OptionPercent from the highest price
+// after profit has reached ActivationPercent
 type ProfitOption struct {
-	Index           int64   `json:"index"`
-	IsTriggerOption bool    `json:"isTriggerOption"`
-	OptionValue     float64 `json:"optionValue"`
-	OptionUnit      string  `json:"optionUnit"`
-	OptionPercent   Percent `json:"optionPercent"`
+	Index             int64   `json:"index"`
+	IsTriggerOption   bool    `json:"isTriggerOption"`
+	OptionValue       float64 `json:"optionValue"`
+	OptionUnit        string  `json:"optionUnit"`
+	OptionPercent     Percent `json:"optionPercent"`
+	OptionType        string  `json:"optionType"`
+	ActivationPercent Percent `json:"activationPercent"`
 }
 
 func (p *ProfitOptions) Scan(src interface{}) error {
@@ -292,6 +335,43 @@ func (p ProfitOptions) Value() (driver.Value, error) {
 	jsonV, err := json.Marshal(p)
 	return string(jsonV), err
 }
+func (p ProfitOptions) GetTakeProfitOptions() ProfitOptions {
+	options := make(ProfitOptions, 0)
+	for _, option := range p {
+		if option.IsTakeProfit() {
+			options = append(options, option)
+		}
+	}
+
+	return options
+}
+func (p ProfitOptions) GetStopLoss() *ProfitOption {
+	for _, option := range p {
+		if option.IsStopLoss() {
+			return &option
+		}
+	}
+
+	return nil
+}
+func (p ProfitOptions) GetTrailingStop() *ProfitOption {
+	for _, option := range p {
+		if option.IsTrailingStop() {
+			return &option
+		}
+	}
+
+	return nil
+}
+func (p ProfitOption) IsTakeProfit() bool {
+	return p.OptionType == "" || p.OptionType == ProfitOptionTypeTakeProfit
+}
+func (p ProfitOption) IsStopLoss() bool {
+	return p.OptionType == ProfitOptionTypeStopLoss
+}
+func (p ProfitOption) IsTrailingStop() bool {
+	return p.OptionType == ProfitOptionTypeTrailingStop
+}
 func (p ProfitOption) IsMinutely() bool {
 	return p.OptionUnit == ProfitOptionUnitMinute
 }
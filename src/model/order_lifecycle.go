@@ -0,0 +1,74 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const OrderLifecycleStatePending = "pending"
+const OrderLifecycleStatePlaced = "placed"
+const OrderLifecycleStateCancelling = "cancelling"
+const OrderLifecycleStateExecuted = "executed"
+const OrderLifecycleStateCompleted = "completed"
+const OrderLifecycleStateFailed = "failed"
+
+const OrderLifecycleOperationBuy = "buy"
+const OrderLifecycleOperationExtraBuy = "extra_buy"
+const OrderLifecycleOperationSell = "sell"
+
+// pending: order is being placed on exchange
+// placed: exchange order is waiting for execution
+// cancelling: cancel is requested (decision or shutdown)
+// executed: exchange order is executed, position is not saved yet
+// completed, failed: final states, lifecycle is removed from storage
+var orderLifecycleTransitions = map[string][]string{
+	OrderLifecycleStatePending:    {OrderLifecycleStatePlaced, OrderLifecycleStateExecuted, OrderLifecycleStateFailed},
+	OrderLifecycleStatePlaced:     {OrderLifecycleStateCancelling, OrderLifecycleStateExecuted, OrderLifecycleStateFailed},
+	OrderLifecycleStateCancelling: {OrderLifecycleStatePlaced, OrderLifecycleStateExecuted, OrderLifecycleStateFailed},
+	OrderLifecycleStateExecuted:   {OrderLifecycleStateCompleted},
+}
+
+// OrderLifecycle is persisted together with exchange order, operation is resumed with the same params after restart
+type OrderLifecycle struct {
+	Symbol          string               `json:"symbol"`
+	Side            string               `json:"side"`
+	Operation       string               `json:"operation"`
+	State           string               `json:"state"`
+	Price           float64              `json:"price"`
+	Quantity        float64              `json:"quantity"`
+	OpenedOrderId   *int64               `json:"openedOrderId"`
+	CloseReason     *string              `json:"closeReason"`
+	ExchangeOrderId *string              `json:"exchangeOrderId"`
+	Signal          *Signal              `json:"signal"`
+	Explanation     *DecisionExplanation `json:"explanation"`
+	CreatedAt       int64                `json:"createdAt"`
+	UpdatedAt       int64                `json:"updatedAt"`
+}
+
+func (l OrderLifecycle) CanTransit(state string) bool {
+	return slices.Contains(orderLifecycleTransitions[l.State], state)
+}
+
+func (l *OrderLifecycle) Transit(state string, timestamp int64) error {
+	if l.State == state {
+		return nil
+	}
+
+	if !l.CanTransit(state) {
+		return errors.New(fmt.Sprintf("[%s] %s order transition %s -> %s is not allowed", l.Symbol, l.Side, l.State, state))
+	}
+
+	l.State = state
+	l.UpdatedAt = timestamp
+
+	return nil
+}
+
+func (l OrderLifecycle) IsFinal() bool {
+	return l.State == OrderLifecycleStateCompleted || l.State == OrderLifecycleStateFailed
+}
+
+func (l OrderLifecycle) IsPending() bool {
+	return l.State == OrderLifecycleStatePending
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+)
+
+// ProtectionOrder is exchange side take profit and stop loss pair of the opened position,
+// it closes position even if the bot is not running
+type ProtectionOrder struct {
+	OrderListId     string   `json:"orderListId"`
+	OrderIds        []string `json:"orderIds"`
+	Quantity        float64  `json:"quantity"`
+	TakeProfitPrice float64  `json:"takeProfitPrice"`
+	StopPrice       float64  `json:"stopPrice"`
+}
+
+func (p *ProtectionOrder) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+
+	return json.Unmarshal(src.([]byte), &p)
+}
+func (p ProtectionOrder) Value() (driver.Value, error) {
+	jsonV, err := json.Marshal(p)
+	return string(jsonV), err
+}
+
+func (p *ProtectionOrder) IsSame(quantity float64, takeProfitPrice float64, stopPrice float64) bool {
+	return p.Quantity == quantity && p.TakeProfitPrice == takeProfitPrice && p.StopPrice == stopPrice
+}
+
+type BinanceOrderListItem struct {
+	Symbol  string `json:"symbol"`
+	OrderId int64  `json:"orderId"`
+}
+
+type BinanceOrderList struct {
+	OrderListId int64                  `json:"orderListId"`
+	Symbol      string                 `json:"symbol"`
+	Orders      []BinanceOrderListItem `json:"orders"`
+}
+
+type BinanceOcoResponse struct {
+	Id     string           `json:"id"`
+	Status int64            `json:"status"`
+	Result BinanceOrderList `json:"result"`
+	Error  *Error           `json:"error"`
+}
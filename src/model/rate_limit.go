@@ -0,0 +1,48 @@
+package model
+
+const RateLimitClassOrder = "order"
+const RateLimitClassAccount = "account"
+const RateLimitClassMarket = "market"
+
+const BinanceRateLimitTypeRequestWeight = "REQUEST_WEIGHT"
+const BinanceRateLimitTypeOrders = "ORDERS"
+
+type RateLimitUsage struct {
+	UsedWeight   int64            `json:"usedWeight"`
+	WeightLimit  int64            `json:"weightLimit"`
+	OrderCount   int64            `json:"orderCount"`
+	OrderLimit   int64            `json:"orderLimit"`
+	Queued       map[string]int64 `json:"queued"`
+	BlockedUntil int64            `json:"blockedUntil"`
+}
+
+func (u RateLimitUsage) GetWeightPercent() float64 {
+	if u.WeightLimit <= 0 {
+		return 0.00
+	}
+
+	return float64(u.UsedWeight) * 100 / float64(u.WeightLimit)
+}
+
+func (u RateLimitUsage) GetQueuedCount() int64 {
+	count := int64(0)
+	for _, queued := range u.Queued {
+		count += queued
+	}
+
+	return count
+}
+
+type BinanceRateLimit struct {
+	RateLimitType string `json:"rateLimitType"`
+	Interval      string `json:"interval"`
+	IntervalNum   int64  `json:"intervalNum"`
+	Limit         int64  `json:"limit"`
+	Count         int64  `json:"count"`
+}
+
+type BinanceRateLimitResponse struct {
+	Id         string             `json:"id"`
+	Status     int64              `json:"status"`
+	RateLimits []BinanceRateLimit `json:"rateLimits"`
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"slices"
+)
+
+const RiskErrorCodeDailyLoss = "risk_daily_loss"
+const RiskErrorCodeMaxPositions = "risk_max_positions"
+const RiskErrorCodeMaxExposure = "risk_max_exposure"
+const RiskErrorCodeMaxAssetPercent = "risk_max_asset_percent"
+const RiskErrorCodeConversion = "risk_conversion"
+
+type RiskConfig struct {
+	MaxExposure        float64 `json:"maxExposure"`
+	MaxPositions       int64   `json:"maxPositions"`
+	MaxAssetPercent    float64 `json:"maxAssetPercent"`
+	DailyLossLimit     float64 `json:"dailyLossLimit"`
+	ExchangeProtection bool    `json:"exchangeProtection"`
+}
+
+// HasAmountLimits returns true if limits need amounts converted to the reporting currency
+func (r RiskConfig) HasAmountLimits() bool {
+	return r.MaxExposure > 0.00 || r.MaxAssetPercent > 0.00 || r.DailyLossLimit > 0.00
+}
+
+func (r *RiskConfig) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+
+	return json.Unmarshal(src.([]byte), &r)
+}
+func (r RiskConfig) Value() (driver.Value, error) {
+	jsonV, err := json.Marshal(r)
+	return string(jsonV), err
+}
+
+type RiskAssetExposure struct {
+	Asset         string  `json:"asset"`
+	Exposure      float64 `json:"exposure"`
+	UnrealizedPnl float64 `json:"unrealizedPnl"`
+}
+
+// RiskStatus amounts are in the reporting currency, amounts of UnconvertedAssets are not counted
+type RiskStatus struct {
+	Config            RiskConfig                   `json:"config"`
+	ReportingCurrency string                       `json:"reportingCurrency"`
+	Balance           float64                      `json:"balance"`
+	Exposure          float64                      `json:"exposure"`
+	Positions         int64                        `json:"positions"`
+	RealizedPnl       float64                      `json:"realizedPnl"`
+	UnrealizedPnl     float64                      `json:"unrealizedPnl"`
+	DailyPnl          float64                      `json:"dailyPnl"`
+	IsBuyPaused       bool                         `json:"isBuyPaused"`
+	Assets            map[string]RiskAssetExposure `json:"assets"`
+	UnconvertedAssets []string                     `json:"unconvertedAssets"`
+}
+
+func (r *RiskStatus) AddUnconvertedAsset(asset string) {
+	if !slices.Contains(r.UnconvertedAssets, asset) {
+		r.UnconvertedAssets = append(r.UnconvertedAssets, asset)
+	}
+}
+
+func (r RiskStatus) IsComplete() bool {
+	return len(r.UnconvertedAssets) == 0
+}
+
+func (r RiskStatus) GetAssetPercent(asset string, amount float64) float64 {
+	capital := r.Balance + r.Exposure
+
+	if capital <= 0.00 {
+		return 0.00
+	}
+
+	return (r.Assets[asset].Exposure + amount) * 100 / capital
+}
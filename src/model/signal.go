@@ -6,12 +6,14 @@ import (
 )
 
 type SignalProfitOption struct {
-	Index           int64   `json:"index"`
-	IsTriggerOption bool    `json:"isTriggerOption"`
-	OptionValue     float64 `json:"optionValue"`
-	OptionUnit      string  `json:"optionUnit"`
-	OptionPercent   Percent `json:"optionPercent"`
-	SellPrice       float64 `json:"sellPrice"`
+	Index             int64   `json:"index"`
+	IsTriggerOption   bool    `json:"isTriggerOption"`
+	OptionValue       float64 `json:"optionValue"`
+	OptionUnit        string  `json:"optionUnit"`
+	OptionPercent     Percent `json:"optionPercent"`
+	OptionType        string  `json:"optionType"`
+	ActivationPercent Percent `json:"activationPercent"`
+	SellPrice         float64 `json:"sellPrice"`
 }
 
 type SignalExtraChargeOption struct {
@@ -44,11 +46,13 @@ func (s *Signal) GetProfitOptions() ProfitOptions {
 
 	for _, option := range s.ProfitOptions {
 		profitOptions = append(profitOptions, ProfitOption{
-			Index:           option.Index,
-			IsTriggerOption: option.IsTriggerOption,
-			OptionValue:     option.OptionValue,
-			OptionUnit:      option.OptionUnit,
-			OptionPercent:   option.OptionPercent,
+			Index:             option.Index,
+			IsTriggerOption:   option.IsTriggerOption,
+			OptionValue:       option.OptionValue,
+			OptionUnit:        option.OptionUnit,
+			OptionPercent:     option.OptionPercent,
+			OptionType:        option.OptionType,
+			ActivationPercent: option.ActivationPercent,
 		})
 	}
 
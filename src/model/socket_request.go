@@ -77,7 +77,7 @@ const BinanceExchangeFilterTypePrice = "PRICE_FILTER"
 const BinanceExchangeFilterTypeLotSize = "LOT_SIZE"
 const BinanceExchangeFilterTypeNotional = "NOTIONAL"
 
-type ExchangeFilter struct {
+type BinanceExchangeFilter struct {
 	FilterType  string   `json:"filterType"`
 	MinPrice    *float64 `json:"minPrice,string"`
 	MaxPrice    *float64 `json:"maxPrice,string"`
@@ -89,32 +89,77 @@ type ExchangeFilter struct {
 	StepSize    *float64 `json:"stepSize,string"`
 }
 
-type ExchangeSymbol struct {
-	Symbol             string           `json:"symbol"`
-	Status             string           `json:"status"`
-	BaseAsset          string           `json:"baseAsset"`
-	QuoteAsset         string           `json:"quoteAsset"`
-	BaseAssetPrecision float64          `json:"baseAssetPrecision"`
-	QuotePrecision     float64          `json:"quotePrecision"`
-	Filters            []ExchangeFilter `json:"filters"`
+type BinanceExchangeSymbol struct {
+	Symbol             string                  `json:"symbol"`
+	Status             string                  `json:"status"`
+	BaseAsset          string                  `json:"baseAsset"`
+	QuoteAsset         string                  `json:"quoteAsset"`
+	BaseAssetPrecision float64                 `json:"baseAssetPrecision"`
+	QuotePrecision     float64                 `json:"quotePrecision"`
+	Filters            []BinanceExchangeFilter `json:"filters"`
+}
+
+func (e *BinanceExchangeSymbol) ToExchangeSymbol() ExchangeSymbol {
+	symbol := ExchangeSymbol{
+		Symbol:             e.Symbol,
+		IsTrading:          e.Status == "TRADING",
+		BaseAsset:          e.BaseAsset,
+		QuoteAsset:         e.QuoteAsset,
+		BaseAssetPrecision: e.BaseAssetPrecision,
+		QuotePrecision:     e.QuotePrecision,
+	}
+
+	for _, filter := range e.Filters {
+		switch filter.FilterType {
+		case BinanceExchangeFilterTypePrice:
+			symbol.MinPrice = valueOrZero(filter.MinPrice)
+			symbol.TickSize = valueOrZero(filter.TickSize)
+		case BinanceExchangeFilterTypeLotSize:
+			symbol.MinQuantity = valueOrZero(filter.MinQuantity)
+			symbol.MaxQuantity = valueOrZero(filter.MaxQuantity)
+			symbol.StepSize = valueOrZero(filter.StepSize)
+		case BinanceExchangeFilterTypeNotional:
+			symbol.MinNotional = valueOrZero(filter.MinNotional)
+			symbol.MaxNotional = valueOrZero(filter.MaxNotional)
+		}
+	}
+
+	return symbol
 }
 
-func (e *ExchangeSymbol) IsTrading() bool {
-	return e.Status == "TRADING"
+func valueOrZero(value *float64) float64 {
+	if value == nil {
+		return 0.00
+	}
+
+	return *value
 }
 
-type ExchangeInfo struct {
-	Timezone   string           `json:"timezone"`
-	ServerTime int64            `json:"serverTime"`
-	RateLimits []RateLimit      `json:"rateLimits"`
-	Symbols    []ExchangeSymbol `json:"symbols"`
+type BinanceExchangeInfo struct {
+	Timezone   string                  `json:"timezone"`
+	ServerTime int64                   `json:"serverTime"`
+	RateLimits []RateLimit             `json:"rateLimits"`
+	Symbols    []BinanceExchangeSymbol `json:"symbols"`
+}
+
+func (e *BinanceExchangeInfo) ToExchangeInfo() ExchangeInfo {
+	symbols := make([]ExchangeSymbol, 0)
+	for _, symbol := range e.Symbols {
+		symbols = append(symbols, symbol.ToExchangeSymbol())
+	}
+
+	return ExchangeInfo{
+		Timezone:   e.Timezone,
+		ServerTime: e.ServerTime,
+		Symbols:    symbols,
+	}
 }
 
 type BinanceExchangeInfoResponse struct {
-	Id     string       `json:"id"`
-	Status int64        `json:"status"`
-	Result ExchangeInfo `json:"result"`
-	Error  *Error       `json:"error"`
+	Id     string              `json:"id"`
+	Status int64               `json:"status"`
+	Result BinanceExchangeInfo `json:"result"`
+	Error  *Error              `json:"error"`
 }
 
 type MyTrade struct {
@@ -139,8 +184,9 @@ type TradesResponse struct {
 }
 
 type OrderBook struct {
-	Bids [][2]Number `json:"bids"`
-	Asks [][2]Number `json:"asks"`
+	LastUpdateId int64       `json:"lastUpdateId"`
+	Bids         [][2]Number `json:"bids"`
+	Asks         [][2]Number `json:"asks"`
 }
 
 type OrderBookEvent struct {
@@ -342,16 +388,11 @@ type BinanceAggTradesResponse struct {
 	Error  *Error  `json:"error"`
 }
 
-type WSTickerPrice struct {
-	Symbol string  `json:"symbol"`
-	Price  float64 `json:"price,string"`
-}
-
 type BinanceTickersPriceResponse struct {
-	Id     string          `json:"id"`
-	Status int64           `json:"status"`
-	Result []WSTickerPrice `json:"result"`
-	Error  *Error          `json:"error"`
+	Id     string           `json:"id"`
+	Status int64            `json:"status"`
+	Result []ExchangeTicker `json:"result"`
+	Error  *Error           `json:"error"`
 }
 
 type MCEvent struct {
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+)
+
+const StrategyInputKLine = "kline"
+const StrategyInputTrade = "trade"
+const StrategyInputDepth = "depth"
+
+type StrategyOptions []StrategyOption
+
+type StrategyOption struct {
+	Name      string  `json:"name"`
+	IsEnabled bool    `json:"isEnabled"`
+	Weight    float64 `json:"weight"`
+}
+
+func (s *StrategyOptions) Scan(src interface{}) error {
+	return json.Unmarshal(src.([]byte), &s)
+}
+func (s StrategyOptions) Value() (driver.Value, error) {
+	jsonV, err := json.Marshal(s)
+	return string(jsonV), err
+}
+
+// IsEnabled strategy is enabled if it is not configured
+func (s StrategyOptions) IsEnabled(name string) bool {
+	for _, option := range s {
+		if option.Name == name {
+			return option.IsEnabled
+		}
+	}
+
+	return true
+}
+
+func (s StrategyOptions) GetWeight(name string) float64 {
+	for _, option := range s {
+		if option.Name == name && option.Weight > 0.00 {
+			return option.Weight
+		}
+	}
+
+	return 1.00
+}
@@ -60,6 +60,10 @@ func (s SwapPair) GetBaseAsset() string {
 	return s.BaseAsset
 }
 
+func (s SwapPair) GetQuoteAsset() string {
+	return s.QuoteAsset
+}
+
 func (s SwapPair) GetSymbol() string {
 	return s.Symbol
 }
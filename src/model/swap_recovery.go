@@ -0,0 +1,159 @@
+package model
+
+const SwapLegOne = "one"
+const SwapLegTwo = "two"
+const SwapLegThree = "three"
+const SwapLegFinished = "finished"
+
+const SwapRecoveryActionReconcile = "reconcile"
+const SwapRecoveryActionRetry = "retry"
+const SwapRecoveryActionForce = "force"
+const SwapRecoveryActionRollback = "rollback"
+
+type SwapRecoveryDecision struct {
+	SwapActionId int64  `json:"swapActionId"`
+	Action       string `json:"action"`
+	Leg          string `json:"leg"`
+	Manual       bool   `json:"manual"`
+	Success      bool   `json:"success"`
+	Message      string `json:"message"`
+	Timestamp    int64  `json:"timestamp"`
+}
+
+// SwapRecoveryState swap action which is not processed for a long time, with actions available for manual resolution
+type SwapRecoveryState struct {
+	SwapAction     SwapAction             `json:"action"`
+	Leg            string                 `json:"leg"`
+	Asset          string                 `json:"asset"`
+	Processing     bool                   `json:"processing"`
+	IdleMinutes    float64                `json:"idleMinutes"`
+	AllowedActions []string               `json:"allowedActions"`
+	Decisions      []SwapRecoveryDecision `json:"decisions"`
+}
+
+func (a *SwapAction) IsProcessing() bool {
+	return a.Status == SwapActionStatusPending || a.Status == SwapActionStatusProcess
+}
+
+func (a *SwapAction) IsLegFilled(leg string) bool {
+	var status *string
+
+	switch leg {
+	case SwapLegOne:
+		status = a.SwapOneExternalStatus
+		break
+	case SwapLegTwo:
+		status = a.SwapTwoExternalStatus
+		break
+	case SwapLegThree:
+		status = a.SwapThreeExternalStatus
+		break
+	}
+
+	return status != nil && *status == string(ExchangeOrderStatusFilled)
+}
+
+// GetUnfinishedLeg the first leg which is not filled yet, funds are in the source asset of this leg
+func (a *SwapAction) GetUnfinishedLeg() string {
+	for _, leg := range []string{SwapLegOne, SwapLegTwo, SwapLegThree} {
+		if !a.IsLegFilled(leg) {
+			return leg
+		}
+	}
+
+	return SwapLegFinished
+}
+
+func (a *SwapAction) GetLegAsset(leg string) string {
+	switch leg {
+	case SwapLegTwo:
+		return a.GetAssetTwo()
+	case SwapLegThree:
+		return a.GetAssetThree()
+	}
+
+	return a.Asset
+}
+
+// GetLastActivityTimestamp swap executor updates leg timestamp on every order status check
+func (a *SwapAction) GetLastActivityTimestamp() int64 {
+	timestamp := a.StartTimestamp
+
+	for _, legTimestamp := range []*int64{a.SwapOneTimestamp, a.SwapTwoTimestamp, a.SwapThreeTimestamp} {
+		if legTimestamp != nil && *legTimestamp > timestamp {
+			timestamp = *legTimestamp
+		}
+	}
+
+	return timestamp
+}
+
+func (a *SwapAction) GetLegOrder(leg string) (string, *string) {
+	switch leg {
+	case SwapLegTwo:
+		return a.SwapTwoSymbol, a.SwapTwoExternalId
+	case SwapLegThree:
+		return a.SwapThreeSymbol, a.SwapThreeExternalId
+	}
+
+	return a.SwapOneSymbol, a.SwapOneExternalId
+}
+
+// UpdateLegOrder leg timestamp is kept, it is the last time swap executor processed the leg
+func (a *SwapAction) UpdateLegOrder(leg string, order ExchangeOrder) {
+	quantity := order.OrigQty
+	price := (*float64)(nil)
+	if order.IsFilled() {
+		fill := order.GetFill()
+		quantity = fill.Quantity
+		price = &fill.Price
+	}
+
+	switch leg {
+	case SwapLegOne:
+		a.SwapOneExternalStatus = order.GetExternalStatus()
+		a.SwapOneSide = &order.Side
+		a.SwapOneQuantity = &quantity
+		if price != nil {
+			a.SwapOnePrice = *price
+		}
+		break
+	case SwapLegTwo:
+		a.SwapTwoExternalStatus = order.GetExternalStatus()
+		a.SwapTwoSide = &order.Side
+		a.SwapTwoQuantity = &quantity
+		if price != nil {
+			a.SwapTwoPrice = *price
+		}
+		break
+	case SwapLegThree:
+		a.SwapThreeExternalStatus = order.GetExternalStatus()
+		a.SwapThreeSide = &order.Side
+		a.SwapThreeQuantity = &quantity
+		if price != nil {
+			a.SwapThreePrice = *price
+		}
+		break
+	}
+}
+
+// ClearLegOrder canceled or expired leg order is placed again by swap executor
+func (a *SwapAction) ClearLegOrder(leg string) {
+	switch leg {
+	case SwapLegOne:
+		a.SwapOneExternalId = nil
+		a.SwapOneExternalStatus = nil
+		a.SwapOneTimestamp = nil
+		break
+	case SwapLegTwo:
+		a.SwapTwoExternalId = nil
+		a.SwapTwoExternalStatus = nil
+		a.SwapTwoTimestamp = nil
+		break
+	case SwapLegThree:
+		a.SwapThreeExternalId = nil
+		a.SwapThreeExternalStatus = nil
+		a.SwapThreeTimestamp = nil
+		break
+	}
+}
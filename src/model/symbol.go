@@ -1,5 +1,24 @@
 package model
 
+import "strings"
+
+const DefaultQuoteAsset = "USDT"
+
+// QuoteAssets are checked in order, so longer (more specific) suffixes go first
+var QuoteAssets = []string{"FDUSD", "USDT", "USDC", "TUSD", "EUR", "TRY", "BTC", "ETH", "BNB", "DAI", "OKB"}
+
 type Symbol struct {
 	Value string
 }
+
+// SplitSymbol returns base and quote assets for exchange symbol without separator, example: BTCFDUSD -> BTC, FDUSD
+func SplitSymbol(symbol string) (string, string) {
+	symbol = strings.ToUpper(symbol)
+	for _, quote := range QuoteAssets {
+		if strings.HasSuffix(symbol, quote) && len(symbol) > len(quote) {
+			return strings.TrimSuffix(symbol, quote), quote
+		}
+	}
+
+	return symbol, DefaultQuoteAsset
+}
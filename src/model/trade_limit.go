@@ -3,7 +3,6 @@ package model
 import (
 	"log"
 	"sort"
-	"strings"
 )
 
 type SymbolInterface interface {
@@ -21,6 +20,7 @@ func (d DummySymbol) GetSymbol() string {
 type TradeLimitInterface interface {
 	GetMinPrice() float64
 	GetBaseAsset() string
+	GetQuoteAsset() string
 	GetMinNotional() float64
 	GetMinQuantity() float64
 	GetSymbol() string
@@ -34,6 +34,8 @@ type SentimentData struct {
 type TradeLimit struct {
 	Id                           int64              `json:"id"`
 	Symbol                       string             `json:"symbol"`
+	BaseAsset                    string             `json:"baseAsset"`
+	QuoteAsset                   string             `json:"quoteAsset"`
 	USDTLimit                    float64            `json:"USDTLimit"`
 	MinPrice                     float64            `json:"minPrice"`
 	MinQuantity                  float64            `json:"minQuantity"`
@@ -49,8 +51,11 @@ type TradeLimit struct {
 	TradeFiltersBuy              TradeFilters       `json:"tradeFiltersBuy"`
 	TradeFiltersSell             TradeFilters       `json:"tradeFiltersSell"`
 	TradeFiltersExtraCharge      TradeFilters       `json:"tradeFiltersExtraCharge"`
+	Strategies                   StrategyOptions    `json:"strategies"`
+	MinStrategyAgreement         int64              `json:"minStrategyAgreement"`
 	SentimentLabel               *string            `json:"sentimentLabel"`
 	SentimentScore               *float64           `json:"sentimentScore"`
+	MLConfig                     MLConfig           `json:"mlConfig"`
 }
 
 func (t TradeLimit) GetMinPrice() float64 {
@@ -70,12 +75,29 @@ func (t TradeLimit) GetMinQuantity() float64 {
 }
 
 func (t TradeLimit) GetBaseAsset() string {
-	return strings.ReplaceAll(t.Symbol, "USDT", "")
+	if t.BaseAsset != "" {
+		return t.BaseAsset
+	}
+
+	baseAsset, _ := SplitSymbol(t.Symbol)
+
+	return baseAsset
+}
+
+// GetQuoteAsset budget (USDTLimit), balance and profit of the symbol are in quote asset
+func (t TradeLimit) GetQuoteAsset() string {
+	if t.QuoteAsset != "" {
+		return t.QuoteAsset
+	}
+
+	_, quoteAsset := SplitSymbol(t.Symbol)
+
+	return quoteAsset
 }
 
 func (t TradeLimit) GetPositionTime() PositionTime {
 	for index, option := range t.ProfitOptions {
-		if option.IsTriggerOption {
+		if option.IsTriggerOption && option.IsTakeProfit() {
 			positionTime, err := option.GetPositionTime()
 			if err == nil {
 				return positionTime
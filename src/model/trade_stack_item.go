@@ -12,10 +12,11 @@ type TradeStackItem struct {
 	PriceChangeSpeedAvg     float64        `json:"priceChangeSpeedAvg"`
 	Percent                 Percent        `json:"percent"`
 	Symbol                  string         `json:"symbol"`
+	QuoteAsset              string         `json:"quoteAsset"`
 	BudgetUsdt              float64        `json:"budgetUsdt"`
 	HasEnoughBalance        bool           `json:"hasEnoughBalance"`
 	BalanceAfter            float64        `json:"balanceAfter"`
-	BinanceOrder            *BinanceOrder  `json:"binanceOrder"`
+	ExchangeOrder           *ExchangeOrder `json:"exchangeOrder"`
 	IsExtraCharge           bool           `json:"isExtraCharge"`
 	StrategyDecisions       []Decision     `json:"strategyDecisions"`
 	IsBuyLocked             bool           `json:"isBuyLocked"`
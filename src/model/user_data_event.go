@@ -0,0 +1,164 @@
+package model
+
+import (
+	"strconv"
+)
+
+const BinanceUserDataEventExecutionReport = "executionReport"
+const BinanceUserDataEventAccountPosition = "outboundAccountPosition"
+const BinanceUserDataEventListenKeyExpired = "listenKeyExpired"
+
+const ByBitUserDataTopicOrder = "order"
+const ByBitUserDataTopicExecution = "execution"
+const ByBitUserDataTopicWallet = "wallet"
+
+type BinanceUserDataEvent struct {
+	EventType string `json:"e"`
+}
+
+// BinanceExecutionReport keys differ by case only, unused keys are declared to prevent case-insensitive matching
+type BinanceExecutionReport struct {
+	EventType           string  `json:"e"`
+	EventTime           int64   `json:"E"`
+	Symbol              string  `json:"s"`
+	Side                string  `json:"S"`
+	Type                string  `json:"o"`
+	OrigQty             float64 `json:"q,string"`
+	Price               float64 `json:"p,string"`
+	ExecutionType       string  `json:"x"`
+	Status              string  `json:"X"`
+	OrderId             int64   `json:"i"`
+	LastExecutedQty     float64 `json:"l,string"`
+	ExecutedQty         float64 `json:"z,string"`
+	LastExecutedPrice   float64 `json:"L,string"`
+	TransactTime        int64   `json:"T"`
+	OrderListId         int64   `json:"g"`
+	CreatedAt           int64   `json:"O"`
+	CummulativeQuoteQty float64 `json:"Z,string"`
+	WorkingTime         int64   `json:"W"`
+	StopPrice           string  `json:"P"`
+	QuoteOrderQty       string  `json:"Q"`
+	TradeId             int64   `json:"t"`
+	Ignore              int64   `json:"I"`
+	IsOnBook            bool    `json:"w"`
+}
+
+func (r BinanceExecutionReport) ToExchangeOrder() (ExchangeOrder, error) {
+	orderListId := r.OrderListId
+
+	return (&BinanceOrderLegacy{
+		OrderId:             r.OrderId,
+		Symbol:              r.Symbol,
+		TransactTime:        r.TransactTime,
+		Price:               r.Price,
+		OrigQty:             r.OrigQty,
+		ExecutedQty:         r.ExecutedQty,
+		CummulativeQuoteQty: r.CummulativeQuoteQty,
+		Status:              r.Status,
+		Type:                r.Type,
+		Side:                r.Side,
+		WorkingTime:         r.WorkingTime,
+		Timestamp:           r.CreatedAt,
+		OrderListId:         &orderListId,
+	}).ToModern()
+}
+
+type BinanceAccountPositionBalance struct {
+	Asset  string  `json:"a"`
+	Free   float64 `json:"f,string"`
+	Locked float64 `json:"l,string"`
+}
+
+type BinanceAccountPosition struct {
+	EventType string                          `json:"e"`
+	EventTime int64                           `json:"E"`
+	Balances  []BinanceAccountPositionBalance `json:"B"`
+}
+
+func (p BinanceAccountPosition) GetBalances() []Balance {
+	balances := make([]Balance, 0)
+	for _, balance := range p.Balances {
+		balances = append(balances, Balance{
+			Asset:  balance.Asset,
+			Free:   balance.Free,
+			Locked: balance.Locked,
+		})
+	}
+
+	return balances
+}
+
+type ByBitWsAuthRequest struct {
+	Operation string `json:"op"`
+	Arguments []any  `json:"args"`
+}
+
+type ByBitWsOperationResponse struct {
+	Success   bool   `json:"success"`
+	Message   string `json:"ret_msg"`
+	Operation string `json:"op"`
+}
+
+type ByBitWsUserDataEvent struct {
+	Topic        string `json:"topic"`
+	CreationTime int64  `json:"creationTime"`
+}
+
+type ByBitWsOrderEvent struct {
+	Topic        string       `json:"topic"`
+	CreationTime int64        `json:"creationTime"`
+	Data         []ByBitOrder `json:"data"`
+}
+
+type ByBitWsExecution struct {
+	Category  string  `json:"category"`
+	Symbol    string  `json:"symbol"`
+	OrderId   string  `json:"orderId"`
+	Side      string  `json:"side"`
+	ExecPrice float64 `json:"execPrice,string"`
+	ExecQty   float64 `json:"execQty,string"`
+	LeavesQty float64 `json:"leavesQty,string"`
+	ExecTime  int64   `json:"execTime,string"`
+}
+
+type ByBitWsExecutionEvent struct {
+	Topic        string             `json:"topic"`
+	CreationTime int64              `json:"creationTime"`
+	Data         []ByBitWsExecution `json:"data"`
+}
+
+// ByBitWsWalletCoin numbers are strings, some of them are empty for unified account
+type ByBitWsWalletCoin struct {
+	Coin                string `json:"coin"`
+	WalletBalance       string `json:"walletBalance"`
+	Locked              string `json:"locked"`
+	Free                string `json:"free"`
+	AvailableToWithdraw string `json:"availableToWithdraw"`
+}
+
+func (c ByBitWsWalletCoin) ToBalance() Balance {
+	walletBalance, _ := strconv.ParseFloat(c.WalletBalance, 64)
+	locked, _ := strconv.ParseFloat(c.Locked, 64)
+
+	free, err := strconv.ParseFloat(c.AvailableToWithdraw, 64)
+	if err != nil {
+		free = walletBalance - locked
+	}
+
+	return Balance{
+		Asset:  c.Coin,
+		Free:   free,
+		Locked: locked,
+	}
+}
+
+type ByBitWsWallet struct {
+	AccountType string              `json:"accountType"`
+	Coin        []ByBitWsWalletCoin `json:"coin"`
+}
+
+type ByBitWsWalletEvent struct {
+	Topic        string          `json:"topic"`
+	CreationTime int64           `json:"creationTime"`
+	Data         []ByBitWsWallet `json:"data"`
+}
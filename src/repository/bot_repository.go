@@ -76,7 +76,9 @@ func (b *BotRepository) GetCurrentBot() *model.Bot {
 			b.is_master_bot as IsMasterBot,
 			b.is_swap_enabled as IsSwapEnabled,
 			b.swap_config as SwapConfig,
-			b.trade_stack_sorting as TradeStackSorting
+			b.trade_stack_sorting as TradeStackSorting,
+			b.risk_config as RiskConfig,
+			b.reporting_currency as ReportingCurrency
 		FROM bots b
 		WHERE b.uuid = ? AND b.exchange = ?`, botUuid, botExchange,
 	).Scan(
@@ -87,6 +89,8 @@ func (b *BotRepository) GetCurrentBot() *model.Bot {
 		&bot.IsSwapEnabled,
 		&bot.SwapConfig,
 		&bot.TradeStackSorting,
+		&bot.RiskConfig,
+		&bot.ReportingCurrency,
 	)
 
 	if err != nil {
@@ -111,7 +115,9 @@ func (b *BotRepository) Create(bot model.Bot) error {
 			is_swap_enabled = ?,
 			is_master_bot = ?,
 			swap_config = ?,
-			trade_stack_sorting = ?
+			trade_stack_sorting = ?,
+			risk_config = ?,
+			reporting_currency = ?
 	`,
 		bot.BotUuid,
 		bot.Exchange,
@@ -119,6 +125,8 @@ func (b *BotRepository) Create(bot model.Bot) error {
 		bot.IsMasterBot,
 		bot.SwapConfig,
 		bot.TradeStackSorting,
+		bot.RiskConfig,
+		bot.GetReportingCurrency(),
 	)
 
 	if err != nil {
@@ -135,13 +143,17 @@ func (b *BotRepository) Update(bot model.Bot) error {
 			b.is_swap_enabled = ?,
 			b.is_master_bot = ?,
 			b.swap_config = ?,
-			b.trade_stack_sorting = ?
+			b.trade_stack_sorting = ?,
+			b.risk_config = ?,
+			b.reporting_currency = ?
 	    WHERE b.uuid = ? AND b.id = ?
 	`,
 		bot.IsSwapEnabled,
 		bot.IsMasterBot,
 		bot.SwapConfig,
 		bot.TradeStackSorting,
+		bot.RiskConfig,
+		bot.GetReportingCurrency(),
 		bot.BotUuid,
 		bot.Id,
 	)
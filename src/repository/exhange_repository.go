@@ -12,7 +12,9 @@ import (
 	"gitlab.com/open-soft/go-crypto-bot/src/utils"
 	"log"
 	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -88,6 +90,15 @@ type ExchangePriceStorageInterface interface {
 	GetSwapPairsByAssets(quoteAsset string, baseAsset string) (model.SwapPair, error)
 }
 
+type MLFeatureStorageInterface interface {
+	GetTradeLimits() []model.TradeLimit
+	GetTradeLimit(symbol string) (model.TradeLimit, error)
+	GetCurrentKline(symbol string) *model.KLine
+	KLineList(symbol string, reverse bool, size int64) []model.KLine
+	GetInterpolation(kLine model.KLine) (model.Interpolation, error)
+	GetCapitalization(symbol string, timestamp model.TimestampMilli) *model.MCObject
+}
+
 type ExchangeRepository struct {
 	DB               *sql.DB
 	RDB              *redis.Client
@@ -96,6 +107,8 @@ type ExchangeRepository struct {
 	Formatter        *utils.Formatter
 	Binance          client.ExchangePriceAPIInterface
 	ObjectRepository *ObjectRepository
+
+	decisionStrategies sync.Map
 }
 
 func (e *ExchangeRepository) GetSubscribedSymbols() []model.Symbol {
@@ -110,6 +123,8 @@ func (e *ExchangeRepository) GetTradeLimits() []model.TradeLimit {
 		SELECT
 		    tl.id as Id,
 		    tl.symbol as Symbol,
+		    tl.base_asset as BaseAsset,
+		    tl.quote_asset as QuoteAsset,
 		    tl.usdt_limit as USDTLimit,
 		    tl.min_price as MinPrice,
 		    tl.min_quantity as MinQuantity,
@@ -125,8 +140,11 @@ func (e *ExchangeRepository) GetTradeLimits() []model.TradeLimit {
 		    tl.trade_filters_buy as TradeFiltersBuy,
 		    tl.trade_filters_sell as TradeFiltersSell,
 		    tl.trade_filters_extra_charge as TradeFiltersExtraCharge,
+		    tl.strategies as Strategies,
+		    tl.min_strategy_agreement as MinStrategyAgreement,
 		    tl.sentiment_label as SentimentLabel,
-		    tl.sentiment_score as SentimentScore
+		    tl.sentiment_score as SentimentScore,
+		    tl.ml_config as MLConfig
 		FROM trade_limit tl WHERE tl.bot_id = ?
 	`, e.CurrentBot.Id)
 	defer res.Close()
@@ -142,6 +160,8 @@ func (e *ExchangeRepository) GetTradeLimits() []model.TradeLimit {
 		err := res.Scan(
 			&tradeLimit.Id,
 			&tradeLimit.Symbol,
+			&tradeLimit.BaseAsset,
+			&tradeLimit.QuoteAsset,
 			&tradeLimit.USDTLimit,
 			&tradeLimit.MinPrice,
 			&tradeLimit.MinQuantity,
@@ -157,8 +177,11 @@ func (e *ExchangeRepository) GetTradeLimits() []model.TradeLimit {
 			&tradeLimit.TradeFiltersBuy,
 			&tradeLimit.TradeFiltersSell,
 			&tradeLimit.TradeFiltersExtraCharge,
+			&tradeLimit.Strategies,
+			&tradeLimit.MinStrategyAgreement,
 			&tradeLimit.SentimentLabel,
 			&tradeLimit.SentimentScore,
+			&tradeLimit.MLConfig,
 		)
 
 		if err != nil {
@@ -171,12 +194,26 @@ func (e *ExchangeRepository) GetTradeLimits() []model.TradeLimit {
 	return list
 }
 
+// GetQuoteAssets returns unique quote assets of the bot trade limits
+func (e *ExchangeRepository) GetQuoteAssets() []string {
+	quoteAssets := make([]string, 0)
+	for _, tradeLimit := range e.GetTradeLimits() {
+		if !slices.Contains(quoteAssets, tradeLimit.GetQuoteAsset()) {
+			quoteAssets = append(quoteAssets, tradeLimit.GetQuoteAsset())
+		}
+	}
+
+	return quoteAssets
+}
+
 func (e *ExchangeRepository) GetTradeLimit(symbol string) (model.TradeLimit, error) {
 	var tradeLimit model.TradeLimit
 	err := e.DB.QueryRow(`
 		SELECT
 		    tl.id as Id,
 		    tl.symbol as Symbol,
+		    tl.base_asset as BaseAsset,
+		    tl.quote_asset as QuoteAsset,
 		    tl.usdt_limit as USDTLimit,
 		    tl.min_price as MinPrice,
 		    tl.min_quantity as MinQuantity,
@@ -192,8 +229,11 @@ func (e *ExchangeRepository) GetTradeLimit(symbol string) (model.TradeLimit, err
 		    tl.trade_filters_buy as TradeFiltersBuy,
 		    tl.trade_filters_sell as TradeFiltersSell,
 		    tl.trade_filters_extra_charge as TradeFiltersExtraCharge,
+		    tl.strategies as Strategies,
+		    tl.min_strategy_agreement as MinStrategyAgreement,
 		    tl.sentiment_label as SentimentLabel,
-		    tl.sentiment_score as SentimentScore
+		    tl.sentiment_score as SentimentScore,
+		    tl.ml_config as MLConfig
 		FROM trade_limit tl
 		WHERE tl.symbol = ? AND tl.bot_id = ?
 	`,
@@ -202,6 +242,8 @@ func (e *ExchangeRepository) GetTradeLimit(symbol string) (model.TradeLimit, err
 	).Scan(
 		&tradeLimit.Id,
 		&tradeLimit.Symbol,
+		&tradeLimit.BaseAsset,
+		&tradeLimit.QuoteAsset,
 		&tradeLimit.USDTLimit,
 		&tradeLimit.MinPrice,
 		&tradeLimit.MinQuantity,
@@ -217,8 +259,11 @@ func (e *ExchangeRepository) GetTradeLimit(symbol string) (model.TradeLimit, err
 		&tradeLimit.TradeFiltersBuy,
 		&tradeLimit.TradeFiltersSell,
 		&tradeLimit.TradeFiltersExtraCharge,
+		&tradeLimit.Strategies,
+		&tradeLimit.MinStrategyAgreement,
 		&tradeLimit.SentimentLabel,
 		&tradeLimit.SentimentScore,
+		&tradeLimit.MLConfig,
 	)
 	if err != nil {
 		return tradeLimit, err
@@ -231,6 +276,8 @@ func (e *ExchangeRepository) CreateTradeLimit(limit model.TradeLimit) (*int64, e
 	res, err := e.DB.Exec(`
 		INSERT INTO trade_limit SET
 		    symbol = ?,
+		    base_asset = ?,
+		    quote_asset = ?,
 		    usdt_limit = ?,
 		    min_price = ?,
 		    min_quantity = ?,
@@ -246,11 +293,16 @@ func (e *ExchangeRepository) CreateTradeLimit(limit model.TradeLimit) (*int64, e
 		    trade_filters_buy = ?,
 		    trade_filters_sell = ?,
 		    trade_filters_extra_charge = ?,
+		    strategies = ?,
+		    min_strategy_agreement = ?,
 		    sentiment_label = ?,
 		    sentiment_score = ?,
+		    ml_config = ?,
 		    bot_id = ?
 	`,
 		limit.Symbol,
+		limit.GetBaseAsset(),
+		limit.GetQuoteAsset(),
 		limit.USDTLimit,
 		limit.MinPrice,
 		limit.MinQuantity,
@@ -266,8 +318,11 @@ func (e *ExchangeRepository) CreateTradeLimit(limit model.TradeLimit) (*int64, e
 		limit.TradeFiltersBuy,
 		limit.TradeFiltersSell,
 		limit.TradeFiltersExtraCharge,
+		limit.Strategies,
+		limit.MinStrategyAgreement,
 		limit.SentimentLabel,
 		limit.SentimentScore,
+		limit.MLConfig,
 		e.CurrentBot.Id,
 	)
 
@@ -647,6 +702,8 @@ func (e *ExchangeRepository) UpdateTradeLimit(limit model.TradeLimit) error {
 	_, err := e.DB.Exec(`
 		UPDATE trade_limit tl SET
 		    tl.symbol = ?,
+		    tl.base_asset = ?,
+		    tl.quote_asset = ?,
 		    tl.usdt_limit = ?,
 		    tl.min_price = ?,
 		    tl.min_quantity = ?,
@@ -662,11 +719,16 @@ func (e *ExchangeRepository) UpdateTradeLimit(limit model.TradeLimit) error {
 		    tl.trade_filters_buy = ?,
 		    tl.trade_filters_sell = ?,
 		    tl.trade_filters_extra_charge = ?,
+		    tl.strategies = ?,
+		    tl.min_strategy_agreement = ?,
 		    tl.sentiment_label = ?,
-		    tl.sentiment_score = ?
+		    tl.sentiment_score = ?,
+		    tl.ml_config = ?
 		WHERE tl.id = ?
 	`,
 		limit.Symbol,
+		limit.GetBaseAsset(),
+		limit.GetQuoteAsset(),
 		limit.USDTLimit,
 		limit.MinPrice,
 		limit.MinQuantity,
@@ -682,8 +744,11 @@ func (e *ExchangeRepository) UpdateTradeLimit(limit model.TradeLimit) error {
 		limit.TradeFiltersBuy,
 		limit.TradeFiltersSell,
 		limit.TradeFiltersExtraCharge,
+		limit.Strategies,
+		limit.MinStrategyAgreement,
 		limit.SentimentLabel,
 		limit.SentimentScore,
+		limit.MLConfig,
 		limit.Id,
 	)
 
@@ -862,6 +927,9 @@ func (e *ExchangeRepository) GetPeriodMinPrice(symbol string, period int64) floa
 	return minPrice
 }
 
+// DepthStreamLimit top levels of every streamed depth update are saved
+const DepthStreamLimit = 20
+
 func (e *ExchangeRepository) SetDepth(depth model.OrderBookModel, limit int64, expires int64) {
 	if len(depth.Asks) == 0 || len(depth.Bids) == 0 {
 		// Recover from cache
@@ -901,6 +969,10 @@ func (e *ExchangeRepository) GetDepth(symbol string, limit int64) model.OrderBoo
 	}
 
 	res := e.RDB.Get(*e.Ctx, fmt.Sprintf("depth-%s-%d", symbol, limit)).Val()
+	if len(res) == 0 && limit > DepthStreamLimit {
+		// deep book is saved only if the stream has more levels (OKX books5 has not), live top depth is preferred to REST
+		res = e.RDB.Get(*e.Ctx, fmt.Sprintf("depth-%s-%d", symbol, DepthStreamLimit)).Val()
+	}
 	if len(res) == 0 {
 		book := e.Binance.GetDepth(symbol, limit)
 		if book != nil {
@@ -1046,6 +1118,7 @@ func (e *ExchangeRepository) GetTradeLimitCached(symbol string) *model.TradeLimi
 }
 
 func (e *ExchangeRepository) SetDecision(decision model.Decision, symbol string) {
+	e.decisionStrategies.Store(decision.StrategyName, true)
 	encoded, _ := json.Marshal(decision)
 	e.RDB.Set(*e.Ctx, fmt.Sprintf("decision-%s-%s-bot-%d", decision.StrategyName, symbol, e.CurrentBot.Id), string(encoded), time.Second*model.PriceValidSeconds*2)
 }
@@ -1141,7 +1214,7 @@ func (e *ExchangeRepository) GetInterpolation(kLine model.KLine) (model.Interpol
 	}
 
 	return model.Interpolation{
-		Asset:                strings.ReplaceAll(kLine.Symbol, "USDT", ""),
+		Asset:                kLine.GetBaseAsset(),
 		EthInterpolationUsdt: 0.00,
 		BtcInterpolationUsdt: 0.00,
 	}, errors.New("interpolation is not found")
@@ -1157,23 +1230,19 @@ func (e *ExchangeRepository) SaveInterpolation(interpolation model.Interpolation
 }
 
 func (e *ExchangeRepository) GetDecisions(symbol string) []model.Decision {
-	currentDecisions := make([]model.Decision, 0)
-	smaDecision := e.GetDecision(model.SmaTradeStrategyName, symbol)
-	kLineDecision := e.GetDecision(model.BaseKlineStrategyName, symbol)
-	marketDepthDecision := e.GetDecision(model.MarketDepthStrategyName, symbol)
-	orderBasedDecision := e.GetDecision(model.OrderBasedStrategyName, symbol)
+	strategies := make([]string, 0)
+	e.decisionStrategies.Range(func(strategy, _ any) bool {
+		strategies = append(strategies, strategy.(string))
+		return true
+	})
+	sort.Strings(strategies)
 
-	if smaDecision != nil {
-		currentDecisions = append(currentDecisions, *smaDecision)
-	}
-	if kLineDecision != nil {
-		currentDecisions = append(currentDecisions, *kLineDecision)
-	}
-	if marketDepthDecision != nil {
-		currentDecisions = append(currentDecisions, *marketDepthDecision)
-	}
-	if orderBasedDecision != nil {
-		currentDecisions = append(currentDecisions, *orderBasedDecision)
+	currentDecisions := make([]model.Decision, 0)
+	for _, strategy := range strategies {
+		decision := e.GetDecision(strategy, symbol)
+		if decision != nil {
+			currentDecisions = append(currentDecisions, *decision)
+		}
 	}
 
 	return currentDecisions
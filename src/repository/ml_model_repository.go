@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"database/sql"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"log"
+	"time"
+)
+
+type MLModelRepositoryInterface interface {
+	Create(entity model.MLModel) (*int64, error)
+	Find(id int64) (model.MLModel, error)
+	GetActive(symbol string) (model.MLModel, error)
+	GetList(symbol string) []model.MLModel
+	Activate(entity model.MLModel) error
+	DeleteOutdated(symbol string, keep int64) error
+}
+
+type MLModelRepository struct {
+	DB         *sql.DB
+	CurrentBot *model.Bot
+}
+
+func (m *MLModelRepository) Create(entity model.MLModel) (*int64, error) {
+	res, err := m.DB.Exec(`
+		INSERT INTO ml_models SET
+			bot_id = ?,
+			symbol = ?,
+			model_type = ?,
+			format_version = ?,
+			features = ?,
+			horizon = ?,
+			metrics = ?,
+			dataset_from = ?,
+			dataset_to = ?,
+			dataset_length = ?,
+			is_active = ?,
+			promotion_reason = ?,
+			payload = ?,
+			created_at = ?
+	`,
+		m.CurrentBot.Id,
+		entity.Symbol,
+		entity.ModelType,
+		entity.FormatVersion,
+		entity.Features,
+		entity.Horizon,
+		entity.Metrics,
+		entity.DatasetFrom,
+		entity.DatasetTo,
+		entity.DatasetLength,
+		false,
+		entity.PromotionReason,
+		entity.Payload,
+		time.Now().Format("2006-01-02 15:04:05"),
+	)
+
+	if err != nil {
+		log.Println(err)
+
+		return nil, err
+	}
+
+	lastId, err := res.LastInsertId()
+
+	return &lastId, err
+}
+
+func (m *MLModelRepository) Find(id int64) (model.MLModel, error) {
+	return m.scanModel(m.DB.QueryRow(`
+		SELECT
+			mm.id, mm.symbol, mm.model_type, mm.format_version, mm.features, mm.horizon, mm.metrics, mm.dataset_from,
+			mm.dataset_to, mm.dataset_length, mm.is_active, mm.promotion_reason, mm.created_at, mm.payload
+		FROM ml_models mm
+		WHERE mm.id = ? AND mm.bot_id = ?
+	`, id, m.CurrentBot.Id))
+}
+
+func (m *MLModelRepository) GetActive(symbol string) (model.MLModel, error) {
+	return m.scanModel(m.DB.QueryRow(`
+		SELECT
+			mm.id, mm.symbol, mm.model_type, mm.format_version, mm.features, mm.horizon, mm.metrics, mm.dataset_from,
+			mm.dataset_to, mm.dataset_length, mm.is_active, mm.promotion_reason, mm.created_at, mm.payload
+		FROM ml_models mm
+		WHERE mm.symbol = ? AND mm.bot_id = ? AND mm.is_active = true
+		ORDER BY mm.id DESC
+		LIMIT 1
+	`, symbol, m.CurrentBot.Id))
+}
+
+// GetList returns registry history without serialized models
+func (m *MLModelRepository) GetList(symbol string) []model.MLModel {
+	list := make([]model.MLModel, 0)
+
+	res, err := m.DB.Query(`
+		SELECT
+			mm.id, mm.symbol, mm.model_type, mm.format_version, mm.features, mm.horizon, mm.metrics, mm.dataset_from,
+			mm.dataset_to, mm.dataset_length, mm.is_active, mm.promotion_reason, mm.created_at, ''
+		FROM ml_models mm
+		WHERE mm.bot_id = ? AND (? = '' OR mm.symbol = ?)
+		ORDER BY mm.id DESC
+		LIMIT 500
+	`, m.CurrentBot.Id, symbol, symbol)
+
+	if err != nil {
+		log.Println(err)
+
+		return list
+	}
+
+	defer res.Close()
+
+	for res.Next() {
+		entity, err := m.scanModel(res)
+		if err != nil {
+			log.Println(err)
+
+			continue
+		}
+
+		list = append(list, entity)
+	}
+
+	return list
+}
+
+func (m *MLModelRepository) Activate(entity model.MLModel) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE ml_models SET is_active = false WHERE bot_id = ? AND symbol = ?`, m.CurrentBot.Id, entity.Symbol)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	_, err = tx.Exec(
+		`UPDATE ml_models SET is_active = true, promotion_reason = ? WHERE id = ? AND bot_id = ?`,
+		entity.PromotionReason,
+		entity.Id,
+		m.CurrentBot.Id,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteOutdated keeps the latest models of the symbol, active model is never deleted
+func (m *MLModelRepository) DeleteOutdated(symbol string, keep int64) error {
+	_, err := m.DB.Exec(`
+		DELETE FROM ml_models
+		WHERE bot_id = ? AND symbol = ? AND is_active = false AND id <= (
+			SELECT id FROM (
+				SELECT mm.id FROM ml_models mm WHERE mm.bot_id = ? AND mm.symbol = ? ORDER BY mm.id DESC LIMIT 1 OFFSET ?
+			) outdated
+		)
+	`, m.CurrentBot.Id, symbol, m.CurrentBot.Id, symbol, keep)
+
+	return err
+}
+
+func (m *MLModelRepository) scanModel(row interface{ Scan(dest ...any) error }) (model.MLModel, error) {
+	var entity model.MLModel
+	err := row.Scan(
+		&entity.Id,
+		&entity.Symbol,
+		&entity.ModelType,
+		&entity.FormatVersion,
+		&entity.Features,
+		&entity.Horizon,
+		&entity.Metrics,
+		&entity.DatasetFrom,
+		&entity.DatasetTo,
+		&entity.DatasetLength,
+		&entity.IsActive,
+		&entity.PromotionReason,
+		&entity.CreatedAt,
+		&entity.Payload,
+	)
+
+	return entity, err
+}
@@ -13,6 +13,10 @@ import (
 	"time"
 )
 
+// orderAssetsSelect selects assets of the order trade limit, orders table has no own asset columns
+const orderAssetsSelect = `IFNULL((SELECT tl.base_asset FROM trade_limit tl WHERE tl.symbol = o.symbol AND tl.bot_id = o.bot_id LIMIT 1), '') as BaseAsset,
+			IFNULL((SELECT tl.quote_asset FROM trade_limit tl WHERE tl.symbol = o.symbol AND tl.bot_id = o.bot_id LIMIT 1), '') as QuoteAsset,`
+
 type OrderUpdaterInterface interface {
 	Update(order model.Order) error
 }
@@ -21,22 +25,32 @@ type OrderCachedReaderInterface interface {
 	GetOpenedOrderCached(symbol string, operation string) *model.Order
 }
 
+type OrderProfitReaderInterface interface {
+	GetRealizedProfitSince(from string) map[string]float64
+}
+
 type OrderStorageInterface interface {
 	Create(order model.Order) (*int64, error)
 	Update(order model.Order) error
 	DeleteManualOrder(symbol string)
 	Find(id int64) (model.Order, error)
 	GetClosesOrderList(buyOrder model.Order) []model.Order
-	DeleteBinanceOrder(order model.BinanceOrder)
+	DeleteExchangeOrder(order model.ExchangeOrder)
 	GetOpenedOrderCached(symbol string, operation string) *model.Order
 	GetManualOrder(symbol string) *model.ManualOrder
-	SetBinanceOrder(order model.BinanceOrder)
-	GetBinanceOrder(symbol string, operation string) *model.BinanceOrder
+	SetExchangeOrder(order model.ExchangeOrder)
+	GetExchangeOrder(symbol string, operation string) *model.ExchangeOrder
 	LockBuy(symbol string, seconds int64)
 	HasBuyLock(symbol string) bool
 	GetTodayExtraOrderMap() *sync.Map
 }
 
+type OrderLifecycleStorageInterface interface {
+	SaveOrderLifecycle(lifecycle model.OrderLifecycle) error
+	GetOrderLifecycle(symbol string, side string) *model.OrderLifecycle
+	DeleteOrderLifecycle(symbol string, side string)
+}
+
 type OrderRepository struct {
 	DB               *sql.DB
 	CurrentBot       *model.Bot
@@ -97,6 +111,7 @@ func (repo *OrderRepository) GetOpenedOrder(symbol string, operation string) (mo
 		SELECT 
 			o.id as Id, 
 			o.symbol as Symbol, 
+			`+orderAssetsSelect+`
 			o.quantity as Quantity,
 			o.executed_quantity as ExecutedQuantity,
 			o.price as Price,
@@ -117,7 +132,10 @@ func (repo *OrderRepository) GetOpenedOrder(symbol string, operation string) (mo
 			o.profit_options as ProfitOptions,
     		IFNULL(SUM(sa.end_quantity - sa.start_quantity), 0) as SwapQuantity,
     		COUNT(extra.id) as ExtraOrdersCount,
-    		o.exchange as Exchange
+    		o.exchange as Exchange,
+    		o.explanation as Explanation,
+    		o.close_reason as CloseReason,
+    		o.protection as Protection
 		FROM orders o
 		LEFT JOIN orders sell ON o.id = sell.closes_order AND sell.operation = 'SELL'
      	LEFT JOIN orders extra ON o.id = extra.closes_order AND extra.operation = 'BUY'
@@ -133,6 +151,8 @@ func (repo *OrderRepository) GetOpenedOrder(symbol string, operation string) (mo
 	).Scan(
 		&order.Id,
 		&order.Symbol,
+		&order.BaseAsset,
+		&order.QuoteAsset,
 		&order.Quantity,
 		&order.ExecutedQuantity,
 		&order.Price,
@@ -154,6 +174,9 @@ func (repo *OrderRepository) GetOpenedOrder(symbol string, operation string) (mo
 		&order.SwapQuantity,
 		&order.ExtraOrdersCount,
 		&order.Exchange,
+		&order.Explanation,
+		&order.CloseReason,
+		&order.Protection,
 	)
 
 	if err != nil {
@@ -184,7 +207,10 @@ func (repo *OrderRepository) Create(order model.Order) (*int64, error) {
 			extra_charge_options = ?,
 			profit_options = ?,
 			bot_id = ?,
-			exchange = ?
+			exchange = ?,
+			explanation = ?,
+			close_reason = ?,
+			protection = ?
 	`,
 		order.Symbol,
 		order.Quantity,
@@ -205,6 +231,9 @@ func (repo *OrderRepository) Create(order model.Order) (*int64, error) {
 		order.ProfitOptions,
 		repo.CurrentBot.Id,
 		repo.CurrentBot.Exchange,
+		order.Explanation,
+		order.CloseReason,
+		order.Protection,
 	)
 
 	if err != nil {
@@ -240,7 +269,10 @@ func (repo *OrderRepository) Update(order model.Order) error {
 			o.commission_asset = ?,
 			o.swap = ?,
 			o.extra_charge_options = ?,
-			o.profit_options = ?
+			o.profit_options = ?,
+			o.explanation = ?,
+			o.close_reason = ?,
+			o.protection = ?
 		WHERE o.id = ? AND o.bot_id = ? AND exchange = ?
 	`,
 		order.Symbol,
@@ -261,6 +293,9 @@ func (repo *OrderRepository) Update(order model.Order) error {
 		order.Swap,
 		order.ExtraChargeOptions,
 		order.ProfitOptions,
+		order.Explanation,
+		order.CloseReason,
+		order.Protection,
 		order.Id,
 		repo.CurrentBot.Id,
 		repo.CurrentBot.Exchange,
@@ -281,6 +316,7 @@ func (repo *OrderRepository) Find(id int64) (model.Order, error) {
 		SELECT 
 			o.id as Id, 
 			o.symbol as Symbol, 
+			`+orderAssetsSelect+`
 			o.quantity as Quantity,
 			o.executed_quantity as ExecutedQuantity,
 			o.price as Price,
@@ -301,7 +337,10 @@ func (repo *OrderRepository) Find(id int64) (model.Order, error) {
 			o.profit_options as ProfitOptions,
     		IFNULL(SUM(sa.end_quantity - sa.start_quantity), 0) as SwapQuantity,
     		COUNT(extra.id) as ExtraOrdersCount,
-    		o.exchange as Exchange
+    		o.exchange as Exchange,
+    		o.explanation as Explanation,
+    		o.close_reason as CloseReason,
+    		o.protection as Protection
 		FROM orders o
 		LEFT JOIN orders sell ON o.id = sell.closes_order AND sell.operation = 'SELL'
      	LEFT JOIN orders extra ON o.id = extra.closes_order AND extra.operation = 'BUY'
@@ -311,6 +350,8 @@ func (repo *OrderRepository) Find(id int64) (model.Order, error) {
 	).Scan(
 		&order.Id,
 		&order.Symbol,
+		&order.BaseAsset,
+		&order.QuoteAsset,
 		&order.Quantity,
 		&order.ExecutedQuantity,
 		&order.Price,
@@ -332,6 +373,9 @@ func (repo *OrderRepository) Find(id int64) (model.Order, error) {
 		&order.SwapQuantity,
 		&order.ExtraOrdersCount,
 		&order.Exchange,
+		&order.Explanation,
+		&order.CloseReason,
+		&order.Protection,
 	)
 
 	if err != nil {
@@ -401,6 +445,7 @@ func (repo *OrderRepository) GetHistoryList(symbol string, from time.Time, to ti
 		SELECT
 		    o.id as Id, 
 			o.symbol as Symbol, 
+			`+orderAssetsSelect+`
 			o.quantity as Quantity,
 			o.executed_quantity as ExecutedQuantity,
 			o.price as Price,
@@ -421,7 +466,10 @@ func (repo *OrderRepository) GetHistoryList(symbol string, from time.Time, to ti
 			o.profit_options as ProfitOptions,
     		IFNULL(SUM(sa.end_quantity - sa.start_quantity), 0) as SwapQuantity,
     		COUNT(extra.id) as ExtraOrdersCount,
-    		o.exchange as Exchange
+    		o.exchange as Exchange,
+    		o.explanation as Explanation,
+    		o.close_reason as CloseReason,
+    		o.protection as Protection
 		FROM orders o 
 		LEFT JOIN orders sell ON o.id = sell.closes_order AND sell.operation = 'SELL'
      	LEFT JOIN orders extra ON o.id = extra.closes_order AND extra.operation = 'BUY'
@@ -449,6 +497,8 @@ func (repo *OrderRepository) GetHistoryList(symbol string, from time.Time, to ti
 		err := res.Scan(
 			&order.Id,
 			&order.Symbol,
+			&order.BaseAsset,
+			&order.QuoteAsset,
 			&order.Quantity,
 			&order.ExecutedQuantity,
 			&order.Price,
@@ -470,6 +520,9 @@ func (repo *OrderRepository) GetHistoryList(symbol string, from time.Time, to ti
 			&order.SwapQuantity,
 			&order.ExtraOrdersCount,
 			&order.Exchange,
+			&order.Explanation,
+			&order.CloseReason,
+			&order.Protection,
 		)
 
 		if err != nil {
@@ -487,6 +540,7 @@ func (repo *OrderRepository) GetList() []model.Order {
 		SELECT
 		    o.id as Id, 
 			o.symbol as Symbol, 
+			`+orderAssetsSelect+`
 			o.quantity as Quantity,
 			o.executed_quantity as ExecutedQuantity,
 			o.price as Price,
@@ -507,7 +561,10 @@ func (repo *OrderRepository) GetList() []model.Order {
 			o.profit_options as ProfitOptions,
     		IFNULL(SUM(sa.end_quantity - sa.start_quantity), 0) as SwapQuantity,
     		COUNT(extra.id) as ExtraOrdersCount,
-    		o.exchange as Exchange
+    		o.exchange as Exchange,
+    		o.explanation as Explanation,
+    		o.close_reason as CloseReason,
+    		o.protection as Protection
 		FROM orders o 
 		LEFT JOIN orders sell ON o.id = sell.closes_order AND sell.operation = 'SELL'
      	LEFT JOIN orders extra ON o.id = extra.closes_order AND extra.operation = 'BUY'
@@ -528,6 +585,8 @@ func (repo *OrderRepository) GetList() []model.Order {
 		err := res.Scan(
 			&order.Id,
 			&order.Symbol,
+			&order.BaseAsset,
+			&order.QuoteAsset,
 			&order.Quantity,
 			&order.ExecutedQuantity,
 			&order.Price,
@@ -549,6 +608,9 @@ func (repo *OrderRepository) GetList() []model.Order {
 			&order.SwapQuantity,
 			&order.ExtraOrdersCount,
 			&order.Exchange,
+			&order.Explanation,
+			&order.CloseReason,
+			&order.Protection,
 		)
 
 		if err != nil {
@@ -566,6 +628,7 @@ func (repo *OrderRepository) GetClosesOrderList(buyOrder model.Order) []model.Or
 		SELECT
 		    o.id as Id, 
 			o.symbol as Symbol, 
+			`+orderAssetsSelect+`
 			o.quantity as Quantity,
 			o.executed_quantity as ExecutedQuantity,
 			o.price as Price,
@@ -586,7 +649,10 @@ func (repo *OrderRepository) GetClosesOrderList(buyOrder model.Order) []model.Or
 			o.profit_options as ProfitOptions,
     		IFNULL(SUM(sa.end_quantity - sa.start_quantity), 0) as SwapQuantity,
     		COUNT(extra.id) as ExtraOrdersCount,
-    		o.exchange as Exchange
+    		o.exchange as Exchange,
+    		o.explanation as Explanation,
+    		o.close_reason as CloseReason,
+    		o.protection as Protection
 		FROM orders o 
 		LEFT JOIN orders sell ON o.id = sell.closes_order AND sell.operation = 'SELL'
      	LEFT JOIN orders extra ON o.id = extra.closes_order AND extra.operation = 'BUY'
@@ -607,6 +673,8 @@ func (repo *OrderRepository) GetClosesOrderList(buyOrder model.Order) []model.Or
 		err := res.Scan(
 			&order.Id,
 			&order.Symbol,
+			&order.BaseAsset,
+			&order.QuoteAsset,
 			&order.Quantity,
 			&order.ExecutedQuantity,
 			&order.Price,
@@ -628,6 +696,9 @@ func (repo *OrderRepository) GetClosesOrderList(buyOrder model.Order) []model.Or
 			&order.SwapQuantity,
 			&order.ExtraOrdersCount,
 			&order.Exchange,
+			&order.Explanation,
+			&order.CloseReason,
+			&order.Protection,
 		)
 
 		if err != nil {
@@ -640,7 +711,7 @@ func (repo *OrderRepository) GetClosesOrderList(buyOrder model.Order) []model.Or
 	return list
 }
 
-func (repo *OrderRepository) SetBinanceOrder(order model.BinanceOrder) {
+func (repo *OrderRepository) SetExchangeOrder(order model.ExchangeOrder) {
 	storageKey := fmt.Sprintf(
 		"binance-order-%s-%s-bot-%d",
 		order.Symbol,
@@ -658,8 +729,8 @@ func (repo *OrderRepository) SetBinanceOrder(order model.BinanceOrder) {
 	}
 }
 
-func (repo *OrderRepository) GetBinanceOrder(symbol string, operation string) *model.BinanceOrder {
-	var dto model.BinanceOrder
+func (repo *OrderRepository) GetExchangeOrder(symbol string, operation string) *model.ExchangeOrder {
+	var dto model.ExchangeOrder
 	storageKey := fmt.Sprintf(
 		"binance-order-%s-%s-bot-%d",
 		symbol,
@@ -699,7 +770,7 @@ func (repo *OrderRepository) GetBinanceOrder(symbol string, operation string) *m
 	return &dto
 }
 
-func (repo *OrderRepository) DeleteBinanceOrder(order model.BinanceOrder) {
+func (repo *OrderRepository) DeleteExchangeOrder(order model.ExchangeOrder) {
 	storageKey := fmt.Sprintf(
 		"binance-order-%s-%s-bot-%d",
 		order.Symbol,
@@ -718,6 +789,37 @@ func (repo *OrderRepository) DeleteBinanceOrder(order model.BinanceOrder) {
 	repo.RDB.Del(*repo.Ctx, storageKey).Val()
 }
 
+func (repo *OrderRepository) getOrderLifecycleKey(symbol string, side string) string {
+	return fmt.Sprintf(
+		"order-lifecycle-%s-%s-bot-%d",
+		symbol,
+		strings.ToLower(side),
+		repo.CurrentBot.Id,
+	)
+}
+
+func (repo *OrderRepository) SaveOrderLifecycle(lifecycle model.OrderLifecycle) error {
+	return repo.ObjectRepository.SaveObject(repo.getOrderLifecycleKey(lifecycle.Symbol, lifecycle.Side), lifecycle)
+}
+
+func (repo *OrderRepository) GetOrderLifecycle(symbol string, side string) *model.OrderLifecycle {
+	var lifecycle model.OrderLifecycle
+	err := repo.ObjectRepository.LoadObject(repo.getOrderLifecycleKey(symbol, side), &lifecycle)
+	if err != nil {
+		if !strings.Contains(err.Error(), "no rows in result set") {
+			log.Printf("[%s] order lifecycle load error: %s", symbol, err.Error())
+		}
+
+		return nil
+	}
+
+	return &lifecycle
+}
+
+func (repo *OrderRepository) DeleteOrderLifecycle(symbol string, side string) {
+	_ = repo.ObjectRepository.DeleteObject(repo.getOrderLifecycleKey(symbol, side))
+}
+
 func (repo *OrderRepository) GetManualOrder(symbol string) *model.ManualOrder {
 	res := repo.RDB.Get(*repo.Ctx, fmt.Sprintf(
 		"manual-order-%s-bot-%d",
@@ -808,3 +910,39 @@ func (repo *OrderRepository) GetTodayExtraOrderMap() *sync.Map {
 
 	return &extraOrderMap
 }
+
+// GetRealizedProfitSince returns profit by symbol of SELL orders created from the date time, it is in quote asset of the symbol
+func (repo *OrderRepository) GetRealizedProfitSince(from string) map[string]float64 {
+	profitMap := make(map[string]float64)
+
+	res, err := repo.DB.Query(`
+		SELECT
+			sell.symbol as Symbol,
+			IFNULL(SUM((sell.price - buy.price) * sell.executed_quantity), 0) as Profit
+		FROM orders sell
+		INNER JOIN orders buy ON buy.id = sell.closes_order AND buy.operation = 'BUY'
+		WHERE sell.operation = 'SELL' AND sell.bot_id = ? AND sell.exchange = ? AND sell.created_at >= ?
+		GROUP BY sell.symbol
+	`, repo.CurrentBot.Id, repo.CurrentBot.Exchange, from)
+
+	if err != nil {
+		log.Printf("Realized profit: %s", err.Error())
+
+		return profitMap
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var symbol string
+		var profit float64
+		err := res.Scan(&symbol, &profit)
+		if err != nil {
+			log.Printf("Realized profit: %s", err.Error())
+			continue
+		}
+
+		profitMap[symbol] = profit
+	}
+
+	return profitMap
+}
@@ -7,6 +7,10 @@ import (
 	"sync"
 )
 
+type TradeStatReaderInterface interface {
+	GetTradeStatList(symbol string, from model.TimestampMilli, to model.TimestampMilli) ([]model.TradeStat, error)
+}
+
 type StatRepository struct {
 	DB         *sql.DB
 	CurrentBot *model.Bot
@@ -138,49 +142,7 @@ func (s *StatRepository) GetStatRange(symbol string, limit int64) *sync.Map {
 	}
 
 	for res.Next() {
-		tradeStat := model.TradeStat{
-			OrderBookStat: model.OrderBookStat{
-				SellIceberg: model.Iceberg{
-					Side: model.IcebergSideSell,
-				},
-				BuyIceberg: model.Iceberg{
-					Side: model.IcebergSideBuy,
-				},
-			},
-		}
-		err := res.Scan(
-			&tradeStat.Symbol,
-			&tradeStat.Timestamp,
-			&tradeStat.BotId,
-			&tradeStat.Exchange,
-			&tradeStat.Price,
-			&tradeStat.BuyQty,
-			&tradeStat.SellQty,
-			&tradeStat.BuyVolume,
-			&tradeStat.SellVolume,
-			&tradeStat.TradeCount,
-			&tradeStat.MaxPSC,
-			&tradeStat.MinPCS,
-			&tradeStat.Open,
-			&tradeStat.Close,
-			&tradeStat.High,
-			&tradeStat.Low,
-			&tradeStat.Volume,
-			&tradeStat.OrderBookStat.BuyLength,
-			&tradeStat.OrderBookStat.SellLength,
-			&tradeStat.OrderBookStat.BuyQtySum,
-			&tradeStat.OrderBookStat.SellQtySum,
-			&tradeStat.OrderBookStat.BuyVolumeSum,
-			&tradeStat.OrderBookStat.SellVolumeSum,
-			&tradeStat.OrderBookStat.BuyIceberg.Quantity,
-			&tradeStat.OrderBookStat.BuyIceberg.Price,
-			&tradeStat.OrderBookStat.SellIceberg.Quantity,
-			&tradeStat.OrderBookStat.SellIceberg.Price,
-			&tradeStat.OrderBookStat.FirstBuyQty,
-			&tradeStat.OrderBookStat.FirstBuyPrice,
-			&tradeStat.OrderBookStat.FirstSellQty,
-			&tradeStat.OrderBookStat.FirstSellPrice,
-		)
+		tradeStat, err := s.scanTradeStat(res)
 
 		if err != nil {
 			log.Fatal(err)
@@ -192,48 +154,110 @@ func (s *StatRepository) GetStatRange(symbol string, limit int64) *sync.Map {
 	return &statMap
 }
 
-func (s *StatRepository) GetMLDataset(symbol string, secondary string) []model.TradeLearnDataset {
-	list := make([]model.TradeLearnDataset, 0)
-
-	res, err := s.DB.Query(
-		`SELECT
-			t1.order_book_buy_first_qty,
-			t1.order_book_sell_first_qty,
-			t1.order_book_buy_qty_sum,
-			t1.order_book_sell_qty_sum,
-			t1.order_book_buy_volume_sum,
-			t1.order_book_sell_volume_sum,
-			t2.close as secondary_price,
-			t1.close
-		FROM default.trades t1
-	 	INNER JOIN default.trades t2 ON t1.timestamp = t2.timestamp AND t2.symbol = ? AND t2.exchange = t1.exchange
-		WHERE t1.symbol = ? AND t1.timestamp >= (toStartOfDay(now()) - toIntervalDay(1)) AND t1.exchange = ?
-	`, secondary, symbol, s.CurrentBot.Exchange)
-	defer res.Close()
+func (s *StatRepository) GetTradeStatList(symbol string, from model.TimestampMilli, to model.TimestampMilli) ([]model.TradeStat, error) {
+	list := make([]model.TradeStat, 0)
+
+	res, err := s.DB.Query(`
+		SELECT
+		    symbol as Symbol,
+			toUnixTimestamp64Milli(timestamp) as DateTime,
+			bot_id as BotId,
+			exchange as Exchange,
+			price as Price,
+			buy_qty as BuyQty,
+			sell_qty as SellQty,
+			buy_volume as BuyVolume,
+			sell_volume as SellVolume,
+			trade_count as TradeCount,
+			max_pcs as MaxPcs,
+			min_pcs as MinPcs,
+			open as Open,
+			close as Close,
+			high as High,
+			low as Low,
+			volume as Volume,
+			order_book_buy_length as OrderBookBuyLength,
+			order_book_sell_length as OrderBookSellLength,
+			order_book_buy_qty_sum as OrderBookBuyQtySum,
+			order_book_sell_qty_sum as OrderBookSellQtySum,
+			order_book_buy_volume_sum as OrderBookBuyVolumeSum,
+			order_book_sell_volume_sum as OrderBookSellVolumeSum,
+			order_book_buy_iceberg_qty as OrderBookBuyIcebergQty,
+			order_book_buy_iceberg_price as OrderBookBuyIcebergPrice,
+			order_book_sell_iceberg_qty as OrderBookSellIcebergQty,
+			order_book_sell_iceberg_price as OrderBookSellIcebergPrice,
+			order_book_buy_first_qty as OrderBookBuyFirstQty,
+			order_book_buy_first_price as OrderBookBuyFirstPrice,
+			order_book_sell_first_qty as OrderBookSellFirstQty,
+			order_book_sell_first_price as OrderBookSellFirstPrice
+		FROM default.trades
+		WHERE symbol = ? AND exchange = ? AND timestamp >= toDateTime(?) AND timestamp <= toDateTime(?)
+		ORDER BY timestamp ASC
+	`, symbol, s.CurrentBot.Exchange, from.Value()/1000, to.Value()/1000)
 
 	if err != nil {
-		log.Fatal(err)
+		return list, err
 	}
 
+	defer res.Close()
+
 	for res.Next() {
-		var datasetItem model.TradeLearnDataset
-		err := res.Scan(
-			&datasetItem.OrderBookBuyFirstQty,
-			&datasetItem.OrderBookSellFirstQty,
-			&datasetItem.OrderBookBuyQtySum,
-			&datasetItem.OrderBookSellQtySum,
-			&datasetItem.OrderBookBuyVolumeSum,
-			&datasetItem.OrderBookSellVolumeSum,
-			&datasetItem.SecondaryPrice,
-			&datasetItem.PrimaryPrice,
-		)
+		tradeStat, err := s.scanTradeStat(res)
 
 		if err != nil {
-			log.Fatal(err)
+			return list, err
 		}
 
-		list = append(list, datasetItem)
+		list = append(list, tradeStat)
 	}
 
-	return list
+	return list, nil
+}
+
+func (s *StatRepository) scanTradeStat(res *sql.Rows) (model.TradeStat, error) {
+	tradeStat := model.TradeStat{
+		OrderBookStat: model.OrderBookStat{
+			SellIceberg: model.Iceberg{
+				Side: model.IcebergSideSell,
+			},
+			BuyIceberg: model.Iceberg{
+				Side: model.IcebergSideBuy,
+			},
+		},
+	}
+	err := res.Scan(
+		&tradeStat.Symbol,
+		&tradeStat.Timestamp,
+		&tradeStat.BotId,
+		&tradeStat.Exchange,
+		&tradeStat.Price,
+		&tradeStat.BuyQty,
+		&tradeStat.SellQty,
+		&tradeStat.BuyVolume,
+		&tradeStat.SellVolume,
+		&tradeStat.TradeCount,
+		&tradeStat.MaxPSC,
+		&tradeStat.MinPCS,
+		&tradeStat.Open,
+		&tradeStat.Close,
+		&tradeStat.High,
+		&tradeStat.Low,
+		&tradeStat.Volume,
+		&tradeStat.OrderBookStat.BuyLength,
+		&tradeStat.OrderBookStat.SellLength,
+		&tradeStat.OrderBookStat.BuyQtySum,
+		&tradeStat.OrderBookStat.SellQtySum,
+		&tradeStat.OrderBookStat.BuyVolumeSum,
+		&tradeStat.OrderBookStat.SellVolumeSum,
+		&tradeStat.OrderBookStat.BuyIceberg.Quantity,
+		&tradeStat.OrderBookStat.BuyIceberg.Price,
+		&tradeStat.OrderBookStat.SellIceberg.Quantity,
+		&tradeStat.OrderBookStat.SellIceberg.Price,
+		&tradeStat.OrderBookStat.FirstBuyQty,
+		&tradeStat.OrderBookStat.FirstBuyPrice,
+		&tradeStat.OrderBookStat.FirstSellQty,
+		&tradeStat.OrderBookStat.FirstSellPrice,
+	)
+
+	return tradeStat, err
 }
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/redis/go-redis/v9"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
@@ -42,6 +43,15 @@ type SwapRepositoryInterface interface {
 	GetSwapPairBySymbol(symbol string) (model.SwapPair, error)
 }
 
+type SwapRecoveryRepositoryInterface interface {
+	GetProcessingSwapActions() []model.SwapAction
+	GetSwapActionById(id int64) (model.SwapAction, error)
+	UpdateSwapAction(action model.SwapAction) error
+	GetSwapChainById(id int64) (model.SwapChainEntity, error)
+	GetSwapRecoveryDecisions(swapActionId int64) []model.SwapRecoveryDecision
+	AddSwapRecoveryDecision(decision model.SwapRecoveryDecision) error
+}
+
 type SwapRepository struct {
 	DB               *sql.DB
 	RDB              *redis.Client
@@ -1026,3 +1036,133 @@ func (repo *SwapRepository) GetSwapActions() []model.SwapActionExtended {
 
 	return list
 }
+
+func (repo *SwapRepository) GetProcessingSwapActions() []model.SwapAction {
+	return repo.findSwapActions(
+		"sa.bot_id = ? AND sa.status IN (?, ?)",
+		repo.CurrentBot.Id,
+		model.SwapActionStatusPending,
+		model.SwapActionStatusProcess,
+	)
+}
+
+func (repo *SwapRepository) GetSwapActionById(id int64) (model.SwapAction, error) {
+	list := repo.findSwapActions("sa.bot_id = ? AND sa.id = ?", repo.CurrentBot.Id, id)
+
+	if len(list) == 0 {
+		return model.SwapAction{}, errors.New(fmt.Sprintf("Swap action %d is not found", id))
+	}
+
+	return list[0], nil
+}
+
+func (repo *SwapRepository) findSwapActions(condition string, args ...interface{}) []model.SwapAction {
+	res, err := repo.DB.Query(fmt.Sprintf(`
+		SELECT
+		    sa.id as Id,
+		    sa.order_id as OrderId,
+		    sa.bot_id as BotId,
+		    sa.swap_chain_id as SwapChainId,
+		    sa.asset as Asset,
+		    sa.status as Status,
+		    sa.start_timestamp as StartTimestamp,
+		    sa.start_quantity as StartQuantity,
+		    sa.end_timestamp as EndTimestamp,
+		    sa.end_quantity as EndQuantity,
+		    sa.swap_one_side as SwapOneSide,
+		    sa.swap_one_quantity as SwapOneQuantity,
+		    sa.swap_one_external_id as SwapOneExternalId,
+		    sa.swap_one_external_status as SwapOneExternalStatus,
+		    sa.swap_one_symbol as SwapOneSymbol,
+		    sa.swap_one_price as SwapOnePrice,
+		    sa.swap_one_timestamp as SwapOneTimestamp,
+		    sa.swap_two_side as SwapTwoSide,
+		    sa.swap_two_quantity as SwapTwoQuantity,
+		    sa.swap_two_external_id as SwapTwoExternalId,
+		    sa.swap_two_external_status as SwapTwoExternalStatus,
+		    sa.swap_two_symbol as SwapTwoSymbol,
+		    sa.swap_two_price as SwapTwoPrice,
+		    sa.swap_two_timestamp as SwapTwoTimestamp,
+		    sa.swap_three_side as SwapThreeSide,
+		    sa.swap_three_quantity as SwapThreeQuantity,
+		    sa.swap_three_external_id as SwapThreeExternalId,
+		    sa.swap_three_external_status as SwapThreeExternalStatus,
+		    sa.swap_three_symbol as SwapThreeSymbol,
+		    sa.swap_three_price as SwapThreePrice,
+		    sa.swap_three_timestamp as SwapThreeTimestamp
+		FROM swap_action sa
+		WHERE %s
+	`, condition), args...)
+
+	list := make([]model.SwapAction, 0)
+
+	if err != nil {
+		log.Println(err)
+		return list
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var action model.SwapAction
+
+		err := res.Scan(
+			&action.Id,
+			&action.OrderId,
+			&action.BotId,
+			&action.SwapChainId,
+			&action.Asset,
+			&action.Status,
+			&action.StartTimestamp,
+			&action.StartQuantity,
+			&action.EndTimestamp,
+			&action.EndQuantity,
+			&action.SwapOneSide,
+			&action.SwapOneQuantity,
+			&action.SwapOneExternalId,
+			&action.SwapOneExternalStatus,
+			&action.SwapOneSymbol,
+			&action.SwapOnePrice,
+			&action.SwapOneTimestamp,
+			&action.SwapTwoSide,
+			&action.SwapTwoQuantity,
+			&action.SwapTwoExternalId,
+			&action.SwapTwoExternalStatus,
+			&action.SwapTwoSymbol,
+			&action.SwapTwoPrice,
+			&action.SwapTwoTimestamp,
+			&action.SwapThreeSide,
+			&action.SwapThreeQuantity,
+			&action.SwapThreeExternalId,
+			&action.SwapThreeExternalStatus,
+			&action.SwapThreeSymbol,
+			&action.SwapThreePrice,
+			&action.SwapThreeTimestamp,
+		)
+
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		list = append(list, action)
+	}
+
+	return list
+}
+
+func (repo *SwapRepository) getSwapRecoveryKey(swapActionId int64) string {
+	return fmt.Sprintf("swap-recovery-%d-bot-%d", swapActionId, repo.CurrentBot.Id)
+}
+
+func (repo *SwapRepository) GetSwapRecoveryDecisions(swapActionId int64) []model.SwapRecoveryDecision {
+	decisions := make([]model.SwapRecoveryDecision, 0)
+	_ = repo.ObjectRepository.LoadObject(repo.getSwapRecoveryKey(swapActionId), &decisions)
+
+	return decisions
+}
+
+func (repo *SwapRepository) AddSwapRecoveryDecision(decision model.SwapRecoveryDecision) error {
+	decisions := append(repo.GetSwapRecoveryDecisions(decision.SwapActionId), decision)
+
+	return repo.ObjectRepository.SaveObject(repo.getSwapRecoveryKey(decision.SwapActionId), decisions)
+}
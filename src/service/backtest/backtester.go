@@ -0,0 +1,410 @@
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/indicator"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/strategy"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"math"
+	"strings"
+	"time"
+)
+
+// SkippedStrategies need full order book, history keeps only the best bid and ask of every minute
+var SkippedStrategies = []string{model.MarketDepthStrategyName}
+
+type Backtester struct {
+	StatRepository repository.TradeStatReaderInterface
+	Formatter      *utils.Formatter
+	CurrentBot     *model.Bot
+}
+
+func (b *Backtester) Run(request model.BacktestRequest) (model.BacktestReport, error) {
+	if request.To.Value() == 0 {
+		request.To = model.TimestampMilli(time.Now().UnixMilli())
+	}
+
+	if request.From.Value() == 0 {
+		request.From = model.TimestampMilli(request.To.Value() - model.BacktestDefaultPeriodMinutes*60*1000)
+	}
+
+	if request.From.Gte(request.To) {
+		return model.BacktestReport{}, errors.New(fmt.Sprintf("[%s] Backtest period is invalid", request.TradeLimit.Symbol))
+	}
+
+	history, err := b.StatRepository.GetTradeStatList(request.TradeLimit.Symbol, request.From, request.To)
+	if err != nil {
+		return model.BacktestReport{}, err
+	}
+
+	if len(history) < 2 {
+		return model.BacktestReport{}, errors.New(fmt.Sprintf("[%s] Not enough history for backtest, %d klines found", request.TradeLimit.Symbol, len(history)))
+	}
+
+	return b.Replay(request, history), nil
+}
+
+// Replay runs every history minute through MakerService, orders are filled against the next minute.
+func (b *Backtester) Replay(request model.BacktestRequest, history []model.TradeStat) model.BacktestReport {
+	if request.InitialBalance <= 0.00 {
+		request.InitialBalance = model.BacktestDefaultBalance
+	}
+
+	if request.FeePercent <= 0.00 {
+		request.FeePercent = model.BacktestDefaultFeePercent
+	}
+
+	symbol := request.TradeLimit.Symbol
+	tradeLimit := request.TradeLimit
+	tradeLimit.IsEnabled = true
+
+	bot := *b.CurrentBot
+	bot.IsSwapEnabled = false
+
+	clock := &Clock{}
+	storage := NewMemoryStorage(clock, tradeLimit)
+	exchangeApi := NewSimulatedExchange(storage, clock, tradeLimit.GetQuoteAsset(), request.InitialBalance, request.FeePercent)
+	botService := &BotService{CurrentBot: &bot}
+
+	profitService := &exchange.ProfitService{
+		Binance:    exchangeApi,
+		BotService: botService,
+	}
+	stopLossService := &exchange.StopLossService{
+		BotService: botService,
+	}
+	lossSecurity := &exchange.LossSecurity{
+		MlEnabled:            false,
+		InterpolationEnabled: false,
+		Formatter:            b.Formatter,
+		ExchangeRepository:   storage,
+		ProfitService:        profitService,
+	}
+	indicatorService := &indicator.IndicatorService{
+		ExchangeRepository: storage,
+	}
+	tradeFilterService := &exchange.TradeFilterService{
+		OrderRepository:   storage,
+		ExchangeTradeInfo: storage,
+		ExchangePriceAPI:  exchangeApi,
+		Formatter:         b.Formatter,
+		SignalStorage:     storage,
+		IndicatorService:  indicatorService,
+	}
+	priceCalculator := &exchange.PriceCalculator{
+		ExchangeRepository: storage,
+		OrderRepository:    storage,
+		Formatter:          b.Formatter,
+		LossSecurity:       lossSecurity,
+		ProfitService:      profitService,
+		BotService:         botService,
+		SignalStorage:      storage,
+	}
+	tradeStack := &TradeStack{
+		Storage:            storage,
+		BalanceService:     exchangeApi,
+		TradeFilterService: tradeFilterService,
+	}
+
+	lockChannel := make(chan model.Lock)
+	// lockFlush request is answered when all lock messages sent before it are applied
+	lockFlush := make(chan chan bool)
+	done := make(chan bool)
+	defer close(done)
+
+	orderExecutor := &exchange.OrderExecutor{
+		TradeStack:         tradeStack,
+		CurrentBot:         &bot,
+		TimeService:        clock,
+		BalanceService:     exchangeApi,
+		Binance:            exchangeApi,
+		OrderRepository:    storage,
+		ExchangeRepository: storage,
+		LossSecurity:       lossSecurity,
+		PriceCalculator:    priceCalculator,
+		ProfitService:      profitService,
+		StopLossService:    stopLossService,
+		CallbackManager:    &CallbackManager{},
+		Formatter:          b.Formatter,
+		BotService:         botService,
+		Lock:               make(map[string]bool),
+		LockChannel:        &lockChannel,
+		CancelRequestMap:   make(map[string]bool),
+	}
+
+	go func() {
+		for {
+			select {
+			case lock := <-lockChannel:
+				orderExecutor.TradeLockMutex.Lock()
+				orderExecutor.Lock[lock.Symbol] = lock.IsLocked
+				orderExecutor.TradeLockMutex.Unlock()
+			case applied := <-lockFlush:
+				close(applied)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	baseKLineStrategy := &strategy.BaseKLineStrategy{
+		Formatter:        b.Formatter,
+		IndicatorService: indicatorService,
+		MlEnabled:        false,
+	}
+	orderBasedStrategy := &strategy.OrderBasedStrategy{
+		ExchangeRepository: storage,
+		OrderRepository:    storage,
+		ProfitService:      profitService,
+		StopLossService:    stopLossService,
+		BotService:         botService,
+		SignalStorage:      storage,
+	}
+	strategyRegistry := &exchange.StrategyRegistry{}
+	_ = strategyRegistry.Register(baseKLineStrategy)
+	_ = strategyRegistry.Register(orderBasedStrategy)
+
+	makerService := &exchange.MakerService{
+		TradeFilterService: tradeFilterService,
+		ExchangeApi:        exchangeApi,
+		Binance:            exchangeApi,
+		TradeStack:         tradeStack,
+		OrderExecutor:      orderExecutor,
+		OrderRepository:    storage,
+		ExchangeRepository: storage,
+		Formatter:          b.Formatter,
+		HoldScore:          75.00,
+		CurrentBot:         &bot,
+		PriceCalculator:    priceCalculator,
+		BotService:         botService,
+		TimeService:        clock,
+		StopLossService:    stopLossService,
+		StrategyFacade: &exchange.StrategyFacade{
+			MinDecisions:        3.00,
+			OrderRepository:     storage,
+			DecisionReadStorage: storage,
+			ExchangeRepository:  storage,
+			BotService:          botService,
+			StrategyRegistry:    strategyRegistry,
+		},
+	}
+
+	kLines := make([]model.KLine, 0)
+	depths := make([]model.OrderBookModel, 0)
+	for _, stat := range history {
+		if stat.Close <= 0.00 {
+			continue
+		}
+
+		kLine := b.toKLine(stat)
+		kLines = append(kLines, kLine)
+		depths = append(depths, b.toDepth(stat, kLine))
+	}
+
+	peakEquity := request.InitialBalance
+	maxDrawdown := 0.00
+	maxDrawdownPercent := 0.00
+	baseAsset := tradeLimit.GetBaseAsset()
+
+	for index, kLine := range kLines {
+		kLine.UpdatedAt = time.Now().Unix()
+		clock.Set(kLine.Timestamp.Value() / 1000)
+		storage.SetCurrentKline(kLine)
+		storage.SetDepth(depths[index], 20, 25)
+
+		if index > 0 {
+			indicatorService.Update(kLines[index-1])
+		}
+
+		if index+1 < len(kLines) {
+			exchangeApi.SetMarket(&kLines[index+1])
+		} else {
+			exchangeApi.SetMarket(nil)
+		}
+
+		for _, decision := range strategyRegistry.DecideKLine(kLine) {
+			storage.SetDecision(decision, symbol)
+		}
+		for _, decision := range strategyRegistry.DecideDepth(depths[index]) {
+			storage.SetDecision(decision, symbol)
+		}
+
+		makerService.Make(symbol)
+
+		applied := make(chan bool)
+		lockFlush <- applied
+		<-applied
+
+		quoteBalance, _ := exchangeApi.GetAssetBalance(exchangeApi.QuoteAsset, false)
+		baseBalance, _ := exchangeApi.GetAssetBalance(baseAsset, false)
+		equity := quoteBalance + baseBalance*kLine.Close.Value()
+
+		peakEquity = math.Max(peakEquity, equity)
+		if peakEquity-equity > maxDrawdown {
+			maxDrawdown = peakEquity - equity
+			maxDrawdownPercent = maxDrawdown * 100 / peakEquity
+		}
+	}
+
+	report := b.buildReport(request, storage, exchangeApi, kLines)
+	for _, name := range SkippedStrategies {
+		if tradeLimit.Strategies.IsEnabled(name) {
+			report.SkippedStrategies = append(report.SkippedStrategies, name)
+		}
+	}
+	report.MaxDrawdown = b.Formatter.ToFixed(maxDrawdown, 2)
+	report.MaxDrawdownPercent = b.Formatter.ToFixed(maxDrawdownPercent, 2)
+
+	return report
+}
+
+func (b *Backtester) buildReport(request model.BacktestRequest, storage *MemoryStorage, exchangeApi *SimulatedExchange, kLines []model.KLine) model.BacktestReport {
+	report := model.BacktestReport{
+		Symbol:            request.TradeLimit.Symbol,
+		From:              request.From,
+		To:                request.To,
+		KLineCount:        int64(len(kLines)),
+		InitialBalance:    request.InitialBalance,
+		SkippedStrategies: make([]string, 0),
+		Orders:            make([]model.BacktestOrder, 0),
+	}
+
+	if len(kLines) > 0 && report.From.Value() == 0 {
+		report.From = kLines[0].OpenTime
+	}
+
+	if len(kLines) > 0 && report.To.Value() == 0 {
+		report.To = kLines[len(kLines)-1].Timestamp
+	}
+
+	fills := make(map[string]Fill)
+	for _, fill := range exchangeApi.GetFills() {
+		fills[fill.Order.OrderId] = fill
+	}
+
+	balance := request.InitialBalance
+	for _, order := range storage.GetOrders() {
+		backtestOrder := model.BacktestOrder{
+			Id:          order.Id,
+			Symbol:      order.Symbol,
+			Operation:   strings.ToUpper(order.Operation),
+			Price:       order.Price,
+			Quantity:    order.ExecutedQuantity,
+			QuoteAmount: b.Formatter.ToFixed(order.ExecutedQuantity*order.Price, 2),
+			ClosesOrder: order.ClosesOrder,
+			CloseReason: order.CloseReason,
+		}
+
+		if order.ExternalId != nil {
+			if fill, ok := fills[*order.ExternalId]; ok {
+				backtestOrder.Commission = fill.Commission
+				backtestOrder.Timestamp = fill.Timestamp
+			}
+		}
+
+		if backtestOrder.Operation == "BUY" {
+			balance -= order.ExecutedQuantity * order.Price
+
+			if order.ClosesOrder == nil {
+				report.BuyCount++
+			} else {
+				report.ExtraBuyCount++
+			}
+
+			if order.IsClosed() {
+				report.ClosedPositions++
+			}
+
+			if order.IsOpened() {
+				report.HasOpenedPosition = true
+
+				opened, err := storage.Find(order.Id)
+				if err == nil && len(kLines) > 0 {
+					remaining := opened.GetRemainingToSellQuantity(false)
+					report.UnrealizedProfit += (kLines[len(kLines)-1].Close.Value() - opened.Price) * remaining
+				}
+			}
+		} else {
+			balance += order.ExecutedQuantity*order.Price - backtestOrder.Commission
+			report.SellCount++
+		}
+
+		backtestOrder.Balance = b.Formatter.ToFixed(balance, 2)
+		report.Orders = append(report.Orders, backtestOrder)
+	}
+
+	quoteBalance, _ := exchangeApi.GetAssetBalance(exchangeApi.QuoteAsset, false)
+	report.FinalBalance = b.Formatter.ToFixed(quoteBalance, 2)
+
+	equity := quoteBalance
+	if len(kLines) > 0 {
+		baseBalance, _ := exchangeApi.GetAssetBalance(strings.TrimSuffix(report.Symbol, exchangeApi.QuoteAsset), false)
+		equity += baseBalance * kLines[len(kLines)-1].Close.Value()
+	}
+
+	report.FinalEquity = b.Formatter.ToFixed(equity, 2)
+	report.RealizedProfit = b.Formatter.ToFixed(equity-request.InitialBalance-report.UnrealizedProfit, 2)
+	report.UnrealizedProfit = b.Formatter.ToFixed(report.UnrealizedProfit, 2)
+	report.ProfitPercent = b.Formatter.ToFixed((equity-request.InitialBalance)*100/request.InitialBalance, 2)
+
+	return report
+}
+
+func (b *Backtester) toKLine(stat model.TradeStat) model.KLine {
+	timestamp := model.TimestampMilli(stat.Timestamp.GetPeriodToMinute())
+
+	return model.KLine{
+		Symbol:    stat.Symbol,
+		Open:      model.Price(stat.Open),
+		Close:     model.Price(stat.Close),
+		Low:       model.Price(stat.Low),
+		High:      model.Price(stat.High),
+		Interval:  "1m",
+		Timestamp: timestamp,
+		OpenTime:  model.TimestampMilli(stat.Timestamp.GetPeriodFromMinute()),
+		Volume:    model.Volume(stat.Volume),
+		UpdatedAt: time.Now().Unix(),
+		PriceChangeSpeed: &model.PriceChangeSpeed{
+			Symbol:    stat.Symbol,
+			Timestamp: timestamp,
+			Changes:   make([]model.PriceChange, 0),
+			MaxChange: stat.MaxPSC,
+			MinChange: stat.MinPCS,
+		},
+		TradeVolume: &model.TradeVolume{
+			Symbol:     stat.Symbol,
+			Timestamp:  timestamp,
+			PeriodFrom: model.TimestampMilli(stat.Timestamp.GetPeriodFromMinute()),
+			PeriodTo:   timestamp,
+			BuyQty:     stat.BuyQty,
+			SellQty:    stat.SellQty,
+		},
+		Source: model.KLineSourceBacktest,
+	}
+}
+
+// toDepth restores the best levels of the order book from the minute stat, it is one level only:
+// price calculation uses it as the best bid and ask, depth strategies are not replayed
+func (b *Backtester) toDepth(stat model.TradeStat, kLine model.KLine) model.OrderBookModel {
+	bidPrice := stat.OrderBookStat.FirstBuyPrice
+	if bidPrice <= 0.00 {
+		bidPrice = kLine.Close.Value()
+	}
+
+	askPrice := stat.OrderBookStat.FirstSellPrice
+	if askPrice <= 0.00 {
+		askPrice = kLine.Close.Value()
+	}
+
+	return model.OrderBookModel{
+		Symbol:    stat.Symbol,
+		Timestamp: kLine.Timestamp.Value(),
+		Bids:      [][2]model.Number{{{Value: bidPrice}, {Value: stat.OrderBookStat.BuyQtySum}}},
+		Asks:      [][2]model.Number{{{Value: askPrice}, {Value: stat.OrderBookStat.SellQtySum}}},
+		UpdatedAt: time.Now().Unix(),
+	}
+}
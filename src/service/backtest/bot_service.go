@@ -0,0 +1,31 @@
+package backtest
+
+import "gitlab.com/open-soft/go-crypto-bot/src/model"
+
+type BotService struct {
+	CurrentBot *model.Bot
+}
+
+func (b *BotService) GetBot() model.Bot {
+	return *b.CurrentBot
+}
+
+func (b *BotService) IsSwapEnabled() bool {
+	return false
+}
+
+func (b *BotService) IsMasterBot() bool {
+	return false
+}
+
+func (b *BotService) GetTradeStackSorting() string {
+	return b.CurrentBot.TradeStackSorting
+}
+
+func (b *BotService) UseSwapCapital() bool {
+	return false
+}
+
+func (b *BotService) GetSwapConfig() model.SwapConfig {
+	return b.CurrentBot.SwapConfig
+}
@@ -0,0 +1,18 @@
+package backtest
+
+import "gitlab.com/open-soft/go-crypto-bot/src/model"
+
+type CallbackManager struct {
+}
+
+func (c *CallbackManager) Error(bot model.Bot, code string, message string, stop bool) {
+}
+
+func (c *CallbackManager) SellOrder(order model.Order, bot model.Bot, details string) {
+}
+
+func (c *CallbackManager) BuyOrder(order model.Order, bot model.Bot, details string) {
+}
+
+func (c *CallbackManager) Notify(notification model.Notification) {
+}
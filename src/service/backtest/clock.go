@@ -0,0 +1,52 @@
+package backtest
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a TimeServiceInterface driven by the replayed history instead of the wall clock.
+type Clock struct {
+	now   int64
+	mutex sync.RWMutex
+}
+
+func (c *Clock) Set(unixTime int64) {
+	c.mutex.Lock()
+	c.now = unixTime
+	c.mutex.Unlock()
+}
+
+func (c *Clock) WaitSeconds(seconds int64) {
+}
+
+func (c *Clock) WaitMilliseconds(milliseconds int64) {
+}
+
+func (c *Clock) GetNowUnix() int64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.now
+}
+
+func (c *Clock) GetNowDateTimeString() string {
+	return time.Unix(c.GetNowUnix(), 0).UTC().Format("2006-01-02 15:04:05")
+}
+
+func (c *Clock) GetNowDiffMinutes(unixTime int64) float64 {
+	return float64(c.GetNowUnix()-unixTime) / 60.00
+}
+
+// ToWallClock shifts a replayed date time into the wall clock frame, so
+// model methods based on time.Now() see the same age as in the replay.
+func (c *Clock) ToWallClock(dateTime string) string {
+	date, err := time.Parse("2006-01-02 15:04:05", dateTime)
+	if err != nil {
+		return dateTime
+	}
+
+	age := c.GetNowUnix() - date.Unix()
+
+	return time.Unix(time.Now().Unix()-age, 0).UTC().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,365 @@
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"strings"
+	"sync"
+	"time"
+)
+
+type MemoryStorage struct {
+	Clock *Clock
+
+	tradeLimit     model.TradeLimit
+	orders         []model.Order
+	exchangeOrders map[string]model.ExchangeOrder
+	kLines         []model.KLine
+	depth          *model.OrderBookModel
+	decisions      map[string]model.Decision
+	buyLocks       map[string]int64
+	mutex          sync.RWMutex
+}
+
+func NewMemoryStorage(clock *Clock, tradeLimit model.TradeLimit) *MemoryStorage {
+	return &MemoryStorage{
+		Clock:          clock,
+		tradeLimit:     tradeLimit,
+		orders:         make([]model.Order, 0),
+		exchangeOrders: make(map[string]model.ExchangeOrder),
+		kLines:         make([]model.KLine, 0),
+		decisions:      make(map[string]model.Decision),
+		buyLocks:       make(map[string]int64),
+	}
+}
+
+func (s *MemoryStorage) Create(order model.Order) (*int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	order.Id = int64(len(s.orders) + 1)
+	s.orders = append(s.orders, order)
+
+	return &order.Id, nil
+}
+
+func (s *MemoryStorage) Update(order model.Order) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for index, stored := range s.orders {
+		if stored.Id == order.Id {
+			// keep creation date in the replay frame, reads return it shifted
+			order.CreatedAt = stored.CreatedAt
+			s.orders[index] = order
+
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Order [%d] is not found", order.Id))
+}
+
+func (s *MemoryStorage) Find(id int64) (model.Order, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, order := range s.orders {
+		if order.Id == id {
+			return s.hydrate(order), nil
+		}
+	}
+
+	return model.Order{}, errors.New(fmt.Sprintf("Order [%d] is not found", id))
+}
+
+func (s *MemoryStorage) GetOrders() []model.Order {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	list := make([]model.Order, len(s.orders))
+	copy(list, s.orders)
+
+	return list
+}
+
+func (s *MemoryStorage) GetClosesOrderList(buyOrder model.Order) []model.Order {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	list := make([]model.Order, 0)
+	for _, order := range s.orders {
+		if order.ClosesOrder != nil && *order.ClosesOrder == buyOrder.Id && strings.EqualFold(order.Operation, "SELL") {
+			list = append(list, s.hydrate(order))
+		}
+	}
+
+	return list
+}
+
+func (s *MemoryStorage) GetOpenedOrderCached(symbol string, operation string) *model.Order {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, order := range s.orders {
+		if order.Symbol == symbol && order.IsOpened() && strings.EqualFold(order.Operation, operation) {
+			opened := s.hydrate(order)
+
+			return &opened
+		}
+	}
+
+	return nil
+}
+
+func (s *MemoryStorage) hydrate(order model.Order) model.Order {
+	soldQuantity := 0.00
+	extraOrdersCount := int64(0)
+
+	for _, closing := range s.orders {
+		if closing.ClosesOrder == nil || *closing.ClosesOrder != order.Id {
+			continue
+		}
+
+		if strings.EqualFold(closing.Operation, "SELL") {
+			soldQuantity += closing.ExecutedQuantity
+		} else {
+			extraOrdersCount++
+		}
+	}
+
+	order.SoldQuantity = &soldQuantity
+	order.ExtraOrdersCount = &extraOrdersCount
+	order.CreatedAt = s.Clock.ToWallClock(order.CreatedAt)
+
+	return order
+}
+
+func (s *MemoryStorage) DeleteManualOrder(symbol string) {
+}
+
+func (s *MemoryStorage) GetManualOrder(symbol string) *model.ManualOrder {
+	return nil
+}
+
+func (s *MemoryStorage) SetExchangeOrder(order model.ExchangeOrder) {
+	s.mutex.Lock()
+	s.exchangeOrders[fmt.Sprintf("%s-%s", order.Symbol, strings.ToLower(order.Side))] = order
+	s.mutex.Unlock()
+}
+
+func (s *MemoryStorage) GetExchangeOrder(symbol string, operation string) *model.ExchangeOrder {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	order, ok := s.exchangeOrders[fmt.Sprintf("%s-%s", symbol, strings.ToLower(operation))]
+	if !ok {
+		return nil
+	}
+
+	return &order
+}
+
+func (s *MemoryStorage) DeleteExchangeOrder(order model.ExchangeOrder) {
+	s.mutex.Lock()
+	delete(s.exchangeOrders, fmt.Sprintf("%s-%s", order.Symbol, strings.ToLower(order.Side)))
+	s.mutex.Unlock()
+}
+
+func (s *MemoryStorage) LockBuy(symbol string, seconds int64) {
+	s.mutex.Lock()
+	s.buyLocks[symbol] = s.Clock.GetNowUnix() + seconds
+	s.mutex.Unlock()
+}
+
+func (s *MemoryStorage) HasBuyLock(symbol string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	lockedTill, ok := s.buyLocks[symbol]
+
+	return ok && lockedTill > s.Clock.GetNowUnix()
+}
+
+func (s *MemoryStorage) GetTodayExtraOrderMap() *sync.Map {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	extraOrderMap := sync.Map{}
+	today := time.Unix(s.Clock.GetNowUnix(), 0).UTC().Format("2006-01-02")
+
+	for _, extra := range s.orders {
+		if extra.ClosesOrder == nil || !strings.EqualFold(extra.Operation, "BUY") || !strings.HasPrefix(extra.CreatedAt, today) {
+			continue
+		}
+
+		for _, origin := range s.orders {
+			if origin.Id == *extra.ClosesOrder && origin.IsOpened() {
+				count, _ := extraOrderMap.LoadOrStore(origin.Symbol, float64(0.00))
+				extraOrderMap.Store(origin.Symbol, count.(float64)+1)
+			}
+		}
+	}
+
+	return &extraOrderMap
+}
+
+func (s *MemoryStorage) SetCurrentKline(kLine model.KLine) {
+	s.mutex.Lock()
+	s.kLines = append(s.kLines, kLine)
+	s.mutex.Unlock()
+}
+
+func (s *MemoryStorage) GetCurrentKline(symbol string) *model.KLine {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if len(s.kLines) == 0 || s.kLines[len(s.kLines)-1].Symbol != symbol {
+		return nil
+	}
+
+	kLine := s.kLines[len(s.kLines)-1]
+
+	return &kLine
+}
+
+func (s *MemoryStorage) KLineList(symbol string, reverse bool, size int64) []model.KLine {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	list := make([]model.KLine, 0)
+	for index := len(s.kLines) - 1; index >= 0 && int64(len(list)) < size; index-- {
+		if s.kLines[index].Symbol == symbol {
+			list = append(list, s.kLines[index])
+		}
+	}
+
+	if !reverse {
+		for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+			list[i], list[j] = list[j], list[i]
+		}
+	}
+
+	return list
+}
+
+func (s *MemoryStorage) GetPeriodMinPrice(symbol string, period int64) float64 {
+	minPrice := 0.00
+	for _, kLine := range s.KLineList(symbol, true, period) {
+		if 0.00 == minPrice || kLine.Low.Value() < minPrice {
+			minPrice = kLine.Low.Value()
+		}
+	}
+
+	return minPrice
+}
+
+func (s *MemoryStorage) SetDepth(depth model.OrderBookModel, limit int64, expires int64) {
+	s.mutex.Lock()
+	s.depth = &depth
+	s.mutex.Unlock()
+}
+
+func (s *MemoryStorage) GetDepth(symbol string, limit int64) model.OrderBookModel {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.depth == nil || s.depth.Symbol != symbol {
+		return model.OrderBookModel{
+			Asks:   make([][2]model.Number, 0),
+			Bids:   make([][2]model.Number, 0),
+			Symbol: symbol,
+		}
+	}
+
+	return *s.depth
+}
+
+func (s *MemoryStorage) SetDecision(decision model.Decision, symbol string) {
+	s.mutex.Lock()
+	s.decisions[decision.StrategyName] = decision
+	s.mutex.Unlock()
+}
+
+func (s *MemoryStorage) GetDecisions(symbol string) []model.Decision {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	decisions := make([]model.Decision, 0)
+	for _, decision := range s.decisions {
+		decisions = append(decisions, decision)
+	}
+
+	return decisions
+}
+
+func (s *MemoryStorage) GetTradeLimit(symbol string) (model.TradeLimit, error) {
+	if symbol != s.tradeLimit.Symbol {
+		return model.TradeLimit{}, errors.New(fmt.Sprintf("[%s] Trade limit is not found", symbol))
+	}
+
+	return s.tradeLimit, nil
+}
+
+func (s *MemoryStorage) GetTradeLimitCached(symbol string) *model.TradeLimit {
+	tradeLimit, err := s.GetTradeLimit(symbol)
+	if err != nil {
+		return nil
+	}
+
+	return &tradeLimit
+}
+
+func (s *MemoryStorage) GetTradeLimits() []model.TradeLimit {
+	return []model.TradeLimit{s.tradeLimit}
+}
+
+func (s *MemoryStorage) UpdateTradeLimit(limit model.TradeLimit) error {
+	s.tradeLimit = limit
+
+	return nil
+}
+
+func (s *MemoryStorage) GetPredict(symbol string) (float64, error) {
+	return 0.00, errors.New("predict is not available in backtest")
+}
+
+func (s *MemoryStorage) GetInterpolation(kLine model.KLine) (model.Interpolation, error) {
+	return model.Interpolation{
+		Asset:                kLine.GetBaseAsset(),
+		EthInterpolationUsdt: 0.00,
+		BtcInterpolationUsdt: 0.00,
+	}, errors.New("interpolation is not available in backtest")
+}
+
+func (s *MemoryStorage) CreateSwapPair(swapPair model.SwapPair) (*int64, error) {
+	return nil, errors.New("swap is not available in backtest")
+}
+
+func (s *MemoryStorage) UpdateSwapPair(swapPair model.SwapPair) error {
+	return errors.New("swap is not available in backtest")
+}
+
+func (s *MemoryStorage) GetSwapPair(symbol string) (model.SwapPair, error) {
+	return model.SwapPair{}, errors.New("swap is not available in backtest")
+}
+
+func (s *MemoryStorage) GetSwapPairsByBaseAsset(baseAsset string) []model.SwapPair {
+	return make([]model.SwapPair, 0)
+}
+
+func (s *MemoryStorage) GetSwapPairsByQuoteAsset(quoteAsset string) []model.SwapPair {
+	return make([]model.SwapPair, 0)
+}
+
+func (s *MemoryStorage) GetSwapPairsByAssets(quoteAsset string, baseAsset string) (model.SwapPair, error) {
+	return model.SwapPair{}, errors.New("swap is not available in backtest")
+}
+
+func (s *MemoryStorage) SaveSignal(signal model.Signal) {
+}
+
+func (s *MemoryStorage) GetSignal(symbol string) *model.Signal {
+	return nil
+}
@@ -0,0 +1,428 @@
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var intervalMinutes = map[string]int64{
+	"1m":  1,
+	"3m":  3,
+	"5m":  5,
+	"15m": 15,
+	"30m": 30,
+	"1h":  60,
+	"2h":  120,
+	"4h":  240,
+	"6h":  360,
+	"8h":  480,
+	"12h": 720,
+	"1d":  1440,
+	"1w":  10080,
+}
+
+type Fill struct {
+	Order      model.ExchangeOrder
+	Commission float64
+	Timestamp  model.TimestampMilli
+}
+
+// SimulatedExchange fills limit orders against the next replayed kline, an order which is not
+// reached by the kline price range expires, it never stays in the order book.
+type SimulatedExchange struct {
+	Storage    *MemoryStorage
+	Clock      *Clock
+	FeePercent float64
+	QuoteAsset string
+
+	balances map[string]float64
+	orders   map[string]model.ExchangeOrder
+	fills    []Fill
+	market   *model.KLine
+	sequence int64
+	mutex    sync.RWMutex
+}
+
+func NewSimulatedExchange(storage *MemoryStorage, clock *Clock, quoteAsset string, balance float64, feePercent float64) *SimulatedExchange {
+	return &SimulatedExchange{
+		Storage:    storage,
+		Clock:      clock,
+		FeePercent: feePercent,
+		QuoteAsset: quoteAsset,
+		balances:   map[string]float64{quoteAsset: balance},
+		orders:     make(map[string]model.ExchangeOrder),
+		fills:      make([]Fill, 0),
+	}
+}
+
+func (e *SimulatedExchange) SetMarket(kLine *model.KLine) {
+	e.mutex.Lock()
+	e.market = kLine
+	e.mutex.Unlock()
+}
+
+func (e *SimulatedExchange) GetFills() []Fill {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	list := make([]Fill, len(e.fills))
+	copy(list, e.fills)
+
+	return list
+}
+
+func (e *SimulatedExchange) getBaseAsset(symbol string) string {
+	return strings.TrimSuffix(symbol, e.QuoteAsset)
+}
+
+func (e *SimulatedExchange) LimitOrder(symbol string, quantity float64, price float64, operation string, timeInForce string) (model.ExchangeOrder, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if quantity <= 0.00 || price <= 0.00 {
+		return model.ExchangeOrder{}, errors.New(fmt.Sprintf("Invalid order quantity %f or price %f", quantity, price))
+	}
+
+	baseAsset := e.getBaseAsset(symbol)
+
+	switch operation {
+	case "BUY":
+		if e.balances[e.QuoteAsset] < quantity*price {
+			return model.ExchangeOrder{}, errors.New("Account has insufficient balance for requested action.")
+		}
+		break
+	case "SELL":
+		if e.balances[baseAsset] < quantity {
+			return model.ExchangeOrder{}, errors.New("Account has insufficient balance for requested action.")
+		}
+		break
+	default:
+		return model.ExchangeOrder{}, errors.New(fmt.Sprintf("Unsupported operation %s", operation))
+	}
+
+	e.sequence++
+	order := model.ExchangeOrder{
+		OrderId:      strconv.FormatInt(e.sequence, 10),
+		Symbol:       symbol,
+		TransactTime: e.Clock.GetNowUnix() * 1000,
+		Price:        price,
+		OrigQty:      quantity,
+		ExecutedQty:  0.00,
+		Status:       model.ExchangeOrderStatusExpired,
+		Type:         "LIMIT",
+		Side:         operation,
+		Timestamp:    e.Clock.GetNowUnix() * 1000,
+	}
+
+	if e.market != nil && e.market.Symbol == symbol {
+		if operation == "BUY" && e.market.Low.Value() <= price {
+			order.Price = math.Min(price, e.market.Open.Value())
+			commission := quantity * e.FeePercent / 100
+			e.balances[e.QuoteAsset] -= quantity * order.Price
+			e.balances[baseAsset] += quantity - commission
+			e.fill(&order, commission)
+		}
+
+		if operation == "SELL" && e.market.High.Value() >= price {
+			order.Price = math.Max(price, e.market.Open.Value())
+			commission := quantity * order.Price * e.FeePercent / 100
+			e.balances[baseAsset] -= quantity
+			e.balances[e.QuoteAsset] += quantity*order.Price - commission
+			e.fill(&order, commission)
+		}
+	}
+
+	e.orders[order.OrderId] = order
+
+	return order, nil
+}
+
+func (e *SimulatedExchange) MarketOrder(symbol string, quantity float64, operation string) (model.ExchangeOrder, error) {
+	return e.marketOrder(symbol, quantity, 0.00, operation)
+}
+
+func (e *SimulatedExchange) QuoteMarketOrder(symbol string, quoteQuantity float64, operation string) (model.ExchangeOrder, error) {
+	return e.marketOrder(symbol, 0.00, quoteQuantity, operation)
+}
+
+// marketOrder is filled by open price of the next replayed kline
+func (e *SimulatedExchange) marketOrder(symbol string, quantity float64, quoteQuantity float64, operation string) (model.ExchangeOrder, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.market == nil || e.market.Symbol != symbol {
+		return model.ExchangeOrder{}, errors.New(fmt.Sprintf("Market %s is unknown", symbol))
+	}
+
+	price := e.market.Open.Value()
+	if quantity == 0.00 && price > 0.00 {
+		quantity = quoteQuantity / price
+	}
+
+	if quantity <= 0.00 || price <= 0.00 {
+		return model.ExchangeOrder{}, errors.New(fmt.Sprintf("Invalid order quantity %f or price %f", quantity, price))
+	}
+
+	baseAsset := e.getBaseAsset(symbol)
+	var commission float64
+
+	switch operation {
+	case "BUY":
+		if e.balances[e.QuoteAsset] < quantity*price {
+			return model.ExchangeOrder{}, errors.New("Account has insufficient balance for requested action.")
+		}
+		commission = quantity * e.FeePercent / 100
+		e.balances[e.QuoteAsset] -= quantity * price
+		e.balances[baseAsset] += quantity - commission
+		break
+	case "SELL":
+		if e.balances[baseAsset] < quantity {
+			return model.ExchangeOrder{}, errors.New("Account has insufficient balance for requested action.")
+		}
+		commission = quantity * price * e.FeePercent / 100
+		e.balances[baseAsset] -= quantity
+		e.balances[e.QuoteAsset] += quantity*price - commission
+		break
+	default:
+		return model.ExchangeOrder{}, errors.New(fmt.Sprintf("Unsupported operation %s", operation))
+	}
+
+	e.sequence++
+	order := model.ExchangeOrder{
+		OrderId:      strconv.FormatInt(e.sequence, 10),
+		Symbol:       symbol,
+		TransactTime: e.Clock.GetNowUnix() * 1000,
+		Price:        price,
+		OrigQty:      quantity,
+		Type:         model.OrderTypeMarket,
+		Side:         operation,
+		Timestamp:    e.Clock.GetNowUnix() * 1000,
+	}
+	e.fill(&order, commission)
+	e.orders[order.OrderId] = order
+
+	return order, nil
+}
+
+func (e *SimulatedExchange) PostOnlyOrder(symbol string, quantity float64, price float64, operation string) (model.ExchangeOrder, error) {
+	e.mutex.RLock()
+	market := e.market
+	e.mutex.RUnlock()
+
+	if market != nil && market.Symbol == symbol {
+		if operation == "BUY" && market.Open.Value() <= price {
+			return model.ExchangeOrder{}, errors.New("Order would immediately match and take.")
+		}
+		if operation == "SELL" && market.Open.Value() >= price {
+			return model.ExchangeOrder{}, errors.New("Order would immediately match and take.")
+		}
+	}
+
+	order, err := e.LimitOrder(symbol, quantity, price, operation, "GTC")
+	if err != nil {
+		return order, err
+	}
+
+	e.mutex.Lock()
+	order.Type = model.OrderTypeLimitMaker
+	e.orders[order.OrderId] = order
+	e.mutex.Unlock()
+
+	return order, nil
+}
+
+func (e *SimulatedExchange) fill(order *model.ExchangeOrder, commission float64) {
+	order.Status = model.ExchangeOrderStatusFilled
+	order.ExecutedQty = order.OrigQty
+	order.CummulativeQuoteQty = order.OrigQty * order.Price
+	order.WorkingTime = e.market.Timestamp.Value()
+
+	e.fills = append(e.fills, Fill{
+		Order:      *order,
+		Commission: commission,
+		Timestamp:  e.market.Timestamp,
+	})
+}
+
+func (e *SimulatedExchange) QueryOrder(symbol string, orderId string) (model.ExchangeOrder, error) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	order, ok := e.orders[orderId]
+	if !ok || order.Symbol != symbol {
+		return model.ExchangeOrder{}, errors.New("Order does not exist.")
+	}
+
+	return order, nil
+}
+
+func (e *SimulatedExchange) CancelOrder(symbol string, orderId string) (model.ExchangeOrder, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	order, ok := e.orders[orderId]
+	if !ok || order.Symbol != symbol {
+		return model.ExchangeOrder{}, errors.New("Order does not exist.")
+	}
+
+	if !order.IsNew() && !order.IsPartiallyFilled() {
+		return order, errors.New("Order was not canceled due to cancel restrictions.")
+	}
+
+	order.Status = model.ExchangeOrderStatusCanceled
+	e.orders[orderId] = order
+
+	return order, nil
+}
+
+func (e *SimulatedExchange) GetOpenedOrders() ([]model.ExchangeOrder, error) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	opened := make([]model.ExchangeOrder, 0)
+	for _, order := range e.orders {
+		if order.IsNew() || order.IsPartiallyFilled() {
+			opened = append(opened, order)
+		}
+	}
+
+	return opened, nil
+}
+
+func (e *SimulatedExchange) GetDepth(symbol string, limit int64) *model.OrderBook {
+	depth := e.Storage.GetDepth(symbol, limit)
+
+	return &model.OrderBook{
+		Bids: depth.Bids,
+		Asks: depth.Asks,
+	}
+}
+
+func (e *SimulatedExchange) GetKLines(symbol string, interval string, limit int64) []model.KLineHistory {
+	history := make([]model.KLineHistory, 0)
+
+	for _, kLine := range e.GetKLinesCached(symbol, interval, limit) {
+		history = append(history, model.KLineHistory{
+			OpenTime:  kLine.OpenTime,
+			Open:      strconv.FormatFloat(kLine.Open.Value(), 'f', -1, 64),
+			High:      strconv.FormatFloat(kLine.High.Value(), 'f', -1, 64),
+			Low:       strconv.FormatFloat(kLine.Low.Value(), 'f', -1, 64),
+			Close:     strconv.FormatFloat(kLine.Close.Value(), 'f', -1, 64),
+			Volume:    strconv.FormatFloat(kLine.Volume.Value(), 'f', -1, 64),
+			CloseTime: kLine.Timestamp,
+		})
+	}
+
+	return history
+}
+
+func (e *SimulatedExchange) TradesAggregate(symbol string, limit int64, startTime int64, endTime int64) []model.Trade {
+	return make([]model.Trade, 0)
+}
+
+// GetKLinesCached builds the requested interval from replayed 1m klines, the last one is not closed yet.
+func (e *SimulatedExchange) GetKLinesCached(symbol string, interval string, limit int64) []model.KLine {
+	minutes, ok := intervalMinutes[interval]
+	if !ok || limit <= 0 {
+		return make([]model.KLine, 0)
+	}
+
+	intervalMilli := minutes * 60 * 1000
+	kLines := make([]model.KLine, 0)
+
+	for _, minute := range e.Storage.KLineList(symbol, false, minutes*limit+minutes) {
+		openTime := minute.OpenTime.Value() - minute.OpenTime.Value()%intervalMilli
+
+		if len(kLines) > 0 && kLines[len(kLines)-1].OpenTime.Value() == openTime {
+			last := &kLines[len(kLines)-1]
+			last.Close = minute.Close
+			last.High = model.Price(math.Max(last.High.Value(), minute.High.Value()))
+			last.Low = model.Price(math.Min(last.Low.Value(), minute.Low.Value()))
+			last.Volume += minute.Volume
+			last.Timestamp = minute.Timestamp
+			last.UpdatedAt = minute.UpdatedAt
+			continue
+		}
+
+		kLine := minute
+		kLine.Interval = interval
+		kLine.OpenTime = model.TimestampMilli(openTime)
+		kLines = append(kLines, kLine)
+	}
+
+	if int64(len(kLines)) > limit {
+		kLines = kLines[int64(len(kLines))-limit:]
+	}
+
+	return kLines
+}
+
+func (e *SimulatedExchange) GetExchangeData(symbols []string) (*model.ExchangeInfo, error) {
+	return &model.ExchangeInfo{
+		Symbols: make([]model.ExchangeSymbol, 0),
+	}, nil
+}
+
+func (e *SimulatedExchange) GetAccountStatus() (*model.AccountStatus, error) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	balances := make([]model.Balance, 0)
+	for asset, free := range e.balances {
+		balances = append(balances, model.Balance{
+			Asset:  asset,
+			Free:   free,
+			Locked: 0.00,
+		})
+	}
+
+	return &model.AccountStatus{
+		Balances: balances,
+	}, nil
+}
+
+func (e *SimulatedExchange) GetTickers(symbols []string) []model.ExchangeTicker {
+	tickers := make([]model.ExchangeTicker, 0)
+
+	for _, symbol := range symbols {
+		kLine := e.Storage.GetCurrentKline(symbol)
+		if kLine != nil {
+			tickers = append(tickers, model.ExchangeTicker{
+				Symbol: symbol,
+				Price:  kLine.Close.Value(),
+			})
+		}
+	}
+
+	return tickers
+}
+
+func (e *SimulatedExchange) IsConnected() bool {
+	return true
+}
+
+func (e *SimulatedExchange) IsWaitMode() bool {
+	return false
+}
+
+func (e *SimulatedExchange) IsAPIKeyCheckCompleted() bool {
+	return true
+}
+
+func (e *SimulatedExchange) SetAPIKeyCheckCompleted(completed bool) {
+}
+
+func (e *SimulatedExchange) GetAssetBalance(asset string, cache bool) (float64, error) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.balances[asset], nil
+}
+
+func (e *SimulatedExchange) InvalidateBalanceCache(asset string) {
+}
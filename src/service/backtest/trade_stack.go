@@ -0,0 +1,51 @@
+package backtest
+
+import (
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+)
+
+// TradeStack is a single symbol version of exchange.TradeStack.CanBuy
+type TradeStack struct {
+	Storage            *MemoryStorage
+	BalanceService     exchange.BalanceServiceInterface
+	TradeFilterService exchange.TradeFilterServiceInterface
+}
+
+func (t *TradeStack) CanBuy(limit model.TradeLimit) bool {
+	if t.Storage.GetExchangeOrder(limit.Symbol, "BUY") != nil {
+		return true
+	}
+
+	if !limit.IsEnabled || t.Storage.HasBuyLock(limit.Symbol) {
+		return false
+	}
+
+	kLine := t.Storage.GetCurrentKline(limit.Symbol)
+	if kLine == nil || kLine.IsPriceExpired() {
+		return false
+	}
+
+	budget := limit.USDTLimit
+	opened := t.Storage.GetOpenedOrderCached(limit.Symbol, "BUY")
+
+	if opened == nil {
+		if !t.TradeFilterService.CanBuy(limit) {
+			return false
+		}
+	} else {
+		if !t.TradeFilterService.CanExtraBuy(limit) || !opened.CanExtraBuy(*kLine, false) {
+			return false
+		}
+
+		if opened.GetProfitPercent(kLine.Close.Value(), false).Gt(limit.GetBuyOnFallPercent(*opened, *kLine, false)) {
+			return false
+		}
+
+		budget = opened.GetAvailableExtraBudget(*kLine, false)
+	}
+
+	balance, err := t.BalanceService.GetAssetBalance(limit.GetQuoteAsset(), true)
+
+	return err == nil && balance >= budget
+}
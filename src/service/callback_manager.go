@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"io"
-	"log"
 	"net/http"
 	"strings"
 )
@@ -16,67 +15,51 @@ type CallbackManagerInterface interface {
 	Error(bot model.Bot, code string, message string, stop bool)
 	SellOrder(order model.Order, bot model.Bot, details string)
 	BuyOrder(order model.Order, bot model.Bot, details string)
+	Notify(notification model.Notification)
 }
 
+// CallbackManager is the notification channel of autotrade.cloud, telegram messages are sent by the SaaS backend
 type CallbackManager struct {
 	AutoTradeHost string
 }
 
-func (t *CallbackManager) Error(bot model.Bot, code string, message string, stop bool) {
-	encoded, _ := json.Marshal(model.ErrorNotification{
-		BotUuid:      bot.BotUuid,
-		Stop:         stop,
-		ErrorCode:    code,
-		ErrorMessage: message,
-	})
-	err := t.Send("/callback/error", encoded)
-	if err == nil {
-		log.Printf("[%s] Error notification sent", message)
-	} else {
-		log.Printf("[%s] Error notification failed: %s", message, err.Error())
-	}
-}
+func (t *CallbackManager) Notify(notification model.Notification) error {
+	switch notification.Event {
+	case model.NotificationEventError, model.NotificationEventRiskLimit:
+		encoded, _ := json.Marshal(model.ErrorNotification{
+			BotUuid:      notification.BotUuid,
+			Stop:         notification.Stop,
+			ErrorCode:    notification.Code,
+			ErrorMessage: notification.Message,
+		})
 
-func (t *CallbackManager) SellOrder(order model.Order, bot model.Bot, details string) {
-	encoded, _ := json.Marshal(model.TgOrderNotification{
-		BotUuid:   bot.BotUuid,
-		Price:     order.Price,
-		Quantity:  order.Quantity,
-		Symbol:    order.Symbol,
-		Operation: strings.ToUpper(order.Operation),
-		DateTime:  order.CreatedAt,
-		Details:   details,
-	})
-	err := t.Send("/callback/telegram", encoded)
-	if err == nil {
-		log.Printf("[%s] Telegram SELL notification sent", order.Symbol)
-	} else {
-		log.Printf("[%s] Telegram notification failed: %s", order.Symbol, err.Error())
-	}
-}
+		return t.Send("/callback/error", encoded)
+	case model.NotificationEventBuy, model.NotificationEventSell, model.NotificationEventExtraCharge:
+		if notification.Order == nil {
+			return nil
+		}
 
-func (t *CallbackManager) BuyOrder(order model.Order, bot model.Bot, details string) {
-	encoded, _ := json.Marshal(model.TgOrderNotification{
-		BotUuid:   bot.BotUuid,
-		Price:     order.Price,
-		Quantity:  order.Quantity,
-		Symbol:    order.Symbol,
-		Operation: strings.ToUpper(order.Operation),
-		DateTime:  order.CreatedAt,
-		Details:   details,
-	})
-	err := t.Send("/callback/telegram", encoded)
-	if err == nil {
-		log.Printf("[%s] Telegram BUY notification sent", order.Symbol)
-	} else {
-		log.Printf("[%s] Telegram notification failed: %s", order.Symbol, err.Error())
+		order := notification.Order
+		encoded, _ := json.Marshal(model.TgOrderNotification{
+			BotUuid:   notification.BotUuid,
+			Price:     order.Price,
+			Quantity:  order.Quantity,
+			Symbol:    order.Symbol,
+			Operation: strings.ToUpper(order.Operation),
+			DateTime:  order.CreatedAt,
+			Details:   notification.Details,
+		})
+
+		return t.Send("/callback/telegram", encoded)
 	}
+
+	return nil
 }
 
 func (t *CallbackManager) Send(path string, message []byte) error {
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/public%s", t.AutoTradeHost, path), bytes.NewReader(message))
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
@@ -272,12 +272,12 @@ func (e *ChartService) ProcessSymbol(symbol string) map[string][]any {
 			}
 		}
 
-		binanceBuyOrder := e.OrderRepository.GetBinanceOrder(symbol, "BUY")
+		binanceBuyOrder := e.OrderRepository.GetExchangeOrder(symbol, "BUY")
 		if binanceBuyOrder != nil && (binanceBuyOrder.IsNew() || binanceBuyOrder.IsPartiallyFilled()) {
 			buyPendingPoint.YAxis = binanceBuyOrder.Price
 		}
 
-		binanceSellOrder := e.OrderRepository.GetBinanceOrder(symbol, "SELL")
+		binanceSellOrder := e.OrderRepository.GetExchangeOrder(symbol, "SELL")
 		if binanceSellOrder != nil && (binanceSellOrder.IsNew() || binanceSellOrder.IsPartiallyFilled()) {
 			sellPendingPoint.YAxis = binanceSellOrder.Price
 		}
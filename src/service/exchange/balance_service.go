@@ -7,7 +7,8 @@ import (
 	"github.com/redis/go-redis/v9"
 	"gitlab.com/open-soft/go-crypto-bot/src/client"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
-	"log"
+	"log/slog"
+	"slices"
 	"strconv"
 	"time"
 )
@@ -17,6 +18,10 @@ type BalanceServiceInterface interface {
 	InvalidateBalanceCache(asset string)
 }
 
+type BalanceUpdaterInterface interface {
+	UpdateBalances(balances []model.Balance)
+}
+
 type BalanceService struct {
 	RDB        *redis.Client
 	Ctx        *context.Context
@@ -88,8 +93,7 @@ func (b *BalanceService) GetAssetBalance(asset string, cache bool) (float64, err
 
 	for _, assetBalance := range accountInfo.Balances {
 		if assetBalance.Asset == asset {
-			log.Printf("[%s] Free balance is: %f", asset, assetBalance.Free)
-			log.Printf("[%s] Locked balance is: %f", asset, assetBalance.Locked)
+			slog.Info("Balance is loaded", "asset", asset, "free", assetBalance.Free, "locked", assetBalance.Locked)
 
 			b.RDB.Set(*b.Ctx, b.getBalanceCacheKey(asset), assetBalance.Free, time.Minute)
 			return assetBalance.Free, nil
@@ -99,6 +103,41 @@ func (b *BalanceService) GetAssetBalance(asset string, cache bool) (float64, err
 	return 0.00, nil
 }
 
+// UpdateBalances applies balance changes pushed by user data stream to the cached account status
+func (b *BalanceService) UpdateBalances(balances []model.Balance) {
+	for _, balance := range balances {
+		b.RDB.Set(*b.Ctx, b.getBalanceCacheKey(balance.Asset), balance.Free, time.Minute)
+	}
+
+	cached := b.RDB.Get(*b.Ctx, b.getAccountCacheKey()).Val()
+	if len(cached) == 0 {
+		return
+	}
+
+	var account model.AccountStatus
+	err := json.Unmarshal([]byte(cached), &account)
+	if err != nil {
+		b.RDB.Del(*b.Ctx, b.getAccountCacheKey())
+		return
+	}
+
+	for _, balance := range balances {
+		index := slices.IndexFunc(account.Balances, func(item model.Balance) bool {
+			return item.Asset == balance.Asset
+		})
+
+		if index >= 0 {
+			account.Balances[index] = balance
+		} else {
+			account.Balances = append(account.Balances, balance)
+		}
+	}
+
+	if encoded, err := json.Marshal(account); err == nil {
+		b.RDB.Set(*b.Ctx, b.getAccountCacheKey(), encoded, time.Minute)
+	}
+}
+
 func (b *BalanceService) getBalanceCacheKey(asset string) string {
 	return fmt.Sprintf("balance-%s-account-%d", asset, b.CurrentBot.Id)
 }
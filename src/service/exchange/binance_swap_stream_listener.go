@@ -5,9 +5,10 @@ import (
 	"fmt"
 	"github.com/gorilla/websocket"
 	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
-	"log"
+	"log/slog"
 	"strings"
 	"sync"
 	"time"
@@ -94,7 +95,7 @@ func (s *BinanceSwapStreamListener) StartListening() {
 				strings.Join(sbi, "/"),
 			), swapKlineChannel, []string{}, 10000+int64(i)))
 			lock.Unlock()
-			log.Printf("Swap batch %d websocket: %d connected", i, len(sbi))
+			slog.Info("Websocket batch connected", logger.KeyExchange, "binance", logger.KeyStream, "swap", "batch", i, "streams", len(sbi))
 		}(streamBatchItem, index)
 	}
 
@@ -0,0 +1,121 @@
+package exchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/metrics"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// UserDataKeepAliveInterval listen key expires in 60 minutes without keepalive
+const UserDataKeepAliveInterval = 30
+
+type BinanceUserDataStream struct {
+	Binance   *client.Binance
+	Processor *UserDataProcessor
+	DSN       string
+}
+
+func (s *BinanceUserDataStream) StartListening() {
+	eventChannel := make(chan []byte, 1000)
+
+	go func() {
+		for {
+			s.handleEvent(<-eventChannel)
+		}
+	}()
+
+	for {
+		userDataStream, err := s.Binance.UserDataStreamStart()
+		if err != nil {
+			slog.Error("Listen key error, wait and retry", logger.KeyExchange, "binance", logger.KeyStream, "user_data", logger.Error(err))
+			time.Sleep(time.Minute)
+			continue
+		}
+
+		connection, done, err := client.ListenPrivate(
+			fmt.Sprintf("%s/ws/%s", s.DSN, userDataStream.ListenKey),
+			eventChannel,
+			[][]byte{},
+			nil,
+		)
+		if err != nil {
+			slog.Error("Connection error, wait and retry", logger.KeyExchange, "binance", logger.KeyStream, "user_data", logger.Error(err))
+			time.Sleep(time.Minute)
+			continue
+		}
+
+		s.Processor.SetConnected(true)
+		slog.Info("Stream is connected", logger.KeyExchange, "binance", logger.KeyStream, "user_data")
+
+		s.keepAlive(userDataStream.ListenKey, done)
+		_ = connection.Close()
+
+		s.Processor.SetConnected(false)
+		slog.Warn("Stream is disconnected, reconnect", logger.KeyExchange, "binance", logger.KeyStream, "user_data")
+		metrics.WSReconnects.Inc("binance", "user_data")
+		time.Sleep(time.Second * 3)
+	}
+}
+
+func (s *BinanceUserDataStream) keepAlive(listenKey string, done <-chan bool) {
+	for {
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Minute * UserDataKeepAliveInterval):
+			err := s.Binance.UserDataStreamPing(listenKey)
+			if err != nil {
+				slog.Error("Keepalive error", logger.KeyExchange, "binance", logger.KeyStream, "user_data", logger.Error(err))
+				return
+			}
+		}
+	}
+}
+
+func (s *BinanceUserDataStream) handleEvent(message []byte) {
+	var event model.BinanceUserDataEvent
+	err := json.Unmarshal(message, &event)
+	if err != nil {
+		return
+	}
+
+	switch event.EventType {
+	case model.BinanceUserDataEventExecutionReport:
+		var report model.BinanceExecutionReport
+		err = json.Unmarshal(message, &report)
+		if err != nil {
+			slog.Error("Execution report error", logger.KeyExchange, "binance", logger.KeyStream, "user_data", logger.Error(err))
+			return
+		}
+
+		order, err := report.ToExchangeOrder()
+		if err != nil {
+			slog.Error("Execution report is not valid", logger.KeyExchange, "binance", logger.KeyStream, "user_data", logger.KeySymbol, report.Symbol, logger.Error(err))
+			return
+		}
+
+		if report.LastExecutedQty > 0.00 {
+			slog.Info("Order fill", logger.KeyExchange, "binance", logger.KeyStream, "user_data", logger.KeySymbol, order.Symbol, logger.KeyOperation, strings.ToLower(order.Side), logger.KeyExchangeOrderId, order.OrderId, "quantity", report.LastExecutedQty, "price", report.LastExecutedPrice)
+		}
+
+		s.Processor.ProcessOrder(order)
+	case model.BinanceUserDataEventAccountPosition:
+		var position model.BinanceAccountPosition
+		err = json.Unmarshal(message, &position)
+		if err != nil {
+			slog.Error("Account position error", logger.KeyExchange, "binance", logger.KeyStream, "user_data", logger.Error(err))
+			return
+		}
+
+		s.Processor.ProcessBalances(position.GetBalances())
+	case model.BinanceUserDataEventListenKeyExpired:
+		slog.Warn("Listen key is expired", logger.KeyExchange, "binance", logger.KeyStream, "user_data")
+		s.Processor.SetConnected(false)
+	}
+}
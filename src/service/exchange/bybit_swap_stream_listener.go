@@ -4,10 +4,11 @@ import (
 	"encoding/json"
 	"github.com/gorilla/websocket"
 	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
 	"gitlab.com/open-soft/go-crypto-bot/src/utils"
-	"log"
+	"log/slog"
 	"os"
 	"strings"
 	"sync"
@@ -45,7 +46,7 @@ func (s *BybitSwapStreamListener) StartListening() {
 						swapSymbol = kLine.Symbol
 					}
 				} else {
-					log.Printf("Swap Kline error bybit: %s", err.Error())
+					slog.Error("Kline error", logger.KeyExchange, "bybit", logger.KeyStream, "swap", logger.Error(err))
 				}
 			}
 
@@ -58,7 +59,7 @@ func (s *BybitSwapStreamListener) StartListening() {
 					s.ExchangeRepository.SetDepth(depth, 20, 25)
 					swapSymbol = depth.Symbol
 				} else {
-					log.Printf("Swap Order book error bybit: %s", err.Error())
+					slog.Error("Order book error", logger.KeyExchange, "bybit", logger.KeyStream, "swap", logger.Error(err))
 				}
 			}
 
@@ -97,7 +98,7 @@ func (s *BybitSwapStreamListener) StartListening() {
 			lock.Lock()
 			swapWebsockets = append(swapWebsockets, client.ListenByBit(os.Getenv("BYBIT_STREAM_DSN"), swapKlineChannel, sbi, int64(i)))
 			lock.Unlock()
-			log.Printf("Batch %d websocket: %d connected", i, len(sbi))
+			slog.Info("Websocket batch connected", logger.KeyExchange, "bybit", logger.KeyStream, "swap", "batch", i, "streams", len(sbi))
 		}(streamBatchItem, index)
 	}
 
@@ -0,0 +1,134 @@
+package exchange
+
+import (
+	"encoding/json"
+	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/metrics"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"log/slog"
+	"time"
+)
+
+type ByBitUserDataStream struct {
+	ByBit     *client.ByBit
+	Processor *UserDataProcessor
+	Formatter *utils.Formatter
+	DSN       string
+}
+
+func (s *ByBitUserDataStream) StartListening() {
+	eventChannel := make(chan []byte, 1000)
+
+	go func() {
+		for {
+			s.handleEvent(<-eventChannel)
+		}
+	}()
+
+	for {
+		auth, _ := json.Marshal(s.ByBit.GetWsAuthRequest())
+		subscribe, _ := json.Marshal(model.ByBitSocketStreamsRequest{
+			Operation: "subscribe",
+			Arguments: []string{
+				model.ByBitUserDataTopicOrder,
+				model.ByBitUserDataTopicExecution,
+				model.ByBitUserDataTopicWallet,
+			},
+		})
+
+		connection, done, err := client.ListenPrivate(s.DSN, eventChannel, [][]byte{auth, subscribe}, []byte(`{"op":"ping"}`))
+		if err != nil {
+			slog.Error("Connection error, wait and retry", logger.KeyExchange, "bybit", logger.KeyStream, "user_data", logger.Error(err))
+			time.Sleep(time.Minute)
+			continue
+		}
+
+		<-done
+		_ = connection.Close()
+
+		s.Processor.SetConnected(false)
+		slog.Warn("Stream is disconnected, reconnect", logger.KeyExchange, "bybit", logger.KeyStream, "user_data")
+		metrics.WSReconnects.Inc("bybit", "user_data")
+		time.Sleep(time.Second * 3)
+	}
+}
+
+func (s *ByBitUserDataStream) handleEvent(message []byte) {
+	var event model.ByBitWsUserDataEvent
+	err := json.Unmarshal(message, &event)
+	if err != nil {
+		return
+	}
+
+	switch event.Topic {
+	case "":
+		var response model.ByBitWsOperationResponse
+		err = json.Unmarshal(message, &response)
+		if err != nil || response.Operation != "auth" {
+			return
+		}
+
+		// updates are trusted only after successful authorization
+		if response.Success {
+			slog.Info("Stream is connected", logger.KeyExchange, "bybit", logger.KeyStream, "user_data")
+		} else {
+			slog.Error("Authorization failed", logger.KeyExchange, "bybit", logger.KeyStream, "user_data", logger.KeyError, response.Message)
+		}
+		s.Processor.SetConnected(response.Success)
+	case model.ByBitUserDataTopicOrder:
+		var orderEvent model.ByBitWsOrderEvent
+		err = json.Unmarshal(message, &orderEvent)
+		if err != nil {
+			slog.Error("Order event error", logger.KeyExchange, "bybit", logger.KeyStream, "user_data", logger.Error(err))
+			return
+		}
+
+		for _, byBitOrder := range orderEvent.Data {
+			if byBitOrder.Category != "spot" {
+				continue
+			}
+
+			order, err := s.Formatter.ByBitOrderToExchangeOrder(byBitOrder)
+			if err != nil {
+				slog.Error("Order event is not valid", logger.KeyExchange, "bybit", logger.KeyStream, "user_data", logger.KeySymbol, byBitOrder.Symbol, logger.Error(err))
+				continue
+			}
+
+			s.Processor.ProcessOrder(order)
+		}
+	case model.ByBitUserDataTopicExecution:
+		var executionEvent model.ByBitWsExecutionEvent
+		err = json.Unmarshal(message, &executionEvent)
+		if err != nil {
+			slog.Error("Execution event error", logger.KeyExchange, "bybit", logger.KeyStream, "user_data", logger.Error(err))
+			return
+		}
+
+		// order state is updated by order topic, executions are logged only
+		for _, execution := range executionEvent.Data {
+			slog.Info("Order fill", logger.KeyExchange, "bybit", logger.KeyStream, "user_data", logger.KeySymbol, execution.Symbol, logger.KeyExchangeOrderId, execution.OrderId, "quantity", execution.ExecQty, "price", execution.ExecPrice)
+		}
+	case model.ByBitUserDataTopicWallet:
+		var walletEvent model.ByBitWsWalletEvent
+		err = json.Unmarshal(message, &walletEvent)
+		if err != nil {
+			slog.Error("Wallet event error", logger.KeyExchange, "bybit", logger.KeyStream, "user_data", logger.Error(err))
+			return
+		}
+
+		balances := make([]model.Balance, 0)
+		for _, wallet := range walletEvent.Data {
+			if wallet.AccountType != model.ByBitAccountTypeUnified {
+				continue
+			}
+
+			for _, coin := range wallet.Coin {
+				balances = append(balances, coin.ToBalance())
+			}
+		}
+
+		s.Processor.ProcessBalances(balances)
+	}
+}
@@ -0,0 +1,84 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"gitlab.com/open-soft/go-crypto-bot/src/service"
+)
+
+type CurrencyConverterInterface interface {
+	Convert(amount float64, fromAsset string, toAsset string) (float64, error)
+	ToReporting(amount float64, asset string) (float64, error)
+	GetReportingCurrency() string
+}
+
+// CurrencyConverter converts amounts between quote assets (USDT, USDC, FDUSD, BTC...) by the last exchange price,
+// it is used to sum budgets and PnL of different quote assets in the bot reporting currency
+type CurrencyConverter struct {
+	Binance            client.ExchangePriceAPIInterface
+	ExchangeRepository repository.BaseTradeStorageInterface
+	BotService         service.BotServiceInterface
+}
+
+func (c *CurrencyConverter) GetReportingCurrency() string {
+	bot := c.BotService.GetBot()
+
+	return bot.GetReportingCurrency()
+}
+
+func (c *CurrencyConverter) ToReporting(amount float64, asset string) (float64, error) {
+	return c.Convert(amount, asset, c.GetReportingCurrency())
+}
+
+func (c *CurrencyConverter) Convert(amount float64, fromAsset string, toAsset string) (float64, error) {
+	if fromAsset == toAsset || amount == 0.00 {
+		return amount, nil
+	}
+
+	rate, err := c.getRate(fromAsset, toAsset)
+	if err == nil {
+		return amount * rate, nil
+	}
+
+	// no direct pair, example: FDUSD -> BTC is converted through USDT
+	if fromAsset != model.DefaultQuoteAsset && toAsset != model.DefaultQuoteAsset {
+		fromRate, fromErr := c.getRate(fromAsset, model.DefaultQuoteAsset)
+		toRate, toErr := c.getRate(model.DefaultQuoteAsset, toAsset)
+		if fromErr == nil && toErr == nil {
+			return amount * fromRate * toRate, nil
+		}
+	}
+
+	return 0.00, err
+}
+
+func (c *CurrencyConverter) getRate(fromAsset string, toAsset string) (float64, error) {
+	price := c.getPrice(fromAsset + toAsset)
+	if price > 0.00 {
+		return price, nil
+	}
+
+	price = c.getPrice(toAsset + fromAsset)
+	if price > 0.00 {
+		return 1 / price, nil
+	}
+
+	return 0.00, errors.New(fmt.Sprintf("Conversion rate %s -> %s is not available", fromAsset, toAsset))
+}
+
+func (c *CurrencyConverter) getPrice(symbol string) float64 {
+	kLine := c.ExchangeRepository.GetCurrentKline(symbol)
+	if kLine != nil && !kLine.IsPriceExpired() {
+		return kLine.Close.Value()
+	}
+
+	kLines := c.Binance.GetKLinesCached(symbol, "1m", 1)
+	if len(kLines) > 0 {
+		return kLines[len(kLines)-1].Close.Value()
+	}
+
+	return 0.00
+}
@@ -0,0 +1,47 @@
+package exchange
+
+import (
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"strconv"
+)
+
+const DailySummaryDefaultHour = 23
+
+// DailySummaryService sends portfolio summary once a day within the configured hour, negative hour disables it
+type DailySummaryService struct {
+	RiskManager     RiskManagerInterface
+	BotService      service.BotServiceInterface
+	CallbackManager service.CallbackManagerInterface
+	TimeService     utils.TimeServiceInterface
+	Hour            int64
+	sentDate        string
+}
+
+func (d *DailySummaryService) StartWatching() {
+	if d.Hour < 0 {
+		return
+	}
+
+	for {
+		d.Check()
+		d.TimeService.WaitSeconds(60)
+	}
+}
+
+// Check returns true if summary is sent
+func (d *DailySummaryService) Check() bool {
+	now := d.TimeService.GetNowDateTimeString()
+	today := now[0:10]
+	hour, _ := strconv.ParseInt(now[11:13], 10, 64)
+
+	if d.sentDate == today || hour != d.Hour {
+		return false
+	}
+
+	d.sentDate = today
+	d.CallbackManager.Notify(model.NewDailySummaryNotification(d.BotService.GetBot(), today, d.RiskManager.GetStatus()))
+
+	return true
+}
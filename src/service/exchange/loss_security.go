@@ -1,14 +1,15 @@
 package exchange
 
 import (
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
 	"gitlab.com/open-soft/go-crypto-bot/src/utils"
-	"log"
+	"log/slog"
 )
 
 type LossSecurityInterface interface {
-	IsRiskyBuy(binanceOrder model.BinanceOrder, limit model.TradeLimit) bool
+	IsRiskyBuy(exchangeOrder model.ExchangeOrder, limit model.TradeLimit) bool
 	BuyPriceCorrection(price float64, limit model.TradeLimit) float64
 	CheckBuyPriceOnHistory(limit model.TradeLimit, buyPrice float64) float64
 }
@@ -21,38 +22,29 @@ type LossSecurity struct {
 	ProfitService        ProfitServiceInterface
 }
 
-func (l *LossSecurity) IsRiskyBuy(binanceOrder model.BinanceOrder, limit model.TradeLimit) bool {
-	kline := l.ExchangeRepository.GetCurrentKline(binanceOrder.Symbol)
+func (l *LossSecurity) IsRiskyBuy(exchangeOrder model.ExchangeOrder, limit model.TradeLimit) bool {
+	kline := l.ExchangeRepository.GetCurrentKline(exchangeOrder.Symbol)
+	riskLog := slog.With(logger.KeySymbol, exchangeOrder.Symbol, logger.KeyExchangeOrderId, exchangeOrder.OrderId)
 
-	if kline != nil && binanceOrder.IsBuy() && binanceOrder.IsNew() {
+	if kline != nil && exchangeOrder.IsBuy() && exchangeOrder.IsNew() {
 		if l.MlEnabled {
 			predict, predictErr := l.ExchangeRepository.GetPredict(kline.Symbol)
-			if predictErr == nil && binanceOrder.Price > l.Formatter.FormatPrice(limit, predict) {
-				log.Printf(
-					"[%s] ML RISK detected: %f > %f",
-					binanceOrder.Symbol,
-					binanceOrder.Price,
-					l.Formatter.FormatPrice(limit, predict),
-				)
+			if predictErr == nil && exchangeOrder.Price > l.Formatter.FormatPrice(limit, predict) {
+				riskLog.Info("ML risk detected", "price", exchangeOrder.Price, "limitPrice", l.Formatter.FormatPrice(limit, predict))
 
 				return true
 			}
 		}
 
-		if binanceOrder.Price > l.Formatter.FormatPrice(limit, kline.Close.Value()) {
-			fallPercent := model.Percent(100.00 - l.Formatter.ComparePercentage(binanceOrder.Price, kline.Close.Value()).Value())
-			minPrice := l.ExchangeRepository.GetPeriodMinPrice(binanceOrder.Symbol, 200)
+		if exchangeOrder.Price > l.Formatter.FormatPrice(limit, kline.Close.Value()) {
+			fallPercent := model.Percent(100.00 - l.Formatter.ComparePercentage(exchangeOrder.Price, kline.Close.Value()).Value())
+			minPrice := l.ExchangeRepository.GetPeriodMinPrice(exchangeOrder.Symbol, 200)
 
 			cancelFallPercent := model.Percent(model.MinProfitPercent)
 
 			// If falls more than (min - 0.5%) cancel current
 			if fallPercent.Gte(cancelFallPercent) && minPrice-(minPrice*0.005) > kline.Close.Value() {
-				log.Printf(
-					"[%s] Close price RISK detected: %f > %f",
-					binanceOrder.Symbol,
-					binanceOrder.Price,
-					l.Formatter.FormatPrice(limit, kline.Close.Value()),
-				)
+				riskLog.Info("Close price risk detected", "price", exchangeOrder.Price, "limitPrice", l.Formatter.FormatPrice(limit, kline.Close.Value()))
 
 				return true
 			}
@@ -60,24 +52,14 @@ func (l *LossSecurity) IsRiskyBuy(binanceOrder model.BinanceOrder, limit model.T
 
 		if l.InterpolationEnabled {
 			interpolation, err := l.ExchangeRepository.GetInterpolation(*kline)
-			if err == nil && interpolation.HasBtc() && binanceOrder.Price > l.Formatter.FormatPrice(limit, interpolation.BtcInterpolationUsdt) {
-				log.Printf(
-					"[%s] BTC Interpolation RISK detected: %f > %f",
-					binanceOrder.Symbol,
-					binanceOrder.Price,
-					l.Formatter.FormatPrice(limit, interpolation.BtcInterpolationUsdt),
-				)
+			if err == nil && interpolation.HasBtc() && exchangeOrder.Price > l.Formatter.FormatPrice(limit, interpolation.BtcInterpolationUsdt) {
+				riskLog.Info("BTC Interpolation risk detected", "price", exchangeOrder.Price, "limitPrice", l.Formatter.FormatPrice(limit, interpolation.BtcInterpolationUsdt))
 
 				return true
 			}
 
-			if err == nil && interpolation.HasEth() && binanceOrder.Price > l.Formatter.FormatPrice(limit, interpolation.EthInterpolationUsdt) {
-				log.Printf(
-					"[%s] ETH Interpolation RISK detected: %f > %f",
-					binanceOrder.Symbol,
-					binanceOrder.Price,
-					l.Formatter.FormatPrice(limit, interpolation.EthInterpolationUsdt),
-				)
+			if err == nil && interpolation.HasEth() && exchangeOrder.Price > l.Formatter.FormatPrice(limit, interpolation.EthInterpolationUsdt) {
+				riskLog.Info("ETH Interpolation risk detected", "price", exchangeOrder.Price, "limitPrice", l.Formatter.FormatPrice(limit, interpolation.EthInterpolationUsdt))
 
 				return true
 			}
@@ -2,11 +2,12 @@ package exchange
 
 import (
 	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
 	"gitlab.com/open-soft/go-crypto-bot/src/service"
 	"gitlab.com/open-soft/go-crypto-bot/src/utils"
-	"log"
+	"log/slog"
 	"runtime"
 	"slices"
 	"strings"
@@ -20,6 +21,9 @@ type MakerService struct {
 	ExchangeRepository repository.BaseTradeStorageInterface
 	BotService         service.BotServiceInterface
 	StrategyFacade     StrategyFacadeInterface
+	StopLossService    StopLossServiceInterface
+	RiskManager        RiskManagerInterface
+	ProtectionService  ProtectionServiceInterface
 	PriceCalculator    PriceCalculatorInterface
 	TradeStack         BuyOrderStackInterface
 	OrderExecutor      OrderExecutorInterface
@@ -27,6 +31,8 @@ type MakerService struct {
 	Formatter          *utils.Formatter
 	CurrentBot         *model.Bot
 	HoldScore          float64
+	TimeService        utils.TimeServiceInterface
+	ShutdownManager    service.ShutdownManagerInterface
 }
 
 func (m *MakerService) Make(symbol string) {
@@ -46,17 +52,20 @@ func (m *MakerService) Make(symbol string) {
 		return
 	}
 
+	// correlation id follows the decision to exchange calls and database updates
+	decision.Explanation.CorrelationId = logger.NewCorrelationId()
+
 	tradeLimit, err := m.ExchangeRepository.GetTradeLimit(symbol)
 
 	if err != nil {
-		log.Println(err.Error())
+		slog.Error("Trade limit", logger.KeySymbol, symbol, logger.Error(err))
 
 		return
 	}
 
 	if decision.Sell > decision.Buy {
 		if openedOrder != nil {
-			m.ProcessSell(tradeLimit, *openedOrder)
+			m.ProcessSell(tradeLimit, *openedOrder, &decision.Explanation)
 		}
 
 		return
@@ -64,37 +73,34 @@ func (m *MakerService) Make(symbol string) {
 
 	if decision.Buy > decision.Sell {
 		if openedOrder == nil {
-			m.ProcessBuy(tradeLimit)
+			m.ProcessBuy(tradeLimit, &decision.Explanation)
 		} else {
-			m.ProcessExtraBuy(tradeLimit, *openedOrder)
+			m.ProcessExtraBuy(tradeLimit, *openedOrder, &decision.Explanation)
 		}
 	}
 }
 
-func (m *MakerService) ProcessBuy(tradeLimit model.TradeLimit) {
+func (m *MakerService) ProcessBuy(tradeLimit model.TradeLimit, explanation *model.DecisionExplanation) {
 	if !tradeLimit.IsEnabled {
 		return
 	}
 
+	opLog := m.decisionLog(tradeLimit.Symbol, "BUY", model.OrderLifecycleOperationBuy, explanation)
+
 	// allow process already opened order
-	limitBuy := m.OrderRepository.GetBinanceOrder(tradeLimit.Symbol, "BUY")
+	limitBuy := m.OrderRepository.GetExchangeOrder(tradeLimit.Symbol, "BUY")
 
 	if limitBuy != nil {
 		// todo: signal := m.SignalStorage.GetSignal(tradeLimit.Symbol)
 		priceModel := m.PriceCalculator.CalculateBuy(tradeLimit)
 
-		err := m.OrderExecutor.Buy(tradeLimit, limitBuy.Price, limitBuy.OrigQty, priceModel.Signal)
+		err := m.OrderExecutor.Buy(tradeLimit, limitBuy.Price, limitBuy.OrigQty, priceModel.Signal, explanation)
 		if err != nil {
-			log.Printf(
-				"[%s] Existing order [%s] BUY Error: %s",
-				tradeLimit.Symbol,
-				limitBuy.OrderId,
-				err,
-			)
+			opLog.Error("Existing order BUY", logger.KeyExchangeOrderId, limitBuy.OrderId, logger.Error(err))
 
 			if strings.Contains(err.Error(), "not enough balance") {
-				log.Printf("[%s] wait 1 minute...", tradeLimit.Symbol)
-				time.Sleep(time.Minute * 1)
+				opLog.Warn("Not enough balance, wait 1 minute")
+				m.TimeService.WaitSeconds(60)
 			}
 		}
 		return
@@ -107,7 +113,7 @@ func (m *MakerService) ProcessBuy(tradeLimit model.TradeLimit) {
 	lastKline := m.ExchangeRepository.GetCurrentKline(tradeLimit.Symbol)
 
 	if lastKline == nil {
-		log.Printf("[%s] Last price is unknown... skip!", tradeLimit.Symbol)
+		opLog.Warn("Last price is unknown, skip")
 
 		return
 	}
@@ -115,23 +121,23 @@ func (m *MakerService) ProcessBuy(tradeLimit model.TradeLimit) {
 	balanceErr := m.OrderExecutor.CheckMinBalance(tradeLimit, *lastKline)
 
 	if balanceErr != nil {
-		log.Printf("[%s] Min balance check: %s", tradeLimit.Symbol, balanceErr.Error())
-		time.Sleep(time.Minute)
+		opLog.Warn("Min balance check", logger.Error(balanceErr))
+		m.TimeService.WaitSeconds(60)
 		return
 	}
 
-	marketDepth := m.PriceCalculator.GetDepth(tradeLimit.Symbol, 20)
+	marketDepth := m.PriceCalculator.GetDepth(tradeLimit.Symbol, LocalOrderBookDepth)
 	manualOrder := m.OrderRepository.GetManualOrder(tradeLimit.Symbol)
 
 	if len(marketDepth.Bids) == 0 && manualOrder == nil {
-		log.Printf("[%s] Too small BIDs amount: %d\n", tradeLimit.Symbol, len(marketDepth.Bids))
+		opLog.Info("Too small BIDs amount", "bids", len(marketDepth.Bids))
 		return
 	}
 
 	priceModel := m.PriceCalculator.CalculateBuy(tradeLimit)
 
 	if priceModel.Error != nil {
-		log.Printf("[%s] Price error: %s", tradeLimit.Symbol, priceModel.Error.Error())
+		opLog.Warn("Price error", logger.Error(priceModel.Error))
 
 		return
 	}
@@ -144,7 +150,7 @@ func (m *MakerService) ProcessBuy(tradeLimit model.TradeLimit) {
 
 	// todo: exclude existing exchange order...
 	if lastKline.IsPriceExpired() {
-		log.Printf("[%s] Price is expired", tradeLimit.Symbol)
+		opLog.Info("Price is expired")
 		return
 	}
 
@@ -153,42 +159,47 @@ func (m *MakerService) ProcessBuy(tradeLimit model.TradeLimit) {
 		quantity := m.Formatter.FormatQuantity(tradeLimit, tradeLimit.USDTLimit/price)
 
 		if (quantity * price) < tradeLimit.MinNotional {
-			log.Printf("[%s] BUY Notional: %.8f < %.8f", tradeLimit.Symbol, quantity*price, tradeLimit.MinNotional)
+			opLog.Info("BUY notional is too small", "notional", quantity*price, "min_notional", tradeLimit.MinNotional)
 			return
 		}
 
-		err := m.OrderExecutor.Buy(tradeLimit, price, quantity, priceModel.Signal)
+		if m.RiskManager != nil {
+			riskErr := m.RiskManager.CanBuy(tradeLimit, quantity*price, false)
+			if riskErr != nil {
+				opLog.Info("Risk check", logger.Error(riskErr))
+				return
+			}
+		}
+
+		err := m.OrderExecutor.Buy(tradeLimit, price, quantity, priceModel.Signal, explanation)
 		if err != nil {
-			log.Printf("[%s] %s", tradeLimit.Symbol, err)
+			opLog.Error("BUY", logger.Error(err))
 
 			if strings.Contains(err.Error(), "not enough balance") {
-				log.Printf("[%s] wait 1 minute...", tradeLimit.Symbol)
-				time.Sleep(time.Minute * 1)
+				opLog.Warn("Not enough balance, wait 1 minute")
+				m.TimeService.WaitSeconds(60)
 			}
 		}
 	} else {
-		log.Printf("[%s] No ASKs on the market", tradeLimit.Symbol)
+		opLog.Info("No ASKs on the market")
 	}
 }
 
-func (m *MakerService) ProcessExtraBuy(tradeLimit model.TradeLimit, openedOrder model.Order) {
+func (m *MakerService) ProcessExtraBuy(tradeLimit model.TradeLimit, openedOrder model.Order, explanation *model.DecisionExplanation) {
+	opLog := m.decisionLog(tradeLimit.Symbol, "BUY", model.OrderLifecycleOperationExtraBuy, explanation).With(logger.KeyOrderId, openedOrder.Id)
+
 	if !tradeLimit.IsEnabled {
-		log.Printf("[%s] BUY operation is disabled", tradeLimit.Symbol)
+		opLog.Info("BUY operation is disabled")
 		return
 	}
 
 	// allow process already opened order
-	limitBuy := m.OrderRepository.GetBinanceOrder(tradeLimit.Symbol, "BUY")
+	limitBuy := m.OrderRepository.GetExchangeOrder(tradeLimit.Symbol, "BUY")
 
 	if limitBuy != nil {
-		err := m.OrderExecutor.BuyExtra(tradeLimit, openedOrder, limitBuy.Price)
+		err := m.OrderExecutor.BuyExtra(tradeLimit, openedOrder, limitBuy.Price, explanation)
 		if err != nil {
-			log.Printf(
-				"[%s] Existing order [%s] Extra BUY Error: %s",
-				tradeLimit.Symbol,
-				limitBuy.OrderId,
-				err,
-			)
+			opLog.Error("Existing order extra BUY", logger.KeyExchangeOrderId, limitBuy.OrderId, logger.Error(err))
 
 			if m.BotService.IsSwapEnabled() {
 				m.OrderExecutor.TrySwap(openedOrder)
@@ -204,36 +215,36 @@ func (m *MakerService) ProcessExtraBuy(tradeLimit model.TradeLimit, openedOrder
 	lastKline := m.ExchangeRepository.GetCurrentKline(tradeLimit.Symbol)
 
 	if lastKline == nil {
-		log.Printf("[%s] Last price is unknown... skip!", tradeLimit.Symbol)
+		opLog.Warn("Last price is unknown, skip")
 
 		return
 	}
 
 	// todo: exclude existing exchange order...
 	if lastKline.IsPriceExpired() {
-		log.Printf("[%s] Price is expired", tradeLimit.Symbol)
+		opLog.Info("Price is expired")
 		return
 	}
 
 	balanceErr := m.OrderExecutor.CheckMinBalance(tradeLimit, *lastKline)
 
 	if balanceErr != nil {
-		log.Printf("[%s] Min balance check: %s", tradeLimit.Symbol, balanceErr.Error())
-		time.Sleep(time.Minute)
+		opLog.Warn("Min balance check", logger.Error(balanceErr))
+		m.TimeService.WaitSeconds(60)
 		return
 	}
 
-	marketDepth := m.PriceCalculator.GetDepth(tradeLimit.Symbol, 20)
+	marketDepth := m.PriceCalculator.GetDepth(tradeLimit.Symbol, LocalOrderBookDepth)
 	manualOrder := m.OrderRepository.GetManualOrder(tradeLimit.Symbol)
 
 	if len(marketDepth.Bids) == 0 && manualOrder == nil {
-		log.Printf("[%s] Too small BIDs amount: %d\n", tradeLimit.Symbol, len(marketDepth.Bids))
+		opLog.Info("Too small BIDs amount", "bids", len(marketDepth.Bids))
 		return
 	}
 
 	priceModel := m.PriceCalculator.CalculateBuy(tradeLimit)
 	if priceModel.Error != nil {
-		log.Printf("[%s] Price error: %s", tradeLimit.Symbol, priceModel.Error.Error())
+		opLog.Warn("Price error", logger.Error(priceModel.Error))
 
 		return
 	}
@@ -249,36 +260,48 @@ func (m *MakerService) ProcessExtraBuy(tradeLimit model.TradeLimit, openedOrder
 			price = m.Formatter.FormatPrice(tradeLimit, lastKline.Close.Value())
 		}
 
-		err := m.OrderExecutor.BuyExtra(tradeLimit, openedOrder, price)
+		if m.RiskManager != nil {
+			riskErr := m.RiskManager.CanBuy(tradeLimit, openedOrder.GetAvailableExtraBudget(*lastKline, m.BotService.UseSwapCapital()), true)
+			if riskErr != nil {
+				opLog.Info("Risk check", logger.Error(riskErr))
+				return
+			}
+		}
+
+		err := m.OrderExecutor.BuyExtra(tradeLimit, openedOrder, price, explanation)
 		if err != nil {
-			log.Printf("[%s] %s", tradeLimit.Symbol, err)
+			opLog.Error("Extra BUY", logger.Error(err))
 
 			if m.BotService.IsSwapEnabled() {
 				m.OrderExecutor.TrySwap(openedOrder)
 			}
 		}
 	} else {
-		log.Printf(
-			"[%s] Extra charge is not allowed: %.2f of %.2f",
-			tradeLimit.Symbol,
-			profit.Value(),
-			extraChargePercent.Value(),
-		)
+		opLog.Debug("Extra charge is not allowed", "profit_percent", profit.Value(), "extra_charge_percent", extraChargePercent.Value())
 	}
 }
 
-func (m *MakerService) ProcessSell(tradeLimit model.TradeLimit, openedOrder model.Order) {
+func (m *MakerService) ProcessSell(tradeLimit model.TradeLimit, openedOrder model.Order, explanation *model.DecisionExplanation) {
+	opLog := m.decisionLog(tradeLimit.Symbol, "SELL", model.OrderLifecycleOperationSell, explanation).With(logger.KeyOrderId, openedOrder.Id)
 	lastKline := m.ExchangeRepository.GetCurrentKline(tradeLimit.Symbol)
 
 	// todo: exclude existing exchange order...
 	if lastKline == nil {
-		log.Printf("[%s] Last price is unknown... skip!", tradeLimit.Symbol)
+		opLog.Warn("Last price is unknown, skip")
 
 		return
 	}
 
+	closeReason := model.OrderCloseReasonTakeProfit
+	if m.StopLossService != nil {
+		if reason, isTriggered := m.StopLossService.Check(openedOrder, lastKline.Close.Value()); isTriggered {
+			closeReason = reason
+		}
+	}
+	isStop := closeReason != model.OrderCloseReasonTakeProfit
+
 	// allow process already opened order
-	limitSell := m.OrderRepository.GetBinanceOrder(tradeLimit.Symbol, "SELL")
+	limitSell := m.OrderRepository.GetExchangeOrder(tradeLimit.Symbol, "SELL")
 
 	if limitSell != nil {
 		err := m.OrderExecutor.Sell(
@@ -286,71 +309,96 @@ func (m *MakerService) ProcessSell(tradeLimit model.TradeLimit, openedOrder mode
 			openedOrder,
 			limitSell.Price,
 			limitSell.OrigQty,
-			false,
+			closeReason,
+			explanation,
 		)
 
 		if err != nil {
-			log.Printf(
-				"[%s] Existing order [%s] SELL error: %s",
-				openedOrder.Symbol,
-				limitSell.OrderId,
-				err.Error(),
-			)
+			opLog.Error("Existing order SELL", logger.KeyExchangeOrderId, limitSell.OrderId, logger.Error(err))
 		}
 		return
 	}
 
-	if !m.TradeFilterService.CanSell(tradeLimit) {
-		log.Printf("[%s] Can't sell, trade filter conditions is not matched", tradeLimit.Symbol)
+	// stop loss ignores trade filters
+	if !isStop && !m.TradeFilterService.CanSell(tradeLimit) {
+		opLog.Info("Can't sell, trade filter conditions is not matched")
 
 		return
 	}
 
 	manualOrder := m.OrderRepository.GetManualOrder(tradeLimit.Symbol)
-	marketDepth := m.PriceCalculator.GetDepth(tradeLimit.Symbol, 20)
+	marketDepth := m.PriceCalculator.GetDepth(tradeLimit.Symbol, LocalOrderBookDepth)
 
 	if len(marketDepth.Asks) == 0 && manualOrder == nil {
-		log.Printf("[%s] Too small ASKs amount: %d\n", tradeLimit.Symbol, len(marketDepth.Asks))
+		opLog.Info("Too small ASKs amount", "asks", len(marketDepth.Asks))
 		return
 	}
 
 	// todo: exclude existing exchange order...
 	if lastKline == nil {
-		log.Printf("[%s] No information about current price", tradeLimit.Symbol)
+		opLog.Warn("No information about current price")
 		return
 	}
 
-	price, priceErr := m.PriceCalculator.CalculateSell(tradeLimit, openedOrder)
+	var price float64
 
-	// todo: exclude existing exchange order...
-	if priceErr != nil {
-		log.Printf("[%s] Price error: %s", tradeLimit.Symbol, priceErr.Error())
+	if isStop {
+		// close position immediately by the best bid
+		price = m.Formatter.FormatPrice(tradeLimit, marketDepth.GetBestBid())
+		opLog.Info("Stop triggered", "close_reason", closeReason, "current_price", lastKline.Close.Value(), "price", price)
+	} else {
+		var priceErr error
+		price, priceErr = m.PriceCalculator.CalculateSell(tradeLimit, openedOrder)
 
-		return
-	}
+		// todo: exclude existing exchange order...
+		if priceErr != nil {
+			opLog.Warn("Price error", logger.Error(priceErr))
 
-	isManual := false
+			return
+		}
 
-	if manualOrder != nil && manualOrder.IsSell() {
-		price = m.Formatter.FormatPrice(tradeLimit, manualOrder.Price)
-		isManual = true
+		if manualOrder != nil && manualOrder.IsSell() {
+			price = m.Formatter.FormatPrice(tradeLimit, manualOrder.Price)
+			closeReason = model.OrderCloseReasonManual
+		}
 	}
 
 	if price > 0 {
 		quantity := m.Formatter.FormatQuantity(tradeLimit, m.OrderExecutor.CalculateSellQuantity(openedOrder))
 
 		if quantity >= tradeLimit.MinQuantity {
-			log.Printf("[%s] SELL QTY = %f", openedOrder.Symbol, quantity)
-			err := m.OrderExecutor.Sell(tradeLimit, openedOrder, price, quantity, isManual)
+			opLog.Info("SELL", "quantity", quantity, "price", price)
+			err := m.OrderExecutor.Sell(tradeLimit, openedOrder, price, quantity, closeReason, explanation)
 			if err != nil {
-				log.Printf("[%s] SELL error: %s", openedOrder.Symbol, err.Error())
+				opLog.Error("SELL", logger.Error(err))
 			}
 		} else {
-			log.Printf("[%s] SELL QTY = %f is too small!", openedOrder.Symbol, quantity)
+			opLog.Info("SELL quantity is too small", "quantity", quantity, "min_quantity", tradeLimit.MinQuantity)
 		}
 	}
 }
 
+// decisionLog sets correlation id for decisions made outside of Make (signals, manual orders)
+func (m *MakerService) decisionLog(symbol string, side string, operation string, explanation *model.DecisionExplanation) *slog.Logger {
+	l := slog.With(logger.KeySymbol, symbol, logger.KeyOperation, operation)
+	if m.CurrentBot != nil {
+		l = l.With(logger.KeyExchange, m.CurrentBot.Exchange)
+	}
+
+	if explanation == nil {
+		return l
+	}
+
+	if explanation.CorrelationId == "" {
+		explanation.CorrelationId = logger.NewCorrelationId()
+	}
+
+	return l.With(
+		logger.KeyStrategy, strings.Join(explanation.GetStrategyNames(side), ","),
+		logger.KeyCorrelationId, explanation.CorrelationId,
+	)
+}
+
 func (m *MakerService) tradeLimit(symbol string) *model.TradeLimit {
 	tradeLimits := m.ExchangeRepository.GetTradeLimits()
 	for _, tradeLimit := range tradeLimits {
@@ -367,7 +415,7 @@ func (m *MakerService) UpdateSwapPairs() {
 	exchangeInfo, _ := m.Binance.GetExchangeData(make([]string, 0))
 	tradeLimits := m.ExchangeRepository.GetTradeLimits()
 
-	log.Printf("Update swap pairs for %d symbols", len(exchangeInfo.Symbols))
+	slog.Info("Update swap pairs", "symbols", len(exchangeInfo.Symbols))
 
 	supportedQuoteAssets := []string{"BTC", "ETH", "BNB", "TRX", "XRP", "EUR", "DAI", "TUSD", "USDC", "AUD", "TRY", "BRL"}
 
@@ -379,7 +427,7 @@ func (m *MakerService) UpdateSwapPairs() {
 		swapMap[tradeLimit.Symbol] = make([]model.ExchangeSymbol, 0)
 
 		for _, exchangeSymbol := range exchangeInfo.Symbols {
-			if !exchangeSymbol.IsTrading() {
+			if !exchangeSymbol.IsTrading {
 				continue
 			}
 
@@ -388,7 +436,7 @@ func (m *MakerService) UpdateSwapPairs() {
 				quoteAsset := exchangeSymbol.QuoteAsset
 
 				for _, exchangeItem := range exchangeInfo.Symbols {
-					if !exchangeItem.IsTrading() {
+					if !exchangeItem.IsTrading {
 						continue
 					}
 
@@ -417,30 +465,18 @@ func (m *MakerService) UpdateSwapPairs() {
 					Exchange:       m.CurrentBot.Exchange,
 				}
 
-				for _, filter := range exchangeItem.Filters {
-					if filter.FilterType == "PRICE_FILTER" {
-						swapPair.MinPrice = *filter.MinPrice
-					}
-					if filter.FilterType == "LOT_SIZE" {
-						swapPair.MinQuantity = *filter.MinQuantity
-					}
-					if filter.FilterType == "NOTIONAL" {
-						swapPair.MinNotional = *filter.MinNotional
-					}
+				swapPair.MinPrice = exchangeItem.MinPrice
+				swapPair.MinQuantity = exchangeItem.MinQuantity
+				if exchangeItem.MinNotional > 0 {
+					swapPair.MinNotional = exchangeItem.MinNotional
 				}
 
 				_, _ = m.ExchangeRepository.CreateSwapPair(swapPair)
 			} else {
-				for _, filter := range exchangeItem.Filters {
-					if filter.FilterType == "PRICE_FILTER" {
-						swapPair.MinPrice = *filter.MinPrice
-					}
-					if filter.FilterType == "LOT_SIZE" {
-						swapPair.MinQuantity = *filter.MinQuantity
-					}
-					if filter.FilterType == "NOTIONAL" {
-						swapPair.MinNotional = *filter.MinNotional
-					}
+				swapPair.MinPrice = exchangeItem.MinPrice
+				swapPair.MinQuantity = exchangeItem.MinQuantity
+				if exchangeItem.MinNotional > 0 {
+					swapPair.MinNotional = exchangeItem.MinNotional
 				}
 
 				_ = m.ExchangeRepository.UpdateSwapPair(swapPair)
@@ -459,7 +495,7 @@ func (m *MakerService) UpdateLimits() {
 	exchangeInfo, err := m.Binance.GetExchangeData([]string{})
 
 	if err != nil {
-		log.Printf("Exchange Limits: %s", err.Error())
+		slog.Error("Exchange limits", logger.Error(err))
 		return
 	}
 
@@ -469,44 +505,75 @@ func (m *MakerService) UpdateLimits() {
 			continue
 		}
 
-		for _, filter := range exchangeSymbol.Filters {
-			if filter.FilterType == "PRICE_FILTER" {
-				tradeLimit.MinPrice = *filter.MinPrice
-			}
-			if filter.FilterType == "LOT_SIZE" {
-				tradeLimit.MinQuantity = *filter.MinQuantity
-			}
-			if filter.FilterType == "NOTIONAL" {
-				tradeLimit.MinNotional = *filter.MinNotional
-			}
+		tradeLimit.MinPrice = exchangeSymbol.MinPrice
+		tradeLimit.MinQuantity = exchangeSymbol.MinQuantity
+		if exchangeSymbol.MinNotional > 0 {
+			tradeLimit.MinNotional = exchangeSymbol.MinNotional
 		}
+		// base and quote assets are taken from exchange, symbol can't be split reliably
+		if exchangeSymbol.BaseAsset != "" && exchangeSymbol.QuoteAsset != "" {
+			tradeLimit.BaseAsset = exchangeSymbol.BaseAsset
+			tradeLimit.QuoteAsset = exchangeSymbol.QuoteAsset
+		}
+
 		err := m.ExchangeRepository.UpdateTradeLimit(tradeLimit)
 		if err != nil {
-			log.Printf("[%s] Trade Limit Update: %s", tradeLimit.Symbol, err.Error())
+			slog.Error("Trade limit update", logger.KeySymbol, tradeLimit.Symbol, logger.Error(err))
 			continue
 		}
 
-		log.Printf(
-			"[%s] Trade Limit Updated, MIN_LOT = %.10f, MIN_PRICE = %.10f",
-			tradeLimit.Symbol,
-			tradeLimit.MinQuantity,
-			tradeLimit.MinPrice,
+		slog.Debug(
+			"Trade limit updated",
+			logger.KeySymbol, tradeLimit.Symbol,
+			"min_lot", tradeLimit.MinQuantity,
+			"min_price", tradeLimit.MinPrice,
 		)
 	}
 }
 
 func (m *MakerService) StartTrade() {
+	// trade limit assets must be known before the first order
+	m.UpdateLimits()
+
 	go func() {
 		for {
-			m.UpdateLimits()
 			time.Sleep(time.Minute * 5)
+			if !m.beginOperation() {
+				return
+			}
+			m.UpdateLimits()
+			m.endOperation()
 		}
 	}()
 
+	if m.ProtectionService != nil {
+		go func() {
+			for {
+				if !m.beginOperation() {
+					return
+				}
+				m.CheckProtections()
+				m.endOperation()
+				time.Sleep(time.Minute)
+			}
+		}()
+	}
+
 	for _, tradeLimit := range m.ExchangeRepository.GetTradeLimits() {
 		go func(symbol string) {
+			// unfinished operation is completed before any new decision
+			if m.beginOperation() {
+				m.OrderExecutor.Resume(symbol)
+				m.endOperation()
+			}
+
 			for {
+				if !m.beginOperation() {
+					slog.Info("Trading is stopped", logger.KeySymbol, symbol)
+					return
+				}
 				m.Make(symbol)
+				m.endOperation()
 
 				runtime.GC()
 				runtime.Gosched()
@@ -516,6 +583,37 @@ func (m *MakerService) StartTrade() {
 	}
 }
 
+// beginOperation returns false when shutdown is requested
+func (m *MakerService) beginOperation() bool {
+	return m.ShutdownManager == nil || m.ShutdownManager.Begin()
+}
+
+func (m *MakerService) endOperation() {
+	if m.ShutdownManager != nil {
+		m.ShutdownManager.End()
+	}
+}
+
+// CheckProtections closes positions sold by exchange protection and protects new or changed positions
+func (m *MakerService) CheckProtections() {
+	for _, tradeLimit := range m.ExchangeRepository.GetTradeLimits() {
+		openedOrder := m.OrderRepository.GetOpenedOrderCached(tradeLimit.Symbol, "BUY")
+
+		if openedOrder == nil || openedOrder.IsSwap() {
+			continue
+		}
+
+		if m.ProtectionService.CheckExecution(*openedOrder) {
+			continue
+		}
+
+		err := m.ProtectionService.Protect(*openedOrder)
+		if err != nil {
+			slog.Error("Protection", logger.KeySymbol, tradeLimit.Symbol, logger.Error(err))
+		}
+	}
+}
+
 func (m *MakerService) RecoverOrders() {
 	tradeLimits := m.ExchangeRepository.GetTradeLimits()
 	symbols := make([]string, 0)
@@ -523,21 +621,21 @@ func (m *MakerService) RecoverOrders() {
 		symbols = append(symbols, limit.Symbol)
 	}
 
-	binanceOrders, err := m.Binance.GetOpenedOrders()
+	exchangeOrders, err := m.Binance.GetOpenedOrders()
 	if err == nil {
-		for _, binanceOrder := range binanceOrders {
-			if binanceOrder.IsCanceled() || binanceOrder.IsExpired() {
+		for _, exchangeOrder := range exchangeOrders {
+			if exchangeOrder.IsCanceled() || exchangeOrder.IsExpired() || exchangeOrder.IsProtection() {
 				continue
 			}
 
-			if !slices.Contains(symbols, binanceOrder.Symbol) {
-				log.Printf("[%s] %s order %s skipped", binanceOrder.Symbol, m.CurrentBot.Exchange, binanceOrder.OrderId)
+			if !slices.Contains(symbols, exchangeOrder.Symbol) {
+				slog.Info("Order skipped", logger.KeySymbol, exchangeOrder.Symbol, logger.KeyExchange, m.CurrentBot.Exchange, logger.KeyExchangeOrderId, exchangeOrder.OrderId)
 
 				continue
 			}
 
-			log.Printf("[%s] loaded %s order %s, status = %s", binanceOrder.Symbol, m.CurrentBot.Exchange, binanceOrder.OrderId, binanceOrder.Status)
-			m.OrderRepository.SetBinanceOrder(binanceOrder)
+			slog.Info("Order loaded", logger.KeySymbol, exchangeOrder.Symbol, logger.KeyExchange, m.CurrentBot.Exchange, logger.KeyExchangeOrderId, exchangeOrder.OrderId, "status", exchangeOrder.Status)
+			m.OrderRepository.SetExchangeOrder(exchangeOrder)
 		}
 	}
 
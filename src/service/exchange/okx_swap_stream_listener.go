@@ -0,0 +1,118 @@
+package exchange
+
+import (
+	"encoding/json"
+	"github.com/gorilla/websocket"
+	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+type OkxSwapStreamListener struct {
+	ExchangeRepository *repository.ExchangeRepository
+	SwapUpdater        *SwapUpdater
+	SwapRepository     *repository.SwapRepository
+	SwapManager        *SwapManager
+	Formatter          *utils.Formatter
+}
+
+func (s *OkxSwapStreamListener) StartListening() {
+	swapKlineChannel := make(chan []byte, 1000)
+	// existing swaps real time monitoring
+	go func() {
+		for {
+			swapMsg := <-swapKlineChannel
+			swapSymbol := ""
+
+			var event model.OkxWsEvent
+			err := json.Unmarshal(swapMsg, &event)
+			if err != nil || event.Event != "" {
+				continue
+			}
+
+			symbol := s.Formatter.OkxInstIdToBinanceSymbol(event.Argument.InstId)
+
+			if event.Argument.Channel == model.OkxChannelCandle {
+				var kLineEvent model.OkxWsKLineEvent
+				err := json.Unmarshal(swapMsg, &kLineEvent)
+				if err == nil {
+					if len(kLineEvent.Data) > 0 {
+						kLine := s.Formatter.OkxKLineToBinanceKline(kLineEvent.Data[0], symbol, "1m")
+						kLine.UpdatedAt = time.Now().Unix()
+
+						s.ExchangeRepository.SetCurrentKline(kLine)
+						swapSymbol = kLine.Symbol
+					}
+				} else {
+					slog.Error("Kline error", logger.KeyExchange, "okx", logger.KeyStream, "swap", logger.Error(err))
+				}
+			}
+
+			if event.Argument.Channel == model.OkxChannelBooks {
+				var orderBookEvent model.OkxWsOrderBookEvent
+				err := json.Unmarshal(swapMsg, &orderBookEvent)
+				if err == nil && len(orderBookEvent.Data) > 0 {
+					depth := orderBookEvent.Data[0].ToOrderBookModel(symbol)
+					depth.UpdatedAt = time.Now().Unix()
+					s.ExchangeRepository.SetDepth(depth, 20, 25)
+					swapSymbol = depth.Symbol
+				} else if err != nil {
+					slog.Error("Order book error", logger.KeyExchange, "okx", logger.KeyStream, "swap", logger.Error(err))
+				}
+			}
+
+			if swapSymbol == "" {
+				continue
+			}
+
+			swapPair, err := s.ExchangeRepository.GetSwapPair(swapSymbol)
+			if err == nil {
+				s.SwapUpdater.UpdateSwapPair(swapPair)
+
+				possibleSwap := s.SwapRepository.GetSwapChainCache(swapPair.BaseAsset)
+				if possibleSwap != nil {
+					go func(asset string) {
+						s.SwapManager.CalculateSwapOptions(asset)
+					}(swapPair.BaseAsset)
+				}
+			}
+		}
+	}()
+
+	swapWebsockets := make([]*websocket.Conn, 0)
+
+	swapPairCollection := make([]model.SymbolInterface, 0)
+	for _, swapPair := range s.ExchangeRepository.GetSwapPairs() {
+		swapPairCollection = append(swapPairCollection, swapPair)
+	}
+
+	lock := sync.Mutex{}
+	sWg := sync.WaitGroup{}
+
+	// candles are streamed by business endpoint only
+	streams := map[string][]string{
+		os.Getenv("OKX_STREAM_DSN"):          {model.OkxChannelBooks},
+		os.Getenv("OKX_BUSINESS_STREAM_DSN"): {model.OkxChannelCandle},
+	}
+
+	for address, channels := range streams {
+		for index, streamBatchItem := range client.GetStreamBatchOkx(swapPairCollection, channels, s.Formatter) {
+			sWg.Add(1)
+			go func(address string, sbi []model.OkxWsArgument, i int) {
+				defer sWg.Done()
+				lock.Lock()
+				swapWebsockets = append(swapWebsockets, client.ListenOkx(address, swapKlineChannel, sbi, int64(i)))
+				lock.Unlock()
+				slog.Info("Websocket batch connected", logger.KeyExchange, "okx", logger.KeyStream, "swap", "batch", i, "streams", len(sbi))
+			}(address, streamBatchItem, index)
+		}
+	}
+
+	sWg.Wait()
+}
@@ -0,0 +1,157 @@
+package exchange
+
+import (
+	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const LocalOrderBookDepth = 500
+const OrderBookSnapshotLimit = 1000
+const OrderBookBufferSize = 1000
+const OrderBookResyncInterval = 5
+
+type OrderBookKeeperInterface interface {
+	Apply(diff model.OrderBookDiff) (model.OrderBookModel, bool)
+}
+
+// OrderBookKeeper maintains local order books from diff-depth streams,
+// updates received during REST snapshot loading are buffered and replayed on top of the snapshot.
+// StreamDepth is set if the stream keeps limited book (ByBit orderbook.50), REST snapshot update id is from
+// another sequence then, the snapshot is trimmed to the stream depth and the book continues from the last update
+type OrderBookKeeper struct {
+	Binance     client.ExchangePriceAPIInterface
+	StreamDepth int
+
+	books      map[string]*model.LocalOrderBook
+	buffers    map[string][]model.OrderBookDiff
+	syncing    map[string]bool
+	lastResync map[string]int64
+	mutex      sync.Mutex
+}
+
+// Apply returns up-to-date depth if the book is in sync, otherwise resync is started
+func (k *OrderBookKeeper) Apply(diff model.OrderBookDiff) (model.OrderBookModel, bool) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if k.books == nil {
+		k.books = make(map[string]*model.LocalOrderBook)
+		k.buffers = make(map[string][]model.OrderBookDiff)
+		k.syncing = make(map[string]bool)
+		k.lastResync = make(map[string]int64)
+	}
+
+	book, ok := k.books[diff.Symbol]
+	if !ok {
+		book = model.NewLocalOrderBook(diff.Symbol)
+		k.books[diff.Symbol] = book
+	}
+
+	if diff.IsSnapshot {
+		// exchange sent full book, REST snapshot is not needed anymore
+		delete(k.syncing, diff.Symbol)
+		delete(k.buffers, diff.Symbol)
+	}
+
+	if k.syncing[diff.Symbol] {
+		buffer := append(k.buffers[diff.Symbol], diff)
+		if len(buffer) > OrderBookBufferSize {
+			buffer = buffer[len(buffer)-OrderBookBufferSize:]
+		}
+		k.buffers[diff.Symbol] = buffer
+
+		return model.OrderBookModel{}, false
+	}
+
+	wasSynced := book.Synced
+	err := book.Apply(diff)
+	if err != nil {
+		if wasSynced {
+			slog.Warn("Order book resync", logger.KeySymbol, diff.Symbol, logger.Error(err))
+		}
+		k.resync(diff)
+
+		return model.OrderBookModel{}, false
+	}
+
+	return book.ToOrderBookModel(k.getDepthLimit(), time.Now().UnixMilli()), true
+}
+
+func (k *OrderBookKeeper) getDepthLimit() int {
+	if k.StreamDepth > 0 {
+		return k.StreamDepth
+	}
+
+	return LocalOrderBookDepth
+}
+
+func (k *OrderBookKeeper) resync(diff model.OrderBookDiff) {
+	if time.Now().Unix()-k.lastResync[diff.Symbol] < OrderBookResyncInterval {
+		return
+	}
+
+	k.lastResync[diff.Symbol] = time.Now().Unix()
+	k.syncing[diff.Symbol] = true
+	k.buffers[diff.Symbol] = []model.OrderBookDiff{diff}
+
+	go k.loadSnapshot(diff.Symbol)
+}
+
+func (k *OrderBookKeeper) loadSnapshot(symbol string) {
+	snapshotLimit := int64(OrderBookSnapshotLimit)
+	if k.StreamDepth > 0 {
+		snapshotLimit = int64(k.StreamDepth)
+	}
+	snapshot := k.Binance.GetDepth(symbol, snapshotLimit)
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if !k.syncing[symbol] {
+		return
+	}
+
+	buffer := k.buffers[symbol]
+	delete(k.syncing, symbol)
+	delete(k.buffers, symbol)
+
+	if snapshot == nil {
+		slog.Warn("Order book snapshot is not available", logger.KeySymbol, symbol)
+		return
+	}
+
+	book := k.books[symbol]
+
+	if k.StreamDepth > 0 {
+		// levels out of the stream depth never get updates, buffered updates can't be ordered with the snapshot
+		lastUpdateId := buffer[len(buffer)-1].LastUpdateId
+		book.ApplySnapshot(lastUpdateId, trimOrderBookLevels(snapshot.Bids, k.StreamDepth), trimOrderBookLevels(snapshot.Asks, k.StreamDepth))
+		slog.Debug("Order book synced", logger.KeySymbol, symbol, "lastUpdateId", book.LastUpdateId)
+
+		return
+	}
+
+	book.ApplySnapshot(snapshot.LastUpdateId, snapshot.Bids, snapshot.Asks)
+
+	for _, diff := range buffer {
+		err := book.Apply(diff)
+		if err != nil {
+			slog.Warn("Order book replay failed", logger.KeySymbol, symbol, logger.Error(err))
+			return
+		}
+	}
+
+	slog.Debug("Order book synced", logger.KeySymbol, symbol, "lastUpdateId", book.LastUpdateId)
+}
+
+func trimOrderBookLevels(levels [][2]model.Number, depth int) [][2]model.Number {
+	if len(levels) > depth {
+		return levels[:depth]
+	}
+
+	return levels
+}
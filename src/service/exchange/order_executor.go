@@ -4,25 +4,29 @@ import (
 	"errors"
 	"fmt"
 	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/metrics"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
 	"gitlab.com/open-soft/go-crypto-bot/src/service"
 	"gitlab.com/open-soft/go-crypto-bot/src/utils"
 	"gitlab.com/open-soft/go-crypto-bot/src/validator"
-	"log"
+	"log/slog"
 	"math"
 	"strings"
 	"sync"
+	"time"
 )
 
 type OrderExecutorInterface interface {
-	BuyExtra(tradeLimit model.TradeLimit, order model.Order, price float64) error
-	Buy(tradeLimit model.TradeLimit, price float64, quantity float64, signal *model.Signal) error
-	Sell(tradeLimit model.TradeLimit, opened model.Order, price float64, quantity float64, isManual bool) error
+	BuyExtra(tradeLimit model.TradeLimit, order model.Order, price float64, explanation *model.DecisionExplanation) error
+	Buy(tradeLimit model.TradeLimit, price float64, quantity float64, signal *model.Signal, explanation *model.DecisionExplanation) error
+	Sell(tradeLimit model.TradeLimit, opened model.Order, price float64, quantity float64, closeReason string, explanation *model.DecisionExplanation) error
 	ProcessSwap(order model.Order) bool
 	TrySwap(order model.Order)
 	CheckMinBalance(limit model.TradeLimit, kLine model.KLine) error
 	CalculateSellQuantity(order model.Order) float64
+	Resume(symbol string)
 }
 
 type OrderExecutor struct {
@@ -36,6 +40,12 @@ type OrderExecutor struct {
 	LossSecurity           LossSecurityInterface
 	PriceCalculator        PriceCalculatorInterface
 	ProfitService          ProfitServiceInterface
+	StopLossService        StopLossServiceInterface
+	ProtectionService      ProtectionServiceInterface
+	RiskManager            RiskManagerInterface
+	UserDataStream         UserDataStreamInterface
+	LifecycleRepository    repository.OrderLifecycleStorageInterface
+	ShutdownManager        service.ShutdownManagerInterface
 	SwapRepository         repository.SwapBasicRepositoryInterface
 	SwapExecutor           SwapExecutorInterface
 	SwapValidator          validator.SwapValidatorInterface
@@ -49,7 +59,7 @@ type OrderExecutor struct {
 	CancelRequestMap       map[string]bool
 }
 
-func (m *OrderExecutor) BuyExtra(tradeLimit model.TradeLimit, order model.Order, price float64) error {
+func (m *OrderExecutor) BuyExtra(tradeLimit model.TradeLimit, order model.Order, price float64, explanation *model.DecisionExplanation) error {
 	lastKline := m.ExchangeRepository.GetCurrentKline(tradeLimit.Symbol)
 
 	if lastKline == nil {
@@ -65,7 +75,7 @@ func (m *OrderExecutor) BuyExtra(tradeLimit model.TradeLimit, order model.Order,
 		return errors.New(fmt.Sprintf("[%s] Extra buy is disabled", tradeLimit.Symbol))
 	}
 
-	binanceBuyOrder := m.OrderRepository.GetBinanceOrder(tradeLimit.Symbol, "BUY")
+	binanceBuyOrder := m.OrderRepository.GetExchangeOrder(tradeLimit.Symbol, "BUY")
 
 	if !order.CanExtraBuy(*lastKline, m.BotService.UseSwapCapital()) && binanceBuyOrder == nil {
 		return errors.New(fmt.Sprintf("[%s] Not enough budget to buy more", tradeLimit.Symbol))
@@ -91,7 +101,7 @@ func (m *OrderExecutor) BuyExtra(tradeLimit model.TradeLimit, order model.Order,
 		return errors.New(fmt.Sprintf("[%s] Extra BUY Notional: %.8f < %.8f", order.Symbol, quantity*price, tradeLimit.MinNotional))
 	}
 
-	balanceErr := m.CheckBalance(order.Symbol, price, quantity)
+	balanceErr := m.CheckBalance(tradeLimit, price, quantity)
 
 	if balanceErr != nil {
 		m.CallbackManager.Error(
@@ -106,6 +116,8 @@ func (m *OrderExecutor) BuyExtra(tradeLimit model.TradeLimit, order model.Order,
 
 	var extraOrder = model.Order{
 		Symbol:             order.Symbol,
+		BaseAsset:          order.BaseAsset,
+		QuoteAsset:         order.QuoteAsset,
 		Quantity:           quantity,
 		Price:              price,
 		CreatedAt:          m.TimeService.GetNowDateTimeString(),
@@ -119,26 +131,43 @@ func (m *OrderExecutor) BuyExtra(tradeLimit model.TradeLimit, order model.Order,
 		ExtraChargeOptions: make(model.ExtraChargeOptions, 0),
 		ProfitOptions:      make(model.ProfitOptions, 0),
 		// todo: add commission???
-		Exchange: m.CurrentBot.Exchange,
+		Exchange:    m.CurrentBot.Exchange,
+		Explanation: explanation,
 	}
 
 	balanceBefore, balanceErr := m.BalanceService.GetAssetBalance(order.GetBaseAsset(), true)
 
-	binanceOrder, err := m.tryLimitOrder(extraOrder, "BUY", 120)
+	opLog := m.operationLog(order.Symbol, "BUY", model.OrderLifecycleOperationExtraBuy, explanation).With(logger.KeyOrderId, order.Id)
+	opLog.Info("Extra charge started", "price", price, "quantity", quantity)
+	defer logger.BindOperation(order.Symbol, opLog)()
+	m.startLifecycle(model.OrderLifecycle{
+		Symbol:        order.Symbol,
+		Side:          "BUY",
+		Operation:     model.OrderLifecycleOperationExtraBuy,
+		Price:         price,
+		Quantity:      quantity,
+		OpenedOrderId: &order.Id,
+		Explanation:   explanation,
+	})
+	exchangeOrder, err := m.tryLimitOrder(extraOrder, "BUY", 120, opLog)
 
 	if err != nil {
-		m.BalanceService.InvalidateBalanceCache("USDT")
+		m.BalanceService.InvalidateBalanceCache(tradeLimit.GetQuoteAsset())
 		return err
 	}
 
-	executedQty := binanceOrder.GetExecutedQuantity()
+	fill := exchangeOrder.GetFill()
 
 	// fill from API
-	extraOrder.ExternalId = &binanceOrder.OrderId
-	extraOrder.ExecutedQuantity = executedQty
-	extraOrder.Price = binanceOrder.Price
+	extraOrder.ExternalId = &exchangeOrder.OrderId
+	extraOrder.ExecutedQuantity = fill.Quantity
+	extraOrder.Price = fill.Price
 	extraOrder.CreatedAt = m.TimeService.GetNowDateTimeString()
 
+	if exchangeOrder.IsMarket() {
+		extraOrder.Quantity = fill.Quantity
+	}
+
 	refreshOrder, refreshErr = m.OrderRepository.Find(order.Id)
 	if refreshErr == nil {
 		order = refreshOrder
@@ -150,9 +179,12 @@ func (m *OrderExecutor) BuyExtra(tradeLimit model.TradeLimit, order model.Order,
 	if err != nil {
 		// remove binance order from cache if we have already had saved in database
 		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "order_external_id_symbol") {
-			m.OrderRepository.DeleteBinanceOrder(binanceOrder)
+			m.OrderRepository.DeleteExchangeOrder(exchangeOrder)
+			m.transitLifecycle(exchangeOrder.Symbol, exchangeOrder.Side, model.OrderLifecycleStateCompleted, nil)
 		}
 
+		opLog.Error("Can't create extra charge order", logger.Error(err))
+
 		return err
 	}
 
@@ -162,9 +194,9 @@ func (m *OrderExecutor) BuyExtra(tradeLimit model.TradeLimit, order model.Order,
 		m.UpdateCommission(balanceBefore, extraOrder)
 	}
 
-	order.ExecutedQuantity = executedQty + order.ExecutedQuantity
+	order.ExecutedQuantity = fill.Quantity + order.ExecutedQuantity
 	order.Price = avgPrice
-	order.UsedExtraBudget = order.UsedExtraBudget + (extraOrder.Price * executedQty)
+	order.UsedExtraBudget = order.UsedExtraBudget + fill.QuoteQuantity
 	commission := 0.00
 	if order.Commission != nil {
 		commission = *order.Commission
@@ -181,30 +213,37 @@ func (m *OrderExecutor) BuyExtra(tradeLimit model.TradeLimit, order model.Order,
 	order.Commission = &commissionSum
 
 	err = m.OrderRepository.Update(order)
-	m.BalanceService.InvalidateBalanceCache("USDT")
+	m.BalanceService.InvalidateBalanceCache(tradeLimit.GetQuoteAsset())
 	m.BalanceService.InvalidateBalanceCache(order.GetBaseAsset())
 
 	if err != nil {
+		opLog.Error("Can't update order", logger.Error(err))
+
 		return err
 	}
 
+	opLog.Info("Extra charge finished", "price", fill.Price, "executedQuantity", fill.Quantity, "avgPrice", avgPrice)
+	m.transitLifecycle(exchangeOrder.Symbol, exchangeOrder.Side, model.OrderLifecycleStateCompleted, nil)
+	m.protect(order)
+
 	go func(extraOrder model.Order, tradeLimit model.TradeLimit) {
 		sellPrice, priceErr := m.PriceCalculator.CalculateSell(tradeLimit, extraOrder)
 
 		if priceErr == nil {
-			m.CallbackManager.BuyOrder(
+			m.CallbackManager.Notify(model.NewOrderNotification(
+				model.NotificationEventExtraCharge,
 				extraOrder,
 				*m.CurrentBot,
-				fmt.Sprintf("Extra Charge! Sell when price will be around: %f USDT", sellPrice),
-			)
+				fmt.Sprintf("Extra Charge! Sell when price will be around: %f %s", sellPrice, tradeLimit.GetQuoteAsset()),
+			))
 		}
 	}(extraOrder, tradeLimit)
-	m.OrderRepository.DeleteBinanceOrder(binanceOrder)
+	m.OrderRepository.DeleteExchangeOrder(exchangeOrder)
 
 	return nil
 }
 
-func (m *OrderExecutor) Buy(tradeLimit model.TradeLimit, price float64, quantity float64, signal *model.Signal) error {
+func (m *OrderExecutor) Buy(tradeLimit model.TradeLimit, price float64, quantity float64, signal *model.Signal, explanation *model.DecisionExplanation) error {
 	if m.isTradeLocked(tradeLimit.Symbol) {
 		return errors.New(fmt.Sprintf("Operation Buy is Locked %s", tradeLimit.Symbol))
 	}
@@ -213,7 +252,7 @@ func (m *OrderExecutor) Buy(tradeLimit model.TradeLimit, price float64, quantity
 		return errors.New(fmt.Sprintf("Available quantity is %f", quantity))
 	}
 
-	balanceErr := m.CheckBalance(tradeLimit.Symbol, price, quantity)
+	balanceErr := m.CheckBalance(tradeLimit, price, quantity)
 
 	if balanceErr != nil {
 		m.CallbackManager.Error(
@@ -246,6 +285,8 @@ func (m *OrderExecutor) Buy(tradeLimit model.TradeLimit, price float64, quantity
 
 	var order = model.Order{
 		Symbol:             tradeLimit.Symbol,
+		BaseAsset:          tradeLimit.GetBaseAsset(),
+		QuoteAsset:         tradeLimit.GetQuoteAsset(),
 		Quantity:           quantity,
 		Price:              price,
 		CreatedAt:          m.TimeService.GetNowDateTimeString(),
@@ -259,61 +300,86 @@ func (m *OrderExecutor) Buy(tradeLimit model.TradeLimit, price float64, quantity
 		ProfitOptions:      profitOptions,
 		ExtraChargeOptions: extraChargeOptions,
 		// todo: add commission???
-		Exchange: m.CurrentBot.Exchange,
+		Exchange:    m.CurrentBot.Exchange,
+		Explanation: explanation,
 	}
 
 	balanceBefore, balanceErr := m.BalanceService.GetAssetBalance(order.GetBaseAsset(), true)
 
-	binanceOrder, err := m.tryLimitOrder(order, "BUY", 480)
+	opLog := m.operationLog(order.Symbol, "BUY", model.OrderLifecycleOperationBuy, explanation)
+	opLog.Info("Buy started", "price", price, "quantity", quantity)
+	defer logger.BindOperation(order.Symbol, opLog)()
+	m.startLifecycle(model.OrderLifecycle{
+		Symbol:      order.Symbol,
+		Side:        "BUY",
+		Operation:   model.OrderLifecycleOperationBuy,
+		Price:       price,
+		Quantity:    quantity,
+		Signal:      signal,
+		Explanation: explanation,
+	})
+	exchangeOrder, err := m.tryLimitOrder(order, "BUY", 480, opLog)
 
 	if err != nil {
-		m.BalanceService.InvalidateBalanceCache("USDT")
+		m.BalanceService.InvalidateBalanceCache(tradeLimit.GetQuoteAsset())
 		return err
 	}
 
 	// fill from API
-	order.ExternalId = &binanceOrder.OrderId
-	order.ExecutedQuantity = binanceOrder.GetExecutedQuantity()
-	order.Price = binanceOrder.Price
+	fill := exchangeOrder.GetFill()
+	order.ExternalId = &exchangeOrder.OrderId
+	order.ExecutedQuantity = fill.Quantity
+	order.Price = fill.Price
 	order.CreatedAt = m.TimeService.GetNowDateTimeString()
 
-	_, err = m.OrderRepository.Create(order)
-	m.BalanceService.InvalidateBalanceCache("USDT")
+	// quantity of market order by quote amount is known after execution
+	if exchangeOrder.IsMarket() {
+		order.Quantity = fill.Quantity
+	}
+
+	lastId, err := m.OrderRepository.Create(order)
+	m.BalanceService.InvalidateBalanceCache(tradeLimit.GetQuoteAsset())
 	m.BalanceService.InvalidateBalanceCache(order.GetBaseAsset())
 
 	if err != nil {
 		// remove binance order from cache if we have already had saved in database
 		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "order_external_id_symbol") {
-			m.OrderRepository.DeleteBinanceOrder(binanceOrder)
+			m.OrderRepository.DeleteExchangeOrder(exchangeOrder)
+			m.transitLifecycle(exchangeOrder.Symbol, exchangeOrder.Side, model.OrderLifecycleStateCompleted, nil)
 		}
 
-		log.Printf("Can't create order: %s", order.Symbol)
+		opLog.Error("Can't create order", logger.Error(err))
 
 		return err
 	}
 
+	opLog.Info("Position opened", logger.KeyOrderId, *lastId, "price", order.Price, "executedQuantity", order.ExecutedQuantity)
+	m.transitLifecycle(exchangeOrder.Symbol, exchangeOrder.Side, model.OrderLifecycleStateCompleted, nil)
 	m.OrderRepository.DeleteManualOrder(order.Symbol)
 
 	if balanceErr == nil {
 		m.UpdateCommission(balanceBefore, order)
 	}
 
+	order.Id = *lastId
+	m.protect(order)
+
 	go func(order model.Order, tradeLimit model.TradeLimit) {
 		sellPrice, priceErr := m.PriceCalculator.CalculateSell(tradeLimit, order)
 		if priceErr == nil {
 			m.CallbackManager.BuyOrder(
 				order,
 				*m.CurrentBot,
-				fmt.Sprintf("Sell when price will be around: %f USDT", sellPrice),
+				fmt.Sprintf("Sell when price will be around: %f %s", sellPrice, tradeLimit.GetQuoteAsset()),
 			)
 		}
 	}(order, tradeLimit)
-	m.OrderRepository.DeleteBinanceOrder(binanceOrder)
+	m.OrderRepository.DeleteExchangeOrder(exchangeOrder)
 
 	return nil
 }
 
-func (m *OrderExecutor) Sell(tradeLimit model.TradeLimit, opened model.Order, price float64, quantity float64, isManual bool) error {
+func (m *OrderExecutor) Sell(tradeLimit model.TradeLimit, opened model.Order, price float64, quantity float64, closeReason string, explanation *model.DecisionExplanation) error {
 	if m.isTradeLocked(opened.Symbol) {
 		return errors.New(fmt.Sprintf("Operation Sell is Locked %s", opened.Symbol))
 	}
@@ -331,9 +397,10 @@ func (m *OrderExecutor) Sell(tradeLimit model.TradeLimit, opened model.Order, pr
 	// Trading fee = (10 ETH * 3,452.55 USDT) * 0.1% = 34.5255 USDT
 
 	profit := (price - opened.Price) * quantity
+	isStop := model.IsStopCloseReason(closeReason)
 
-	// loose money control
-	if opened.Price >= price {
+	// loose money control, stop loss is allowed to close position with any profit
+	if opened.Price >= price && !isStop {
 		return errors.New(fmt.Sprintf(
 			"[%s] Bad deal, wait for positive profit: %.6f [o:%.6f, c:%.6f]",
 			tradeLimit.Symbol,
@@ -345,11 +412,11 @@ func (m *OrderExecutor) Sell(tradeLimit model.TradeLimit, opened model.Order, pr
 
 	minPrice := m.Formatter.FormatPrice(tradeLimit, m.ProfitService.GetMinClosePrice(opened, opened.Price))
 
-	if isManual {
+	if closeReason == model.OrderCloseReasonManual {
 		minPrice = m.Formatter.FormatPrice(tradeLimit, opened.GetManualMinClosePrice())
 	}
 
-	if price < minPrice {
+	if price < minPrice && !isStop {
 		return errors.New(fmt.Sprintf(
 			"[%s] Minimum profit is not reached, Price %.6f < %.6f",
 			opened.Symbol,
@@ -360,6 +427,8 @@ func (m *OrderExecutor) Sell(tradeLimit model.TradeLimit, opened model.Order, pr
 
 	var order = model.Order{
 		Symbol:             opened.Symbol,
+		BaseAsset:          opened.BaseAsset,
+		QuoteAsset:         opened.QuoteAsset,
 		Quantity:           quantity,
 		Price:              price,
 		CreatedAt:          m.TimeService.GetNowDateTimeString(),
@@ -373,21 +442,47 @@ func (m *OrderExecutor) Sell(tradeLimit model.TradeLimit, opened model.Order, pr
 		ExtraChargeOptions: make(model.ExtraChargeOptions, 0),
 		ProfitOptions:      make(model.ProfitOptions, 0),
 		// todo: add commission???
-		Exchange: m.CurrentBot.Exchange,
+		Exchange:    m.CurrentBot.Exchange,
+		Explanation: explanation,
+		CloseReason: &closeReason,
 	}
 
-	binanceOrder, err := m.tryLimitOrder(order, "SELL", 480)
+	// protected quantity is locked by exchange, protection has to be cancelled before selling
+	if m.ProtectionService != nil && opened.Protection != nil {
+		err := m.ProtectionService.Unprotect(opened)
+		if err != nil {
+			return err
+		}
+		opened.Protection = nil
+	}
+
+	opLog := m.operationLog(opened.Symbol, "SELL", model.OrderLifecycleOperationSell, explanation).With(logger.KeyOrderId, opened.Id)
+	opLog.Info("Sell started", "price", price, "quantity", quantity, "closeReason", closeReason)
+	defer logger.BindOperation(opened.Symbol, opLog)()
+	m.startLifecycle(model.OrderLifecycle{
+		Symbol:        opened.Symbol,
+		Side:          "SELL",
+		Operation:     model.OrderLifecycleOperationSell,
+		Price:         price,
+		Quantity:      quantity,
+		OpenedOrderId: &opened.Id,
+		CloseReason:   &closeReason,
+		Explanation:   explanation,
+	})
+	exchangeOrder, err := m.tryLimitOrder(order, "SELL", 480, opLog)
 
 	if err != nil {
-		m.BalanceService.InvalidateBalanceCache("USDT")
+		m.BalanceService.InvalidateBalanceCache(tradeLimit.GetQuoteAsset())
 		m.BalanceService.InvalidateBalanceCache(order.GetBaseAsset())
+		m.protect(opened)
 		return err
 	}
 
 	// fill from API
-	order.ExternalId = &binanceOrder.OrderId
-	order.ExecutedQuantity = binanceOrder.GetExecutedQuantity()
-	order.Price = binanceOrder.Price
+	fill := exchangeOrder.GetFill()
+	order.ExternalId = &exchangeOrder.OrderId
+	order.ExecutedQuantity = fill.Quantity
+	order.Price = fill.Price
 	order.CreatedAt = m.TimeService.GetNowDateTimeString()
 
 	lastId, err := m.OrderRepository.Create(order)
@@ -396,10 +491,11 @@ func (m *OrderExecutor) Sell(tradeLimit model.TradeLimit, opened model.Order, pr
 		// todo: test 2024/02/02 08:24:29 [XLMUSDT] Error 1062 (23000): Duplicate entry '207993-XLMUSDT' for key 'order_external_id_symbol'
 		// remove binance order from cache if we have already had saved in database
 		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "order_external_id_symbol") {
-			m.OrderRepository.DeleteBinanceOrder(binanceOrder)
+			m.OrderRepository.DeleteExchangeOrder(exchangeOrder)
+			m.transitLifecycle(exchangeOrder.Symbol, exchangeOrder.Side, model.OrderLifecycleStateCompleted, nil)
 		}
 
-		log.Printf("Can't create order: %s", order.Symbol)
+		opLog.Error("Can't create order", logger.Error(err))
 
 		return err
 	}
@@ -408,7 +504,7 @@ func (m *OrderExecutor) Sell(tradeLimit model.TradeLimit, opened model.Order, pr
 	_, err = m.OrderRepository.Find(*lastId)
 
 	if err != nil {
-		log.Printf("Can't get created order [%d]: %s", lastId, order.Symbol)
+		opLog.Error("Can't get created order", "sellOrderId", *lastId, logger.Error(err))
 
 		return err
 	}
@@ -430,23 +526,34 @@ func (m *OrderExecutor) Sell(tradeLimit model.TradeLimit, opened model.Order, pr
 	}
 
 	err = m.OrderRepository.Update(opened)
-	m.BalanceService.InvalidateBalanceCache("USDT")
+	m.BalanceService.InvalidateBalanceCache(tradeLimit.GetQuoteAsset())
 	m.BalanceService.InvalidateBalanceCache(opened.GetBaseAsset())
 
 	if err != nil {
-		log.Printf("Can't udpdate order [%d]: %s", order.Id, order.Symbol)
+		opLog.Error("Can't update order", logger.Error(err))
 
 		return err
 	}
 
+	opLog.Info("Position closed", "sellOrderId", *lastId, "price", fill.Price, "executedQuantity", fill.Quantity, "status", opened.Status)
+	m.transitLifecycle(exchangeOrder.Symbol, exchangeOrder.Side, model.OrderLifecycleStateCompleted, nil)
+	metrics.RealizedPnl.Add((fill.Price-opened.Price)*fill.Quantity, order.Symbol, order.GetQuoteAsset())
+	if m.RiskManager != nil {
+		m.RiskManager.AddRealizedProfit(order.Symbol, (fill.Price-opened.Price)*fill.Quantity)
+	}
+
+	if opened.IsOpened() {
+		m.protect(opened)
+	}
+
 	go func(order model.Order, profit float64) {
 		m.CallbackManager.SellOrder(
 			order,
 			*m.CurrentBot,
-			fmt.Sprintf("Profit is: %f USDT", m.Formatter.ToFixed(profit, 2)),
+			fmt.Sprintf("Profit is: %f %s, close reason: %s", m.Formatter.ToFixed(profit, 2), order.GetQuoteAsset(), closeReason),
 		)
 	}(order, profit)
-	m.OrderRepository.DeleteBinanceOrder(binanceOrder)
+	m.OrderRepository.DeleteExchangeOrder(exchangeOrder)
 
 	return nil
 }
@@ -454,7 +561,7 @@ func (m *OrderExecutor) Sell(tradeLimit model.TradeLimit, opened model.Order, pr
 func (m *OrderExecutor) ProcessSwap(order model.Order) bool {
 	switch true {
 	case m.BotService.IsSwapEnabled() && order.IsSwap():
-		log.Printf("[%s] Swap Order [%d] Mode: processing...", order.Symbol, order.Id)
+		m.orderLog(order).Info("Swap order mode: processing")
 		m.SwapExecutor.Execute(order)
 		return true
 	case m.BotService.IsSwapEnabled():
@@ -465,7 +572,7 @@ func (m *OrderExecutor) ProcessSwap(order model.Order) bool {
 
 		swapAction, err := m.SwapRepository.GetActiveSwapAction(order)
 		if err == nil && swapAction.OrderId == order.Id {
-			log.Printf("[%s] Swap Order [%d] Mode: processing...", order.Symbol, order.Id)
+			m.orderLog(order).Info("Swap order mode: processing")
 			m.SwapExecutor.Execute(order)
 			return true
 		}
@@ -494,92 +601,147 @@ func (m *OrderExecutor) TrySwap(order model.Order) {
 
 			if violation == nil {
 				chainCurrentPercent := m.SwapValidator.CalculatePercent(possibleSwap)
-				log.Printf(
-					"[%s] TRY SWAP -> Swap chain [%s] is found for order #%d, initial percent: %.2f, current = %.2f",
-					order.Symbol,
-					swapChain.Title,
-					order.Id,
-					swapChain.Percent,
-					chainCurrentPercent,
+				m.orderLog(order).Info(
+					"Try swap, swap chain is found",
+					"swapChain", swapChain.Title,
+					"initialPercent", swapChain.Percent,
+					"currentPercent", chainCurrentPercent,
 				)
 				m.MakeSwap(order, possibleSwap)
 			} else {
-				log.Printf("TrySwap: %s", violation.Error())
+				m.orderLog(order).Debug("Try swap, swap chain is not valid", logger.Error(violation))
 			}
 		}
 	}
 }
 
 // todo: order has to be Interface
-func (m *OrderExecutor) tryLimitOrder(order model.Order, operation string, ttl int64) (model.BinanceOrder, error) {
+func (m *OrderExecutor) tryLimitOrder(order model.Order, operation string, ttl int64, opLog *slog.Logger) (model.ExchangeOrder, error) {
+	exchangeOrder, err := m.executeLimitOrder(order, operation, ttl, opLog)
+
+	if err != nil {
+		opLog.Warn("Order is not executed", logger.KeyExchangeOrderId, exchangeOrder.OrderId, logger.Error(err))
+
+		if exchangeOrder.IsCanceled() || exchangeOrder.IsExpired() {
+			metrics.OrdersCancelled.Inc(order.Symbol, operation)
+		}
+
+		// lifecycle is kept while exchange order is not resolved
+		if m.LifecycleRepository != nil && m.OrderRepository.GetExchangeOrder(order.Symbol, operation) == nil {
+			m.transitLifecycle(order.Symbol, operation, model.OrderLifecycleStateFailed, nil)
+		}
+
+		return exchangeOrder, err
+	}
+
+	opLog.Info("Order is executed", logger.KeyExchangeOrderId, exchangeOrder.OrderId, "status", exchangeOrder.Status, "executedQuantity", exchangeOrder.ExecutedQty)
+	m.transitLifecycle(order.Symbol, operation, model.OrderLifecycleStateExecuted, &exchangeOrder.OrderId)
+	metrics.OrdersFilled.Inc(order.Symbol, operation)
+	if placedAt := exchangeOrder.GetPlacedAt(); placedAt > 0 {
+		metrics.OrderFillLatency.Observe(float64(time.Now().UnixMilli()-placedAt)/1000, order.Symbol, operation)
+	}
+
+	return exchangeOrder, nil
+}
+
+func (m *OrderExecutor) executeLimitOrder(order model.Order, operation string, ttl int64, opLog *slog.Logger) (model.ExchangeOrder, error) {
 	// todo: extra order flag...
-	binanceOrder, err := m.findOrCreateOrder(order, operation)
+	exchangeOrder, err := m.findOrCreateOrder(order, operation, opLog)
 
 	if err != nil {
-		return binanceOrder, err
+		return exchangeOrder, err
 	}
 
-	if (binanceOrder.IsCanceled() || binanceOrder.IsExpired()) && binanceOrder.ExecutedQty == 0 {
-		m.OrderRepository.DeleteBinanceOrder(binanceOrder)
+	if (exchangeOrder.IsCanceled() || exchangeOrder.IsExpired()) && exchangeOrder.ExecutedQty == 0 {
+		m.OrderRepository.DeleteExchangeOrder(exchangeOrder)
 
-		return binanceOrder, errors.New(fmt.Sprintf("%s order [%s] is cancelled or expired", m.CurrentBot.Exchange, binanceOrder.OrderId))
+		return exchangeOrder, errors.New(fmt.Sprintf("%s order [%s] is cancelled or expired", m.CurrentBot.Exchange, exchangeOrder.OrderId))
 	}
 
-	if binanceOrder.IsFilled() {
-		return binanceOrder, nil
+	if exchangeOrder.IsFilled() {
+		return exchangeOrder, nil
 	}
 
-	if binanceOrder.IsCanceled() && binanceOrder.ExecutedQty > 0.00 {
-		return binanceOrder, nil
+	if exchangeOrder.IsCanceled() && exchangeOrder.ExecutedQty > 0.00 {
+		return exchangeOrder, nil
 	}
 
 	// todo: save sell order in buy order to make sure it is saved after processing...
-	binanceOrder, err = m.waitExecution(binanceOrder, ttl)
+	exchangeOrder, err = m.waitExecution(exchangeOrder, ttl, opLog)
 
 	if err != nil {
-		return binanceOrder, err
+		return exchangeOrder, err
+	}
+
+	return exchangeOrder, nil
+}
+
+func (m *OrderExecutor) hasUserDataUpdate(exchangeOrder model.ExchangeOrder) bool {
+	if m.UserDataStream == nil || !m.UserDataStream.IsConnected() {
+		return false
+	}
+
+	update := m.UserDataStream.GetOrderUpdate(exchangeOrder.OrderId)
+
+	return update != nil && (update.Status != exchangeOrder.Status || update.ExecutedQty != exchangeOrder.ExecutedQty)
+}
+
+// queryOrder takes the order state pushed by user data stream, the exchange is queried if the stream
+// is disconnected and once per UserDataQueryInterval seconds in case an update is lost
+func (m *OrderExecutor) queryOrder(exchangeOrder model.ExchangeOrder, lastQueryAt *int64) (model.ExchangeOrder, error) {
+	if m.UserDataStream != nil && m.UserDataStream.IsConnected() {
+		now := m.TimeService.GetNowUnix()
+
+		if now-*lastQueryAt < UserDataQueryInterval {
+			update := m.UserDataStream.GetOrderUpdate(exchangeOrder.OrderId)
+			if update != nil {
+				return *update, nil
+			}
+
+			return exchangeOrder, nil
+		}
+
+		*lastQueryAt = now
 	}
 
-	return binanceOrder, nil
+	return m.Binance.QueryOrder(exchangeOrder.Symbol, exchangeOrder.OrderId)
 }
 
-func (m *OrderExecutor) waitExecution(binanceOrder model.BinanceOrder, seconds int64) (model.BinanceOrder, error) {
-	if binanceOrder.IsFilled() {
-		return binanceOrder, nil
+func (m *OrderExecutor) waitExecution(exchangeOrder model.ExchangeOrder, seconds int64, opLog *slog.Logger) (model.ExchangeOrder, error) {
+	if exchangeOrder.IsFilled() {
+		return exchangeOrder, nil
 	}
 
-	depth := m.PriceCalculator.GetDepth(binanceOrder.Symbol, 20)
+	opLog = opLog.With(logger.KeyExchangeOrderId, exchangeOrder.OrderId)
+
+	depth := m.PriceCalculator.GetDepth(exchangeOrder.Symbol, LocalOrderBookDepth)
 
 	var currentPosition int
 	var book [2]model.Number
-	if "BUY" == binanceOrder.Side {
-		currentPosition, book = depth.GetBidPosition(binanceOrder.Price)
+	if "BUY" == exchangeOrder.Side {
+		currentPosition, book = depth.GetBidPosition(exchangeOrder.Price)
 	} else {
-		currentPosition, book = depth.GetAskPosition(binanceOrder.Price)
+		currentPosition, book = depth.GetAskPosition(exchangeOrder.Price)
 	}
-	log.Printf(
-		"[%s] Order Book start position is [%d] %.6f\n",
-		binanceOrder.Symbol,
-		currentPosition,
-		book[0],
-	)
+	opLog.Info("Order book start position", "position", currentPosition, "bookPrice", book[0])
 
 	executedQty := 0.00
+	lastQueryAt := int64(0)
 
 	orderManageChannel := make(chan string)
 	control := make(chan string)
 	defer close(orderManageChannel)
 	defer close(control)
 
-	tradeLimit, err := m.ExchangeRepository.GetTradeLimit(binanceOrder.Symbol)
+	tradeLimit, err := m.ExchangeRepository.GetTradeLimit(exchangeOrder.Symbol)
 
 	if err != nil {
-		return binanceOrder, err
+		return exchangeOrder, err
 	}
 
 	go func(
 		tradeLimit model.TradeLimit,
-		binanceOrder *model.BinanceOrder,
+		exchangeOrder *model.ExchangeOrder,
 		ttl *int64,
 		control chan string,
 		orderManageChannel chan string,
@@ -588,7 +750,7 @@ func (m *OrderExecutor) waitExecution(binanceOrder model.BinanceOrder, seconds i
 		start := m.TimeService.GetNowUnix()
 
 		for {
-			if binanceOrder.IsCanceled() || binanceOrder.IsExpired() || binanceOrder.IsFilled() {
+			if exchangeOrder.IsCanceled() || exchangeOrder.IsExpired() || exchangeOrder.IsFilled() {
 				orderManageChannel <- "status"
 				action := <-control
 				if action == "stop" {
@@ -599,21 +761,32 @@ func (m *OrderExecutor) waitExecution(binanceOrder model.BinanceOrder, seconds i
 				continue
 			}
 
+			if m.hasUserDataUpdate(*exchangeOrder) {
+				orderManageChannel <- "status"
+				action := <-control
+				if action == "stop" {
+					return
+				}
+			}
+
 			end := m.TimeService.GetNowUnix()
 
-			if m.CheckIsTimeToSwap(binanceOrder, orderManageChannel, control) {
+			if m.CheckIsShutdown(exchangeOrder, orderManageChannel, control) {
+				return
+			}
+			if m.CheckIsTimeToSwap(exchangeOrder, orderManageChannel, control) {
 				return
 			}
-			if m.CheckIsRiskyBuy(tradeLimit, binanceOrder, orderManageChannel, control) {
+			if m.CheckIsRiskyBuy(tradeLimit, exchangeOrder, orderManageChannel, control) {
 				return
 			}
-			if m.CheckIsTimeToCancel(tradeLimit, binanceOrder, orderManageChannel, control) {
+			if m.CheckIsTimeToCancel(tradeLimit, exchangeOrder, orderManageChannel, control) {
 				return
 			}
-			if m.CheckIsTimeToSell(binanceOrder, orderManageChannel, control) {
+			if m.CheckIsTimeToSell(exchangeOrder, orderManageChannel, control) {
 				return
 			}
-			if m.CheckIsTimeToExtraBuy(tradeLimit, binanceOrder, orderManageChannel, control) {
+			if m.CheckIsTimeToExtraBuy(tradeLimit, exchangeOrder, orderManageChannel, control) {
 				return
 			}
 
@@ -622,17 +795,14 @@ func (m *OrderExecutor) waitExecution(binanceOrder model.BinanceOrder, seconds i
 
 				orderManageChannel <- "status"
 				action := <-control
-				log.Printf(
-					"[%s] %s Order [%s] status [%s] wait handler (%s), current price is [%.10f], order price [%.10f], ExecutedQty: %.6f of %.6f\"",
-					binanceOrder.Symbol,
-					binanceOrder.Side,
-					binanceOrder.OrderId,
-					binanceOrder.Status,
-					action,
-					kline.Close,
-					binanceOrder.Price,
-					binanceOrder.ExecutedQty,
-					binanceOrder.OrigQty,
+				opLog.Debug(
+					"Order wait handler",
+					"status", exchangeOrder.Status,
+					"action", action,
+					"currentPrice", kline.Close,
+					"price", exchangeOrder.Price,
+					"executedQuantity", exchangeOrder.ExecutedQty,
+					"quantity", exchangeOrder.OrigQty,
 				)
 				if action == "stop" {
 					return
@@ -642,20 +812,20 @@ func (m *OrderExecutor) waitExecution(binanceOrder model.BinanceOrder, seconds i
 				m.TimeService.WaitSeconds(1)
 
 				// check only new timeout
-				if end >= (start+*ttl) && binanceOrder.IsNew() {
-					if m.CheckIsSellExpired(binanceOrder, orderManageChannel, control) {
+				if end >= (start+*ttl) && exchangeOrder.IsNew() {
+					if m.CheckIsSellExpired(exchangeOrder, orderManageChannel, control) {
 						return
 					}
 
-					if m.CheckIsBuyExpired(binanceOrder, orderManageChannel, control) {
+					if m.CheckIsBuyExpired(exchangeOrder, orderManageChannel, control) {
 						return
 					}
 				}
 			} else {
-				manualOrder := m.OrderRepository.GetManualOrder(binanceOrder.Symbol)
+				manualOrder := m.OrderRepository.GetManualOrder(exchangeOrder.Symbol)
 				// cancel current immediately on new manual order
-				if manualOrder != nil && manualOrder.Price != binanceOrder.Price {
-					if m.TryCancel(binanceOrder, orderManageChannel, control, func() {}, false) {
+				if manualOrder != nil && manualOrder.Price != exchangeOrder.Price {
+					if m.TryCancel(exchangeOrder, orderManageChannel, control, func() {}, false) {
 						return
 					}
 				} else {
@@ -670,7 +840,7 @@ func (m *OrderExecutor) waitExecution(binanceOrder model.BinanceOrder, seconds i
 			timer = timer + 30
 			m.TimeService.WaitMilliseconds(20)
 		}
-	}(tradeLimit, &binanceOrder, &seconds, control, orderManageChannel)
+	}(tradeLimit, &exchangeOrder, &seconds, control, orderManageChannel)
 
 	for {
 		action := <-orderManageChannel
@@ -680,86 +850,82 @@ func (m *OrderExecutor) waitExecution(binanceOrder model.BinanceOrder, seconds i
 		}
 
 		if action == "cancel" {
-			log.Printf(
-				"[%s] %s Order %s, cancel signal has received",
-				binanceOrder.Symbol,
-				binanceOrder.Side,
-				binanceOrder.OrderId,
-			)
+			opLog.Info("Order cancel signal has received", "status", exchangeOrder.Status)
+			m.transitLifecycle(exchangeOrder.Symbol, exchangeOrder.Side, model.OrderLifecycleStateCancelling, nil)
 			break
 		}
 
-		queryOrder, err := m.Binance.QueryOrder(binanceOrder.Symbol, binanceOrder.OrderId)
+		queryOrder, err := m.queryOrder(exchangeOrder, &lastQueryAt)
 
 		if err != nil {
-			log.Printf("[%s] QueryOrder: %s", binanceOrder.Symbol, err.Error())
+			opLog.Warn("Query order failed", logger.Error(err))
 
 			if strings.Contains(err.Error(), "Order was canceled or expired") {
 				control <- "stop"
 
 				// todo: refactor in next release, must be on the top level
-				m.OrderRepository.DeleteBinanceOrder(binanceOrder)
-				return binanceOrder, errors.New(fmt.Sprintf("Order %s was CANCELED or EXPIRED", binanceOrder.OrderId))
+				m.OrderRepository.DeleteExchangeOrder(exchangeOrder)
+				return exchangeOrder, errors.New(fmt.Sprintf("Order %s was CANCELED or EXPIRED", exchangeOrder.OrderId))
 			}
 
 			if strings.Contains(err.Error(), "Order does not exist") {
 				control <- "stop"
 
 				// todo: refactor in next release, must be on the top level
-				m.OrderRepository.DeleteBinanceOrder(binanceOrder)
-				return binanceOrder, err
+				m.OrderRepository.DeleteExchangeOrder(exchangeOrder)
+				return exchangeOrder, err
 			}
 
-			log.Printf("[%s] Retry query order...", binanceOrder.Symbol)
+			opLog.Info("Retry query order")
 			m.TimeService.WaitSeconds(120)
 
 			control <- "continue"
 			continue
 		}
 
-		binanceOrder = queryOrder
-		m.OrderRepository.SetBinanceOrder(binanceOrder)
+		exchangeOrder = queryOrder
+		m.OrderRepository.SetExchangeOrder(exchangeOrder)
 
-		if binanceOrder.IsPartiallyFilled() {
+		if exchangeOrder.IsPartiallyFilled() {
 			// Add 5 minutes more if ExecutedQty moves up!
-			if binanceOrder.GetExecutedQuantity() > executedQty {
+			if exchangeOrder.GetExecutedQuantity() > executedQty {
 				seconds = seconds + (60 * 5)
 			}
 
-			executedQty = binanceOrder.GetExecutedQuantity()
-			m.OrderRepository.SetBinanceOrder(binanceOrder)
+			executedQty = exchangeOrder.GetExecutedQuantity()
+			m.OrderRepository.SetExchangeOrder(exchangeOrder)
 			control <- "continue"
 			continue
 		}
 
-		if binanceOrder.IsExpired() {
-			if binanceOrder.HasExecutedQuantity() {
+		if exchangeOrder.IsExpired() {
+			if exchangeOrder.HasExecutedQuantity() {
 				control <- "stop"
-				return binanceOrder, nil
+				return exchangeOrder, nil
 			}
 
 			// todo: refactor in next release, must be on the top level
-			m.OrderRepository.DeleteBinanceOrder(binanceOrder)
-			return binanceOrder, errors.New(fmt.Sprintf("Order %s was EXPIRED", binanceOrder.OrderId))
+			m.OrderRepository.DeleteExchangeOrder(exchangeOrder)
+			return exchangeOrder, errors.New(fmt.Sprintf("Order %s was EXPIRED", exchangeOrder.OrderId))
 		}
 
-		if binanceOrder.IsCanceled() {
-			if binanceOrder.HasExecutedQuantity() {
+		if exchangeOrder.IsCanceled() {
+			if exchangeOrder.HasExecutedQuantity() {
 				control <- "stop"
-				return binanceOrder, nil
+				return exchangeOrder, nil
 			}
 
 			control <- "stop"
 			// todo: refactor in next release, must be on the top level
-			m.OrderRepository.DeleteBinanceOrder(binanceOrder)
-			return binanceOrder, errors.New(fmt.Sprintf("Order %s was CANCELED", binanceOrder.OrderId))
+			m.OrderRepository.DeleteExchangeOrder(exchangeOrder)
+			return exchangeOrder, errors.New(fmt.Sprintf("Order %s was CANCELED", exchangeOrder.OrderId))
 		}
 
-		if binanceOrder.IsFilled() {
-			log.Printf("[%s] Order [%s] is executed [%s]", binanceOrder.Symbol, binanceOrder.OrderId, binanceOrder.Status)
+		if exchangeOrder.IsFilled() {
+			opLog.Info("Order is filled", "status", exchangeOrder.Status)
 
 			control <- "stop"
-			return binanceOrder, nil
+			return exchangeOrder, nil
 		}
 
 		control <- "continue"
@@ -786,131 +952,107 @@ func (m *OrderExecutor) waitExecution(binanceOrder model.BinanceOrder, seconds i
 	//    "side": "BUY",
 	//    "selfTradePreventionMode": "EXPIRE_MAKER"
 	//}
-	cancelOrder, err := m.Binance.CancelOrder(binanceOrder.Symbol, binanceOrder.OrderId)
+	cancelOrder, err := m.Binance.CancelOrder(exchangeOrder.Symbol, exchangeOrder.OrderId)
 
 	if err != nil {
 		// Possible case: {"code": -2011,"msg": "Order was not canceled due to cancel restrictions."}
-		log.Printf("[%s] Cancel failed: %s", binanceOrder.Symbol, err.Error())
-		queryOrder, retryErr := m.Binance.QueryOrder(binanceOrder.Symbol, binanceOrder.OrderId)
+		opLog.Warn("Order cancel failed", logger.Error(err))
+		queryOrder, retryErr := m.Binance.QueryOrder(exchangeOrder.Symbol, exchangeOrder.OrderId)
 
 		if retryErr == nil {
-			binanceOrder = queryOrder
+			exchangeOrder = queryOrder
 			control <- "stop"
-			log.Printf("[%s] Order [%s] is recovered [%s]", binanceOrder.Symbol, binanceOrder.OrderId, binanceOrder.Status)
+			opLog.Info("Order is recovered", "status", exchangeOrder.Status)
 
-			if binanceOrder.IsFilled() {
-				return binanceOrder, nil
+			if exchangeOrder.IsFilled() {
+				return exchangeOrder, nil
 			}
 
 			// Just in case of bug...
-			if binanceOrder.IsPartiallyFilled() {
-				log.Printf(
-					"[%s] Order [%s] status is [%s], try again waitExecution...",
-					binanceOrder.Symbol,
-					binanceOrder.OrderId,
-					binanceOrder.Status,
-				)
+			if exchangeOrder.IsPartiallyFilled() {
+				opLog.Warn("Order is not cancelled, wait execution again", "status", exchangeOrder.Status)
+				m.transitLifecycle(exchangeOrder.Symbol, exchangeOrder.Side, model.OrderLifecycleStatePlaced, nil)
 
-				return m.waitExecution(binanceOrder, 120)
+				return m.waitExecution(exchangeOrder, 120, opLog)
 			}
 
 			// Just in case of bug...
-			if binanceOrder.IsNew() {
-				log.Printf(
-					"[%s] Order [%s] status is [%s], try again waitExecution...",
-					binanceOrder.Symbol,
-					binanceOrder.OrderId,
-					binanceOrder.Status,
-				)
+			if exchangeOrder.IsNew() {
+				opLog.Warn("Order is not cancelled, wait execution again", "status", exchangeOrder.Status)
+				m.transitLifecycle(exchangeOrder.Symbol, exchangeOrder.Side, model.OrderLifecycleStatePlaced, nil)
 
-				return m.waitExecution(binanceOrder, 120)
+				return m.waitExecution(exchangeOrder, 120, opLog)
 			}
 
-			if binanceOrder.HasExecutedQuantity() {
-				log.Printf(
-					"Order [%s] is [%s], ExecutedQty = %.8f",
-					binanceOrder.OrderId,
-					binanceOrder.Status,
-					binanceOrder.GetExecutedQuantity(),
-				)
+			if exchangeOrder.HasExecutedQuantity() {
+				opLog.Info("Order is cancelled with executed quantity", "status", exchangeOrder.Status, "executedQuantity", exchangeOrder.GetExecutedQuantity())
 
-				return binanceOrder, nil
+				return exchangeOrder, nil
 			} else {
 				// todo: refactor in next release, must be on the top level
-				m.OrderRepository.DeleteBinanceOrder(binanceOrder)
-				return binanceOrder, errors.New(fmt.Sprintf("Order %s was CANCELED", binanceOrder.OrderId))
+				m.OrderRepository.DeleteExchangeOrder(exchangeOrder)
+				return exchangeOrder, errors.New(fmt.Sprintf("Order %s was CANCELED", exchangeOrder.OrderId))
 			}
 		} else {
 			// todo: loop??? timeout + loop???
 			control <- "stop"
-			return binanceOrder, err
+			return exchangeOrder, err
 		}
 	}
 
-	binanceOrder = cancelOrder
-	m.OrderRepository.SetBinanceOrder(binanceOrder)
+	exchangeOrder = cancelOrder
+	m.OrderRepository.SetExchangeOrder(exchangeOrder)
 	control <- "stop"
 
 	// handle cancel error and get again
 
-	if binanceOrder.HasExecutedQuantity() {
-		log.Printf(
-			"Order [%s] is [%s], ExecutedQty = %.8f",
-			binanceOrder.OrderId,
-			binanceOrder.Status,
-			binanceOrder.GetExecutedQuantity(),
-		)
+	if exchangeOrder.HasExecutedQuantity() {
+		opLog.Info("Order is cancelled with executed quantity", "status", exchangeOrder.Status, "executedQuantity", exchangeOrder.GetExecutedQuantity())
 
-		return binanceOrder, nil
+		return exchangeOrder, nil
 	}
 
-	log.Printf("Order [%s] is [%s]", binanceOrder.OrderId, binanceOrder.Status)
+	opLog.Info("Order is cancelled", "status", exchangeOrder.Status)
 
 	// todo: refactor in next release, must be on the top level
-	m.OrderRepository.DeleteBinanceOrder(binanceOrder)
-	return binanceOrder, errors.New(fmt.Sprintf("Order %s was CANCELED", binanceOrder.OrderId))
+	m.OrderRepository.DeleteExchangeOrder(exchangeOrder)
+	return exchangeOrder, errors.New(fmt.Sprintf("Order %s was CANCELED", exchangeOrder.OrderId))
 }
 
 func (m *OrderExecutor) CheckIsBuyExpired(
-	binanceOrder *model.BinanceOrder,
+	exchangeOrder *model.ExchangeOrder,
 	orderManageChannel chan string,
 	control chan string,
 ) bool {
-	if !binanceOrder.IsBuy() {
+	if !exchangeOrder.IsBuy() {
 		return false
 	}
 
-	kline := m.ExchangeRepository.GetCurrentKline(binanceOrder.Symbol)
+	kline := m.ExchangeRepository.GetCurrentKline(exchangeOrder.Symbol)
 
 	if kline == nil {
 		return false
 	}
 
-	positionPercentage := m.Formatter.ComparePercentage(binanceOrder.Price, kline.Close.Value())
+	positionPercentage := m.Formatter.ComparePercentage(exchangeOrder.Price, kline.Close.Value())
 	if positionPercentage.Gte(101.00) {
-		log.Printf(
-			"[%s] %s Order [%s] status [%s] ttl reached, current price is [%.10f], order price [%.10f], diff percent: %.2f",
-			binanceOrder.Symbol,
-			binanceOrder.Side,
-			binanceOrder.OrderId,
-			binanceOrder.Status,
-			kline.Close,
-			binanceOrder.Price,
-			positionPercentage.Value(),
+		m.exchangeOrderLog(*exchangeOrder).Info(
+			"Order ttl reached",
+			"status", exchangeOrder.Status,
+			"currentPrice", kline.Close,
+			"price", exchangeOrder.Price,
+			"diffPercent", positionPercentage.Value(),
 		)
-		if m.TryCancel(binanceOrder, orderManageChannel, control, func() {}, false) {
+		if m.TryCancel(exchangeOrder, orderManageChannel, control, func() {}, false) {
 			return true
 		}
 	} else {
-		log.Printf(
-			"[%s] %s Order [%s] status [%s] ttl ignored, current price is [%.10f], order price [%.10f], diff percent: %.2f",
-			binanceOrder.Symbol,
-			binanceOrder.Side,
-			binanceOrder.OrderId,
-			binanceOrder.Status,
-			kline.Close,
-			binanceOrder.Price,
-			positionPercentage.Value(),
+		m.exchangeOrderLog(*exchangeOrder).Debug(
+			"Order ttl ignored",
+			"status", exchangeOrder.Status,
+			"currentPrice", kline.Close,
+			"price", exchangeOrder.Price,
+			"diffPercent", positionPercentage.Value(),
 		)
 	}
 
@@ -918,61 +1060,51 @@ func (m *OrderExecutor) CheckIsBuyExpired(
 }
 
 func (m *OrderExecutor) CheckIsSellExpired(
-	binanceOrder *model.BinanceOrder,
+	exchangeOrder *model.ExchangeOrder,
 	orderManageChannel chan string,
 	control chan string,
 ) bool {
-	if !binanceOrder.IsSell() {
+	if !exchangeOrder.IsSell() {
 		return false
 	}
 
-	kline := m.ExchangeRepository.GetCurrentKline(binanceOrder.Symbol)
+	kline := m.ExchangeRepository.GetCurrentKline(exchangeOrder.Symbol)
 
 	if kline == nil {
 		return false
 	}
 
-	openedBuyPosition := m.OrderRepository.GetOpenedOrderCached(binanceOrder.Symbol, "BUY")
+	openedBuyPosition := m.OrderRepository.GetOpenedOrderCached(exchangeOrder.Symbol, "BUY")
 	if openedBuyPosition != nil {
 		profitPercent := openedBuyPosition.GetProfitPercent(kline.Close.Value(), m.BotService.UseSwapCapital())
 		if profitPercent.Lte(0.00) {
-			log.Printf(
-				"[%s] %s Order [%s] status [%s] ttl reached, current price is [%.10f], order price [%.10f], open [%.10f], profit: %.2f",
-				binanceOrder.Symbol,
-				binanceOrder.Side,
-				binanceOrder.OrderId,
-				binanceOrder.Status,
-				kline.Close,
-				binanceOrder.Price,
-				openedBuyPosition.Price,
-				profitPercent.Value(),
+			m.exchangeOrderLog(*exchangeOrder).Info(
+				"Order ttl reached",
+				logger.KeyOrderId, openedBuyPosition.Id,
+				"status", exchangeOrder.Status,
+				"currentPrice", kline.Close,
+				"price", exchangeOrder.Price,
+				"openPrice", openedBuyPosition.Price,
+				"profitPercent", profitPercent.Value(),
 			)
-			if m.TryCancel(binanceOrder, orderManageChannel, control, func() {}, false) {
+			if m.TryCancel(exchangeOrder, orderManageChannel, control, func() {}, false) {
 				return true
 			}
 		} else {
-			log.Printf(
-				"[%s] %s Order [%s] status [%s] ttl ignored, current price is [%.10f], order price [%.10f], open [%.10f], profit: %.2f",
-				binanceOrder.Symbol,
-				binanceOrder.Side,
-				binanceOrder.OrderId,
-				binanceOrder.Status,
-				kline.Close,
-				binanceOrder.Price,
-				openedBuyPosition.Price,
-				profitPercent.Value(),
+			m.exchangeOrderLog(*exchangeOrder).Debug(
+				"Order ttl ignored",
+				logger.KeyOrderId, openedBuyPosition.Id,
+				"status", exchangeOrder.Status,
+				"currentPrice", kline.Close,
+				"price", exchangeOrder.Price,
+				"openPrice", openedBuyPosition.Price,
+				"profitPercent", profitPercent.Value(),
 			)
 		}
 	} else {
 		// todo: redundant case???
-		log.Printf(
-			"[%s] %s Order [%s] %s",
-			binanceOrder.Symbol,
-			binanceOrder.Side,
-			binanceOrder.OrderId,
-			"Is not found",
-		)
-		if m.TryCancel(binanceOrder, orderManageChannel, control, func() {}, false) {
+		m.exchangeOrderLog(*exchangeOrder).Warn("Opened position is not found")
+		if m.TryCancel(exchangeOrder, orderManageChannel, control, func() {}, false) {
 			return true
 		}
 	}
@@ -982,29 +1114,29 @@ func (m *OrderExecutor) CheckIsSellExpired(
 
 func (m *OrderExecutor) CheckIsTimeToExtraBuy(
 	tradeLimit model.TradeLimit,
-	binanceOrder *model.BinanceOrder,
+	exchangeOrder *model.ExchangeOrder,
 	orderManageChannel chan string,
 	control chan string,
 ) bool {
-	if !binanceOrder.IsSell() {
+	if !exchangeOrder.IsSell() {
 		return false
 	}
 
-	kline := m.ExchangeRepository.GetCurrentKline(binanceOrder.Symbol)
+	kline := m.ExchangeRepository.GetCurrentKline(exchangeOrder.Symbol)
 
 	if kline == nil {
 		return false
 	}
 
-	if binanceOrder.IsNew() || binanceOrder.IsPartiallyFilled() {
-		openedBuyPosition := m.OrderRepository.GetOpenedOrderCached(binanceOrder.Symbol, "BUY")
+	if exchangeOrder.IsNew() || exchangeOrder.IsPartiallyFilled() {
+		openedBuyPosition := m.OrderRepository.GetOpenedOrderCached(exchangeOrder.Symbol, "BUY")
 		if openedBuyPosition != nil && openedBuyPosition.CanExtraBuy(*kline, m.BotService.UseSwapCapital()) && m.TradeStack.CanBuy(tradeLimit) && openedBuyPosition.GetProfitPercent(kline.Close.Value(), m.BotService.UseSwapCapital()).Lte(tradeLimit.GetBuyOnFallPercent(*openedBuyPosition, *kline, m.BotService.UseSwapCapital())) {
-			log.Printf(
-				"[%s] Extra Charge percent reached, current profit is: %.2f, SELL order is cancelled",
-				binanceOrder.Symbol,
-				openedBuyPosition.GetProfitPercent(kline.Close.Value(), m.BotService.UseSwapCapital()).Value(),
+			m.exchangeOrderLog(*exchangeOrder).Info(
+				"Extra charge percent reached, order is cancelled",
+				logger.KeyOrderId, openedBuyPosition.Id,
+				"profitPercent", openedBuyPosition.GetProfitPercent(kline.Close.Value(), m.BotService.UseSwapCapital()).Value(),
 			)
-			if m.TryCancel(binanceOrder, orderManageChannel, control, func() {}, false) {
+			if m.TryCancel(exchangeOrder, orderManageChannel, control, func() {}, false) {
 				return true
 			}
 		}
@@ -1013,39 +1145,55 @@ func (m *OrderExecutor) CheckIsTimeToExtraBuy(
 	return false
 }
 
+// CheckIsShutdown cancels waiting order on shutdown, executed quantity is saved as usual
+func (m *OrderExecutor) CheckIsShutdown(
+	exchangeOrder *model.ExchangeOrder,
+	orderManageChannel chan string,
+	control chan string,
+) bool {
+	if m.ShutdownManager == nil || !m.ShutdownManager.IsStopping() {
+		return false
+	}
+
+	m.exchangeOrderLog(*exchangeOrder).Info(
+		"Shutdown, order is cancelled",
+		"executedQuantity", exchangeOrder.ExecutedQty,
+		"quantity", exchangeOrder.OrigQty,
+	)
+
+	return m.TryCancel(exchangeOrder, orderManageChannel, control, func() {}, false)
+}
+
 func (m *OrderExecutor) CheckIsTimeToCancel(
 	tradeLimit model.TradeLimit,
-	binanceOrder *model.BinanceOrder,
+	exchangeOrder *model.ExchangeOrder,
 	orderManageChannel chan string,
 	control chan string,
 ) bool {
-	if binanceOrder.IsNew() && m.HasCancelRequest(binanceOrder.Symbol) {
-		log.Printf(
-			"[%s] Cancel request received from user",
-			binanceOrder.Symbol,
-		)
-		if m.TryCancel(binanceOrder, orderManageChannel, control, func() {}, true) {
+	if exchangeOrder.IsNew() && m.HasCancelRequest(exchangeOrder.Symbol) {
+		m.exchangeOrderLog(*exchangeOrder).Info("Cancel request received from user")
+		if m.TryCancel(exchangeOrder, orderManageChannel, control, func() {}, true) {
 			return true
 		}
 	}
 
 	// todo: add filter conditions: CanSell() + CanBuy()
 
-	if binanceOrder.IsSell() && binanceOrder.IsNew() {
-		openedBuyPosition := m.OrderRepository.GetOpenedOrderCached(binanceOrder.Symbol, "BUY")
+	if exchangeOrder.IsSell() && exchangeOrder.IsNew() {
+		openedBuyPosition := m.OrderRepository.GetOpenedOrderCached(exchangeOrder.Symbol, "BUY")
 
 		if openedBuyPosition != nil {
-			kline := m.ExchangeRepository.GetCurrentKline(binanceOrder.Symbol)
+			kline := m.ExchangeRepository.GetCurrentKline(exchangeOrder.Symbol)
 
 			if kline == nil {
 				return false
 			}
 
-			if kline.Close.Value() >= binanceOrder.Price {
+			if kline.Close.Value() >= exchangeOrder.Price {
 				return false
 			}
 
-			manualOrder := m.OrderRepository.GetManualOrder(binanceOrder.Symbol)
+			manualOrder := m.OrderRepository.GetManualOrder(exchangeOrder.Symbol)
 
 			if manualOrder != nil {
 				return false
@@ -1054,20 +1202,19 @@ func (m *OrderExecutor) CheckIsTimeToCancel(
 			// Check is sell price changed
 			newSellPrice, priceErr := m.PriceCalculator.CalculateSell(tradeLimit, *openedBuyPosition)
 			if priceErr == nil {
-				priceDiff := math.Abs(newSellPrice - m.Formatter.FormatPrice(tradeLimit, binanceOrder.Price))
+				priceDiff := math.Abs(newSellPrice - m.Formatter.FormatPrice(tradeLimit, exchangeOrder.Price))
 
 				// Allow 2 points diff
 				if priceDiff > (tradeLimit.MinPrice * 2) {
-					log.Printf(
-						"[%s] Sell Price is changed %.8f -> %.8f diff = %.8f",
-						binanceOrder.Symbol,
-						binanceOrder.Price,
-						newSellPrice,
-						priceDiff,
+					m.exchangeOrderLog(*exchangeOrder).Info(
+						"Sell price is changed",
+						"price", exchangeOrder.Price,
+						"newPrice", newSellPrice,
+						"diff", priceDiff,
 					)
 
 					// Do cancel operation
-					if m.TryCancel(binanceOrder, orderManageChannel, control, func() {}, true) {
+					if m.TryCancel(exchangeOrder, orderManageChannel, control, func() {}, true) {
 						return true
 					}
 				}
@@ -1080,16 +1227,16 @@ func (m *OrderExecutor) CheckIsTimeToCancel(
 
 func (m *OrderExecutor) CheckIsRiskyBuy(
 	tradeLimit model.TradeLimit,
-	binanceOrder *model.BinanceOrder,
+	exchangeOrder *model.ExchangeOrder,
 	orderManageChannel chan string,
 	control chan string,
 ) bool {
-	if m.LossSecurity.IsRiskyBuy(*binanceOrder, tradeLimit) {
-		log.Printf("[%s] (LossSecurity) Check status signal sent!", binanceOrder.Symbol)
+	if m.LossSecurity.IsRiskyBuy(*exchangeOrder, tradeLimit) {
+		m.exchangeOrderLog(*exchangeOrder).Warn("Risky buy, check status signal sent")
 		lockCallback := func() {
-			m.OrderRepository.LockBuy(binanceOrder.Symbol, 10)
+			m.OrderRepository.LockBuy(exchangeOrder.Symbol, 10)
 		}
-		if m.TryCancel(binanceOrder, orderManageChannel, control, lockCallback, true) {
+		if m.TryCancel(exchangeOrder, orderManageChannel, control, lockCallback, true) {
 			return true
 		}
 	}
@@ -1098,32 +1245,49 @@ func (m *OrderExecutor) CheckIsRiskyBuy(
 }
 
 func (m *OrderExecutor) CheckIsTimeToSell(
-	binanceOrder *model.BinanceOrder,
+	exchangeOrder *model.ExchangeOrder,
 	orderManageChannel chan string,
 	control chan string,
 ) bool {
-	if !binanceOrder.IsBuy() {
+	if !exchangeOrder.IsBuy() && m.StopLossService == nil {
 		return false
 	}
 
-	kline := m.ExchangeRepository.GetCurrentKline(binanceOrder.Symbol)
+	kline := m.ExchangeRepository.GetCurrentKline(exchangeOrder.Symbol)
 
 	if kline == nil {
 		return false
 	}
 
-	openedBuyPosition := m.OrderRepository.GetOpenedOrderCached(binanceOrder.Symbol, "BUY")
+	openedBuyPosition := m.OrderRepository.GetOpenedOrderCached(exchangeOrder.Symbol, "BUY")
+
+	// Extra BUY order or SELL order above current price blocks position closing by stop loss
+	if openedBuyPosition != nil && m.StopLossService != nil && (exchangeOrder.IsBuy() || exchangeOrder.Price > kline.Close.Value()) {
+		if closeReason, isTriggered := m.StopLossService.Check(*openedBuyPosition, kline.Close.Value()); isTriggered {
+			m.exchangeOrderLog(*exchangeOrder).Info(
+				"Stop triggered, order is cancelled",
+				logger.KeyOrderId, openedBuyPosition.Id,
+				"closeReason", closeReason,
+				"currentPrice", kline.Close.Value(),
+			)
+			if m.TryCancel(exchangeOrder, orderManageChannel, control, func() {}, false) {
+				return true
+			}
+		}
+	}
+
+	if !exchangeOrder.IsBuy() {
+		return false
+	}
 
 	// [BUY] Check is it time to sell (maybe we have already partially filled)
-	if openedBuyPosition != nil && binanceOrder.IsPartiallyFilled() && binanceOrder.GetProfitPercent(kline.Close.Value()).Gte(m.ProfitService.GetMinProfitPercent(openedBuyPosition)) {
-		log.Printf(
-			"[%s] Max profit percent reached, current profit is: %.2f, %s [%s] order is cancelled",
-			binanceOrder.Symbol,
-			binanceOrder.GetProfitPercent(kline.Close.Value()).Value(),
-			binanceOrder.Side,
-			binanceOrder.OrderId,
+	if openedBuyPosition != nil && exchangeOrder.IsPartiallyFilled() && exchangeOrder.GetProfitPercent(kline.Close.Value()).Gte(m.ProfitService.GetMinProfitPercent(openedBuyPosition)) {
+		m.exchangeOrderLog(*exchangeOrder).Info(
+			"Max profit percent reached, order is cancelled",
+			logger.KeyOrderId, openedBuyPosition.Id,
+			"profitPercent", exchangeOrder.GetProfitPercent(kline.Close.Value()).Value(),
 		)
-		if m.TryCancel(binanceOrder, orderManageChannel, control, func() {}, false) {
+		if m.TryCancel(exchangeOrder, orderManageChannel, control, func() {}, false) {
 			return true
 		}
 	}
@@ -1158,18 +1322,16 @@ func (m *OrderExecutor) HasSwapOption(openedBuyPosition *model.Order) *model.Swa
 
 			if violation == nil {
 				chainCurrentPercent := m.SwapValidator.CalculatePercent(possibleSwap)
-				log.Printf(
-					"[%s] Swap chain [%s] is found for order #%d, initial percent: %.2f, current = %.2f",
-					openedBuyPosition.Symbol,
-					swapChain.Title,
-					openedBuyPosition.Id,
-					swapChain.Percent,
-					chainCurrentPercent,
+				m.orderLog(*openedBuyPosition).Info(
+					"Swap chain is found",
+					"swapChain", swapChain.Title,
+					"initialPercent", swapChain.Percent,
+					"currentPercent", chainCurrentPercent,
 				)
 
 				return &possibleSwap
 			} else {
-				log.Printf("CheckIsTimeToSwap: %s", violation.Error())
+				m.orderLog(*openedBuyPosition).Debug("Swap chain is not valid", logger.Error(violation))
 			}
 		}
 	}
@@ -1178,7 +1340,7 @@ func (m *OrderExecutor) HasSwapOption(openedBuyPosition *model.Order) *model.Swa
 }
 
 func (m *OrderExecutor) CheckIsTimeToSwap(
-	binanceOrder *model.BinanceOrder,
+	exchangeOrder *model.ExchangeOrder,
 	orderManageChannel chan string,
 	control chan string,
 ) bool {
@@ -1186,14 +1348,14 @@ func (m *OrderExecutor) CheckIsTimeToSwap(
 		return false
 	}
 
-	kline := m.ExchangeRepository.GetCurrentKline(binanceOrder.Symbol)
+	kline := m.ExchangeRepository.GetCurrentKline(exchangeOrder.Symbol)
 
 	if kline == nil {
 		return false
 	}
 
-	if binanceOrder.IsSell() && binanceOrder.IsNew() {
-		openedBuyPosition := m.OrderRepository.GetOpenedOrderCached(binanceOrder.Symbol, "BUY")
+	if exchangeOrder.IsSell() && exchangeOrder.IsNew() {
+		openedBuyPosition := m.OrderRepository.GetOpenedOrderCached(exchangeOrder.Symbol, "BUY")
 
 		// Try arbitrage for long orders >= 4 hours and with profit < -1.00%
 		if openedBuyPosition != nil {
@@ -1202,7 +1364,7 @@ func (m *OrderExecutor) CheckIsTimeToSwap(
 				swapCallback := func() {
 					m.MakeSwap(*openedBuyPosition, *possibleSwap)
 				}
-				if m.TryCancel(binanceOrder, orderManageChannel, control, swapCallback, true) {
+				if m.TryCancel(exchangeOrder, orderManageChannel, control, swapCallback, true) {
 					return true
 				}
 			}
@@ -1213,7 +1375,7 @@ func (m *OrderExecutor) CheckIsTimeToSwap(
 }
 
 func (m *OrderExecutor) TryCancel(
-	binanceOrder *model.BinanceOrder,
+	exchangeOrder *model.ExchangeOrder,
 	orderManageChannel chan string,
 	control chan string,
 	callback func(),
@@ -1227,8 +1389,8 @@ func (m *OrderExecutor) TryCancel(
 		}
 	}
 
-	if binanceOrder.IsNew() || !checkStatus {
-		log.Printf("[%s] Cancel signal sent!", binanceOrder.Symbol)
+	if exchangeOrder.IsNew() || !checkStatus {
+		m.exchangeOrderLog(*exchangeOrder).Info("Cancel signal sent")
 		orderManageChannel <- "cancel"
 		action := <-control
 		if action == "stop" {
@@ -1241,10 +1403,10 @@ func (m *OrderExecutor) TryCancel(
 }
 
 func (m *OrderExecutor) CalculateSellQuantity(order model.Order) float64 {
-	binanceOrder := m.OrderRepository.GetBinanceOrder(order.Symbol, "SELL")
+	exchangeOrder := m.OrderRepository.GetExchangeOrder(order.Symbol, "SELL")
 
-	if binanceOrder != nil {
-		return binanceOrder.OrigQty
+	if exchangeOrder != nil {
+		return exchangeOrder.OrigQty
 	}
 
 	m.recoverCommission(order)
@@ -1267,11 +1429,20 @@ func (m *OrderExecutor) MakeSwap(order model.Order, swapChain model.SwapChainEnt
 	baseAsset := order.GetBaseAsset()
 
 	if baseAsset != swapChain.SwapOne.BaseAsset {
-		log.Printf("[%s] Wrong swap asset given %s, expected %s", order.Symbol, swapChain.SwapOne.BaseAsset, baseAsset)
+		m.orderLog(order).Error("Wrong swap asset given", "asset", swapChain.SwapOne.BaseAsset, "expected", baseAsset)
 
 		return
 	}
 
+	if m.ProtectionService != nil && order.Protection != nil {
+		err := m.ProtectionService.Unprotect(order)
+		if err != nil {
+			m.orderLog(order).Error("Swap protection cancel failed", logger.Error(err))
+
+			return
+		}
+	}
+
 	assetBalance, err := m.BalanceService.GetAssetBalance(baseAsset, false)
 
 	if err != nil {
@@ -1286,14 +1457,14 @@ func (m *OrderExecutor) MakeSwap(order model.Order, swapChain model.SwapChainEnt
 	swapAction, err := m.SwapRepository.GetActiveSwapAction(order)
 
 	if err == nil {
-		log.Printf("[%s] Swap has already exists: %s", swapChain.SwapOne.BaseAsset, swapAction.Status)
+		m.orderLog(order).Info("Swap already exists", logger.KeySwapActionId, swapAction.Id, "status", swapAction.Status)
 
 		return
 	}
 
 	// todo: transaction
 	// create swap
-	_, err = m.SwapRepository.CreateSwapAction(model.SwapAction{
+	swapActionId, err := m.SwapRepository.CreateSwapAction(model.SwapAction{
 		Id:              0,
 		OrderId:         order.Id,
 		BotId:           m.CurrentBot.Id,
@@ -1314,11 +1485,7 @@ func (m *OrderExecutor) MakeSwap(order model.Order, swapChain model.SwapChainEnt
 	})
 
 	if err != nil {
-		log.Printf(
-			"[%s] Swap couldn't be created: %s",
-			swapChain.SwapOne.BaseAsset,
-			err.Error(),
-		)
+		m.orderLog(order).Error("Swap couldn't be created", "swapChain", swapChain.Title, logger.Error(err))
 
 		return
 	}
@@ -1327,7 +1494,11 @@ func (m *OrderExecutor) MakeSwap(order model.Order, swapChain model.SwapChainEnt
 	order.Swap = true
 	err = m.OrderRepository.Update(order)
 	if err == nil {
-		log.Printf("[%s] Swap order mode enabled [%s]", order.Symbol, swapChain.Title)
+		opLog := m.orderLog(order)
+		if swapActionId != nil {
+			opLog = opLog.With(logger.KeySwapActionId, *swapActionId)
+		}
+		opLog.Info("Swap order mode enabled", "swapChain", swapChain.Title)
 	}
 }
 
@@ -1336,7 +1507,7 @@ func (m *OrderExecutor) UpdateCommission(balanceBefore float64, order model.Orde
 	balanceAfter, err := m.BalanceService.GetAssetBalance(assetSymbol, true)
 
 	if err != nil {
-		log.Printf("[%s] Can't update commission: %s", order.Status, err.Error())
+		m.orderLog(order).Error("Can't update commission", logger.Error(err))
 		return
 	}
 
@@ -1353,10 +1524,204 @@ func (m *OrderExecutor) UpdateCommission(balanceBefore float64, order model.Orde
 
 	err = m.OrderRepository.Update(order)
 	if err != nil {
-		log.Printf("[%s] Order Commission Update: %s", order.Symbol, err.Error())
+		m.orderLog(order).Error("Order commission update failed", logger.Error(err))
 	}
 }
 
+// Resume continues unfinished order operation after restart with the same params, it is not decided again
+func (m *OrderExecutor) Resume(symbol string) {
+	if m.LifecycleRepository == nil {
+		return
+	}
+
+	for _, side := range []string{"BUY", "SELL"} {
+		lifecycle := m.LifecycleRepository.GetOrderLifecycle(symbol, side)
+		if lifecycle == nil {
+			continue
+		}
+		opLog := m.lifecycleLog(*lifecycle)
+
+		// placement is not finished, order is found in exchange if request has been processed
+		if lifecycle.IsPending() {
+			exchangeOrder, _ := m.findExchangeOrder(symbol, side, false)
+			if exchangeOrder == nil {
+				opLog.Warn("Resume: order is not placed, operation is dropped")
+				m.transitLifecycle(symbol, side, model.OrderLifecycleStateFailed, nil)
+				continue
+			}
+		}
+
+		opLog.Info("Resume operation", "state", lifecycle.State)
+		err := m.resumeLifecycle(*lifecycle)
+		if err == nil {
+			continue
+		}
+
+		opLog.Error("Resume operation failed", logger.Error(err))
+
+		// exchange order is resolved, but position couldn't be changed by it
+		if m.LifecycleRepository.GetOrderLifecycle(symbol, side) != nil && m.OrderRepository.GetExchangeOrder(symbol, side) == nil {
+			opLog.Warn("Resume: operation is dropped")
+			m.LifecycleRepository.DeleteOrderLifecycle(symbol, side)
+		}
+	}
+}
+
+func (m *OrderExecutor) resumeLifecycle(lifecycle model.OrderLifecycle) error {
+	tradeLimit, err := m.ExchangeRepository.GetTradeLimit(lifecycle.Symbol)
+	if err != nil {
+		return err
+	}
+
+	if lifecycle.Operation == model.OrderLifecycleOperationBuy {
+		return m.Buy(tradeLimit, lifecycle.Price, lifecycle.Quantity, lifecycle.Signal, lifecycle.Explanation)
+	}
+
+	if lifecycle.OpenedOrderId == nil {
+		return errors.New(fmt.Sprintf("opened order is unknown for %s operation", lifecycle.Operation))
+	}
+
+	opened, err := m.OrderRepository.Find(*lifecycle.OpenedOrderId)
+	if err != nil {
+		return err
+	}
+
+	if lifecycle.Operation == model.OrderLifecycleOperationExtraBuy {
+		return m.BuyExtra(tradeLimit, opened, lifecycle.Price, lifecycle.Explanation)
+	}
+
+	closeReason := model.OrderCloseReasonTakeProfit
+	if lifecycle.CloseReason != nil {
+		closeReason = *lifecycle.CloseReason
+	}
+
+	return m.Sell(tradeLimit, opened, lifecycle.Price, lifecycle.Quantity, closeReason, lifecycle.Explanation)
+}
+
+// startLifecycle keeps state of resumed operation
+func (m *OrderExecutor) startLifecycle(lifecycle model.OrderLifecycle) {
+	if m.LifecycleRepository == nil || m.LifecycleRepository.GetOrderLifecycle(lifecycle.Symbol, lifecycle.Side) != nil {
+		return
+	}
+
+	now := m.TimeService.GetNowUnix()
+	lifecycle.State = model.OrderLifecycleStatePending
+	lifecycle.CreatedAt = now
+	lifecycle.UpdatedAt = now
+
+	err := m.LifecycleRepository.SaveOrderLifecycle(lifecycle)
+	if err != nil {
+		m.lifecycleLog(lifecycle).Error("Order lifecycle save error", logger.Error(err))
+	}
+}
+
+func (m *OrderExecutor) transitLifecycle(symbol string, side string, state string, exchangeOrderId *string) {
+	if m.LifecycleRepository == nil {
+		return
+	}
+
+	lifecycle := m.LifecycleRepository.GetOrderLifecycle(symbol, side)
+	if lifecycle == nil {
+		return
+	}
+
+	err := lifecycle.Transit(state, m.TimeService.GetNowUnix())
+	if err != nil {
+		m.lifecycleLog(*lifecycle).Error("Order lifecycle transition error", logger.Error(err))
+		return
+	}
+
+	if exchangeOrderId != nil {
+		lifecycle.ExchangeOrderId = exchangeOrderId
+	}
+
+	if lifecycle.IsFinal() {
+		m.lifecycleLog(*lifecycle).Info("Operation is finished", "state", state)
+		m.LifecycleRepository.DeleteOrderLifecycle(symbol, side)
+		return
+	}
+
+	err = m.LifecycleRepository.SaveOrderLifecycle(*lifecycle)
+	if err != nil {
+		m.lifecycleLog(*lifecycle).Error("Order lifecycle save error", logger.Error(err))
+	}
+}
+
+// operationLog returns logger of buy, extra charge or sell operation, correlation id is kept in decision explanation
+// which is saved with order and lifecycle, so the operation is logged with the same id after resume
+func (m *OrderExecutor) operationLog(symbol string, side string, operation string, explanation *model.DecisionExplanation) *slog.Logger {
+	correlationId := ""
+	strategies := make([]string, 0)
+	if explanation != nil {
+		if explanation.CorrelationId == "" {
+			explanation.CorrelationId = logger.NewCorrelationId()
+		}
+		correlationId = explanation.CorrelationId
+		strategies = explanation.GetStrategyNames(side)
+	} else {
+		correlationId = logger.NewCorrelationId()
+	}
+
+	return slog.With(
+		logger.KeySymbol, symbol,
+		logger.KeyOperation, operation,
+		logger.KeyExchange, m.CurrentBot.Exchange,
+		logger.KeyStrategy, strings.Join(strategies, ","),
+		logger.KeyCorrelationId, correlationId,
+	)
+}
+
+func (m *OrderExecutor) lifecycleLog(lifecycle model.OrderLifecycle) *slog.Logger {
+	l := slog.With(
+		logger.KeySymbol, lifecycle.Symbol,
+		logger.KeyOperation, lifecycle.Operation,
+		logger.KeyExchange, m.CurrentBot.Exchange,
+	)
+
+	if lifecycle.OpenedOrderId != nil {
+		l = l.With(logger.KeyOrderId, *lifecycle.OpenedOrderId)
+	}
+	if lifecycle.ExchangeOrderId != nil {
+		l = l.With(logger.KeyExchangeOrderId, *lifecycle.ExchangeOrderId)
+	}
+	if lifecycle.Explanation != nil && lifecycle.Explanation.CorrelationId != "" {
+		l = l.With(logger.KeyCorrelationId, lifecycle.Explanation.CorrelationId)
+	}
+
+	return l
+}
+
+func (m *OrderExecutor) orderLog(order model.Order) *slog.Logger {
+	return newOrderLog(order, m.CurrentBot)
+}
+
+// newOrderLog keeps correlation id of the decision which opened the order, it is shared by order related services
+func newOrderLog(order model.Order, bot *model.Bot) *slog.Logger {
+	l := slog.With(
+		logger.KeySymbol, order.Symbol,
+		logger.KeyOrderId, order.Id,
+	)
+
+	if bot != nil {
+		l = l.With(logger.KeyExchange, bot.Exchange)
+	}
+	if order.Explanation != nil && order.Explanation.CorrelationId != "" {
+		l = l.With(logger.KeyCorrelationId, order.Explanation.CorrelationId)
+	}
+
+	return l
+}
+
+// exchangeOrderLog is used by order watchers, they are not aware of the operation
+func (m *OrderExecutor) exchangeOrderLog(exchangeOrder model.ExchangeOrder) *slog.Logger {
+	return slog.With(
+		logger.KeySymbol, exchangeOrder.Symbol,
+		logger.KeyOperation, strings.ToLower(exchangeOrder.Side),
+		logger.KeyExchangeOrderId, exchangeOrder.OrderId,
+		logger.KeyExchange, m.CurrentBot.Exchange,
+	)
+}
+
 func (m *OrderExecutor) isTradeLocked(symbol string) bool {
 	m.TradeLockMutex.Lock()
 	isLocked, _ := m.Lock[symbol]
@@ -1373,11 +1738,11 @@ func (m *OrderExecutor) releaseLock(symbol string) {
 	*m.LockChannel <- model.Lock{IsLocked: false, Symbol: symbol}
 }
 
-func (m *OrderExecutor) findBinanceOrder(symbol string, operation string, cachedOnly bool) (*model.BinanceOrder, error) {
-	cached := m.OrderRepository.GetBinanceOrder(symbol, operation)
+func (m *OrderExecutor) findExchangeOrder(symbol string, operation string, cachedOnly bool) (*model.ExchangeOrder, error) {
+	cached := m.OrderRepository.GetExchangeOrder(symbol, operation)
 
 	if cached != nil {
-		log.Printf("[%s] findBinanceOrder: Found cached %s order %s in %s, status = %s", symbol, operation, cached.OrderId, m.CurrentBot.Exchange, cached.Status)
+		m.exchangeOrderLog(*cached).Debug("Cached order is found", "status", cached.Status)
 
 		return cached, nil
 	}
@@ -1389,14 +1754,19 @@ func (m *OrderExecutor) findBinanceOrder(symbol string, operation string, cached
 	openedOrders, err := m.Binance.GetOpenedOrders()
 
 	if err != nil {
-		log.Printf("[%s] Opened: %s", symbol, err.Error())
+		slog.Error("Opened orders request failed", logger.KeySymbol, symbol, logger.KeyExchange, m.CurrentBot.Exchange, logger.Error(err))
 		return nil, err
 	}
 
 	for _, opened := range openedOrders {
+		// protection orders are managed by ProtectionService
+		if opened.IsProtection() {
+			continue
+		}
+
 		if opened.Side == operation && opened.Symbol == symbol {
-			log.Printf("[%s] Found opened %s order %s in %s, status = %s", symbol, operation, opened.OrderId, m.CurrentBot.Exchange, opened.Status)
-			m.OrderRepository.SetBinanceOrder(opened)
+			m.exchangeOrderLog(opened).Info("Opened order is found", "status", opened.Status)
+			m.OrderRepository.SetExchangeOrder(opened)
 
 			return &opened, nil
 		}
@@ -1405,32 +1775,80 @@ func (m *OrderExecutor) findBinanceOrder(symbol string, operation string, cached
 	return nil, errors.New(fmt.Sprintf("[%s] %s order is not found", symbol, m.CurrentBot.Exchange))
 }
 
-func (m *OrderExecutor) findOrCreateOrder(order model.Order, operation string) (model.BinanceOrder, error) {
+func (m *OrderExecutor) findOrCreateOrder(order model.Order, operation string, opLog *slog.Logger) (model.ExchangeOrder, error) {
 	// todo: extra order flag...
-	cached, err := m.findBinanceOrder(order.Symbol, operation, false)
+	cached, err := m.findExchangeOrder(order.Symbol, operation, false)
 
 	if cached != nil {
-		log.Printf("[%s] findOrCreateOrder Found cached %s order %s in %s, status = %s", order.Symbol, operation, cached.OrderId, m.CurrentBot.Exchange, cached.Status)
+		opLog.Info("Existing order is found", logger.KeyExchangeOrderId, cached.OrderId, "status", cached.Status)
+
+		if cached.IsNew() || cached.IsPartiallyFilled() {
+			m.transitLifecycle(order.Symbol, operation, model.OrderLifecycleStatePlaced, &cached.OrderId)
+		}
 
 		return *cached, nil
 	}
 
-	binanceOrder, err := m.Binance.LimitOrder(order.Symbol, order.Quantity, order.Price, operation, "GTC")
+	orderType := m.getOrderType(order, operation)
+	exchangeOrder, err := m.placeOrder(order, operation, orderType)
 
 	if err != nil {
-		log.Printf("[%s] Limit: %s", order.Symbol, err.Error())
-		return binanceOrder, err
+		opLog.Error("Order placement failed", "orderType", orderType, logger.Error(err))
+		return exchangeOrder, err
 	}
 
-	log.Printf("[%s] %s Order created %s, Price: %.6f", order.Symbol, operation, binanceOrder.OrderId, binanceOrder.Price)
-	m.OrderRepository.SetBinanceOrder(binanceOrder)
+	opLog.Info("Order created", logger.KeyExchangeOrderId, exchangeOrder.OrderId, "orderType", orderType, "price", exchangeOrder.Price, "quantity", exchangeOrder.OrigQty)
+	metrics.OrdersPlaced.Inc(order.Symbol, operation)
+	m.OrderRepository.SetExchangeOrder(exchangeOrder)
+	m.transitLifecycle(order.Symbol, operation, model.OrderLifecycleStatePlaced, &exchangeOrder.OrderId)
 	if order.IsBuy() {
-		m.BalanceService.InvalidateBalanceCache("USDT")
+		m.BalanceService.InvalidateBalanceCache(order.GetQuoteAsset())
 	} else {
 		m.BalanceService.InvalidateBalanceCache(order.GetBaseAsset())
 	}
 
-	return binanceOrder, nil
+	return exchangeOrder, nil
+}
+
+// getOrderType returns MARKET for stop loss exit, manual order can set any supported order type
+func (m *OrderExecutor) getOrderType(order model.Order, operation string) string {
+	if order.IsStopClose() {
+		return model.OrderTypeMarket
+	}
+
+	manualOrder := m.OrderRepository.GetManualOrder(order.Symbol)
+	if manualOrder != nil && strings.ToUpper(manualOrder.Operation) == operation && manualOrder.OrderType != "" {
+		return manualOrder.OrderType
+	}
+
+	return model.OrderTypeLimit
+}
+
+func (m *OrderExecutor) placeOrder(order model.Order, operation string, orderType string) (model.ExchangeOrder, error) {
+	switch orderType {
+	case model.OrderTypeMarket:
+		if operation == "BUY" {
+			// spend exact amount of quote asset, quantity depends on the order book
+			return m.Binance.QuoteMarketOrder(order.Symbol, m.Formatter.ToFixed(order.Quantity*order.Price, 8), operation)
+		}
+
+		return m.Binance.MarketOrder(order.Symbol, order.Quantity, operation)
+	case model.OrderTypeLimitMaker:
+		return m.Binance.PostOnlyOrder(order.Symbol, order.Quantity, order.Price, operation)
+	default:
+		return m.Binance.LimitOrder(order.Symbol, order.Quantity, order.Price, operation, "GTC")
+	}
+}
+
+func (m *OrderExecutor) protect(order model.Order) {
+	if m.ProtectionService == nil {
+		return
+	}
+
+	err := m.ProtectionService.Protect(order)
+	if err != nil {
+		m.orderLog(order).Error("Protection failed", logger.Error(err))
+	}
 }
 
 func (m *OrderExecutor) GetAvgPrice(opened model.Order, extra model.Order) float64 {
@@ -1446,7 +1864,7 @@ func (m *OrderExecutor) recoverCommission(order model.Order) {
 	balanceAfter, err := m.BalanceService.GetAssetBalance(assetSymbol, true)
 
 	if err != nil {
-		log.Printf("[%s] Can't recover commission: %s", order.Status, err.Error())
+		m.orderLog(order).Error("Can't recover commission", logger.Error(err))
 		return
 	}
 
@@ -1459,25 +1877,25 @@ func (m *OrderExecutor) recoverCommission(order model.Order) {
 
 	err = m.OrderRepository.Update(order)
 	if err != nil {
-		log.Printf("[%s] Order Commission Recover: %s", order.Symbol, err.Error())
+		m.orderLog(order).Error("Order commission recover failed", logger.Error(err))
 	}
 }
 
-func (m *OrderExecutor) CheckBalance(symbol string, priceUsdt float64, quantity float64) error {
-	cached, _ := m.findBinanceOrder(symbol, "BUY", true)
+func (m *OrderExecutor) CheckBalance(limit model.TradeLimit, price float64, quantity float64) error {
+	cached, _ := m.findExchangeOrder(limit.Symbol, "BUY", true)
 
 	// Check balance for new order
 	if cached == nil {
-		usdtAvailableBalance, err := m.BalanceService.GetAssetBalance("USDT", true)
+		availableBalance, err := m.BalanceService.GetAssetBalance(limit.GetQuoteAsset(), true)
 
 		if err != nil {
-			return errors.New(fmt.Sprintf("[%s] BUY balance error: %s", symbol, err.Error()))
+			return errors.New(fmt.Sprintf("[%s] BUY balance error: %s", limit.Symbol, err.Error()))
 		}
 
-		requiredUsdtAmount := priceUsdt * quantity
+		requiredAmount := price * quantity
 
-		if requiredUsdtAmount > usdtAvailableBalance {
-			return errors.New(fmt.Sprintf("[%s] BUY not enough balance: %f/%f", symbol, usdtAvailableBalance, requiredUsdtAmount))
+		if requiredAmount > availableBalance {
+			return errors.New(fmt.Sprintf("[%s] BUY not enough %s balance: %f/%f", limit.Symbol, limit.GetQuoteAsset(), availableBalance, requiredAmount))
 		}
 	}
 
@@ -1486,24 +1904,24 @@ func (m *OrderExecutor) CheckBalance(symbol string, priceUsdt float64, quantity
 
 func (m *OrderExecutor) CheckMinBalance(limit model.TradeLimit, kLine model.KLine) error {
 	opened := m.OrderRepository.GetOpenedOrderCached(limit.Symbol, "BUY")
-	limitUsdt := limit.USDTLimit
+	budget := limit.USDTLimit
 
 	if opened != nil {
-		limitUsdt = opened.GetAvailableExtraBudget(kLine, m.BotService.UseSwapCapital())
+		budget = opened.GetAvailableExtraBudget(kLine, m.BotService.UseSwapCapital())
 	}
 
-	cached, _ := m.findBinanceOrder(limit.Symbol, "BUY", true)
+	cached, _ := m.findExchangeOrder(limit.Symbol, "BUY", true)
 
 	// Check balance for new order
 	if cached == nil {
-		usdtAvailableBalance, err := m.BalanceService.GetAssetBalance("USDT", true)
+		availableBalance, err := m.BalanceService.GetAssetBalance(limit.GetQuoteAsset(), true)
 
 		if err != nil {
 			return errors.New(fmt.Sprintf("[%s] BUY balance error: %s", limit.Symbol, err.Error()))
 		}
 
-		if limitUsdt > usdtAvailableBalance {
-			return errors.New(fmt.Sprintf("[%s] BUY not enough balance: %f/%f", limit.Symbol, usdtAvailableBalance, limitUsdt))
+		if budget > availableBalance {
+			return errors.New(fmt.Sprintf("[%s] BUY not enough %s balance: %f/%f", limit.Symbol, limit.GetQuoteAsset(), availableBalance, budget))
 		}
 	}
 
@@ -7,7 +7,6 @@ import (
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
 	"gitlab.com/open-soft/go-crypto-bot/src/service"
 	"gitlab.com/open-soft/go-crypto-bot/src/utils"
-	"strings"
 )
 
 type PriceCalculatorInterface interface {
@@ -134,7 +133,9 @@ func (m *PriceCalculator) GetBestFrameBuy(limit model.TradeLimit, marketDepth mo
 }
 
 func (m *PriceCalculator) InterpolatePrice(limit model.TradeLimit) model.Interpolation {
-	asset := strings.ReplaceAll(limit.Symbol, "USDT", "")
+	asset := limit.GetBaseAsset()
+	// interpolated prices are in quote asset of the trade limit, BTC and ETH prices are taken from BTC/ETH + quote pairs
+	quoteAsset := limit.GetQuoteAsset()
 	btcPair, err := m.ExchangeRepository.GetSwapPairsByAssets("BTC", asset)
 
 	interpolation := model.Interpolation{
@@ -143,9 +144,9 @@ func (m *PriceCalculator) InterpolatePrice(limit model.TradeLimit) model.Interpo
 		EthInterpolationUsdt: 0.00,
 	}
 
-	if err == nil {
+	if err == nil && quoteAsset != "BTC" {
 		priceXBtc := btcPair.BuyPrice
-		lastKlineBtc := m.ExchangeRepository.GetCurrentKline("BTCUSDT")
+		lastKlineBtc := m.ExchangeRepository.GetCurrentKline("BTC" + quoteAsset)
 		if lastKlineBtc != nil && !lastKlineBtc.IsPriceExpired() && !btcPair.IsPriceExpired() {
 			interpolation.BtcInterpolationUsdt = m.Formatter.FormatPrice(limit, priceXBtc*lastKlineBtc.Close.Value())
 		}
@@ -153,9 +154,9 @@ func (m *PriceCalculator) InterpolatePrice(limit model.TradeLimit) model.Interpo
 
 	ethPair, err := m.ExchangeRepository.GetSwapPairsByAssets("ETH", asset)
 
-	if err == nil {
+	if err == nil && quoteAsset != "ETH" {
 		priceXEth := ethPair.BuyPrice
-		lastKlineEth := m.ExchangeRepository.GetCurrentKline("ETHUSDT")
+		lastKlineEth := m.ExchangeRepository.GetCurrentKline("ETH" + quoteAsset)
 		if lastKlineEth != nil && !lastKlineEth.IsPriceExpired() && !ethPair.IsPriceExpired() {
 			interpolation.EthInterpolationUsdt = m.Formatter.FormatPrice(limit, priceXEth*lastKlineEth.Close.Value())
 		}
@@ -2,9 +2,10 @@ package exchange
 
 import (
 	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/service"
-	"log"
+	"log/slog"
 	"math"
 	"sort"
 )
@@ -71,7 +72,7 @@ func (p *ProfitService) CheckBuyPriceOnHistory(limit model.TradeLimit, buyPrice
 
 func (p *ProfitService) GetMinProfitPercent(order model.ProfitPositionInterface) model.Percent {
 	minAllowedValue := model.Percent(0.5)
-	profitOptions := order.GetProfitOptions()
+	profitOptions := order.GetProfitOptions().GetTakeProfitOptions()
 	sort.SliceStable(profitOptions, func(i int, j int) bool {
 		return profitOptions[i].Index < profitOptions[j].Index
 	})
@@ -82,7 +83,7 @@ func (p *ProfitService) GetMinProfitPercent(order model.ProfitPositionInterface)
 		optionPositionTime, err := option.GetPositionTime()
 
 		if err != nil {
-			log.Printf("[%s] Price Calculator: profit position [%d] time is invalid", order.GetSymbol(), index)
+			slog.Warn("Profit position time is invalid", logger.KeySymbol, order.GetSymbol(), "profitOption", index)
 			continue
 		}
 
@@ -0,0 +1,227 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"gitlab.com/open-soft/go-crypto-bot/src/service"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"log/slog"
+)
+
+type ProtectionServiceInterface interface {
+	Protect(order model.Order) error
+	Unprotect(order model.Order) error
+	CheckExecution(order model.Order) bool
+}
+
+// ProtectionService keeps exchange side take profit and stop loss pair for opened position,
+// position is closed by exchange even if the bot is stopped or disconnected
+type ProtectionService struct {
+	Binance            client.ExchangeOrderAPIInterface
+	ProtectionApi      client.ExchangeProtectionAPIInterface
+	OrderRepository    repository.OrderStorageInterface
+	ExchangeRepository repository.BaseTradeStorageInterface
+	ProfitService      ProfitServiceInterface
+	BalanceService     BalanceServiceInterface
+	RiskManager        RiskManagerInterface
+	BotService         service.BotServiceInterface
+	CallbackManager    service.CallbackManagerInterface
+	Formatter          *utils.Formatter
+	TimeService        utils.TimeServiceInterface
+	CurrentBot         *model.Bot
+}
+
+func (p *ProtectionService) isEnabled() bool {
+	return p.ProtectionApi != nil && p.BotService.GetBot().RiskConfig.ExchangeProtection
+}
+
+// Protect places (or replaces if quantity or prices are changed) protection pair for opened position
+func (p *ProtectionService) Protect(order model.Order) error {
+	if !p.isEnabled() {
+		return nil
+	}
+
+	refreshOrder, err := p.OrderRepository.Find(order.Id)
+	if err != nil {
+		return err
+	}
+	order = refreshOrder
+
+	stopLoss := order.ProfitOptions.GetStopLoss()
+	if !order.IsOpened() || stopLoss == nil {
+		return p.Unprotect(order)
+	}
+
+	tradeLimit, err := p.ExchangeRepository.GetTradeLimit(order.Symbol)
+	if err != nil {
+		return err
+	}
+
+	quantity := order.GetRemainingToSellQuantity(p.BotService.UseSwapCapital())
+	balance, err := p.BalanceService.GetAssetBalance(order.GetBaseAsset(), false)
+	if order.Protection != nil {
+		// protected quantity is locked and is not a part of free balance
+		balance += order.Protection.Quantity
+	}
+	if err == nil && balance < quantity {
+		quantity = balance
+	}
+
+	quantity = p.Formatter.FormatQuantity(tradeLimit, quantity)
+	takeProfitPrice := p.Formatter.FormatPrice(tradeLimit, p.ProfitService.GetMinClosePrice(order, order.Price))
+	stopPrice := p.Formatter.FormatPrice(tradeLimit, order.Price*(100+stopLoss.OptionPercent.Value())/100)
+
+	if order.Protection != nil && order.Protection.IsSame(quantity, takeProfitPrice, stopPrice) {
+		return nil
+	}
+
+	if quantity*stopPrice < tradeLimit.MinNotional {
+		return errors.New(fmt.Sprintf(
+			"[%s] Protection quantity %f is less than min notional %.2f",
+			order.Symbol,
+			quantity,
+			tradeLimit.MinNotional,
+		))
+	}
+
+	err = p.Unprotect(order)
+	if err != nil {
+		return err
+	}
+	order.Protection = nil
+
+	protection, err := p.ProtectionApi.ProtectionOrder(order.Symbol, quantity, takeProfitPrice, stopPrice)
+	p.BalanceService.InvalidateBalanceCache(order.GetBaseAsset())
+
+	if err != nil {
+		return err
+	}
+
+	p.orderLog(order).Info(
+		"Protection is placed",
+		"quantity", quantity,
+		"takeProfit", takeProfitPrice,
+		"stop", stopPrice,
+	)
+
+	order.Protection = &protection
+
+	return p.OrderRepository.Update(order)
+}
+
+// Unprotect cancels protection pair, it has to be done before the bot sells the position itself
+func (p *ProtectionService) Unprotect(order model.Order) error {
+	if order.Protection == nil || p.ProtectionApi == nil {
+		return nil
+	}
+
+	err := p.ProtectionApi.CancelProtectionOrder(order.Symbol, *order.Protection)
+	p.BalanceService.InvalidateBalanceCache(order.GetBaseAsset())
+
+	if err != nil {
+		return err
+	}
+
+	p.orderLog(order).Info("Protection is cancelled")
+
+	order.Protection = nil
+
+	return p.OrderRepository.Update(order)
+}
+
+// CheckExecution closes the position if one of protection orders is filled on the exchange side
+func (p *ProtectionService) CheckExecution(order model.Order) bool {
+	if order.Protection == nil || p.ProtectionApi == nil {
+		return false
+	}
+
+	for _, orderId := range order.Protection.OrderIds {
+		exchangeOrder, err := p.Binance.QueryOrder(order.Symbol, orderId)
+		if err != nil {
+			p.orderLog(order).Error("Protection order query failed", logger.KeyExchangeOrderId, orderId, logger.Error(err))
+			continue
+		}
+
+		if !exchangeOrder.IsFilled() {
+			continue
+		}
+
+		// the second order of the pair is not needed anymore (Binance OCO expires it automatically)
+		err = p.ProtectionApi.CancelProtectionOrder(order.Symbol, *order.Protection)
+		if err != nil {
+			p.orderLog(order).Error("Protection cancel failed", logger.Error(err))
+		}
+
+		p.close(order, exchangeOrder)
+
+		return true
+	}
+
+	return false
+}
+
+func (p *ProtectionService) close(opened model.Order, exchangeOrder model.ExchangeOrder) {
+	closeReason := model.OrderCloseReasonTakeProfit
+	if exchangeOrder.Price < opened.Price {
+		closeReason = model.OrderCloseReasonStopLoss
+	}
+
+	var order = model.Order{
+		Symbol:             opened.Symbol,
+		BaseAsset:          opened.BaseAsset,
+		QuoteAsset:         opened.QuoteAsset,
+		Quantity:           exchangeOrder.OrigQty,
+		ExecutedQuantity:   exchangeOrder.GetExecutedQuantity(),
+		Price:              exchangeOrder.Price,
+		CreatedAt:          p.TimeService.GetNowDateTimeString(),
+		Status:             "closed",
+		Operation:          "sell",
+		ExternalId:         &exchangeOrder.OrderId,
+		ClosesOrder:        &opened.Id,
+		ExtraChargeOptions: make(model.ExtraChargeOptions, 0),
+		ProfitOptions:      make(model.ProfitOptions, 0),
+		Exchange:           p.CurrentBot.Exchange,
+		CloseReason:        &closeReason,
+	}
+
+	closeLog := p.orderLog(opened).With(logger.KeyExchangeOrderId, exchangeOrder.OrderId)
+
+	_, err := p.OrderRepository.Create(order)
+	if err != nil {
+		closeLog.Error("Can't create protection order", logger.Error(err))
+
+		return
+	}
+
+	opened.Status = "closed"
+	opened.Protection = nil
+	err = p.OrderRepository.Update(opened)
+	p.BalanceService.InvalidateBalanceCache(opened.GetQuoteAsset())
+	p.BalanceService.InvalidateBalanceCache(opened.GetBaseAsset())
+
+	if err != nil {
+		closeLog.Error("Can't close order", logger.Error(err))
+
+		return
+	}
+
+	profit := (order.Price - opened.Price) * order.ExecutedQuantity
+	closeLog.Info("Position is closed by exchange protection", "closeReason", closeReason, "profit", profit)
+	if p.RiskManager != nil {
+		p.RiskManager.AddRealizedProfit(order.Symbol, profit)
+	}
+
+	p.CallbackManager.SellOrder(
+		order,
+		*p.CurrentBot,
+		fmt.Sprintf("Profit is: %f %s, close reason: %s", p.Formatter.ToFixed(profit, 2), order.GetQuoteAsset(), closeReason),
+	)
+}
+
+func (p *ProtectionService) orderLog(order model.Order) *slog.Logger {
+	return newOrderLog(order, p.CurrentBot).With(logger.KeyOperation, "protection")
+}
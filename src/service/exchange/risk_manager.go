@@ -0,0 +1,287 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"gitlab.com/open-soft/go-crypto-bot/src/service"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+type RiskManagerInterface interface {
+	CanBuy(tradeLimit model.TradeLimit, amount float64, isExtra bool) error
+	GetStatus() model.RiskStatus
+	AddRealizedProfit(symbol string, profit float64)
+}
+
+// RiskManager checks the whole portfolio before every BUY, the daily loss breaker pauses buying until the end of the day.
+// All the limits are in the bot reporting currency, amounts of every quote asset are converted to it.
+// The day starts at midnight of Location (UTC if empty), realized profit is loaded once a day and updated on SELL.
+type RiskManager struct {
+	OrderRepository    repository.OrderCachedReaderInterface
+	ProfitRepository   repository.OrderProfitReaderInterface
+	ExchangeRepository repository.BaseTradeStorageInterface
+	BalanceService     BalanceServiceInterface
+	CurrencyConverter  CurrencyConverterInterface
+	BotService         service.BotServiceInterface
+	CallbackManager    service.CallbackManagerInterface
+	TimeService        utils.TimeServiceInterface
+	Location           *time.Location
+	pausedDate         string
+	notifiedDates      map[string]string
+	realizedDate       string
+	realizedProfit     map[string]float64
+	mutex              sync.Mutex
+}
+
+func (r *RiskManager) CanBuy(tradeLimit model.TradeLimit, amount float64, isExtra bool) error {
+	status := r.GetStatus()
+	config := status.Config
+	currency := status.ReportingCurrency
+	amount, err := r.toReporting(amount, tradeLimit.GetQuoteAsset())
+	if err != nil {
+		status.AddUnconvertedAsset(tradeLimit.GetQuoteAsset())
+	}
+
+	if status.IsBuyPaused {
+		return errors.New(fmt.Sprintf(
+			"Buying is paused, daily loss %.2f %s reached limit %.2f %s",
+			status.DailyPnl,
+			currency,
+			config.DailyLossLimit,
+			currency,
+		))
+	}
+
+	// limits can't be checked by not converted amounts, BUY is refused until the price is known
+	if !status.IsComplete() && config.HasAmountLimits() {
+		return r.limitError(model.RiskErrorCodeConversion, fmt.Sprintf(
+			"Risk limits can't be checked, %s can't be converted to %s",
+			strings.Join(status.UnconvertedAssets, ", "),
+			currency,
+		))
+	}
+
+	if !isExtra && config.MaxPositions > 0 && status.Positions >= config.MaxPositions {
+		return r.limitError(model.RiskErrorCodeMaxPositions, fmt.Sprintf("Max positions %d reached", config.MaxPositions))
+	}
+
+	if config.MaxExposure > 0.00 && status.Exposure+amount > config.MaxExposure {
+		return r.limitError(model.RiskErrorCodeMaxExposure, fmt.Sprintf(
+			"Max exposure %.2f %s exceeded: %.2f + %.2f %s",
+			config.MaxExposure,
+			currency,
+			status.Exposure,
+			amount,
+			currency,
+		))
+	}
+
+	assetPercent := status.GetAssetPercent(tradeLimit.GetBaseAsset(), amount)
+	if config.MaxAssetPercent > 0.00 && assetPercent > config.MaxAssetPercent {
+		return r.limitError(model.RiskErrorCodeMaxAssetPercent, fmt.Sprintf(
+			"Max %s concentration %.2f%% exceeded: %.2f%%",
+			tradeLimit.GetBaseAsset(),
+			config.MaxAssetPercent,
+			assetPercent,
+		))
+	}
+
+	return nil
+}
+
+func (r *RiskManager) GetStatus() model.RiskStatus {
+	bot := r.BotService.GetBot()
+	useSwapCapital := r.BotService.UseSwapCapital()
+
+	status := model.RiskStatus{
+		Config:            bot.RiskConfig,
+		ReportingCurrency: bot.GetReportingCurrency(),
+		Assets:            make(map[string]model.RiskAssetExposure),
+	}
+
+	quoteAssets := make(map[string]bool)
+	symbolQuoteAssets := make(map[string]string)
+
+	for _, tradeLimit := range r.ExchangeRepository.GetTradeLimits() {
+		quoteAssets[tradeLimit.GetQuoteAsset()] = true
+		symbolQuoteAssets[tradeLimit.Symbol] = tradeLimit.GetQuoteAsset()
+		openedOrder := r.OrderRepository.GetOpenedOrderCached(tradeLimit.Symbol, "BUY")
+
+		if openedOrder == nil {
+			continue
+		}
+
+		price := openedOrder.Price
+		lastKline := r.ExchangeRepository.GetCurrentKline(tradeLimit.Symbol)
+		if lastKline != nil {
+			price = lastKline.Close.Value()
+		}
+
+		status.Positions++
+		quantity := openedOrder.GetRemainingToSellQuantity(useSwapCapital)
+		exposure, exposureErr := r.toReporting(quantity*price, tradeLimit.GetQuoteAsset())
+		unrealizedPnl, pnlErr := r.toReporting((price-openedOrder.Price)*quantity, tradeLimit.GetQuoteAsset())
+		if exposureErr != nil || pnlErr != nil {
+			status.AddUnconvertedAsset(tradeLimit.GetQuoteAsset())
+			continue
+		}
+
+		asset := status.Assets[tradeLimit.GetBaseAsset()]
+		asset.Asset = tradeLimit.GetBaseAsset()
+		asset.Exposure += exposure
+		asset.UnrealizedPnl += unrealizedPnl
+		status.Assets[asset.Asset] = asset
+
+		status.Exposure += exposure
+		status.UnrealizedPnl += unrealizedPnl
+	}
+
+	for quoteAsset := range quoteAssets {
+		balance, err := r.BalanceService.GetAssetBalance(quoteAsset, true)
+		if err != nil {
+			continue
+		}
+
+		converted, err := r.toReporting(balance, quoteAsset)
+		if err != nil {
+			status.AddUnconvertedAsset(quoteAsset)
+			continue
+		}
+		status.Balance += converted
+	}
+
+	for symbol, profit := range r.getRealizedProfit() {
+		quoteAsset, ok := symbolQuoteAssets[symbol]
+		if !ok {
+			_, quoteAsset = model.SplitSymbol(symbol)
+		}
+
+		converted, err := r.toReporting(profit, quoteAsset)
+		if err != nil {
+			status.AddUnconvertedAsset(quoteAsset)
+			continue
+		}
+		status.RealizedPnl += converted
+	}
+
+	status.DailyPnl = status.RealizedPnl + status.UnrealizedPnl
+	status.IsBuyPaused = r.isBuyPaused(bot, status.DailyPnl)
+
+	return status
+}
+
+// AddRealizedProfit updates today realized profit of the symbol (in quote asset) when position is closed
+func (r *RiskManager) AddRealizedProfit(symbol string, profit float64) {
+	today, _ := r.today()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// not loaded profit will be taken from the database with this order
+	if r.realizedDate != today {
+		return
+	}
+
+	r.realizedProfit[symbol] += profit
+}
+
+// getRealizedProfit loads today realized profit once a day, it is updated by AddRealizedProfit later
+func (r *RiskManager) getRealizedProfit() map[string]float64 {
+	today, dayStart := r.today()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.realizedDate != today {
+		r.realizedProfit = r.ProfitRepository.GetRealizedProfitSince(dayStart.Local().Format(time.DateTime))
+		r.realizedDate = today
+		if r.realizedProfit == nil {
+			r.realizedProfit = make(map[string]float64)
+		}
+	}
+
+	profitMap := make(map[string]float64, len(r.realizedProfit))
+	for symbol, profit := range r.realizedProfit {
+		profitMap[symbol] = profit
+	}
+
+	return profitMap
+}
+
+// today returns the current date and its start in the risk location
+func (r *RiskManager) today() (string, time.Time) {
+	location := r.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	now := time.Unix(r.TimeService.GetNowUnix(), 0).In(location)
+
+	return now.Format(time.DateOnly), time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+}
+
+// toReporting returns error if conversion rate is not available, the amount must not be counted in reporting currency
+func (r *RiskManager) toReporting(amount float64, asset string) (float64, error) {
+	converted, err := r.CurrencyConverter.ToReporting(amount, asset)
+	if err != nil {
+		slog.Warn("Risk manager conversion failed", "asset", asset, logger.Error(err))
+
+		return 0.00, err
+	}
+
+	return converted, nil
+}
+
+func (r *RiskManager) isBuyPaused(bot model.Bot, dailyPnl float64) bool {
+	today, _ := r.today()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.pausedDate == today {
+		return true
+	}
+
+	if bot.RiskConfig.DailyLossLimit <= 0.00 || dailyPnl > -bot.RiskConfig.DailyLossLimit {
+		return false
+	}
+
+	r.pausedDate = today
+	message := fmt.Sprintf(
+		"Daily loss %.2f %s reached limit %.2f %s, buying is paused until the end of the day",
+		dailyPnl,
+		bot.GetReportingCurrency(),
+		bot.RiskConfig.DailyLossLimit,
+		bot.GetReportingCurrency(),
+	)
+	slog.Warn(message, "bot", bot.BotUuid, "code", model.RiskErrorCodeDailyLoss)
+	r.CallbackManager.Notify(model.NewRiskLimitNotification(bot, model.RiskErrorCodeDailyLoss, message))
+
+	return true
+}
+
+// limitError notifies once a day about every limit, BUY is checked on every decision
+func (r *RiskManager) limitError(code string, message string) error {
+	today, _ := r.today()
+
+	r.mutex.Lock()
+	if r.notifiedDates == nil {
+		r.notifiedDates = make(map[string]string)
+	}
+	isNotified := r.notifiedDates[code] == today
+	r.notifiedDates[code] = today
+	r.mutex.Unlock()
+
+	if !isNotified {
+		r.CallbackManager.Notify(model.NewRiskLimitNotification(r.BotService.GetBot(), code, message))
+	}
+
+	return errors.New(message)
+}
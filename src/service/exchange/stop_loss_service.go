@@ -0,0 +1,74 @@
+package exchange
+
+import (
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service"
+	"sync"
+)
+
+type StopLossServiceInterface interface {
+	Check(order model.Order, price float64) (string, bool)
+}
+
+type positionPeak struct {
+	OrderId int64
+	Price   float64
+}
+
+// StopLossService keeps the highest price of opened position in memory, after restart trailing is started from current price
+type StopLossService struct {
+	BotService service.BotServiceInterface
+	peaks      map[string]positionPeak
+	mutex      sync.Mutex
+}
+
+// Check returns close reason if stop loss or trailing stop of opened position is triggered
+func (s *StopLossService) Check(order model.Order, price float64) (string, bool) {
+	if price <= 0.00 {
+		return "", false
+	}
+
+	peakPrice := s.updatePeak(order, price)
+
+	stopLoss := order.ProfitOptions.GetStopLoss()
+	if stopLoss != nil && order.GetProfitPercent(price, s.BotService.UseSwapCapital()).Lte(stopLoss.OptionPercent) {
+		return model.OrderCloseReasonStopLoss, true
+	}
+
+	trailingStop := order.ProfitOptions.GetTrailingStop()
+	if trailingStop != nil {
+		isActivated := order.GetProfitPercent(peakPrice, s.BotService.UseSwapCapital()).Gte(trailingStop.ActivationPercent)
+		stopPrice := peakPrice * (100 - trailingStop.OptionPercent.Value()) / 100
+
+		if isActivated && price <= stopPrice {
+			return model.OrderCloseReasonTrailingStop, true
+		}
+	}
+
+	return "", false
+}
+
+func (s *StopLossService) updatePeak(order model.Order, price float64) float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.peaks == nil {
+		s.peaks = make(map[string]positionPeak)
+	}
+
+	peak, ok := s.peaks[order.Symbol]
+	if !ok || peak.OrderId != order.Id {
+		peak = positionPeak{
+			OrderId: order.Id,
+			Price:   price,
+		}
+	}
+
+	if price > peak.Price {
+		peak.Price = price
+	}
+
+	s.peaks[order.Symbol] = peak
+
+	return peak.Price
+}
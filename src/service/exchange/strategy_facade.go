@@ -17,6 +17,7 @@ type StrategyFacade struct {
 	ExchangeRepository  repository.ExchangeTradeInfoInterface
 	OrderRepository     repository.OrderStorageInterface
 	BotService          service.BotServiceInterface
+	StrategyRegistry    *StrategyRegistry
 	MinDecisions        float64
 }
 
@@ -28,31 +29,90 @@ func (s *StrategyFacade) Decide(symbol string) (model.FacadeResponse, error) {
 	holdScore := 0.00
 	decisionAmount := 0.00
 	priceSum := 0.00
+	minDecisions := s.MinDecisions
+	strategyOptions := make(model.StrategyOptions, 0)
+	explanation := model.DecisionExplanation{
+		Strategies: make([]model.StrategyExplanation, 0),
+	}
+	hasPriorityBuy := false
+	hasPrioritySell := false
+
+	if s.StrategyRegistry != nil {
+		var limit *model.TradeLimit
+		tradeLimit, err := s.ExchangeRepository.GetTradeLimit(symbol)
+		if err == nil {
+			limit = &tradeLimit
+			strategyOptions = tradeLimit.Strategies
+			explanation.MinAgreement = tradeLimit.MinStrategyAgreement
+		}
+
+		enabledAmount := float64(len(s.StrategyRegistry.GetEnabledNames(limit)))
+		if enabledAmount < minDecisions {
+			minDecisions = enabledAmount
+		}
+	}
 
 	for _, decision := range decisions {
+		if s.StrategyRegistry != nil && (!s.StrategyRegistry.Has(decision.StrategyName) || !strategyOptions.IsEnabled(decision.StrategyName)) {
+			continue
+		}
+
+		score := decision.Score
+		weight := 1.00
+		// Highest priority decision is not weighted
+		if score != model.DecisionHighestPriorityScore {
+			weight = strategyOptions.GetWeight(decision.StrategyName)
+			score = score * weight
+		}
+
 		decisionAmount = decisionAmount + 1.00
 		switch decision.Operation {
 		case "BUY":
-			buyScore += decision.Score
+			buyScore += score
+			explanation.BuyVotes++
+			hasPriorityBuy = hasPriorityBuy || score == model.DecisionHighestPriorityScore
 			break
 		case "SELL":
-			sellScore += decision.Score
+			sellScore += score
+			explanation.SellVotes++
+			hasPrioritySell = hasPrioritySell || score == model.DecisionHighestPriorityScore
 			break
 		case "HOLD":
-			holdScore += decision.Score
+			holdScore += score
 			break
 		}
 		priceSum += decision.Price
+
+		explanation.Strategies = append(explanation.Strategies, model.StrategyExplanation{
+			StrategyName: decision.StrategyName,
+			Operation:    decision.Operation,
+			Score:        score,
+			Weight:       weight,
+			Price:        decision.Price,
+			Reason:       decision.Reason,
+			Params:       decision.Params,
+		})
+	}
+
+	// N of M strategies have to agree, highest priority decisions are not voted
+	if explanation.MinAgreement > 0 {
+		if !hasPriorityBuy && explanation.BuyVotes < explanation.MinAgreement {
+			buyScore = 0.00
+		}
+
+		if !hasPrioritySell && explanation.SellVotes < explanation.MinAgreement {
+			sellScore = 0.00
+		}
 	}
 
 	manualOrder := s.OrderRepository.GetManualOrder(symbol)
 
-	if decisionAmount < s.MinDecisions && manualOrder == nil {
+	if decisionAmount < minDecisions && manualOrder == nil {
 		return model.FacadeResponse{
 			Hold: model.DecisionHighestPriorityScore,
 			Buy:  0.00,
 			Sell: 0.00,
-		}, errors.New(fmt.Sprintf("[%s] Not enough decision amount %d of %d", symbol, int64(decisionAmount), int64(s.MinDecisions)))
+		}, errors.New(fmt.Sprintf("[%s] Not enough decision amount %d of %d", symbol, int64(decisionAmount), int64(minDecisions)))
 	}
 
 	tradeLimit, err := s.ExchangeRepository.GetTradeLimit(symbol)
@@ -89,9 +149,14 @@ func (s *StrategyFacade) Decide(symbol string) (model.FacadeResponse, error) {
 		holdScore = 0.00
 	}
 
+	explanation.Buy = buyScore
+	explanation.Sell = sellScore
+	explanation.Hold = holdScore
+
 	return model.FacadeResponse{
-		Sell: sellScore,
-		Buy:  buyScore,
-		Hold: holdScore,
+		Sell:        sellScore,
+		Buy:         buyScore,
+		Hold:        holdScore,
+		Explanation: explanation,
 	}, nil
 }
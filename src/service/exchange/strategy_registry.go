@@ -0,0 +1,139 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"sync"
+)
+
+type StrategyInterface interface {
+	GetName() string
+	GetInput() string
+}
+
+type KLineStrategyInterface interface {
+	StrategyInterface
+	Decide(kLine model.KLine) model.Decision
+}
+
+type TradeStrategyInterface interface {
+	StrategyInterface
+	Decide(trade model.Trade) model.Decision
+}
+
+type DepthStrategyInterface interface {
+	StrategyInterface
+	Decide(depth model.OrderBookModel) model.Decision
+}
+
+type StrategyRegistry struct {
+	strategies []StrategyInterface
+	mutex      sync.RWMutex
+}
+
+func (r *StrategyRegistry) Register(strategy StrategyInterface) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, registered := range r.strategies {
+		if registered.GetName() == strategy.GetName() {
+			return errors.New(fmt.Sprintf("Strategy %s is already registered", strategy.GetName()))
+		}
+	}
+
+	isValid := false
+	switch strategy.GetInput() {
+	case model.StrategyInputKLine:
+		_, isValid = strategy.(KLineStrategyInterface)
+		break
+	case model.StrategyInputTrade:
+		_, isValid = strategy.(TradeStrategyInterface)
+		break
+	case model.StrategyInputDepth:
+		_, isValid = strategy.(DepthStrategyInterface)
+		break
+	}
+
+	if !isValid {
+		return errors.New(fmt.Sprintf("Strategy %s can't consume %s input", strategy.GetName(), strategy.GetInput()))
+	}
+
+	r.strategies = append(r.strategies, strategy)
+
+	return nil
+}
+
+func (r *StrategyRegistry) Has(name string) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, strategy := range r.strategies {
+		if strategy.GetName() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetEnabledNames returns all strategies if trade limit is unknown
+func (r *StrategyRegistry) GetEnabledNames(limit *model.TradeLimit) []string {
+	names := make([]string, 0)
+
+	for _, strategy := range r.getEnabled(limit, "") {
+		names = append(names, strategy.GetName())
+	}
+
+	return names
+}
+
+func (r *StrategyRegistry) getEnabled(limit *model.TradeLimit, input string) []StrategyInterface {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	enabled := make([]StrategyInterface, 0)
+	for _, strategy := range r.strategies {
+		if input != "" && strategy.GetInput() != input {
+			continue
+		}
+
+		if limit != nil && !limit.Strategies.IsEnabled(strategy.GetName()) {
+			continue
+		}
+
+		enabled = append(enabled, strategy)
+	}
+
+	return enabled
+}
+
+func (r *StrategyRegistry) DecideKLine(kLine model.KLine) []model.Decision {
+	decisions := make([]model.Decision, 0)
+
+	for _, strategy := range r.getEnabled(nil, model.StrategyInputKLine) {
+		decisions = append(decisions, strategy.(KLineStrategyInterface).Decide(kLine))
+	}
+
+	return decisions
+}
+
+func (r *StrategyRegistry) DecideTrade(trade model.Trade) []model.Decision {
+	decisions := make([]model.Decision, 0)
+
+	for _, strategy := range r.getEnabled(nil, model.StrategyInputTrade) {
+		decisions = append(decisions, strategy.(TradeStrategyInterface).Decide(trade))
+	}
+
+	return decisions
+}
+
+func (r *StrategyRegistry) DecideDepth(depth model.OrderBookModel) []model.Decision {
+	decisions := make([]model.Decision, 0)
+
+	for _, strategy := range r.getEnabled(nil, model.StrategyInputDepth) {
+		decisions = append(decisions, strategy.(DepthStrategyInterface).Decide(depth))
+	}
+
+	return decisions
+}
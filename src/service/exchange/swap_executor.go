@@ -4,12 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/metrics"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"gitlab.com/open-soft/go-crypto-bot/src/service"
 	"gitlab.com/open-soft/go-crypto-bot/src/utils"
-	"log"
+	"log/slog"
 	"math"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -18,6 +22,9 @@ const SwapSecondAmendmentSteps = 50
 const SwapThirdAmendmentSteps = 250
 const SwapStepCommission = 0.002
 
+// limit IOC orders of rollback and force swap are replaced by market orders after this amount of attempts
+const SwapMarketOrderAttempts = 3.00
+
 type SwapExecutorInterface interface {
 	Execute(order model.Order)
 }
@@ -33,13 +40,18 @@ type SwapExecutor struct {
 	SwapFirstAmendmentSteps  float64
 	SwapSecondAmendmentSteps float64
 	SwapThirdAmendmentSteps  float64
+	ShutdownManager          service.ShutdownManagerInterface
+	CallbackManager          service.CallbackManagerInterface
+
+	actionLock map[int64]bool
+	mutex      sync.Mutex
 }
 
 func (s *SwapExecutor) Execute(order model.Order) {
 	swapAction, err := s.SwapRepository.GetActiveSwapAction(order)
 
 	if err != nil {
-		log.Printf("[%s] Swap processing error: %s", order.Symbol, err.Error())
+		slog.Error("Swap processing error", logger.KeySymbol, order.Symbol, logger.KeyOrderId, order.Id, logger.Error(err))
 
 		if strings.Contains(err.Error(), "no rows in result set") {
 			order.Swap = false
@@ -49,23 +61,48 @@ func (s *SwapExecutor) Execute(order model.Order) {
 		return
 	}
 
+	actionLog := s.swapLog(swapAction).With(logger.KeySymbol, order.Symbol)
+	if order.Explanation != nil && order.Explanation.CorrelationId != "" {
+		actionLog = actionLog.With(logger.KeyCorrelationId, order.Explanation.CorrelationId)
+	}
+
+	// swap action can be resolved manually in the meantime
+	if !s.TryLockAction(swapAction.Id) {
+		actionLog.Info("Swap is processed by another operation")
+		return
+	}
+	defer s.UnlockAction(swapAction.Id)
+
+	// started swap is finished on shutdown, funds must not be left in intermediate asset
+	if swapAction.SwapOneExternalId == nil && s.ShutdownManager != nil && s.ShutdownManager.IsStopping() {
+		actionLog.Info("Swap is not started, shutdown is in progress")
+		return
+	}
+
 	balanceBefore, _ := s.BalanceService.GetAssetBalance(swapAction.Asset, false)
 
 	if swapAction.IsPending() {
+		actionLog.Info("Swap started", "startQuantity", swapAction.StartQuantity)
 		swapAction.Status = model.SwapActionStatusProcess
 		_ = s.SwapRepository.UpdateSwapAction(swapAction)
+		s.notify(model.NotificationEventSwapStarted, swapAction, "")
 	}
 
 	swapChain, err := s.SwapRepository.GetSwapChainById(swapAction.SwapChainId)
 
 	if err != nil {
-		log.Printf("Swap chain %d is not found", swapAction.SwapChainId)
+		actionLog.Error("Swap chain is not found", "swapChainId", swapAction.SwapChainId, logger.Error(err))
 		return
 	}
 
 	swapOneOrder := s.ExecuteSwapOne(&swapAction, order)
 
 	if swapOneOrder == nil {
+		if swapAction.Status == model.SwapActionStatusCanceled {
+			metrics.SwapOutcomes.Inc(model.SwapActionStatusCanceled)
+			s.notify(model.NotificationEventSwapFailed, swapAction, "Swap one order is not filled, funds are left in the original asset")
+		}
+
 		return
 	}
 
@@ -95,21 +132,28 @@ func (s *SwapExecutor) Execute(order model.Order) {
 	swapAction.EndQuantity = &endQuantity
 	_ = s.SwapRepository.UpdateSwapAction(swapAction)
 	_ = s.OrderRepository.Update(order)
+	metrics.SwapOutcomes.Inc(model.SwapActionStatusSuccess)
 
 	s.BalanceService.InvalidateBalanceCache(swapAction.Asset)
 	balanceAfter, _ := s.BalanceService.GetAssetBalance(swapAction.Asset, false)
 
-	log.Printf(
-		"[%s] Swap funished, balance %s before = %f after = %f",
-		order.Symbol,
+	actionLog.Info(
+		"Swap finished",
+		"balanceBefore", balanceBefore,
+		"balanceAfter", balanceAfter,
+	)
+	s.notify(model.NotificationEventSwapFinished, swapAction, fmt.Sprintf(
+		"End quantity: %f %s, balance: %f -> %f %s",
+		endQuantity,
 		swapAction.Asset,
 		balanceBefore,
 		balanceAfter,
-	)
+		swapAction.Asset,
+	))
 }
 
-func (s *SwapExecutor) ExecuteSwapOne(swapAction *model.SwapAction, order model.Order) *model.BinanceOrder {
-	var swapOneOrder *model.BinanceOrder = nil
+func (s *SwapExecutor) ExecuteSwapOne(swapAction *model.SwapAction, order model.Order) *model.ExchangeOrder {
+	var swapOneOrder *model.ExchangeOrder = nil
 
 	if swapAction.SwapOneExternalId == nil {
 		swapPrice := swapAction.SwapOnePrice
@@ -117,7 +161,7 @@ func (s *SwapExecutor) ExecuteSwapOne(swapAction *model.SwapAction, order model.
 		// Price can grow before we start processing, take max price for swap
 		swapPrice = math.Max(swapPrice, swapPair.SellPrice-(swapPair.MinPrice*s.SwapFirstAmendmentSteps))
 
-		binanceOrder, err := s.Binance.LimitOrder(
+		exchangeOrder, err := s.Binance.LimitOrder(
 			swapAction.SwapOneSymbol,
 			s.Formatter.FormatQuantity(swapPair, swapAction.StartQuantity),
 			s.Formatter.FormatPrice(swapPair, swapPrice),
@@ -126,12 +170,7 @@ func (s *SwapExecutor) ExecuteSwapOne(swapAction *model.SwapAction, order model.
 		)
 
 		if err != nil {
-			log.Printf(
-				"[%s] Swap [%d] error: %s",
-				order.Symbol,
-				swapAction.Id,
-				err.Error(),
-			)
+			s.swapLog(*swapAction).Error("Swap one order placement failed", logger.Error(err))
 
 			orderStatus := "ERROR"
 			swapAction.SwapOneExternalStatus = &orderStatus
@@ -147,22 +186,22 @@ func (s *SwapExecutor) ExecuteSwapOne(swapAction *model.SwapAction, order model.
 			return nil
 		}
 
-		swapOneOrder = &binanceOrder
-		swapAction.SwapOneExternalId = &binanceOrder.OrderId
-		swapAction.SwapOneSide = &binanceOrder.Side
-		swapAction.SwapOneQuantity = &binanceOrder.OrigQty
+		swapOneOrder = &exchangeOrder
+		swapAction.SwapOneExternalId = &exchangeOrder.OrderId
+		swapAction.SwapOneSide = &exchangeOrder.Side
+		swapAction.SwapOneQuantity = &exchangeOrder.OrigQty
 		nowTimestamp := time.Now().Unix()
 		swapAction.SwapOneTimestamp = &nowTimestamp
-		swapAction.SwapOneExternalStatus = &binanceOrder.Status
+		swapAction.SwapOneExternalStatus = exchangeOrder.GetExternalStatus()
 		_ = s.SwapRepository.UpdateSwapAction(*swapAction)
 	} else {
-		binanceOrder, err := s.Binance.QueryOrder(swapAction.SwapOneSymbol, *swapAction.SwapOneExternalId)
+		exchangeOrder, err := s.Binance.QueryOrder(swapAction.SwapOneSymbol, *swapAction.SwapOneExternalId)
 		if err != nil {
-			log.Printf("[%s] Swap error: %s", order.Symbol, err.Error())
+			s.swapLog(*swapAction).Error("Swap one query order failed", logger.Error(err))
 			return nil
 		}
 
-		if binanceOrder.IsCanceled() || binanceOrder.IsExpired() {
+		if exchangeOrder.IsCanceled() || exchangeOrder.IsExpired() {
 			swapAction.SwapOneExternalId = nil
 			swapAction.SwapOneTimestamp = nil
 			swapAction.SwapOneExternalStatus = nil
@@ -172,10 +211,10 @@ func (s *SwapExecutor) ExecuteSwapOne(swapAction *model.SwapAction, order model.
 			return nil
 		}
 
-		swapOneOrder = &binanceOrder
-		swapAction.SwapOneExternalStatus = &binanceOrder.Status
-		swapAction.SwapOneSide = &binanceOrder.Side
-		swapAction.SwapOneQuantity = &binanceOrder.OrigQty
+		swapOneOrder = &exchangeOrder
+		swapAction.SwapOneExternalStatus = exchangeOrder.GetExternalStatus()
+		swapAction.SwapOneSide = &exchangeOrder.Side
+		swapAction.SwapOneQuantity = &exchangeOrder.OrigQty
 		_ = s.SwapRepository.UpdateSwapAction(*swapAction)
 	}
 
@@ -185,55 +224,47 @@ func (s *SwapExecutor) ExecuteSwapOne(swapAction *model.SwapAction, order model.
 	if !swapOneOrder.IsFilled() {
 		s.TimeService.WaitSeconds(5)
 		for {
-			binanceOrder, err := s.Binance.QueryOrder(swapOneOrder.Symbol, swapOneOrder.OrderId)
+			exchangeOrder, err := s.Binance.QueryOrder(swapOneOrder.Symbol, swapOneOrder.OrderId)
 			if err != nil {
-				log.Printf(
-					"[%s] Swap %s error: %s",
-					order.Symbol,
-					s.CurrentBot.Exchange,
-					err.Error(),
-				)
+				s.swapLog(*swapAction).Warn("Swap one query order failed", logger.KeyExchangeOrderId, swapOneOrder.OrderId, logger.Error(err))
 
 				continue
 			}
 
-			swapPair, err := s.SwapRepository.GetSwapPairBySymbol(binanceOrder.Symbol)
+			swapPair, err := s.SwapRepository.GetSwapPairBySymbol(exchangeOrder.Symbol)
 
-			log.Printf(
-				"[%s] Swap [%d] one [%s] processing, status %s [%d], price %f, current = %f, Executed %f of %f",
-				swapAction.SwapOneSymbol,
-				swapAction.Id,
-				binanceOrder.Side,
-				binanceOrder.Status,
-				binanceOrder.OrderId,
-				binanceOrder.Price,
-				swapPair.SellPrice,
-				binanceOrder.ExecutedQty,
-				binanceOrder.OrigQty,
+			s.legLog(*swapAction, "one", exchangeOrder).Info(
+				"Swap leg processing",
+				"status", exchangeOrder.Status,
+				"price", exchangeOrder.Price,
+				"currentPrice", swapPair.SellPrice,
+				"executedQuantity", exchangeOrder.ExecutedQty,
+				"quantity", exchangeOrder.OrigQty,
 			)
 
 			// update value, set new memory address
-			swapOneOrder = &binanceOrder
+			swapOneOrder = &exchangeOrder
 
 			nowTimestamp := time.Now().Unix()
 			swapAction.SwapOneTimestamp = &nowTimestamp
-			swapAction.SwapOneExternalStatus = &binanceOrder.Status
-			swapAction.SwapOneSide = &binanceOrder.Side
-			swapAction.SwapOneQuantity = &binanceOrder.OrigQty
-			if binanceOrder.IsFilled() {
-				swapAction.SwapOnePrice = binanceOrder.Price
-				swapAction.SwapOneQuantity = &binanceOrder.ExecutedQty
+			swapAction.SwapOneExternalStatus = exchangeOrder.GetExternalStatus()
+			swapAction.SwapOneSide = &exchangeOrder.Side
+			swapAction.SwapOneQuantity = &exchangeOrder.OrigQty
+			if exchangeOrder.IsFilled() {
+				fill := exchangeOrder.GetFill()
+				swapAction.SwapOnePrice = fill.Price
+				swapAction.SwapOneQuantity = &fill.Quantity
 			}
 			_ = s.SwapRepository.UpdateSwapAction(*swapAction)
 
-			if binanceOrder.IsFilled() {
+			if exchangeOrder.IsFilled() {
 				break
 			}
 
 			// todo: timeout... cancel and remove swap action...
 
-			if binanceOrder.IsCanceled() || binanceOrder.IsExpired() {
-				swapAction.SwapOneExternalStatus = &binanceOrder.Status
+			if exchangeOrder.IsCanceled() || exchangeOrder.IsExpired() {
+				swapAction.SwapOneExternalStatus = exchangeOrder.GetExternalStatus()
 				swapAction.Status = model.SwapActionStatusCanceled
 				nowTimestamp := time.Now().Unix()
 				swapAction.EndTimestamp = &nowTimestamp
@@ -243,16 +274,16 @@ func (s *SwapExecutor) ExecuteSwapOne(swapAction *model.SwapAction, order model.
 				_ = s.OrderRepository.Update(order)
 				// invalidate balance cache
 				s.BalanceService.InvalidateBalanceCache(swapAction.Asset)
-				log.Printf("[%s] Swap one process cancelled, cancel all the operation!", order.Symbol)
+				s.swapLog(*swapAction).Warn("Swap one order is cancelled, swap is cancelled", "status", exchangeOrder.Status)
 
 				return nil
 			}
 
 			// cancel if we can not start processing more than 1 minute
-			if binanceOrder.IsNew() && s.TimeService.GetNowDiffMinutes(swapAction.StartTimestamp) >= 1 {
-				cancelOrder, err := s.Binance.CancelOrder(binanceOrder.Symbol, binanceOrder.OrderId)
+			if exchangeOrder.IsNew() && s.TimeService.GetNowDiffMinutes(swapAction.StartTimestamp) >= 1 {
+				cancelOrder, err := s.Binance.CancelOrder(exchangeOrder.Symbol, exchangeOrder.OrderId)
 				if err == nil {
-					swapAction.SwapOneExternalStatus = &cancelOrder.Status
+					swapAction.SwapOneExternalStatus = cancelOrder.GetExternalStatus()
 					swapAction.Status = model.SwapActionStatusCanceled
 					nowTimestamp := time.Now().Unix()
 					swapAction.EndTimestamp = &nowTimestamp
@@ -262,13 +293,13 @@ func (s *SwapExecutor) ExecuteSwapOne(swapAction *model.SwapAction, order model.
 					_ = s.OrderRepository.Update(order)
 					// invalidate balance cache
 					s.BalanceService.InvalidateBalanceCache(swapAction.Asset)
-					log.Printf("[%s] Swap process cancelled, couldn't be processed more than 60 seconds", order.Symbol)
+					s.swapLog(*swapAction).Warn("Swap is cancelled, swap one couldn't be processed more than 60 seconds")
 
 					return nil
 				}
 			}
 
-			if binanceOrder.IsPartiallyFilled() {
+			if exchangeOrder.IsPartiallyFilled() {
 				s.TimeService.WaitSeconds(7)
 			} else {
 				s.TimeService.WaitSeconds(15)
@@ -282,11 +313,11 @@ func (s *SwapExecutor) ExecuteSwapOne(swapAction *model.SwapAction, order model.
 func (s *SwapExecutor) ExecuteSwapTwo(
 	swapAction *model.SwapAction,
 	swapChain model.SwapChainEntity,
-	swapOneOrder model.BinanceOrder,
-) *model.BinanceOrder {
+	swapOneOrder model.ExchangeOrder,
+) *model.ExchangeOrder {
 	assetTwo := swapAction.GetAssetTwo()
 
-	var swapTwoOrder *model.BinanceOrder = nil
+	var swapTwoOrder *model.ExchangeOrder = nil
 
 	if swapAction.SwapTwoExternalId == nil {
 		balance, _ := s.BalanceService.GetAssetBalance(assetTwo, false)
@@ -299,34 +330,22 @@ func (s *SwapExecutor) ExecuteSwapTwo(
 		}
 
 		if s.Formatter.ComparePercentage(initialQty, quantity).Lte(99.9) {
-			log.Printf(
-				"[%d] swap quantity is less than allowed: %.10f > %.10f (step two)",
-				swapAction.Id,
-				initialQty,
-				quantity,
-			)
+			s.swapLog(*swapAction).Error("Swap quantity is less than allowed", "leg", "two", "initialQuantity", initialQty, "quantity", quantity)
 			return nil
 		}
 
-		log.Printf(
-			"[%s] Swap [%d] two balance %s is %f, operation SELL %s",
-			swapChain.SwapTwo.Symbol,
-			swapAction.Id,
-			assetTwo,
-			balance,
-			swapAction.SwapTwoSymbol,
-		)
+		s.swapLog(*swapAction).Info("Swap two started", "leg", "two", "asset", assetTwo, "balance", balance, logger.KeySymbol, swapAction.SwapTwoSymbol)
 
 		swapPrice := swapAction.SwapTwoPrice
 		swapPair, err := s.SwapRepository.GetSwapPairBySymbol(swapAction.SwapTwoSymbol)
 
-		var binanceOrder model.BinanceOrder
+		var exchangeOrder model.ExchangeOrder
 
 		if swapChain.IsSSB() {
 			// Price can grow before we start processing, take max price for swap
 			swapPrice = math.Max(swapPrice, swapPair.SellPrice-(swapPair.MinPrice*s.SwapSecondAmendmentSteps))
 
-			binanceOrder, err = s.Binance.LimitOrder(
+			exchangeOrder, err = s.Binance.LimitOrder(
 				swapAction.SwapTwoSymbol,
 				s.Formatter.FormatQuantity(swapPair, quantity),
 				s.Formatter.FormatPrice(swapPair, swapPrice),
@@ -339,7 +358,7 @@ func (s *SwapExecutor) ExecuteSwapTwo(
 			// Price can fall down before we start processing, take min price for swap
 			swapPrice = math.Min(swapPrice, swapPair.BuyPrice+(swapPair.MinPrice*s.SwapSecondAmendmentSteps))
 
-			binanceOrder, err = s.Binance.LimitOrder(
+			exchangeOrder, err = s.Binance.LimitOrder(
 				swapAction.SwapTwoSymbol,
 				s.Formatter.FormatQuantity(swapPair, quantity/swapPrice),
 				s.Formatter.FormatPrice(swapPair, swapPrice),
@@ -349,31 +368,26 @@ func (s *SwapExecutor) ExecuteSwapTwo(
 		}
 
 		if err != nil {
-			log.Printf(
-				"[%s] Swap [%d] error: %s",
-				swapAction.SwapTwoSymbol,
-				swapAction.Id,
-				err.Error(),
-			)
+			s.swapLog(*swapAction).Error("Swap two order placement failed", logger.Error(err))
 			return nil
 		}
 
-		swapTwoOrder = &binanceOrder
-		swapAction.SwapTwoExternalId = &binanceOrder.OrderId
-		swapAction.SwapTwoSide = &binanceOrder.Side
-		swapAction.SwapTwoQuantity = &binanceOrder.OrigQty
+		swapTwoOrder = &exchangeOrder
+		swapAction.SwapTwoExternalId = &exchangeOrder.OrderId
+		swapAction.SwapTwoSide = &exchangeOrder.Side
+		swapAction.SwapTwoQuantity = &exchangeOrder.OrigQty
 		nowTimestamp := time.Now().Unix()
 		swapAction.SwapTwoTimestamp = &nowTimestamp
-		swapAction.SwapTwoExternalStatus = &binanceOrder.Status
+		swapAction.SwapTwoExternalStatus = exchangeOrder.GetExternalStatus()
 		_ = s.SwapRepository.UpdateSwapAction(*swapAction)
 	} else {
-		binanceOrder, err := s.Binance.QueryOrder(swapAction.SwapTwoSymbol, *swapAction.SwapTwoExternalId)
+		exchangeOrder, err := s.Binance.QueryOrder(swapAction.SwapTwoSymbol, *swapAction.SwapTwoExternalId)
 		if err != nil {
-			log.Printf("[%s] Swap error: %s", swapAction.SwapTwoSymbol, err.Error())
+			s.swapLog(*swapAction).Error("Swap two query order failed", logger.Error(err))
 			return nil
 		}
 
-		if binanceOrder.IsCanceled() || binanceOrder.IsExpired() {
+		if exchangeOrder.IsCanceled() || exchangeOrder.IsExpired() {
 			swapAction.SwapTwoExternalId = nil
 			swapAction.SwapTwoTimestamp = nil
 			swapAction.SwapTwoExternalStatus = nil
@@ -383,62 +397,54 @@ func (s *SwapExecutor) ExecuteSwapTwo(
 			return nil
 		}
 
-		swapTwoOrder = &binanceOrder
-		swapAction.SwapTwoExternalStatus = &binanceOrder.Status
-		swapAction.SwapTwoSide = &binanceOrder.Side
-		swapAction.SwapTwoQuantity = &binanceOrder.OrigQty
+		swapTwoOrder = &exchangeOrder
+		swapAction.SwapTwoExternalStatus = exchangeOrder.GetExternalStatus()
+		swapAction.SwapTwoSide = &exchangeOrder.Side
+		swapAction.SwapTwoQuantity = &exchangeOrder.OrigQty
 		_ = s.SwapRepository.UpdateSwapAction(*swapAction)
 	}
 
 	if !swapTwoOrder.IsFilled() {
 		s.TimeService.WaitSeconds(5)
 		for {
-			binanceOrder, err := s.Binance.QueryOrder(swapTwoOrder.Symbol, swapTwoOrder.OrderId)
+			exchangeOrder, err := s.Binance.QueryOrder(swapTwoOrder.Symbol, swapTwoOrder.OrderId)
 			if err != nil {
-				log.Printf(
-					"[%s] Swap %s error: %s",
-					swapAction.SwapTwoSymbol,
-					s.CurrentBot.Exchange,
-					err.Error(),
-				)
+				s.swapLog(*swapAction).Warn("Swap two query order failed", logger.KeyExchangeOrderId, swapTwoOrder.OrderId, logger.Error(err))
 
 				continue
 			}
 
-			swapPair, err := s.SwapRepository.GetSwapPairBySymbol(binanceOrder.Symbol)
+			swapPair, err := s.SwapRepository.GetSwapPairBySymbol(exchangeOrder.Symbol)
 
-			log.Printf(
-				"[%s] Swap [%d] two [%s] processing, status %s [%d], price %f, current = %f, Executed %f of %f",
-				swapAction.SwapTwoSymbol,
-				swapAction.Id,
-				binanceOrder.Side,
-				binanceOrder.Status,
-				binanceOrder.OrderId,
-				binanceOrder.Price,
-				swapPair.SellPrice,
-				binanceOrder.ExecutedQty,
-				binanceOrder.OrigQty,
+			s.legLog(*swapAction, "two", exchangeOrder).Info(
+				"Swap leg processing",
+				"status", exchangeOrder.Status,
+				"price", exchangeOrder.Price,
+				"currentPrice", swapPair.SellPrice,
+				"executedQuantity", exchangeOrder.ExecutedQty,
+				"quantity", exchangeOrder.OrigQty,
 			)
 
 			// update value, set new memory address
-			swapTwoOrder = &binanceOrder
+			swapTwoOrder = &exchangeOrder
 
 			nowTimestamp := time.Now().Unix()
 			swapAction.SwapTwoTimestamp = &nowTimestamp
-			swapAction.SwapTwoExternalStatus = &binanceOrder.Status
-			swapAction.SwapTwoSide = &binanceOrder.Side
-			swapAction.SwapTwoQuantity = &binanceOrder.OrigQty
-			if binanceOrder.IsFilled() {
-				swapAction.SwapTwoPrice = binanceOrder.Price
-				swapAction.SwapTwoQuantity = &binanceOrder.ExecutedQty
+			swapAction.SwapTwoExternalStatus = exchangeOrder.GetExternalStatus()
+			swapAction.SwapTwoSide = &exchangeOrder.Side
+			swapAction.SwapTwoQuantity = &exchangeOrder.OrigQty
+			if exchangeOrder.IsFilled() {
+				fill := exchangeOrder.GetFill()
+				swapAction.SwapTwoPrice = fill.Price
+				swapAction.SwapTwoQuantity = &fill.Quantity
 			}
 			_ = s.SwapRepository.UpdateSwapAction(*swapAction)
 
-			if binanceOrder.IsFilled() {
+			if exchangeOrder.IsFilled() {
 				break
 			}
 
-			if binanceOrder.IsCanceled() || binanceOrder.IsExpired() {
+			if exchangeOrder.IsCanceled() || exchangeOrder.IsExpired() {
 				swapAction.SwapTwoExternalId = nil
 				swapAction.SwapTwoTimestamp = nil
 				swapAction.SwapTwoExternalStatus = nil
@@ -449,17 +455,17 @@ func (s *SwapExecutor) ExecuteSwapTwo(
 			}
 
 			// 2 hours can not process second step
-			if binanceOrder.IsNew() && s.TimeService.GetNowDiffMinutes(*swapAction.SwapOneTimestamp) > 5 {
+			if exchangeOrder.IsNew() && s.TimeService.GetNowDiffMinutes(*swapAction.SwapOneTimestamp) > 5 {
 				// todo: rollback savepoint!!!
 				err = s.TryRollbackSwapTwo(swapAction, swapChain, swapOneOrder, assetTwo)
 				if err == nil {
 					return nil
 				}
 
-				log.Printf("Swap two [%d] rollback: %s", swapAction.Id, err.Error())
+				s.swapLog(*swapAction).Warn("Swap two rollback failed", logger.Error(err))
 			}
 
-			if binanceOrder.IsPartiallyFilled() {
+			if exchangeOrder.IsPartiallyFilled() {
 				s.TimeService.WaitSeconds(7)
 			} else {
 				s.TimeService.WaitSeconds(15)
@@ -473,10 +479,10 @@ func (s *SwapExecutor) ExecuteSwapTwo(
 func (s *SwapExecutor) ExecuteSwapThree(
 	swapAction *model.SwapAction,
 	swapChain model.SwapChainEntity,
-	swapTwoOrder model.BinanceOrder,
-) *model.BinanceOrder {
+	swapTwoOrder model.ExchangeOrder,
+) *model.ExchangeOrder {
 	assetThree := swapAction.GetAssetThree()
-	var swapThreeOrder *model.BinanceOrder = nil
+	var swapThreeOrder *model.ExchangeOrder = nil
 
 	if swapAction.SwapThreeExternalId == nil {
 		balance, _ := s.BalanceService.GetAssetBalance(assetThree, false)
@@ -492,34 +498,22 @@ func (s *SwapExecutor) ExecuteSwapThree(
 		}
 
 		if s.Formatter.ComparePercentage(initialQty, quantity).Lte(99.9) {
-			log.Printf(
-				"[%d] swap quantity is less than allowed: %.10f > %.10f (step three)",
-				swapAction.Id,
-				initialQty,
-				quantity,
-			)
+			s.swapLog(*swapAction).Error("Swap quantity is less than allowed", "leg", "three", "initialQuantity", initialQty, "quantity", quantity)
 			return nil
 		}
 
-		log.Printf(
-			"[%s] Swap [%d] three balance %s is %f, operation BUY %s",
-			swapChain.SwapThree.Symbol,
-			swapAction.Id,
-			assetThree,
-			balance,
-			swapAction.SwapThreeSymbol,
-		)
+		s.swapLog(*swapAction).Info("Swap three started", "leg", "three", "asset", assetThree, "balance", balance, logger.KeySymbol, swapAction.SwapThreeSymbol)
 
 		swapPrice := swapAction.SwapThreePrice
 		swapPair, err := s.SwapRepository.GetSwapPairBySymbol(swapAction.SwapThreeSymbol)
 
-		var binanceOrder model.BinanceOrder
+		var exchangeOrder model.ExchangeOrder
 
 		if swapChain.IsSSB() || swapChain.IsSBB() {
 			// Price can fall down before we start processing, take min price for swap
 			swapPrice = math.Min(swapPrice, swapPair.BuyPrice+(swapPair.MinPrice*s.SwapThirdAmendmentSteps))
 
-			binanceOrder, err = s.Binance.LimitOrder(
+			exchangeOrder, err = s.Binance.LimitOrder(
 				swapAction.SwapThreeSymbol,
 				s.Formatter.FormatQuantity(swapPair, quantity/swapPrice),
 				s.Formatter.FormatPrice(swapPair, swapPrice),
@@ -532,7 +526,7 @@ func (s *SwapExecutor) ExecuteSwapThree(
 			// Price can grow before we start processing, take max price for swap
 			swapPrice = math.Max(swapPrice, swapPair.SellPrice-(swapPair.MinPrice*s.SwapThirdAmendmentSteps))
 
-			binanceOrder, err = s.Binance.LimitOrder(
+			exchangeOrder, err = s.Binance.LimitOrder(
 				swapAction.SwapThreeSymbol,
 				s.Formatter.FormatQuantity(swapPair, quantity),
 				s.Formatter.FormatPrice(swapPair, swapPrice),
@@ -542,31 +536,26 @@ func (s *SwapExecutor) ExecuteSwapThree(
 		}
 
 		if err != nil {
-			log.Printf(
-				"[%s] Swap [%d] three error: %s",
-				swapChain.SwapThree.Symbol,
-				swapAction.Id,
-				err.Error(),
-			)
+			s.swapLog(*swapAction).Error("Swap three order placement failed", logger.Error(err))
 			return nil
 		}
 
-		swapThreeOrder = &binanceOrder
-		swapAction.SwapThreeExternalId = &binanceOrder.OrderId
-		swapAction.SwapThreeSide = &binanceOrder.Side
-		swapAction.SwapThreeQuantity = &binanceOrder.OrigQty
+		swapThreeOrder = &exchangeOrder
+		swapAction.SwapThreeExternalId = &exchangeOrder.OrderId
+		swapAction.SwapThreeSide = &exchangeOrder.Side
+		swapAction.SwapThreeQuantity = &exchangeOrder.OrigQty
 		nowTimestamp := time.Now().Unix()
 		swapAction.SwapThreeTimestamp = &nowTimestamp
-		swapAction.SwapThreeExternalStatus = &binanceOrder.Status
+		swapAction.SwapThreeExternalStatus = exchangeOrder.GetExternalStatus()
 		_ = s.SwapRepository.UpdateSwapAction(*swapAction)
 	} else {
-		binanceOrder, err := s.Binance.QueryOrder(swapAction.SwapThreeSymbol, *swapAction.SwapThreeExternalId)
+		exchangeOrder, err := s.Binance.QueryOrder(swapAction.SwapThreeSymbol, *swapAction.SwapThreeExternalId)
 		if err != nil {
-			log.Printf("[%s] Swap error: %s", swapChain.SwapThree.Symbol, err.Error())
+			s.swapLog(*swapAction).Error("Swap three query order failed", logger.Error(err))
 			return nil
 		}
 
-		if binanceOrder.IsCanceled() || binanceOrder.IsExpired() {
+		if exchangeOrder.IsCanceled() || exchangeOrder.IsExpired() {
 			swapAction.SwapThreeExternalId = nil
 			swapAction.SwapThreeTimestamp = nil
 			swapAction.SwapThreeExternalStatus = nil
@@ -576,10 +565,10 @@ func (s *SwapExecutor) ExecuteSwapThree(
 			return nil
 		}
 
-		swapThreeOrder = &binanceOrder
-		swapAction.SwapThreeExternalStatus = &binanceOrder.Status
-		swapAction.SwapThreeSide = &binanceOrder.Side
-		swapAction.SwapThreeQuantity = &binanceOrder.OrigQty
+		swapThreeOrder = &exchangeOrder
+		swapAction.SwapThreeExternalStatus = exchangeOrder.GetExternalStatus()
+		swapAction.SwapThreeSide = &exchangeOrder.Side
+		swapAction.SwapThreeQuantity = &exchangeOrder.OrigQty
 		_ = s.SwapRepository.UpdateSwapAction(*swapAction)
 	}
 
@@ -587,52 +576,44 @@ func (s *SwapExecutor) ExecuteSwapThree(
 	if !swapThreeOrder.IsFilled() {
 		s.TimeService.WaitSeconds(5)
 		for {
-			binanceOrder, err := s.Binance.QueryOrder(swapThreeOrder.Symbol, swapThreeOrder.OrderId)
+			exchangeOrder, err := s.Binance.QueryOrder(swapThreeOrder.Symbol, swapThreeOrder.OrderId)
 			if err != nil {
-				log.Printf(
-					"[%s] Swap %s error: %s",
-					swapChain.SwapThree.Symbol,
-					s.CurrentBot.Exchange,
-					err.Error(),
-				)
+				s.swapLog(*swapAction).Warn("Swap three query order failed", logger.KeyExchangeOrderId, swapThreeOrder.OrderId, logger.Error(err))
 
 				continue
 			}
 
-			swapPair, err := s.SwapRepository.GetSwapPairBySymbol(binanceOrder.Symbol)
+			swapPair, err := s.SwapRepository.GetSwapPairBySymbol(exchangeOrder.Symbol)
 
-			log.Printf(
-				"[%s] Swap [%d] three [%s] processing, status %s [%d], price %f, current = %f, Executed %f of %f",
-				swapAction.SwapThreeSymbol,
-				swapAction.Id,
-				binanceOrder.Side,
-				binanceOrder.Status,
-				binanceOrder.OrderId,
-				binanceOrder.Price,
-				swapPair.BuyPrice,
-				binanceOrder.ExecutedQty,
-				binanceOrder.OrigQty,
+			s.legLog(*swapAction, "three", exchangeOrder).Info(
+				"Swap leg processing",
+				"status", exchangeOrder.Status,
+				"price", exchangeOrder.Price,
+				"currentPrice", swapPair.BuyPrice,
+				"executedQuantity", exchangeOrder.ExecutedQty,
+				"quantity", exchangeOrder.OrigQty,
 			)
 
 			// update value, set new memory address
-			swapThreeOrder = &binanceOrder
+			swapThreeOrder = &exchangeOrder
 
 			nowTimestamp := time.Now().Unix()
 			swapAction.SwapThreeTimestamp = &nowTimestamp
-			swapAction.SwapThreeExternalStatus = &binanceOrder.Status
-			swapAction.SwapThreeSide = &binanceOrder.Side
-			swapAction.SwapThreeQuantity = &binanceOrder.OrigQty
-			if binanceOrder.IsFilled() {
-				swapAction.SwapThreePrice = binanceOrder.Price
-				swapAction.SwapThreeQuantity = &binanceOrder.ExecutedQty
+			swapAction.SwapThreeExternalStatus = exchangeOrder.GetExternalStatus()
+			swapAction.SwapThreeSide = &exchangeOrder.Side
+			swapAction.SwapThreeQuantity = &exchangeOrder.OrigQty
+			if exchangeOrder.IsFilled() {
+				fill := exchangeOrder.GetFill()
+				swapAction.SwapThreePrice = fill.Price
+				swapAction.SwapThreeQuantity = &fill.Quantity
 			}
 			_ = s.SwapRepository.UpdateSwapAction(*swapAction)
 
-			if binanceOrder.IsFilled() {
+			if exchangeOrder.IsFilled() {
 				break
 			}
 
-			if binanceOrder.IsCanceled() || binanceOrder.IsExpired() {
+			if exchangeOrder.IsCanceled() || exchangeOrder.IsExpired() {
 				swapAction.SwapThreeExternalId = nil
 				swapAction.SwapThreeTimestamp = nil
 				swapAction.SwapThreeExternalStatus = nil
@@ -647,7 +628,7 @@ func (s *SwapExecutor) ExecuteSwapThree(
 			if swapChain.IsSBS() {
 				currentPrice = swapPair.SellPrice
 			}
-			priceDiff := s.Formatter.ComparePercentage(binanceOrder.Price, currentPrice) - 100.00
+			priceDiff := s.Formatter.ComparePercentage(exchangeOrder.Price, currentPrice) - 100.00
 
 			// todo: half of minimum swap percent
 			if (swapChain.IsSSB() || swapChain.IsSBB()) && priceDiff.Gte(0.15) {
@@ -658,7 +639,7 @@ func (s *SwapExecutor) ExecuteSwapThree(
 			}
 
 			// 2 hours can not process third step
-			if binanceOrder.IsNew() && (s.TimeService.GetNowDiffMinutes(*swapAction.SwapTwoTimestamp) > 10 || priceDeadlineReached) {
+			if exchangeOrder.IsNew() && (s.TimeService.GetNowDiffMinutes(*swapAction.SwapTwoTimestamp) > 10 || priceDeadlineReached) {
 				// todo: force swap savepoint!!!
 				err = s.TryForceSwapThree(swapAction, swapChain, swapTwoOrder, assetThree)
 				if err == nil {
@@ -666,14 +647,14 @@ func (s *SwapExecutor) ExecuteSwapThree(
 					return nil
 				}
 
-				log.Printf("Swap three [%d] force swap: %s", swapAction.Id, err.Error())
+				s.swapLog(*swapAction).Warn("Swap three force swap failed", logger.Error(err))
 			}
 
-			if binanceOrder.IsPartiallyFilled() {
-				swapAction.EndQuantity = &binanceOrder.ExecutedQty
+			if exchangeOrder.IsPartiallyFilled() {
+				swapAction.EndQuantity = &exchangeOrder.ExecutedQty
 				_ = s.SwapRepository.UpdateSwapAction(*swapAction)
 
-				if (nowTimestamp-swapAction.StartTimestamp) > (3600*4) && binanceOrder.IsNearlyFilled() {
+				if (nowTimestamp-swapAction.StartTimestamp) > (3600*4) && exchangeOrder.IsNearlyFilled() {
 					break // Do not cancel order, but check it later...
 				}
 				s.TimeService.WaitSeconds(7)
@@ -689,7 +670,7 @@ func (s *SwapExecutor) ExecuteSwapThree(
 func (s *SwapExecutor) TryRollbackSwapTwo(
 	action *model.SwapAction,
 	swapChain model.SwapChainEntity,
-	swapOneOrder model.BinanceOrder,
+	swapOneOrder model.ExchangeOrder,
 	asset string,
 ) error {
 	if !swapChain.IsSSB() && !swapChain.IsSBS() && !swapChain.IsSBB() {
@@ -719,9 +700,11 @@ func (s *SwapExecutor) TryRollbackSwapTwo(
 		return err
 	}
 
-	_, err = s.Binance.CancelOrder(action.SwapTwoSymbol, *action.SwapTwoExternalId)
-	if err != nil {
-		return err
+	if action.SwapTwoExternalId != nil {
+		_, err = s.Binance.CancelOrder(action.SwapTwoSymbol, *action.SwapTwoExternalId)
+		if err != nil {
+			return err
+		}
 	}
 
 	for i := 1.00; i <= 100.00; i++ {
@@ -747,49 +730,59 @@ func (s *SwapExecutor) TryRollbackSwapTwo(
 		percent = s.Formatter.ComparePercentage(action.StartQuantity, endQuantity) - 100.00
 
 		if percent.Gte(minSwapRollbackPercent) {
-			binanceOrder, err := s.Binance.LimitOrder(
-				swapOneOrder.Symbol,
-				endQuantity,
-				s.Formatter.FormatPrice(swapPair, price),
-				"BUY",
-				"IOC",
-			)
+			var exchangeOrder model.ExchangeOrder
+
+			if i > SwapMarketOrderAttempts {
+				exchangeOrder, err = s.Binance.QuoteMarketOrder(
+					swapOneOrder.Symbol,
+					s.Formatter.ToFixed(quantity, 8),
+					"BUY",
+				)
+			} else {
+				exchangeOrder, err = s.Binance.LimitOrder(
+					swapOneOrder.Symbol,
+					endQuantity,
+					s.Formatter.FormatPrice(swapPair, price),
+					"BUY",
+					"IOC",
+				)
+			}
 			if err != nil {
 				return err
 			}
 
-			if !binanceOrder.IsFilled() {
-				log.Printf(
-					"Can not fill rollback order, status: %s | price: %f, current: %f [%.2f%s] %f -> %f",
-					binanceOrder.Status,
-					binanceOrder.Price,
-					swapPair.BuyPrice,
-					percent,
-					"%",
-					action.StartQuantity,
-					endQuantity,
+			if !exchangeOrder.IsFilled() {
+				s.legLog(*action, "rollback", exchangeOrder).Warn(
+					"Can not fill rollback order",
+					"status", exchangeOrder.Status,
+					"price", exchangeOrder.Price,
+					"currentPrice", swapPair.BuyPrice,
+					"percent", percent,
+					"startQuantity", action.StartQuantity,
+					"endQuantity", endQuantity,
 				)
 				s.TimeService.WaitSeconds(5)
 				continue
 			}
 
 			// save information about rollback transaction...
-			action.EndQuantity = &binanceOrder.ExecutedQty
+			action.EndQuantity = &exchangeOrder.ExecutedQty
 			now := time.Now().Unix()
 			action.EndTimestamp = &now
-			status := fmt.Sprintf("%s_RB", binanceOrder.Status)
+			status := fmt.Sprintf("%s_RB", exchangeOrder.Status)
 			action.SwapTwoTimestamp = &now
 			action.SwapTwoExternalStatus = &status
-			action.SwapTwoPrice = binanceOrder.Price
-			action.SwapTwoSymbol = binanceOrder.Symbol
-			action.SwapTwoExternalId = &binanceOrder.OrderId
-			action.SwapTwoSide = &binanceOrder.Side
-			action.SwapTwoQuantity = &binanceOrder.OrigQty
+			action.SwapTwoPrice = exchangeOrder.Price
+			action.SwapTwoSymbol = exchangeOrder.Symbol
+			action.SwapTwoExternalId = &exchangeOrder.OrderId
+			action.SwapTwoSide = &exchangeOrder.Side
+			action.SwapTwoQuantity = &exchangeOrder.OrigQty
 			action.Status = model.SwapActionStatusSuccess
 			err = s.SwapRepository.UpdateSwapAction(*action)
 			if err != nil {
 				panic(err)
 			}
+			metrics.SwapOutcomes.Inc("rollback")
 			return nil
 		} else {
 			return errors.New(fmt.Sprintf("Can't rollback swap, percent is too low: %.2f%s", percent, "%"))
@@ -802,7 +795,7 @@ func (s *SwapExecutor) TryRollbackSwapTwo(
 func (s *SwapExecutor) TryForceSwapThree(
 	swapAction *model.SwapAction,
 	swapChain model.SwapChainEntity,
-	swapTwoOrder model.BinanceOrder,
+	swapTwoOrder model.ExchangeOrder,
 	asset string,
 ) error {
 	if !swapChain.IsSSB() && !swapChain.IsSBS() && !swapChain.IsSBB() {
@@ -848,9 +841,11 @@ func (s *SwapExecutor) TryForceSwapThree(
 		))
 	}
 
-	_, err = s.Binance.CancelOrder(swapAction.SwapThreeSymbol, *swapAction.SwapThreeExternalId)
-	if err != nil {
-		return err
+	if swapAction.SwapThreeExternalId != nil {
+		_, err = s.Binance.CancelOrder(swapAction.SwapThreeSymbol, *swapAction.SwapThreeExternalId)
+		if err != nil {
+			return err
+		}
 	}
 
 	balance, err := s.BalanceService.GetAssetBalance(asset, false)
@@ -885,12 +880,22 @@ func (s *SwapExecutor) TryForceSwapThree(
 		percent = s.Formatter.ComparePercentage(swapAction.StartQuantity, predictedEndQty) - 100.00
 
 		if percent.Gte(minSwapRollbackPercent) {
-			var binanceOrder model.BinanceOrder
+			var exchangeOrder model.ExchangeOrder
 
 			// todo: find required quantity in order book
 
-			if swapChain.IsSSB() || swapChain.IsSBB() {
-				binanceOrder, err = s.Binance.LimitOrder(
+			isMarket := i > SwapMarketOrderAttempts
+
+			if (swapChain.IsSSB() || swapChain.IsSBB()) && isMarket {
+				exchangeOrder, err = s.Binance.QuoteMarketOrder(
+					swapAction.SwapThreeSymbol,
+					s.Formatter.ToFixed(quantity, 8),
+					"BUY",
+				)
+			}
+
+			if (swapChain.IsSSB() || swapChain.IsSBB()) && !isMarket {
+				exchangeOrder, err = s.Binance.LimitOrder(
 					swapAction.SwapThreeSymbol,
 					s.Formatter.FormatQuantity(swapPair, quantity/price),
 					s.Formatter.FormatPrice(swapPair, price),
@@ -899,8 +904,16 @@ func (s *SwapExecutor) TryForceSwapThree(
 				)
 			}
 
-			if swapChain.IsSBS() {
-				binanceOrder, err = s.Binance.LimitOrder(
+			if swapChain.IsSBS() && isMarket {
+				exchangeOrder, err = s.Binance.MarketOrder(
+					swapAction.SwapThreeSymbol,
+					s.Formatter.FormatQuantity(swapPair, quantity),
+					"SELL",
+				)
+			}
+
+			if swapChain.IsSBS() && !isMarket {
+				exchangeOrder, err = s.Binance.LimitOrder(
 					swapAction.SwapThreeSymbol,
 					s.Formatter.FormatQuantity(swapPair, quantity),
 					s.Formatter.FormatPrice(swapPair, price),
@@ -909,41 +922,41 @@ func (s *SwapExecutor) TryForceSwapThree(
 				)
 			}
 
-			if !binanceOrder.IsFilled() {
-				log.Printf(
-					"Can not fill force swap order, status: %s | price: %f, current: %f [%.2f%s] %f -> %f",
-					binanceOrder.Status,
-					binanceOrder.Price,
-					swapPair.BuyPrice,
-					percent,
-					"%",
-					swapAction.StartQuantity,
-					endQuantity,
+			if !exchangeOrder.IsFilled() {
+				s.legLog(*swapAction, "force", exchangeOrder).Warn(
+					"Can not fill force swap order",
+					"status", exchangeOrder.Status,
+					"price", exchangeOrder.Price,
+					"currentPrice", swapPair.BuyPrice,
+					"percent", percent,
+					"startQuantity", swapAction.StartQuantity,
+					"endQuantity", endQuantity,
 				)
 				s.TimeService.WaitSeconds(5)
 				continue
 			}
 
 			// save information about rollback transaction...
-			swapAction.EndQuantity = &binanceOrder.ExecutedQty
+			swapAction.EndQuantity = &exchangeOrder.ExecutedQty
 			if swapChain.IsSBS() {
-				swapAction.EndQuantity = &binanceOrder.CummulativeQuoteQty
+				swapAction.EndQuantity = &exchangeOrder.CummulativeQuoteQty
 			}
 			now := time.Now().Unix()
 			swapAction.EndTimestamp = &now
-			status := fmt.Sprintf("%s_FORCE", binanceOrder.Status)
+			status := fmt.Sprintf("%s_FORCE", exchangeOrder.Status)
 			swapAction.SwapThreeTimestamp = &now
 			swapAction.SwapThreeExternalStatus = &status
-			swapAction.SwapThreePrice = binanceOrder.Price
-			swapAction.SwapThreeSymbol = binanceOrder.Symbol
-			swapAction.SwapThreeExternalId = &binanceOrder.OrderId
-			swapAction.SwapThreeSide = &binanceOrder.Side
-			swapAction.SwapThreeQuantity = &binanceOrder.OrigQty
+			swapAction.SwapThreePrice = exchangeOrder.Price
+			swapAction.SwapThreeSymbol = exchangeOrder.Symbol
+			swapAction.SwapThreeExternalId = &exchangeOrder.OrderId
+			swapAction.SwapThreeSide = &exchangeOrder.Side
+			swapAction.SwapThreeQuantity = &exchangeOrder.OrigQty
 			swapAction.Status = model.SwapActionStatusSuccess
 			err = s.SwapRepository.UpdateSwapAction(*swapAction)
 			if err != nil {
 				panic(err)
 			}
+			metrics.SwapOutcomes.Inc("force")
 			return nil
 		} else {
 			return errors.New(fmt.Sprintf("Can't force swap, percent is too low: %.2f%s", percent, "%"))
@@ -952,3 +965,67 @@ func (s *SwapExecutor) TryForceSwapThree(
 
 	return errors.New("Can't force swap")
 }
+
+// notify is skipped if executor is created without notifications
+func (s *SwapExecutor) notify(event string, swapAction model.SwapAction, details string) {
+	if s.CallbackManager == nil || s.CurrentBot == nil {
+		return
+	}
+
+	go s.CallbackManager.Notify(model.NewSwapNotification(event, swapAction, *s.CurrentBot, details))
+}
+
+// swapLog is not aware of position symbol, every leg is logged with own symbol
+func (s *SwapExecutor) swapLog(swapAction model.SwapAction) *slog.Logger {
+	l := slog.With(
+		"asset", swapAction.Asset,
+		logger.KeyOrderId, swapAction.OrderId,
+		logger.KeySwapActionId, swapAction.Id,
+	)
+
+	if s.CurrentBot != nil {
+		l = l.With(logger.KeyExchange, s.CurrentBot.Exchange)
+	}
+
+	return l
+}
+
+func (s *SwapExecutor) legLog(swapAction model.SwapAction, leg string, exchangeOrder model.ExchangeOrder) *slog.Logger {
+	return s.swapLog(swapAction).With(
+		"leg", leg,
+		logger.KeySymbol, exchangeOrder.Symbol,
+		logger.KeyOperation, strings.ToLower(exchangeOrder.Side),
+		logger.KeyExchangeOrderId, exchangeOrder.OrderId,
+	)
+}
+
+func (s *SwapExecutor) TryLockAction(swapActionId int64) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.actionLock == nil {
+		s.actionLock = make(map[int64]bool)
+	}
+
+	if s.actionLock[swapActionId] {
+		return false
+	}
+
+	s.actionLock[swapActionId] = true
+
+	return true
+}
+
+func (s *SwapExecutor) UnlockAction(swapActionId int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.actionLock, swapActionId)
+}
+
+func (s *SwapExecutor) IsActionLocked(swapActionId int64) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.actionLock[swapActionId]
+}
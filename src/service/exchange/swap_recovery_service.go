@@ -0,0 +1,398 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"gitlab.com/open-soft/go-crypto-bot/src/service"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"log/slog"
+	"time"
+)
+
+const SwapRecoveryStuckMinutes = 30.00
+const SwapRecoveryCheckInterval = time.Minute * 5
+
+// SwapRecoveryService finds swap actions which are not processed for a long time,
+// reconciles legs with exchange orders and resolves them manually (retry, force, rollback)
+type SwapRecoveryService struct {
+	SwapRepository  repository.SwapRecoveryRepositoryInterface
+	OrderRepository repository.OrderStorageInterface
+	SwapExecutor    *SwapExecutor
+	Binance         client.ExchangeOrderAPIInterface
+	BalanceService  BalanceServiceInterface
+	TimeService     utils.TimeServiceInterface
+	StuckMinutes    float64
+	ShutdownManager service.ShutdownManagerInterface
+}
+
+func (s *SwapRecoveryService) StartWatching() {
+	for {
+		stuckList := s.GetStuckList()
+		for _, state := range stuckList {
+			s.swapLog(state.SwapAction).Warn(
+				"Swap is stuck",
+				"leg", state.Leg,
+				"idleMinutes", state.IdleMinutes,
+				"fundsAsset", state.Asset,
+			)
+		}
+
+		time.Sleep(SwapRecoveryCheckInterval)
+	}
+}
+
+func (s *SwapRecoveryService) GetStuckList() []model.SwapRecoveryState {
+	list := make([]model.SwapRecoveryState, 0)
+
+	for _, action := range s.SwapRepository.GetProcessingSwapActions() {
+		if s.TimeService.GetNowDiffMinutes(action.GetLastActivityTimestamp()) < s.StuckMinutes {
+			continue
+		}
+
+		// do not reconcile the action which is processed by swap executor right now
+		if s.SwapExecutor.TryLockAction(action.Id) {
+			s.reconcile(&action)
+			s.SwapExecutor.UnlockAction(action.Id)
+		}
+
+		list = append(list, s.getState(action))
+	}
+
+	return list
+}
+
+func (s *SwapRecoveryService) GetState(swapActionId int64) (model.SwapRecoveryState, error) {
+	action, err := s.SwapRepository.GetSwapActionById(swapActionId)
+	if err != nil {
+		return model.SwapRecoveryState{}, err
+	}
+
+	return s.getState(action), nil
+}
+
+// Retry places canceled or expired leg order again, swap executor continues processing from the unfinished leg
+func (s *SwapRecoveryService) Retry(swapActionId int64) (model.SwapRecoveryState, error) {
+	action, _, err := s.acquire(swapActionId)
+	if err != nil {
+		return model.SwapRecoveryState{}, err
+	}
+	// operation is finished by swap executor goroutine
+	isStarted := false
+	defer func() {
+		if !isStarted {
+			s.endOperation()
+		}
+	}()
+
+	leg := action.GetUnfinishedLeg()
+
+	if leg == model.SwapLegFinished {
+		s.SwapExecutor.UnlockAction(action.Id)
+		err = errors.New("All swap legs are filled, use force to complete swap")
+		s.decide(action, model.SwapRecoveryActionRetry, leg, false, err.Error())
+
+		return s.getState(action), err
+	}
+
+	order, err := s.OrderRepository.Find(action.OrderId)
+	if err != nil {
+		s.SwapExecutor.UnlockAction(action.Id)
+		s.decide(action, model.SwapRecoveryActionRetry, leg, false, err.Error())
+
+		return s.getState(action), err
+	}
+
+	order.Swap = true
+	_ = s.OrderRepository.Update(order)
+	s.SwapExecutor.UnlockAction(action.Id)
+	s.decide(action, model.SwapRecoveryActionRetry, leg, true, fmt.Sprintf("Swap executor is restarted from leg %s", leg))
+
+	isStarted = true
+	go func(order model.Order) {
+		defer s.endOperation()
+		s.SwapExecutor.Execute(order)
+	}(order)
+
+	return s.getState(action), nil
+}
+
+// Force finishes the swap: sells intermediate asset of the third leg or closes the action if all legs are filled
+func (s *SwapRecoveryService) Force(swapActionId int64) (model.SwapRecoveryState, error) {
+	action, orders, err := s.acquire(swapActionId)
+	if err != nil {
+		return model.SwapRecoveryState{}, err
+	}
+	defer s.endOperation()
+	defer s.SwapExecutor.UnlockAction(action.Id)
+
+	leg := action.GetUnfinishedLeg()
+
+	swapChain, err := s.SwapRepository.GetSwapChainById(action.SwapChainId)
+	if err != nil {
+		s.decide(action, model.SwapRecoveryActionForce, leg, false, err.Error())
+		return s.getState(action), err
+	}
+
+	switch leg {
+	case model.SwapLegThree:
+		if orders[model.SwapLegTwo] == nil {
+			err = errors.New("Swap two order is not found")
+			break
+		}
+		err = s.SwapExecutor.TryForceSwapThree(&action, swapChain, *orders[model.SwapLegTwo], action.GetAssetThree())
+		break
+	case model.SwapLegFinished:
+		if orders[model.SwapLegThree] == nil {
+			err = errors.New("Swap three order is not found")
+			break
+		}
+		endQuantity := orders[model.SwapLegThree].ExecutedQty
+		if swapChain.IsSBS() {
+			endQuantity = orders[model.SwapLegThree].CummulativeQuoteQty
+		}
+		nowTimestamp := s.TimeService.GetNowUnix()
+		action.Status = model.SwapActionStatusSuccess
+		action.EndTimestamp = &nowTimestamp
+		action.EndQuantity = &endQuantity
+		err = s.SwapRepository.UpdateSwapAction(action)
+		break
+	default:
+		err = errors.New(fmt.Sprintf("Force is possible when swap two is filled, current leg is %s", leg))
+		break
+	}
+
+	if err != nil {
+		s.decide(action, model.SwapRecoveryActionForce, leg, false, err.Error())
+		return s.getState(action), err
+	}
+
+	s.finish(action)
+	s.decide(action, model.SwapRecoveryActionForce, leg, true, fmt.Sprintf("Swap is completed, end quantity %f %s", *action.EndQuantity, action.Asset))
+
+	return s.getState(action), nil
+}
+
+// Rollback returns funds to the original asset, it is possible until swap two is filled
+func (s *SwapRecoveryService) Rollback(swapActionId int64) (model.SwapRecoveryState, error) {
+	action, orders, err := s.acquire(swapActionId)
+	if err != nil {
+		return model.SwapRecoveryState{}, err
+	}
+	defer s.endOperation()
+	defer s.SwapExecutor.UnlockAction(action.Id)
+
+	leg := action.GetUnfinishedLeg()
+
+	switch leg {
+	case model.SwapLegOne:
+		err = s.rollbackSwapOne(&action, orders[model.SwapLegOne])
+		break
+	case model.SwapLegTwo:
+		if orders[model.SwapLegOne] == nil {
+			err = errors.New("Swap one order is not found")
+			break
+		}
+		swapChain, chainErr := s.SwapRepository.GetSwapChainById(action.SwapChainId)
+		if chainErr != nil {
+			err = chainErr
+			break
+		}
+		err = s.SwapExecutor.TryRollbackSwapTwo(&action, swapChain, *orders[model.SwapLegOne], action.GetAssetTwo())
+		break
+	default:
+		err = errors.New(fmt.Sprintf("Rollback is possible until swap two is filled, current leg is %s", leg))
+		break
+	}
+
+	if err != nil {
+		s.decide(action, model.SwapRecoveryActionRollback, leg, false, err.Error())
+		return s.getState(action), err
+	}
+
+	s.finish(action)
+	s.decide(action, model.SwapRecoveryActionRollback, leg, true, fmt.Sprintf("Swap is rolled back, end quantity %f %s", *action.EndQuantity, action.Asset))
+
+	return s.getState(action), nil
+}
+
+func (s *SwapRecoveryService) rollbackSwapOne(action *model.SwapAction, swapOneOrder *model.ExchangeOrder) error {
+	if swapOneOrder != nil {
+		if swapOneOrder.ExecutedQty > 0 {
+			return errors.New(fmt.Sprintf("Swap one order is partially filled: %f of %f, use retry", swapOneOrder.ExecutedQty, swapOneOrder.OrigQty))
+		}
+
+		canceled, err := s.Binance.CancelOrder(swapOneOrder.Symbol, swapOneOrder.OrderId)
+		if err != nil {
+			return err
+		}
+
+		action.SwapOneExternalStatus = canceled.GetExternalStatus()
+	}
+
+	nowTimestamp := s.TimeService.GetNowUnix()
+	action.Status = model.SwapActionStatusCanceled
+	action.EndTimestamp = &nowTimestamp
+	action.EndQuantity = &action.StartQuantity
+
+	return s.SwapRepository.UpdateSwapAction(*action)
+}
+
+// acquire begins shutdown tracked operation, caller must call endOperation if there is no error
+func (s *SwapRecoveryService) acquire(swapActionId int64) (model.SwapAction, map[string]*model.ExchangeOrder, error) {
+	action, err := s.SwapRepository.GetSwapActionById(swapActionId)
+	if err != nil {
+		return action, nil, err
+	}
+
+	if !action.IsProcessing() {
+		return action, nil, errors.New(fmt.Sprintf("Swap action %d is %s", action.Id, action.Status))
+	}
+
+	if !s.beginOperation() {
+		return action, nil, errors.New("Shutdown is in progress, swap action will be resumed after restart")
+	}
+
+	if !s.SwapExecutor.TryLockAction(action.Id) {
+		s.endOperation()
+		return action, nil, errors.New(fmt.Sprintf("Swap action %d is processed by swap executor", action.Id))
+	}
+
+	return action, s.reconcile(&action), nil
+}
+
+// reconcile updates leg statuses from exchange order history, canceled or expired leg orders are cleared
+func (s *SwapRecoveryService) reconcile(action *model.SwapAction) map[string]*model.ExchangeOrder {
+	orders := make(map[string]*model.ExchangeOrder)
+
+	for _, leg := range []string{model.SwapLegOne, model.SwapLegTwo, model.SwapLegThree} {
+		symbol, externalId := action.GetLegOrder(leg)
+		if externalId == nil {
+			break
+		}
+
+		exchangeOrder, err := s.Binance.QueryOrder(symbol, *externalId)
+		if err != nil {
+			s.decide(*action, model.SwapRecoveryActionReconcile, leg, false, fmt.Sprintf("Order %s query error: %s", *externalId, err.Error()))
+			break
+		}
+
+		before := action.IsLegFilled(leg)
+		action.UpdateLegOrder(leg, exchangeOrder)
+
+		if exchangeOrder.IsCanceled() || exchangeOrder.IsExpired() {
+			action.ClearLegOrder(leg)
+			_ = s.SwapRepository.UpdateSwapAction(*action)
+			s.BalanceService.InvalidateBalanceCache(action.GetLegAsset(leg))
+			s.decide(*action, model.SwapRecoveryActionReconcile, leg, true, fmt.Sprintf("Order %s is %s, order is cleared", exchangeOrder.OrderId, exchangeOrder.Status))
+			break
+		}
+
+		_ = s.SwapRepository.UpdateSwapAction(*action)
+		orders[leg] = &exchangeOrder
+
+		if before != exchangeOrder.IsFilled() {
+			s.decide(*action, model.SwapRecoveryActionReconcile, leg, true, fmt.Sprintf("Order %s status is %s", exchangeOrder.OrderId, exchangeOrder.Status))
+		}
+
+		if !exchangeOrder.IsFilled() {
+			break
+		}
+	}
+
+	return orders
+}
+
+func (s *SwapRecoveryService) finish(action model.SwapAction) {
+	order, err := s.OrderRepository.Find(action.OrderId)
+	if err == nil {
+		order.Swap = false
+		_ = s.OrderRepository.Update(order)
+	}
+
+	for _, leg := range []string{model.SwapLegOne, model.SwapLegTwo, model.SwapLegThree} {
+		s.BalanceService.InvalidateBalanceCache(action.GetLegAsset(leg))
+	}
+}
+
+func (s *SwapRecoveryService) decide(action model.SwapAction, recoveryAction string, leg string, success bool, message string) {
+	decisionLog := s.swapLog(action).With("recoveryAction", recoveryAction, "leg", leg)
+	if success {
+		decisionLog.Info("Swap recovery decision", "message", message)
+	} else {
+		decisionLog.Warn("Swap recovery decision", "message", message)
+	}
+
+	err := s.SwapRepository.AddSwapRecoveryDecision(model.SwapRecoveryDecision{
+		SwapActionId: action.Id,
+		Action:       recoveryAction,
+		Leg:          leg,
+		Manual:       recoveryAction != model.SwapRecoveryActionReconcile,
+		Success:      success,
+		Message:      message,
+		Timestamp:    s.TimeService.GetNowUnix(),
+	})
+
+	if err != nil {
+		decisionLog.Error("Swap recovery decision is not saved", logger.Error(err))
+	}
+}
+
+func (s *SwapRecoveryService) getState(action model.SwapAction) model.SwapRecoveryState {
+	leg := action.GetUnfinishedLeg()
+	processing := s.SwapExecutor.IsActionLocked(action.Id)
+	allowedActions := make([]string, 0)
+
+	if action.IsProcessing() && !processing {
+		switch leg {
+		case model.SwapLegOne, model.SwapLegTwo:
+			allowedActions = append(allowedActions, model.SwapRecoveryActionRetry, model.SwapRecoveryActionRollback)
+			break
+		case model.SwapLegThree:
+			allowedActions = append(allowedActions, model.SwapRecoveryActionRetry, model.SwapRecoveryActionForce)
+			break
+		case model.SwapLegFinished:
+			allowedActions = append(allowedActions, model.SwapRecoveryActionForce)
+			break
+		}
+	}
+
+	return model.SwapRecoveryState{
+		SwapAction:     action,
+		Leg:            leg,
+		Asset:          action.GetLegAsset(leg),
+		Processing:     processing,
+		IdleMinutes:    s.TimeService.GetNowDiffMinutes(action.GetLastActivityTimestamp()),
+		AllowedActions: allowedActions,
+		Decisions:      s.SwapRepository.GetSwapRecoveryDecisions(action.Id),
+	}
+}
+
+// swapLog adds correlation id of the swapped order, recovery is logged as a part of the same operation
+func (s *SwapRecoveryService) swapLog(action model.SwapAction) *slog.Logger {
+	l := s.SwapExecutor.swapLog(action).With(logger.KeySymbol, action.SwapOneSymbol, logger.KeyOperation, "swap_recovery")
+	if s.OrderRepository == nil {
+		return l
+	}
+
+	order, err := s.OrderRepository.Find(action.OrderId)
+	if err == nil && order.Explanation != nil && order.Explanation.CorrelationId != "" {
+		l = l.With(logger.KeyCorrelationId, order.Explanation.CorrelationId)
+	}
+
+	return l
+}
+
+// beginOperation returns false when shutdown is requested
+func (s *SwapRecoveryService) beginOperation() bool {
+	return s.ShutdownManager == nil || s.ShutdownManager.Begin()
+}
+
+func (s *SwapRecoveryService) endOperation() {
+	if s.ShutdownManager != nil {
+		s.ShutdownManager.End()
+	}
+}
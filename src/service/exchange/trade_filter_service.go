@@ -4,6 +4,7 @@ import (
 	"gitlab.com/open-soft/go-crypto-bot/src/client"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/indicator"
 	"gitlab.com/open-soft/go-crypto-bot/src/utils"
 	"strconv"
 	"strings"
@@ -21,6 +22,7 @@ type TradeFilterService struct {
 	ExchangePriceAPI  client.ExchangePriceAPIInterface
 	Formatter         *utils.Formatter
 	SignalStorage     repository.SignalStorageInterface
+	IndicatorService  indicator.IndicatorServiceInterface
 }
 
 func (t *TradeFilterService) CanBuy(limit model.TradeLimit) bool {
@@ -133,6 +135,25 @@ func (t *TradeFilterService) IsValueMatched(filter model.TradeFilter) bool {
 			matched = t.CompareString(label, filter)
 		}
 		break
+	case model.IndicatorEma9,
+		model.IndicatorEma21,
+		model.IndicatorEmaCross,
+		model.IndicatorRsi14,
+		model.IndicatorMacd,
+		model.IndicatorMacdSignal,
+		model.IndicatorMacdHistogram,
+		model.IndicatorBollingerUpper,
+		model.IndicatorBollingerMiddle,
+		model.IndicatorBollingerLower,
+		model.IndicatorBollingerPercent,
+		model.IndicatorAtr14,
+		model.IndicatorVwap:
+		if t.IndicatorService != nil {
+			if value, ok := t.IndicatorService.GetValue(filter.Symbol, filter.Parameter); ok {
+				matched = t.CompareFloat(value, filter)
+			}
+		}
+		break
 	}
 
 	return matched
@@ -7,11 +7,12 @@ import (
 	"fmt"
 	"github.com/redis/go-redis/v9"
 	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
 	"gitlab.com/open-soft/go-crypto-bot/src/service"
 	"gitlab.com/open-soft/go-crypto-bot/src/utils"
-	"log"
+	"log/slog"
 	"sort"
 	"strings"
 	"sync"
@@ -48,8 +49,8 @@ type TradeStackParams struct {
 
 func (t *TradeStack) CanBuy(limit model.TradeLimit) bool {
 	// Allow to process existing order
-	binanceOrder := t.OrderRepository.GetBinanceOrder(limit.Symbol, "BUY")
-	if binanceOrder != nil {
+	exchangeOrder := t.OrderRepository.GetExchangeOrder(limit.Symbol, "BUY")
+	if exchangeOrder != nil {
 		return true
 	}
 
@@ -69,26 +70,39 @@ func (t *TradeStack) CanBuy(limit model.TradeLimit) bool {
 		AttachDecisions: false,
 	})
 
-	if len(result) == 0 {
-		return false
+	// every quote asset has own balance, so the stack is ranked per quote asset
+	for _, stackItem := range result {
+		if stackItem.QuoteAsset == limit.GetQuoteAsset() {
+			return limit.Symbol == stackItem.Symbol
+		}
 	}
 
-	return limit.Symbol == result[0].Symbol
+	return false
 }
 
 func (t *TradeStack) GetTradeStack(params TradeStackParams) []model.TradeStackItem {
-	balanceUsdt, err := t.BalanceService.GetAssetBalance("USDT", true)
 	stack := make([]model.TradeStackItem, 0)
+	tradeLimits := t.ExchangeRepository.GetTradeLimits()
+	balances := make(map[string]float64)
+
+	for _, tradeLimit := range tradeLimits {
+		quoteAsset := tradeLimit.GetQuoteAsset()
+		if _, ok := balances[quoteAsset]; ok {
+			continue
+		}
 
-	if err != nil {
-		log.Printf("Trade stack balance error: %s", err.Error())
-		return stack
+		balance, err := t.BalanceService.GetAssetBalance(quoteAsset, true)
+		if err != nil {
+			slog.Error("Trade stack balance error", "quoteAsset", quoteAsset, logger.Error(err))
+			return stack
+		}
+		balances[quoteAsset] = balance
 	}
 
 	wg := sync.WaitGroup{}
 	lock := sync.Mutex{}
 
-	for index, tradeLimit := range t.ExchangeRepository.GetTradeLimits() {
+	for index, tradeLimit := range tradeLimits {
 		wg.Add(1)
 		go func(l model.TradeLimit, i int64, p TradeStackParams) {
 			defer wg.Done()
@@ -120,25 +134,26 @@ func (t *TradeStack) GetTradeStack(params TradeStackParams) []model.TradeStackIt
 	impossible := make([]model.TradeStackItem, 0)
 
 	for index, stackItem := range stack {
-		if stackItem.BinanceOrder != nil {
-			balanceUsdt += stackItem.BinanceOrder.OrigQty * stackItem.BinanceOrder.Price
+		if stackItem.ExchangeOrder != nil {
+			balances[stackItem.QuoteAsset] += stackItem.ExchangeOrder.OrigQty * stackItem.ExchangeOrder.Price
 		}
 
 		stack[index].Index = int64(index)
 	}
 
 	for _, stackItem := range stack {
-		if balanceUsdt >= stackItem.BudgetUsdt {
-			balanceUsdt -= stackItem.BudgetUsdt
+		if balances[stackItem.QuoteAsset] >= stackItem.BudgetUsdt {
+			balances[stackItem.QuoteAsset] -= stackItem.BudgetUsdt
 
 			result = append(result, model.TradeStackItem{
 				Index:                   stackItem.Index,
 				Symbol:                  stackItem.Symbol,
+				QuoteAsset:              stackItem.QuoteAsset,
 				Percent:                 stackItem.Percent,
 				BudgetUsdt:              stackItem.BudgetUsdt,
 				HasEnoughBalance:        true,
-				BalanceAfter:            balanceUsdt,
-				BinanceOrder:            stackItem.BinanceOrder,
+				BalanceAfter:            balances[stackItem.QuoteAsset],
+				ExchangeOrder:           stackItem.ExchangeOrder,
 				IsExtraCharge:           stackItem.IsExtraCharge,
 				IsPriceValid:            stackItem.IsPriceValid,
 				Price:                   stackItem.Price,
@@ -165,16 +180,17 @@ func (t *TradeStack) GetTradeStack(params TradeStackParams) []model.TradeStackIt
 
 	if !params.BalanceFilter {
 		for _, stackItem := range impossible {
-			balanceUsdt -= stackItem.BudgetUsdt
+			balances[stackItem.QuoteAsset] -= stackItem.BudgetUsdt
 
 			result = append(result, model.TradeStackItem{
 				Index:                   stackItem.Index,
 				Symbol:                  stackItem.Symbol,
+				QuoteAsset:              stackItem.QuoteAsset,
 				Percent:                 stackItem.Percent,
 				BudgetUsdt:              stackItem.BudgetUsdt,
 				HasEnoughBalance:        false,
-				BalanceAfter:            balanceUsdt,
-				BinanceOrder:            stackItem.BinanceOrder,
+				BalanceAfter:            balances[stackItem.QuoteAsset],
+				ExchangeOrder:           stackItem.ExchangeOrder,
 				IsExtraCharge:           stackItem.IsExtraCharge,
 				IsPriceValid:            stackItem.IsPriceValid,
 				Price:                   stackItem.Price,
@@ -239,8 +255,8 @@ func (t *TradeStack) ProcessItem(
 	}
 
 	// Skip if order has already opened
-	binanceOrder := t.OrderRepository.GetBinanceOrder(tradeLimit.Symbol, "BUY")
-	if binanceOrder != nil && params.SkipPending {
+	exchangeOrder := t.OrderRepository.GetExchangeOrder(tradeLimit.Symbol, "BUY")
+	if exchangeOrder != nil && params.SkipPending {
 		return nil
 	}
 
@@ -268,8 +284,8 @@ func (t *TradeStack) ProcessItem(
 		MarketPrice:    0.00,
 	}
 
-	if binanceOrder != nil {
-		buyPrice = binanceOrder.Price
+	if exchangeOrder != nil {
+		buyPrice = exchangeOrder.Price
 	} else if lastKLine != nil {
 		if !lastKLine.IsPriceExpired() {
 			buyPrice = t.GetBuyPriceCached(tradeLimit)
@@ -293,7 +309,7 @@ func (t *TradeStack) ProcessItem(
 
 	signal := t.SignalStorage.GetSignal(tradeLimit.Symbol)
 	interpolation := model.Interpolation{
-		Asset:                strings.ReplaceAll(tradeLimit.Symbol, "USDT", ""),
+		Asset:                tradeLimit.GetBaseAsset(),
 		EthInterpolationUsdt: 0.00,
 		BtcInterpolationUsdt: 0.00,
 	}
@@ -311,10 +327,11 @@ func (t *TradeStack) ProcessItem(
 				return &model.TradeStackItem{
 					Index:                   index,
 					Symbol:                  tradeLimit.Symbol,
+					QuoteAsset:              tradeLimit.GetQuoteAsset(),
 					Percent:                 profitPercent,
 					BudgetUsdt:              openedOrder.GetAvailableExtraBudget(*kline, t.BotService.UseSwapCapital()),
 					HasEnoughBalance:        false,
-					BinanceOrder:            binanceOrder,
+					ExchangeOrder:           exchangeOrder,
 					IsExtraCharge:           true,
 					Price:                   lastPrice,
 					IsPriceValid:            isPriceValid,
@@ -346,10 +363,11 @@ func (t *TradeStack) ProcessItem(
 		return &model.TradeStackItem{
 			Index:                   index,
 			Symbol:                  tradeLimit.Symbol,
+			QuoteAsset:              tradeLimit.GetQuoteAsset(),
 			Percent:                 model.Percent(t.Formatter.ToFixed((t.Formatter.ComparePercentage(kLine.Open.Value(), kLine.Close.Value()) - 100.00).Value(), 2)),
 			BudgetUsdt:              tradeLimit.USDTLimit,
 			HasEnoughBalance:        false,
-			BinanceOrder:            binanceOrder,
+			ExchangeOrder:           exchangeOrder,
 			IsExtraCharge:           false,
 			Price:                   lastPrice,
 			IsPriceValid:            isPriceValid,
@@ -380,7 +398,7 @@ func (t *TradeStack) InvalidateBuyPriceCache(symbol string) {
 }
 
 func (t *TradeStack) GetBuyPriceCached(limit model.TradeLimit) float64 {
-	var ticker model.WSTickerPrice
+	var ticker model.ExchangeTicker
 
 	cacheKey := fmt.Sprintf("buy-price-cached-%s-%d", strings.ToUpper(limit.Symbol), t.BotService.GetBot().Id)
 	buyPriceCached := t.RDB.Get(*t.Ctx, cacheKey).Val()
@@ -394,7 +412,7 @@ func (t *TradeStack) GetBuyPriceCached(limit model.TradeLimit) float64 {
 
 	buyPriceModel := t.PriceCalculator.CalculateBuy(limit)
 	if buyPriceModel.Error == nil {
-		ticker = model.WSTickerPrice{
+		ticker = model.ExchangeTicker{
 			Symbol: limit.Symbol,
 			Price:  buyPriceModel.Price,
 		}
@@ -0,0 +1,111 @@
+package exchange
+
+import (
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+const UserDataOrderTTL = 3600
+const UserDataQueryInterval = 300
+
+type UserDataStreamInterface interface {
+	IsConnected() bool
+	GetOrderUpdate(orderId string) *model.ExchangeOrder
+}
+
+type UserDataStreamListenerInterface interface {
+	StartListening()
+}
+
+// UserDataProcessor applies order and balance updates pushed by exchange user data stream,
+// order executor relies on them while the stream is connected instead of polling the exchange
+type UserDataProcessor struct {
+	OrderRepository repository.OrderStorageInterface
+	BalanceService  BalanceUpdaterInterface
+
+	orders    map[string]model.ExchangeOrder
+	updatedAt map[string]int64
+	connected bool
+	mutex     sync.Mutex
+}
+
+func (p *UserDataProcessor) SetConnected(connected bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.connected = connected
+}
+
+func (p *UserDataProcessor) IsConnected() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.connected
+}
+
+func (p *UserDataProcessor) GetOrderUpdate(orderId string) *model.ExchangeOrder {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	order, ok := p.orders[orderId]
+	if !ok {
+		return nil
+	}
+
+	return &order
+}
+
+func (p *UserDataProcessor) ProcessOrder(order model.ExchangeOrder) {
+	p.mutex.Lock()
+	if p.orders == nil {
+		p.orders = make(map[string]model.ExchangeOrder)
+		p.updatedAt = make(map[string]int64)
+	}
+
+	// events may come out of order, executed quantity never decreases
+	previous, ok := p.orders[order.OrderId]
+	if ok && previous.ExecutedQty > order.ExecutedQty {
+		p.mutex.Unlock()
+		return
+	}
+
+	now := time.Now().Unix()
+	p.orders[order.OrderId] = order
+	p.updatedAt[order.OrderId] = now
+	for orderId, updatedAt := range p.updatedAt {
+		if now-updatedAt > UserDataOrderTTL {
+			delete(p.orders, orderId)
+			delete(p.updatedAt, orderId)
+		}
+	}
+	p.mutex.Unlock()
+
+	slog.Info(
+		"User data order update",
+		logger.KeySymbol, order.Symbol,
+		logger.KeyOperation, strings.ToLower(order.Side),
+		logger.KeyExchangeOrderId, order.OrderId,
+		"status", order.Status,
+		"executedQty", order.ExecutedQty,
+		"origQty", order.OrigQty,
+	)
+
+	// only orders tracked by the bot are updated, manual and swap orders are ignored
+	cached := p.OrderRepository.GetExchangeOrder(order.Symbol, order.Side)
+	if cached != nil && cached.OrderId == order.OrderId && cached.ExecutedQty <= order.ExecutedQty {
+		p.OrderRepository.SetExchangeOrder(order)
+	}
+}
+
+func (p *UserDataProcessor) ProcessBalances(balances []model.Balance) {
+	if len(balances) == 0 {
+		return
+	}
+
+	p.BalanceService.UpdateBalances(balances)
+}
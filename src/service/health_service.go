@@ -7,6 +7,7 @@ import (
 	"github.com/rafacas/sysstats"
 	"github.com/redis/go-redis/v9"
 	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/metrics"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
 	"gitlab.com/open-soft/go-crypto-bot/src/service/ml"
@@ -18,7 +19,7 @@ import (
 type HealthService struct {
 	ExchangeRepository *repository.ExchangeRepository
 	BotRepository      *repository.BotRepository
-	PythonMLBridge     *ml.PythonMLBridge
+	MLService          *ml.MLService
 	DB                 *sql.DB
 	SwapDb             *sql.DB
 	RDB                *redis.Client
@@ -26,6 +27,7 @@ type HealthService struct {
 	Binance            client.ExchangeAPIInterface
 	CurrentBot         *model.Bot
 	TimeService        utils.TimeServiceInterface
+	RateLimitGovernor  *client.RateLimitGovernor
 }
 
 func (h *HealthService) HealthCheck() model.BotHealth {
@@ -56,16 +58,7 @@ func (h *HealthService) HealthCheck() model.BotHealth {
 	memStats, _ := sysstats.GetMemStats()
 	loadAvg, _ := sysstats.GetLoadAvg()
 
-	binanceStatus := model.BinanceStatusOk
-	if !h.Binance.IsConnected() {
-		binanceStatus = model.BinanceStatusDisconnected
-	}
-	if h.Binance.IsWaitMode() {
-		binanceStatus = model.BinanceStatusBan
-	}
-	if !h.Binance.IsAPIKeyCheckCompleted() {
-		binanceStatus = model.BinanceStatusApiKeyCheck
-	}
+	binanceStatus, rateLimit := h.getExchangeStatus()
 
 	dbStatus := model.DbStatusOk
 	if h.DB.Ping() != nil {
@@ -80,7 +73,7 @@ func (h *HealthService) HealthCheck() model.BotHealth {
 		redisStatus = model.RedisStatusFail
 	}
 	mlStatus := model.MlStatusReady
-	if h.PythonMLBridge.IsLearning() {
+	if h.MLService.IsLearning() {
 		mlStatus = model.MlStatusLearning
 	}
 
@@ -109,5 +102,76 @@ func (h *HealthService) HealthCheck() model.BotHealth {
 		GOMAXPROCS:    runtime.GOMAXPROCS(0),
 		NumGoroutine:  runtime.NumGoroutine(),
 		DateTimeNow:   h.TimeService.GetNowDateTimeString(),
+		RateLimit:     rateLimit,
 	}
 }
+
+func (h *HealthService) getExchangeStatus() (string, *model.RateLimitUsage) {
+	binanceStatus := model.BinanceStatusOk
+
+	// requests are queued or budget is almost spent, ban is close
+	var rateLimit *model.RateLimitUsage
+	if h.RateLimitGovernor != nil {
+		usage := h.RateLimitGovernor.GetUsage()
+		rateLimit = &usage
+		if usage.GetQueuedCount() > 0 || usage.GetWeightPercent() >= 90.00 {
+			binanceStatus = model.BinanceStatusThrottled
+		}
+	}
+	if !h.Binance.IsConnected() {
+		binanceStatus = model.BinanceStatusDisconnected
+	}
+	if h.Binance.IsWaitMode() {
+		binanceStatus = model.BinanceStatusBan
+	}
+	if !h.Binance.IsAPIKeyCheckCompleted() {
+		binanceStatus = model.BinanceStatusApiKeyCheck
+	}
+
+	return binanceStatus, rateLimit
+}
+
+// UpdateMetrics refreshes health gauges before metrics are scraped
+func (h *HealthService) UpdateMetrics() {
+	now := time.Now().Unix()
+
+	for _, limit := range h.ExchangeRepository.GetTradeLimits() {
+		kLine := h.ExchangeRepository.GetCurrentKline(limit.Symbol)
+		if kLine != nil && kLine.UpdatedAt > 0 {
+			metrics.PriceAge.Set(float64(now-kLine.UpdatedAt), limit.Symbol)
+		}
+
+		orderBook := h.ExchangeRepository.GetDepth(limit.Symbol, 20)
+		if !orderBook.IsEmpty() {
+			metrics.OrderBookAge.Set(float64(now-orderBook.UpdatedAt), limit.Symbol)
+		}
+	}
+
+	exchangeStatus, _ := h.getExchangeStatus()
+	for _, status := range []string{
+		model.BinanceStatusOk,
+		model.BinanceStatusBan,
+		model.BinanceStatusThrottled,
+		model.BinanceStatusDisconnected,
+		model.BinanceStatusApiKeyCheck,
+	} {
+		value := 0.00
+		if status == exchangeStatus {
+			value = 1.00
+		}
+		metrics.ExchangeStatus.Set(value, status)
+	}
+
+	metrics.StorageUp.Set(upValue(h.DB.Ping() == nil), "db")
+	metrics.StorageUp.Set(upValue(h.SwapDb.Ping() == nil), "swap_db")
+	metrics.StorageUp.Set(upValue(h.RDB.Ping(*h.Ctx).Err() == nil), "redis")
+	metrics.Goroutines.Set(float64(runtime.NumGoroutine()))
+}
+
+func upValue(isUp bool) float64 {
+	if isUp {
+		return 1.00
+	}
+
+	return 0.00
+}
@@ -0,0 +1,39 @@
+package indicator
+
+import "math"
+
+// ATR uses Wilder's smoothing
+type ATR struct {
+	Period    int64
+	prevClose float64
+	value     float64
+	count     int64
+}
+
+func (a *ATR) Update(high float64, low float64, close float64) float64 {
+	trueRange := high - low
+	if a.count > 0 {
+		trueRange = math.Max(trueRange, math.Max(math.Abs(high-a.prevClose), math.Abs(low-a.prevClose)))
+	}
+
+	a.count++
+	a.prevClose = close
+
+	if a.count <= a.Period {
+		a.value += (trueRange - a.value) / float64(a.count)
+
+		return a.value
+	}
+
+	a.value = (a.value*float64(a.Period-1) + trueRange) / float64(a.Period)
+
+	return a.value
+}
+
+func (a *ATR) Value() float64 {
+	return a.value
+}
+
+func (a *ATR) IsReady() bool {
+	return a.count >= a.Period
+}
@@ -0,0 +1,70 @@
+package indicator
+
+import "math"
+
+type Bollinger struct {
+	Period     int64
+	Multiplier float64
+	window     []float64
+	last       float64
+}
+
+func (b *Bollinger) Update(value float64) float64 {
+	b.last = value
+	b.window = append(b.window, value)
+
+	if int64(len(b.window)) > b.Period {
+		b.window = b.window[1:]
+	}
+
+	return b.Middle()
+}
+
+func (b *Bollinger) Middle() float64 {
+	if len(b.window) == 0 {
+		return 0.00
+	}
+
+	sum := 0.00
+	for _, value := range b.window {
+		sum += value
+	}
+
+	return sum / float64(len(b.window))
+}
+
+func (b *Bollinger) Deviation() float64 {
+	if len(b.window) == 0 {
+		return 0.00
+	}
+
+	middle := b.Middle()
+	sum := 0.00
+	for _, value := range b.window {
+		sum += math.Pow(value-middle, 2)
+	}
+
+	return math.Sqrt(sum / float64(len(b.window)))
+}
+
+func (b *Bollinger) Upper() float64 {
+	return b.Middle() + b.Multiplier*b.Deviation()
+}
+
+func (b *Bollinger) Lower() float64 {
+	return b.Middle() - b.Multiplier*b.Deviation()
+}
+
+// Percent is %B: 0 - last value is on lower band, 1 - on upper band
+func (b *Bollinger) Percent() float64 {
+	width := b.Upper() - b.Lower()
+	if width == 0.00 {
+		return 0.50
+	}
+
+	return (b.last - b.Lower()) / width
+}
+
+func (b *Bollinger) IsReady() bool {
+	return int64(len(b.window)) >= b.Period
+}
@@ -0,0 +1,33 @@
+package indicator
+
+// EMA is seeded with SMA of the first Period values
+type EMA struct {
+	Period int64
+	value  float64
+	count  int64
+	sum    float64
+}
+
+func (e *EMA) Update(value float64) float64 {
+	e.count++
+
+	if e.count <= e.Period {
+		e.sum += value
+		e.value = e.sum / float64(e.count)
+
+		return e.value
+	}
+
+	multiplier := 2.00 / float64(e.Period+1)
+	e.value = (value-e.value)*multiplier + e.value
+
+	return e.value
+}
+
+func (e *EMA) Value() float64 {
+	return e.value
+}
+
+func (e *EMA) IsReady() bool {
+	return e.count >= e.Period
+}
@@ -0,0 +1,131 @@
+package indicator
+
+import (
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"log"
+	"sync"
+	"time"
+)
+
+// IndicatorHistorySize amount of minute bars required to warm up all indicators
+const IndicatorHistorySize = 200
+
+type KLineHistoryInterface interface {
+	GetCurrentKline(symbol string) *model.KLine
+	KLineList(symbol string, reverse bool, size int64) []model.KLine
+}
+
+type IndicatorServiceInterface interface {
+	GetValues(symbol string) model.IndicatorValues
+	GetValue(symbol string, name string) (float64, bool)
+	Update(kLine model.KLine)
+}
+
+type IndicatorService struct {
+	ExchangeRepository KLineHistoryInterface
+	StatRepository     repository.TradeStatReaderInterface
+	sets               map[string]*IndicatorSet
+	mutex              sync.Mutex
+}
+
+func (i *IndicatorService) GetValues(symbol string) model.IndicatorValues {
+	set := i.getSet(symbol)
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return set.GetValues()
+}
+
+func (i *IndicatorService) GetValue(symbol string, name string) (float64, bool) {
+	return i.GetValues(symbol).Get(name)
+}
+
+// Update applies closed kline
+func (i *IndicatorService) Update(kLine model.KLine) {
+	set := i.getSet(kLine.Symbol)
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	set.UpdateKLine(kLine)
+}
+
+// getSet warms up new symbol without lock, history loading of one symbol must not block the others
+func (i *IndicatorService) getSet(symbol string) *IndicatorSet {
+	i.mutex.Lock()
+	set, ok := i.sets[symbol]
+	i.mutex.Unlock()
+
+	if ok {
+		return set
+	}
+
+	warmedUp := i.warmUp(symbol)
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if i.sets == nil {
+		i.sets = make(map[string]*IndicatorSet)
+	}
+
+	// the symbol could be warmed up by another caller meanwhile
+	set, ok = i.sets[symbol]
+	if !ok {
+		set = warmedUp
+		i.sets[symbol] = set
+	}
+
+	return set
+}
+
+func (i *IndicatorService) warmUp(symbol string) *IndicatorSet {
+	set := NewIndicatorSet(symbol)
+
+	currentPeriod := int64(0)
+	current := i.ExchangeRepository.GetCurrentKline(symbol)
+	if current != nil {
+		currentPeriod = current.Timestamp.GetPeriodToMinute()
+	}
+
+	kLines := make([]model.KLine, 0)
+	for _, kLine := range i.ExchangeRepository.KLineList(symbol, true, IndicatorHistorySize) {
+		// current kline is not closed yet
+		if currentPeriod > 0 && kLine.Timestamp.GetPeriodToMinute() >= currentPeriod {
+			continue
+		}
+		kLines = append(kLines, kLine)
+	}
+
+	if len(kLines) < IndicatorHistorySize && i.StatRepository != nil {
+		to := time.Now()
+		if len(kLines) > 0 {
+			to = time.UnixMilli(kLines[0].Timestamp.Value())
+		}
+		from := to.Add(-time.Minute * time.Duration(IndicatorHistorySize-len(kLines)))
+
+		stats, err := i.StatRepository.GetTradeStatList(
+			symbol,
+			model.TimestampMilli(from.UnixMilli()),
+			model.TimestampMilli(to.UnixMilli()-1),
+		)
+
+		if err != nil {
+			log.Printf("[%s] Indicator history: %s", symbol, err.Error())
+		}
+
+		for _, stat := range stats {
+			set.UpdateTradeStat(stat)
+		}
+	}
+
+	for _, kLine := range kLines {
+		set.UpdateKLine(kLine)
+	}
+
+	log.Printf("[%s] Indicators are warmed up by %d bars", symbol, set.Count)
+
+	return set
+}
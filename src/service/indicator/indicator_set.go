@@ -0,0 +1,120 @@
+package indicator
+
+import "gitlab.com/open-soft/go-crypto-bot/src/model"
+
+// IndicatorSet contains state of all indicators of one symbol, it is updated by closed klines
+type IndicatorSet struct {
+	Symbol        string
+	LastTimestamp int64
+	Count         int64
+	ema9          EMA
+	ema21         EMA
+	rsi14         RSI
+	macd          MACD
+	bollinger     Bollinger
+	atr14         ATR
+	vwap          VWAP
+}
+
+func NewIndicatorSet(symbol string) *IndicatorSet {
+	return &IndicatorSet{
+		Symbol:    symbol,
+		ema9:      EMA{Period: 9},
+		ema21:     EMA{Period: 21},
+		rsi14:     RSI{Period: 14},
+		macd:      MACD{FastPeriod: 12, SlowPeriod: 26, SignalPeriod: 9},
+		bollinger: Bollinger{Period: 20, Multiplier: 2.00},
+		atr14:     ATR{Period: 14},
+		vwap:      VWAP{Period: 60},
+	}
+}
+
+func (s *IndicatorSet) Update(timestamp int64, open float64, high float64, low float64, close float64, volume float64) {
+	// Skip duplicates and outdated bars
+	if timestamp <= s.LastTimestamp {
+		return
+	}
+
+	s.LastTimestamp = timestamp
+	s.Count++
+
+	s.ema9.Update(close)
+	s.ema21.Update(close)
+	s.rsi14.Update(close)
+	s.macd.Update(close)
+	s.bollinger.Update(close)
+	s.atr14.Update(high, low, close)
+	s.vwap.Update(high, low, close, volume)
+}
+
+func (s *IndicatorSet) UpdateKLine(kLine model.KLine) {
+	s.Update(
+		kLine.Timestamp.GetPeriodToMinute(),
+		kLine.Open.Value(),
+		kLine.High.Value(),
+		kLine.Low.Value(),
+		kLine.Close.Value(),
+		kLine.Volume.Value(),
+	)
+}
+
+func (s *IndicatorSet) UpdateTradeStat(stat model.TradeStat) {
+	s.Update(
+		stat.Timestamp.GetPeriodToMinute(),
+		stat.Open,
+		stat.High,
+		stat.Low,
+		stat.Close,
+		stat.Volume,
+	)
+}
+
+func (s *IndicatorSet) GetValues() model.IndicatorValues {
+	values := make(model.IndicatorValues)
+
+	if s.ema9.IsReady() {
+		values[model.IndicatorEma9] = s.ema9.Value()
+	}
+
+	if s.ema21.IsReady() {
+		values[model.IndicatorEma21] = s.ema21.Value()
+	}
+
+	// 1 - fast EMA is above slow EMA, -1 - below
+	if s.ema9.IsReady() && s.ema21.IsReady() {
+		values[model.IndicatorEmaCross] = 0.00
+		if s.ema9.Value() > s.ema21.Value() {
+			values[model.IndicatorEmaCross] = 1.00
+		}
+		if s.ema9.Value() < s.ema21.Value() {
+			values[model.IndicatorEmaCross] = -1.00
+		}
+	}
+
+	if s.rsi14.IsReady() {
+		values[model.IndicatorRsi14] = s.rsi14.Value()
+	}
+
+	if s.macd.IsReady() {
+		values[model.IndicatorMacd] = s.macd.Value()
+		values[model.IndicatorMacdSignal] = s.macd.Signal()
+		values[model.IndicatorMacdHistogram] = s.macd.Histogram()
+	}
+
+	if s.bollinger.IsReady() {
+		values[model.IndicatorBollingerUpper] = s.bollinger.Upper()
+		values[model.IndicatorBollingerMiddle] = s.bollinger.Middle()
+		values[model.IndicatorBollingerLower] = s.bollinger.Lower()
+		values[model.IndicatorBollingerPercent] = s.bollinger.Percent()
+	}
+
+	if s.atr14.IsReady() {
+		values[model.IndicatorAtr14] = s.atr14.Value()
+	}
+
+	if s.vwap.IsReady() {
+		values[model.IndicatorVwap] = s.vwap.Value()
+	}
+
+	return values
+}
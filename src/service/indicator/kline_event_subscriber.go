@@ -0,0 +1,22 @@
+package indicator
+
+import "gitlab.com/open-soft/go-crypto-bot/src/event"
+
+type KLineEventSubscriber struct {
+	IndicatorService IndicatorServiceInterface
+}
+
+func (k KLineEventSubscriber) GetSubscribedEvents() map[string]func(interface{}) {
+	return map[string]func(interface{}){
+		event.EventNewKLineReceived: k.OnNewKlineReceived,
+	}
+}
+
+func (k KLineEventSubscriber) OnNewKlineReceived(eventModel interface{}) {
+	e, ok := eventModel.(event.NewKlineReceived)
+	if !ok {
+		return
+	}
+
+	k.IndicatorService.Update(*e.Previous)
+}
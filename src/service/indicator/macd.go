@@ -0,0 +1,45 @@
+package indicator
+
+type MACD struct {
+	FastPeriod   int64
+	SlowPeriod   int64
+	SignalPeriod int64
+	fast         EMA
+	slow         EMA
+	signal       EMA
+	value        float64
+}
+
+func (m *MACD) Update(value float64) float64 {
+	m.fast.Period = m.FastPeriod
+	m.slow.Period = m.SlowPeriod
+	m.signal.Period = m.SignalPeriod
+
+	m.fast.Update(value)
+	m.slow.Update(value)
+
+	if !m.slow.IsReady() {
+		return 0.00
+	}
+
+	m.value = m.fast.Value() - m.slow.Value()
+	m.signal.Update(m.value)
+
+	return m.value
+}
+
+func (m *MACD) Value() float64 {
+	return m.value
+}
+
+func (m *MACD) Signal() float64 {
+	return m.signal.Value()
+}
+
+func (m *MACD) Histogram() float64 {
+	return m.value - m.signal.Value()
+}
+
+func (m *MACD) IsReady() bool {
+	return m.slow.IsReady() && m.signal.IsReady()
+}
@@ -0,0 +1,64 @@
+package indicator
+
+// RSI uses Wilder's smoothing
+type RSI struct {
+	Period    int64
+	prevValue float64
+	avgGain   float64
+	avgLoss   float64
+	count     int64
+}
+
+func (r *RSI) Update(value float64) float64 {
+	r.count++
+
+	if r.count == 1 {
+		r.prevValue = value
+
+		return r.Value()
+	}
+
+	change := value - r.prevValue
+	r.prevValue = value
+
+	gain := 0.00
+	loss := 0.00
+	if change > 0 {
+		gain = change
+	} else {
+		loss = -change
+	}
+
+	changes := r.count - 1
+	if changes <= r.Period {
+		r.avgGain += (gain - r.avgGain) / float64(changes)
+		r.avgLoss += (loss - r.avgLoss) / float64(changes)
+
+		return r.Value()
+	}
+
+	r.avgGain = (r.avgGain*float64(r.Period-1) + gain) / float64(r.Period)
+	r.avgLoss = (r.avgLoss*float64(r.Period-1) + loss) / float64(r.Period)
+
+	return r.Value()
+}
+
+func (r *RSI) Value() float64 {
+	if r.count < 2 {
+		return 50.00
+	}
+
+	if r.avgLoss == 0.00 {
+		if r.avgGain == 0.00 {
+			return 50.00
+		}
+
+		return 100.00
+	}
+
+	return 100.00 - 100.00/(1.00+r.avgGain/r.avgLoss)
+}
+
+func (r *RSI) IsReady() bool {
+	return r.count > r.Period
+}
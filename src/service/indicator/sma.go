@@ -0,0 +1,31 @@
+package indicator
+
+type SMA struct {
+	Period int64
+	window []float64
+	sum    float64
+}
+
+func (s *SMA) Update(value float64) float64 {
+	s.window = append(s.window, value)
+	s.sum += value
+
+	if int64(len(s.window)) > s.Period {
+		s.sum -= s.window[0]
+		s.window = s.window[1:]
+	}
+
+	return s.Value()
+}
+
+func (s *SMA) Value() float64 {
+	if len(s.window) == 0 {
+		return 0.00
+	}
+
+	return s.sum / float64(len(s.window))
+}
+
+func (s *SMA) IsReady() bool {
+	return int64(len(s.window)) >= s.Period
+}
@@ -0,0 +1,44 @@
+package indicator
+
+// VWAP is calculated over rolling Period of bars (crypto market has no session)
+type VWAP struct {
+	Period  int64
+	prices  []float64
+	volumes []float64
+}
+
+func (v *VWAP) Update(high float64, low float64, close float64, volume float64) float64 {
+	v.prices = append(v.prices, (high+low+close)/3)
+	v.volumes = append(v.volumes, volume)
+
+	if int64(len(v.prices)) > v.Period {
+		v.prices = v.prices[1:]
+		v.volumes = v.volumes[1:]
+	}
+
+	return v.Value()
+}
+
+func (v *VWAP) Value() float64 {
+	volumeSum := 0.00
+	priceVolumeSum := 0.00
+
+	for index, price := range v.prices {
+		volumeSum += v.volumes[index]
+		priceVolumeSum += price * v.volumes[index]
+	}
+
+	if volumeSum == 0.00 {
+		if len(v.prices) == 0 {
+			return 0.00
+		}
+
+		return v.prices[len(v.prices)-1]
+	}
+
+	return priceVolumeSum / volumeSum
+}
+
+func (v *VWAP) IsReady() bool {
+	return int64(len(v.prices)) >= v.Period
+}
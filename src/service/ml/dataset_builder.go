@@ -1,56 +1,142 @@
 package ml
 
 import (
-	"encoding/csv"
 	"errors"
-	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
-	"log"
-	"os"
+	"log/slog"
+	"slices"
+	"time"
 )
 
+const MinDatasetLength = 30
+
+// Dataset rows are sorted by timestamp, walk-forward validation relies on it
+type Dataset struct {
+	Symbol     string
+	Features   []string
+	Horizon    int64
+	Timestamps []int64
+	X          [][]float64
+	Y          []float64
+}
+
+func (d Dataset) GetFrom() int64 {
+	if len(d.Timestamps) == 0 {
+		return 0
+	}
+
+	return d.Timestamps[0]
+}
+
+func (d Dataset) GetTo() int64 {
+	if len(d.Timestamps) == 0 {
+		return 0
+	}
+
+	return d.Timestamps[len(d.Timestamps)-1]
+}
+
 type DataSetBuilder struct {
-	StatRepository *repository.StatRepository
+	StatRepository     repository.TradeStatReaderInterface
+	ExchangeRepository repository.MLFeatureStorageInterface
 }
 
-func (d *DataSetBuilder) PrepareDataset(symbol string) (string, error) {
-	datasetPath := fmt.Sprintf("/go/src/app/datasets/dataset_%s.csv", symbol)
-	_ = os.Remove(datasetPath)
-	csvFile, err := os.Create(datasetPath)
+// PrepareDataset features of every minute are labeled by the close price of the minute "horizon" minutes later
+func (d *DataSetBuilder) PrepareDataset(symbol string, config model.MLConfig) (Dataset, error) {
+	features := config.GetFeatures()
+	horizon := config.GetHorizon()
+
+	dataset := Dataset{
+		Symbol:     symbol,
+		Features:   features,
+		Horizon:    horizon,
+		Timestamps: make([]int64, 0),
+		X:          make([][]float64, 0),
+		Y:          make([]float64, 0),
+	}
+
+	now := time.Now()
+	from := model.TimestampMilli(time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, now.Location()).UnixMilli())
+	to := model.TimestampMilli(now.UnixMilli())
 
+	slog.Debug("Fetching ML dataset", logger.KeySymbol, symbol)
+	stats, err := d.StatRepository.GetTradeStatList(symbol, from, to)
 	if err != nil {
-		return "", err
+		return dataset, err
 	}
 
-	csvWriter := csv.NewWriter(csvFile)
-	log.Printf("[%s] Fetching ML dataset...", symbol)
-	dataset := d.StatRepository.GetMLDataset(symbol, d.GetSecondarySymbol(symbol))
-	log.Printf("[%s] ML dataset length is %d", symbol, len(dataset))
+	secondaryPrices := make(map[int64]float64)
+	if slices.Contains(features, model.MLFeatureSecondaryPrice) {
+		secondaryStats, err := d.StatRepository.GetTradeStatList(d.GetSecondarySymbol(symbol), from, to)
+		if err != nil {
+			return dataset, err
+		}
 
-	if len(dataset) < 30 {
-		return "", errors.New("not enough dataset length")
+		for _, secondaryStat := range secondaryStats {
+			secondaryPrices[secondaryStat.Timestamp.GetPeriodToMinute()] = secondaryStat.Close
+		}
 	}
 
-	for _, record := range dataset {
-		row := []string{
-			fmt.Sprintf("%.10f", record.OrderBookBuyFirstQty),
-			fmt.Sprintf("%.10f", record.OrderBookSellFirstQty),
-			fmt.Sprintf("%.10f", record.OrderBookBuyQtySum),
-			fmt.Sprintf("%.10f", record.OrderBookSellQtySum),
-			fmt.Sprintf("%.10f", record.OrderBookBuyVolumeSum),
-			fmt.Sprintf("%.10f", record.OrderBookSellVolumeSum),
-			fmt.Sprintf("%.10f", record.SecondaryPrice),
-			fmt.Sprintf("%.10f", record.PrimaryPrice),
+	sources := make([]FeatureSource, 0, len(stats))
+	for _, stat := range stats {
+		sources = append(sources, d.GetFeatureSource(stat, secondaryPrices[stat.Timestamp.GetPeriodToMinute()], features))
+	}
+
+	for index := range sources {
+		labelIndex := index + int(horizon)
+		if labelIndex >= len(sources) {
+			break
+		}
+
+		timestamp := sources[index].Stat.Timestamp
+		label := sources[labelIndex].Stat
+		if label.Timestamp.GetPeriodToMinute()-timestamp.GetPeriodToMinute() != horizon*60000 {
+			continue
+		}
+
+		row, err := ExtractFeatures(features, sources[max(0, index-MaxFeatureLag):index+1])
+		if err != nil {
+			continue
 		}
 
-		_ = csvWriter.Write(row)
-		csvWriter.Flush()
+		dataset.Timestamps = append(dataset.Timestamps, timestamp.Value())
+		dataset.X = append(dataset.X, row)
+		dataset.Y = append(dataset.Y, label.Close)
+	}
+
+	slog.Info("ML dataset is prepared", logger.KeySymbol, symbol, "length", len(dataset.Y), "minutes", len(stats))
+
+	if len(dataset.Y) < MinDatasetLength {
+		return dataset, errors.New("not enough dataset length")
 	}
 
-	csvWriter.Flush()
-	_ = csvFile.Close()
+	return dataset, nil
+}
+
+// GetFeatureSource reads market data only if it is required by the feature set
+func (d *DataSetBuilder) GetFeatureSource(stat model.TradeStat, secondaryPrice float64, features []string) FeatureSource {
+	source := FeatureSource{
+		Stat:           stat,
+		SecondaryPrice: secondaryPrice,
+	}
+
+	if slices.Contains(features, model.MLFeatureBtcInterpolation) || slices.Contains(features, model.MLFeatureEthInterpolation) {
+		interpolation, err := d.ExchangeRepository.GetInterpolation(model.KLine{
+			Symbol:    stat.Symbol,
+			Timestamp: stat.Timestamp,
+		})
+		if err == nil {
+			source.Interpolation = &interpolation
+		}
+	}
+
+	if slices.Contains(features, model.MLFeatureBtcCapitalization) {
+		source.Capitalization = d.ExchangeRepository.GetCapitalization("BTCUSDT", stat.Timestamp)
+	}
 
-	return datasetPath, nil
+	return source
 }
 
 func (d *DataSetBuilder) GetSecondarySymbol(symbol string) string {
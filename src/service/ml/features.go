@@ -0,0 +1,137 @@
+package ml
+
+import (
+	"errors"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+)
+
+// MaxFeatureLag is the longest history (in minutes) required by lagged features
+const MaxFeatureLag = 15
+
+// FeatureSource minute snapshot of the symbol, optional market data is nil when it is unknown
+type FeatureSource struct {
+	Stat           model.TradeStat
+	SecondaryPrice float64
+	Interpolation  *model.Interpolation
+	Capitalization *model.MCObject
+}
+
+// ExtractFeatures history is sorted by timestamp, the last item is the minute the features are calculated for
+func ExtractFeatures(features []string, history []FeatureSource) ([]float64, error) {
+	if len(history) == 0 {
+		return nil, errors.New("feature history is empty")
+	}
+
+	values := make([]float64, 0, len(features))
+	for _, feature := range features {
+		value, err := extractFeature(feature, history)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func extractFeature(feature string, history []FeatureSource) (float64, error) {
+	current := history[len(history)-1]
+	stat := current.Stat
+	book := stat.OrderBookStat
+
+	switch feature {
+	case model.MLFeatureOrderBookBuyFirstQty,
+		model.MLFeatureOrderBookSellFirstQty,
+		model.MLFeatureOrderBookBuyQtySum,
+		model.MLFeatureOrderBookSellQtySum,
+		model.MLFeatureOrderBookBuyVolumeSum,
+		model.MLFeatureOrderBookSellVolumeSum:
+		if book.BuyLength == 0 && book.SellLength == 0 {
+			return 0.00, errors.New("order depth is empty")
+		}
+
+		switch feature {
+		case model.MLFeatureOrderBookBuyFirstQty:
+			return book.FirstBuyQty, nil
+		case model.MLFeatureOrderBookSellFirstQty:
+			return book.FirstSellQty, nil
+		case model.MLFeatureOrderBookBuyQtySum:
+			return book.BuyQtySum, nil
+		case model.MLFeatureOrderBookSellQtySum:
+			return book.SellQtySum, nil
+		case model.MLFeatureOrderBookBuyVolumeSum:
+			return book.BuyVolumeSum, nil
+		default:
+			return book.SellVolumeSum, nil
+		}
+	case model.MLFeatureSecondaryPrice:
+		if current.SecondaryPrice <= 0.00 {
+			return 0.00, errors.New("secondary price is unknown")
+		}
+
+		return current.SecondaryPrice, nil
+	case model.MLFeatureReturn1m:
+		return getLaggedReturn(history, 1)
+	case model.MLFeatureReturn5m:
+		return getLaggedReturn(history, 5)
+	case model.MLFeatureReturn15m:
+		return getLaggedReturn(history, 15)
+	case model.MLFeatureTradeFlowImbalance:
+		total := stat.BuyVolume + stat.SellVolume
+		if total <= 0.00 {
+			return 0.00, nil
+		}
+
+		return (stat.BuyVolume - stat.SellVolume) / total, nil
+	case model.MLFeatureMaxPriceChangeSpeed:
+		return stat.MaxPSC, nil
+	case model.MLFeatureMinPriceChangeSpeed:
+		return stat.MinPCS, nil
+	case model.MLFeatureBuyIcebergQty:
+		return book.BuyIceberg.Quantity, nil
+	case model.MLFeatureSellIcebergQty:
+		return book.SellIceberg.Quantity, nil
+	case model.MLFeatureBtcCapitalization:
+		if current.Capitalization == nil {
+			return 0.00, errors.New("BTC capitalization is unknown")
+		}
+
+		return current.Capitalization.Capitalization, nil
+	case model.MLFeatureBtcInterpolation:
+		if current.Interpolation == nil || !current.Interpolation.HasBtc() || stat.Close <= 0.00 {
+			return 0.00, errors.New("BTC interpolation is unknown")
+		}
+
+		return current.Interpolation.BtcInterpolationUsdt/stat.Close - 1, nil
+	case model.MLFeatureEthInterpolation:
+		if current.Interpolation == nil || !current.Interpolation.HasEth() || stat.Close <= 0.00 {
+			return 0.00, errors.New("ETH interpolation is unknown")
+		}
+
+		return current.Interpolation.EthInterpolationUsdt/stat.Close - 1, nil
+	}
+
+	return 0.00, errors.New(fmt.Sprintf("ML feature %s is not supported", feature))
+}
+
+func getLaggedReturn(history []FeatureSource, lag int) (float64, error) {
+	index := len(history) - 1 - lag
+	if index < 0 {
+		return 0.00, errors.New(fmt.Sprintf("%d minutes history is required", lag))
+	}
+
+	current := history[len(history)-1].Stat
+	previous := history[index].Stat
+
+	if current.Timestamp.GetPeriodToMinute()-previous.Timestamp.GetPeriodToMinute() != int64(lag)*60000 {
+		return 0.00, errors.New(fmt.Sprintf("%d minutes history has gaps", lag))
+	}
+
+	if previous.Close <= 0.00 {
+		return 0.00, errors.New("previous close price is unknown")
+	}
+
+	return current.Close/previous.Close - 1, nil
+}
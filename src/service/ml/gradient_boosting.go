@@ -0,0 +1,204 @@
+package ml
+
+import (
+	"errors"
+	"sort"
+)
+
+type TreeNode struct {
+	Feature   int     `json:"f"`
+	Threshold float64 `json:"t"`
+	Left      int     `json:"l"`
+	Right     int     `json:"r"`
+	Value     float64 `json:"v"`
+	IsLeaf    bool    `json:"leaf"`
+}
+
+// RegressionTree is stored as flat node list, the root is the first node
+type RegressionTree struct {
+	Nodes []TreeNode `json:"nodes"`
+}
+
+func (t *RegressionTree) Predict(features []float64) float64 {
+	if len(t.Nodes) == 0 {
+		return 0.00
+	}
+
+	node := t.Nodes[0]
+	for !node.IsLeaf {
+		if features[node.Feature] <= node.Threshold {
+			node = t.Nodes[node.Left]
+		} else {
+			node = t.Nodes[node.Right]
+		}
+	}
+
+	return node.Value
+}
+
+// GradientBoosting is gradient boosted regression trees with squared error loss
+type GradientBoosting struct {
+	Estimators     int              `json:"estimators"`
+	LearningRate   float64          `json:"learningRate"`
+	MaxDepth       int              `json:"maxDepth"`
+	MinSamplesLeaf int              `json:"minSamplesLeaf"`
+	BasePrediction float64          `json:"basePrediction"`
+	Trees          []RegressionTree `json:"trees"`
+}
+
+func (g *GradientBoosting) GetType() string {
+	return ModelTypeGradientBoosting
+}
+
+func (g *GradientBoosting) Fit(x [][]float64, y []float64) error {
+	err := validateDataset(x, y)
+	if err != nil {
+		return err
+	}
+
+	if g.Estimators <= 0 || g.LearningRate <= 0.00 || g.MaxDepth <= 0 {
+		return errors.New("gradient boosting params are invalid")
+	}
+
+	if g.MinSamplesLeaf <= 0 {
+		g.MinSamplesLeaf = 1
+	}
+
+	g.BasePrediction = 0.00
+	for _, value := range y {
+		g.BasePrediction += value
+	}
+	g.BasePrediction /= float64(len(y))
+
+	predictions := make([]float64, len(y))
+	residuals := make([]float64, len(y))
+	indexes := make([]int, len(y))
+	for i := range predictions {
+		predictions[i] = g.BasePrediction
+		indexes[i] = i
+	}
+
+	g.Trees = make([]RegressionTree, 0, g.Estimators)
+	for e := 0; e < g.Estimators; e++ {
+		for i := range y {
+			residuals[i] = y[i] - predictions[i]
+		}
+
+		tree := RegressionTree{Nodes: make([]TreeNode, 0)}
+		g.buildNode(&tree, x, residuals, indexes, 0)
+		g.Trees = append(g.Trees, tree)
+
+		for i, row := range x {
+			predictions[i] += g.LearningRate * tree.Predict(row)
+		}
+	}
+
+	return nil
+}
+
+func (g *GradientBoosting) Predict(features []float64) float64 {
+	prediction := g.BasePrediction
+	for _, tree := range g.Trees {
+		prediction += g.LearningRate * tree.Predict(features)
+	}
+
+	return prediction
+}
+
+func (g *GradientBoosting) buildNode(tree *RegressionTree, x [][]float64, target []float64, indexes []int, depth int) int {
+	position := len(tree.Nodes)
+	tree.Nodes = append(tree.Nodes, TreeNode{IsLeaf: true, Value: mean(target, indexes)})
+
+	if depth >= g.MaxDepth || len(indexes) < 2*g.MinSamplesLeaf {
+		return position
+	}
+
+	feature, threshold, found := g.findSplit(x, target, indexes)
+	if !found {
+		return position
+	}
+
+	left := make([]int, 0)
+	right := make([]int, 0)
+	for _, index := range indexes {
+		if x[index][feature] <= threshold {
+			left = append(left, index)
+		} else {
+			right = append(right, index)
+		}
+	}
+
+	leftPosition := g.buildNode(tree, x, target, left, depth+1)
+	rightPosition := g.buildNode(tree, x, target, right, depth+1)
+	tree.Nodes[position] = TreeNode{
+		Feature:   feature,
+		Threshold: threshold,
+		Left:      leftPosition,
+		Right:     rightPosition,
+	}
+
+	return position
+}
+
+// findSplit chooses feature and threshold with maximal squared error reduction
+func (g *GradientBoosting) findSplit(x [][]float64, target []float64, indexes []int) (int, float64, bool) {
+	totalSum := 0.00
+	for _, index := range indexes {
+		totalSum += target[index]
+	}
+	count := float64(len(indexes))
+
+	bestGain := 0.00
+	bestFeature := 0
+	bestThreshold := 0.00
+	found := false
+
+	sorted := make([]int, len(indexes))
+	for feature := 0; feature < len(x[indexes[0]]); feature++ {
+		copy(sorted, indexes)
+		sort.Slice(sorted, func(a, b int) bool {
+			return x[sorted[a]][feature] < x[sorted[b]][feature]
+		})
+
+		leftSum := 0.00
+		for i := 0; i < len(sorted)-1; i++ {
+			leftSum += target[sorted[i]]
+			leftCount := float64(i + 1)
+			rightCount := count - leftCount
+
+			if i+1 < g.MinSamplesLeaf || len(sorted)-i-1 < g.MinSamplesLeaf {
+				continue
+			}
+
+			current := x[sorted[i]][feature]
+			next := x[sorted[i+1]][feature]
+			if current == next {
+				continue
+			}
+
+			rightSum := totalSum - leftSum
+			gain := leftSum*leftSum/leftCount + rightSum*rightSum/rightCount - totalSum*totalSum/count
+			if gain > bestGain {
+				bestGain = gain
+				bestFeature = feature
+				bestThreshold = (current + next) / 2
+				found = true
+			}
+		}
+	}
+
+	return bestFeature, bestThreshold, found
+}
+
+func mean(values []float64, indexes []int) float64 {
+	if len(indexes) == 0 {
+		return 0.00
+	}
+
+	sum := 0.00
+	for _, index := range indexes {
+		sum += values[index]
+	}
+
+	return sum / float64(len(indexes))
+}
@@ -0,0 +1,148 @@
+package ml
+
+import (
+	"errors"
+	"math"
+)
+
+// LinearRegression is ordinary least squares, Alpha > 0 makes it ridge regression.
+// Features are standardized before solving normal equations, weights are kept in standardized space.
+type LinearRegression struct {
+	Alpha     float64   `json:"alpha"`
+	Means     []float64 `json:"means"`
+	Scales    []float64 `json:"scales"`
+	Weights   []float64 `json:"weights"`
+	Intercept float64   `json:"intercept"`
+}
+
+func (l *LinearRegression) GetType() string {
+	if l.Alpha > 0.00 {
+		return ModelTypeRidge
+	}
+
+	return ModelTypeLinear
+}
+
+func (l *LinearRegression) Fit(x [][]float64, y []float64) error {
+	err := validateDataset(x, y)
+	if err != nil {
+		return err
+	}
+
+	width := len(x[0])
+	l.Means = make([]float64, width)
+	l.Scales = make([]float64, width)
+
+	for j := 0; j < width; j++ {
+		for _, row := range x {
+			l.Means[j] += row[j]
+		}
+		l.Means[j] /= float64(len(x))
+
+		for _, row := range x {
+			l.Scales[j] += (row[j] - l.Means[j]) * (row[j] - l.Means[j])
+		}
+		l.Scales[j] = math.Sqrt(l.Scales[j] / float64(len(x)))
+		// constant feature does not affect the label
+		if l.Scales[j] == 0.00 {
+			l.Scales[j] = 1.00
+		}
+	}
+
+	l.Intercept = 0.00
+	for _, value := range y {
+		l.Intercept += value
+	}
+	l.Intercept /= float64(len(y))
+
+	// (XᵀX + αI)w = Xᵀy
+	matrix := make([][]float64, width)
+	vector := make([]float64, width)
+	for j := 0; j < width; j++ {
+		matrix[j] = make([]float64, width)
+	}
+
+	for i, row := range x {
+		scaled := l.scale(row)
+		for j := 0; j < width; j++ {
+			vector[j] += scaled[j] * (y[i] - l.Intercept)
+			for k := 0; k < width; k++ {
+				matrix[j][k] += scaled[j] * scaled[k]
+			}
+		}
+	}
+
+	for j := 0; j < width; j++ {
+		matrix[j][j] += l.Alpha
+	}
+
+	weights, err := solveLinearSystem(matrix, vector)
+	if err != nil {
+		return err
+	}
+	l.Weights = weights
+
+	return nil
+}
+
+func (l *LinearRegression) Predict(features []float64) float64 {
+	prediction := l.Intercept
+	for j, value := range l.scale(features) {
+		if j < len(l.Weights) {
+			prediction += l.Weights[j] * value
+		}
+	}
+
+	return prediction
+}
+
+func (l *LinearRegression) scale(features []float64) []float64 {
+	scaled := make([]float64, len(features))
+	for j, value := range features {
+		if j < len(l.Means) {
+			scaled[j] = (value - l.Means[j]) / l.Scales[j]
+		}
+	}
+
+	return scaled
+}
+
+// solveLinearSystem is Gaussian elimination with partial pivoting
+func solveLinearSystem(matrix [][]float64, vector []float64) ([]float64, error) {
+	size := len(vector)
+
+	for column := 0; column < size; column++ {
+		pivot := column
+		for row := column + 1; row < size; row++ {
+			if math.Abs(matrix[row][column]) > math.Abs(matrix[pivot][column]) {
+				pivot = row
+			}
+		}
+
+		if math.Abs(matrix[pivot][column]) < 1e-12 {
+			return nil, errors.New("matrix is singular, features are collinear (use ridge regression)")
+		}
+
+		matrix[column], matrix[pivot] = matrix[pivot], matrix[column]
+		vector[column], vector[pivot] = vector[pivot], vector[column]
+
+		for row := column + 1; row < size; row++ {
+			factor := matrix[row][column] / matrix[column][column]
+			for k := column; k < size; k++ {
+				matrix[row][k] -= factor * matrix[column][k]
+			}
+			vector[row] -= factor * vector[column]
+		}
+	}
+
+	result := make([]float64, size)
+	for row := size - 1; row >= 0; row-- {
+		sum := vector[row]
+		for k := row + 1; k < size; k++ {
+			sum -= matrix[row][k] * result[k]
+		}
+		result[row] = sum / matrix[row][row]
+	}
+
+	return result, nil
+}
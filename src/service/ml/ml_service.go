@@ -0,0 +1,366 @@
+package ml
+
+import (
+	"errors"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/metrics"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+const MLModelHistoryLength = 48
+
+type TradeStatProviderInterface interface {
+	GetTradeStat(kLine model.KLine, cache bool, full bool) model.TradeStat
+}
+
+// activeModel keeps the feature set the regressor is trained on, prediction uses the same features
+type activeModel struct {
+	Regressor Regressor
+	Features  []string
+}
+
+// MLService trains price models in-process, every trained model is saved into the registry,
+// the model is promoted for prediction only if it beats the active one on out-of-sample data
+type MLService struct {
+	DataSetBuilder     *DataSetBuilder
+	ExchangeRepository repository.MLFeatureStorageInterface
+	MLModelRepository  repository.MLModelRepositoryInterface
+	StatService        TradeStatProviderInterface
+	TimeService        *utils.TimeHelper
+
+	models   map[string]activeModel
+	loaded   map[string]bool
+	learning map[string]bool
+	mutex    sync.RWMutex
+}
+
+func (m *MLService) getCandidateFactories() []func() Regressor {
+	return []func() Regressor{
+		func() Regressor {
+			return &LinearRegression{}
+		},
+		func() Regressor {
+			return &LinearRegression{Alpha: 1.00}
+		},
+		func() Regressor {
+			return &GradientBoosting{
+				Estimators:     100,
+				LearningRate:   0.1,
+				MaxDepth:       3,
+				MinSamplesLeaf: 5,
+			}
+		},
+	}
+}
+
+func (m *MLService) IsLearning() bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return len(m.learning) > 0
+}
+
+func (m *MLService) setLearning(symbol string, value bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.learning == nil {
+		m.learning = make(map[string]bool)
+	}
+
+	if value {
+		m.learning[symbol] = true
+	} else {
+		delete(m.learning, symbol)
+	}
+}
+
+func (m *MLService) LearnModel(symbol string) error {
+	m.setLearning(symbol, true)
+	defer m.setLearning(symbol, false)
+	learnLog := slog.With(logger.KeySymbol, symbol, logger.KeyOperation, "ml_learn")
+
+	// BTCUSDT and ETHUSDT models are learned for secondary symbols, they may have no trade limit
+	config := model.MLConfig{}
+	tradeLimit, err := m.ExchangeRepository.GetTradeLimit(symbol)
+	if err == nil {
+		config = tradeLimit.MLConfig
+	}
+
+	dataset, err := m.DataSetBuilder.PrepareDataset(symbol, config)
+	if err != nil {
+		learnLog.Error("ML dataset error", logger.Error(err))
+		return err
+	}
+
+	var best WalkForwardResult
+	var bestFactory func() Regressor
+	for _, factory := range m.getCandidateFactories() {
+		result, err := WalkForward(factory, dataset, WalkForwardFolds)
+		if err != nil {
+			learnLog.Error("ML walk-forward error", "modelType", factory().GetType(), logger.Error(err))
+			continue
+		}
+
+		learnLog.Info(
+			"ML walk-forward",
+			"modelType", result.LastFoldModel.GetType(),
+			"rmse", result.Metrics.RMSE,
+			"r2", result.Metrics.R2,
+		)
+
+		if bestFactory == nil || result.Metrics.RMSE < best.Metrics.RMSE {
+			best = result
+			bestFactory = factory
+		}
+	}
+
+	if bestFactory == nil {
+		return errors.New("no model is trained")
+	}
+
+	challenger := bestFactory()
+	err = challenger.Fit(dataset.X, dataset.Y)
+	if err != nil {
+		return err
+	}
+	trainMetrics := Evaluate(challenger, dataset.X, dataset.Y)
+
+	var active Regressor
+	var activeEntry *model.MLModel
+	entry, err := m.MLModelRepository.GetActive(symbol)
+	if err == nil {
+		active, _, err = DecodeModel([]byte(entry.Payload))
+		if err == nil {
+			activeEntry = &entry
+		} else {
+			learnLog.Error("ML active model decode error", "modelId", entry.Id, logger.Error(err))
+		}
+	}
+
+	decision := ValidateChallenger(dataset, best, active, activeEntry)
+
+	payload, err := EncodeModel(challenger, SerializedModel{
+		Symbol:    symbol,
+		Features:  dataset.Features,
+		Horizon:   dataset.Horizon,
+		Metrics:   best.Metrics,
+		CreatedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	entity := model.MLModel{
+		Symbol:        symbol,
+		ModelType:     challenger.GetType(),
+		FormatVersion: ModelFormatVersion,
+		Features:      dataset.Features,
+		Horizon:       dataset.Horizon,
+		Metrics: model.MLModelMetrics{
+			TrainRMSE:            trainMetrics.RMSE,
+			TrainR2:              trainMetrics.R2,
+			WalkForwardRMSE:      best.Metrics.RMSE,
+			WalkForwardR2:        best.Metrics.R2,
+			WalkForwardFolds:     int64(best.Folds),
+			ValidationLength:     int64(decision.Length),
+			ValidationRMSE:       decision.ChallengerRMSE,
+			ActiveValidationRMSE: decision.ActiveRMSE,
+		},
+		DatasetFrom:     model.TimestampMilli(dataset.GetFrom()),
+		DatasetTo:       model.TimestampMilli(dataset.GetTo()),
+		DatasetLength:   int64(len(dataset.Y)),
+		PromotionReason: decision.Reason,
+		Payload:         string(payload),
+	}
+
+	id, err := m.MLModelRepository.Create(entity)
+	if err != nil {
+		return err
+	}
+	entity.Id = *id
+
+	if decision.Promote {
+		err = m.MLModelRepository.Activate(entity)
+		if err != nil {
+			return err
+		}
+		m.setModel(symbol, activeModel{Regressor: challenger, Features: dataset.Features})
+		learnLog.Info("ML model is promoted", "modelType", entity.ModelType, "modelId", entity.Id, "reason", decision.Reason)
+	} else {
+		learnLog.Info("ML model is not promoted", "modelType", entity.ModelType, "modelId", entity.Id, "reason", decision.Reason)
+	}
+
+	err = m.MLModelRepository.DeleteOutdated(symbol, MLModelHistoryLength)
+	if err != nil {
+		learnLog.Error("ML registry cleanup error", logger.Error(err))
+	}
+
+	return nil
+}
+
+// ActivateModel allows to rollback to any model from the registry
+func (m *MLService) ActivateModel(id int64) (model.MLModel, error) {
+	entity, err := m.MLModelRepository.Find(id)
+	if err != nil {
+		return entity, err
+	}
+
+	regressor, meta, err := DecodeModel([]byte(entity.Payload))
+	if err != nil {
+		return entity, err
+	}
+
+	entity.PromotionReason = "manual activation"
+	err = m.MLModelRepository.Activate(entity)
+	if err != nil {
+		return entity, err
+	}
+	entity.IsActive = true
+
+	m.setModel(entity.Symbol, activeModel{Regressor: regressor, Features: meta.Features})
+	slog.Info("ML model is activated manually", logger.KeySymbol, entity.Symbol, "modelType", entity.ModelType, "modelId", entity.Id)
+
+	return entity, nil
+}
+
+func (m *MLService) setModel(symbol string, active activeModel) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.models == nil {
+		m.models = make(map[string]activeModel)
+	}
+
+	m.models[symbol] = active
+}
+
+// getModel loads active model from the registry once, later it is replaced by promoted or activated model
+func (m *MLService) getModel(symbol string) (activeModel, error) {
+	m.mutex.Lock()
+	active, ok := m.models[symbol]
+	isLoaded := m.loaded[symbol]
+	if m.loaded == nil {
+		m.loaded = make(map[string]bool)
+	}
+	m.loaded[symbol] = true
+	m.mutex.Unlock()
+
+	if ok {
+		return active, nil
+	}
+
+	if isLoaded {
+		return active, errors.New("model is not trained")
+	}
+
+	entity, err := m.MLModelRepository.GetActive(symbol)
+	if err != nil {
+		return active, errors.New("model is not trained")
+	}
+
+	regressor, meta, err := DecodeModel([]byte(entity.Payload))
+	if err != nil {
+		return active, err
+	}
+	active = activeModel{Regressor: regressor, Features: meta.Features}
+	m.setModel(symbol, active)
+
+	return active, nil
+}
+
+// Predict returns the close price predicted for the horizon of the active model
+func (m *MLService) Predict(symbol string) (float64, error) {
+	defer metrics.MLPredictDuration.ObserveSince(time.Now(), symbol)
+
+	active, err := m.getModel(symbol)
+	if err != nil {
+		return 0.00, err
+	}
+
+	kLine := m.ExchangeRepository.GetCurrentKline(symbol)
+
+	if kLine == nil {
+		return 0.00, errors.New("price is unknown")
+	}
+
+	secondaryPrice := 0.00
+	if slices.Contains(active.Features, model.MLFeatureSecondaryPrice) {
+		secondaryKline := m.ExchangeRepository.GetCurrentKline(m.DataSetBuilder.GetSecondarySymbol(symbol))
+
+		if secondaryKline == nil {
+			return 0.00, errors.New("secondary price is unknown")
+		}
+
+		secondaryPrice = secondaryKline.Close.Value()
+	}
+
+	history := make([]FeatureSource, 0)
+	lastTimestamp := int64(0)
+	for _, item := range m.ExchangeRepository.KLineList(symbol, true, MaxFeatureLag+1) {
+		timestamp := item.Timestamp.GetPeriodToMinute()
+		if timestamp <= lastTimestamp || timestamp >= kLine.Timestamp.GetPeriodToMinute() {
+			continue
+		}
+		lastTimestamp = timestamp
+
+		history = append(history, FeatureSource{
+			Stat: model.TradeStat{
+				Symbol:    symbol,
+				Timestamp: item.Timestamp,
+				Close:     item.Close.Value(),
+			},
+		})
+	}
+
+	stat := m.StatService.GetTradeStat(*kLine, true, true)
+	history = append(history, m.DataSetBuilder.GetFeatureSource(stat, secondaryPrice, active.Features))
+
+	features, err := ExtractFeatures(active.Features, history)
+	if err != nil {
+		return 0.00, err
+	}
+
+	return active.Regressor.Predict(features), nil
+}
+
+func (m *MLService) StartAutoLearn() {
+	symbols := make([]string, 0)
+	for _, tradeLimit := range m.ExchangeRepository.GetTradeLimits() {
+		symbols = append(symbols, tradeLimit.Symbol)
+	}
+	if !slices.Contains(symbols, "BTCUSDT") {
+		symbols = append(symbols, "BTCUSDT")
+	}
+	if !slices.Contains(symbols, "ETHUSDT") {
+		symbols = append(symbols, "ETHUSDT")
+	}
+
+	wg := sync.WaitGroup{}
+	for _, symbol := range symbols {
+		wg.Add(1)
+		go func(s string) {
+			for {
+				err := m.LearnModel(s)
+				wg.Done()
+				if err != nil {
+					slog.Error("ML learning failed", logger.KeySymbol, s, logger.Error(err))
+					m.TimeService.WaitSeconds(60)
+					wg.Add(1) // just to handle negative counter
+					continue
+				}
+				m.TimeService.WaitSeconds(3600)
+				wg.Add(1) // just to handle negative counter
+			}
+		}(symbol)
+	}
+
+	wg.Wait()
+	slog.Info("ML autolearn enabled, all models processed")
+}
@@ -0,0 +1,63 @@
+package ml
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ModelFormatVersion is increased when serialized model structure is changed, models of other versions are retrained
+const ModelFormatVersion = 1
+
+type SerializedModel struct {
+	Version   int             `json:"version"`
+	Type      string          `json:"type"`
+	Symbol    string          `json:"symbol"`
+	Features  []string        `json:"features"`
+	Horizon   int64           `json:"horizon"`
+	Metrics   ModelMetrics    `json:"metrics"`
+	CreatedAt int64           `json:"createdAt"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+func EncodeModel(regressor Regressor, meta SerializedModel) ([]byte, error) {
+	payload, err := json.Marshal(regressor)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.Version = ModelFormatVersion
+	meta.Type = regressor.GetType()
+	meta.Payload = payload
+
+	return json.Marshal(meta)
+}
+
+func DecodeModel(encoded []byte) (Regressor, SerializedModel, error) {
+	var meta SerializedModel
+	err := json.Unmarshal(encoded, &meta)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	if meta.Version != ModelFormatVersion {
+		return nil, meta, errors.New(fmt.Sprintf("Model format version %d is not supported", meta.Version))
+	}
+
+	var regressor Regressor
+	switch meta.Type {
+	case ModelTypeLinear, ModelTypeRidge:
+		regressor = &LinearRegression{}
+	case ModelTypeGradientBoosting:
+		regressor = &GradientBoosting{}
+	default:
+		return nil, meta, errors.New(fmt.Sprintf("Model type %s is not supported", meta.Type))
+	}
+
+	err = json.Unmarshal(meta.Payload, regressor)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	return regressor, meta, nil
+}
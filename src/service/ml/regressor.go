@@ -0,0 +1,66 @@
+package ml
+
+import (
+	"errors"
+	"math"
+)
+
+const ModelTypeLinear = "linear"
+const ModelTypeRidge = "ridge"
+const ModelTypeGradientBoosting = "gradient_boosting"
+
+type Regressor interface {
+	Fit(x [][]float64, y []float64) error
+	Predict(features []float64) float64
+	GetType() string
+}
+
+type ModelMetrics struct {
+	RMSE float64 `json:"rmse"`
+	R2   float64 `json:"r2"`
+}
+
+func Evaluate(regressor Regressor, x [][]float64, y []float64) ModelMetrics {
+	if len(y) == 0 {
+		return ModelMetrics{}
+	}
+
+	mean := 0.00
+	for _, value := range y {
+		mean += value
+	}
+	mean /= float64(len(y))
+
+	squaredError := 0.00
+	squaredTotal := 0.00
+	for i, row := range x {
+		diff := y[i] - regressor.Predict(row)
+		squaredError += diff * diff
+		squaredTotal += (y[i] - mean) * (y[i] - mean)
+	}
+
+	r2 := 0.00
+	if squaredTotal > 0.00 {
+		r2 = 1 - squaredError/squaredTotal
+	}
+
+	return ModelMetrics{
+		RMSE: math.Sqrt(squaredError / float64(len(y))),
+		R2:   r2,
+	}
+}
+
+func validateDataset(x [][]float64, y []float64) error {
+	if len(x) == 0 || len(x) != len(y) {
+		return errors.New("dataset is empty or features do not match labels")
+	}
+
+	width := len(x[0])
+	for _, row := range x {
+		if len(row) != width {
+			return errors.New("dataset rows have different feature count")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package ml
+
+import (
+	"errors"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"math"
+)
+
+const WalkForwardFolds = 4
+const MinValidationLength = 10
+
+type WalkForwardResult struct {
+	Metrics ModelMetrics
+	Folds   int
+	// LastFoldModel is trained on rows before LastFoldStart only, it is used for out-of-sample comparison
+	LastFoldModel Regressor
+	LastFoldStart int
+}
+
+// WalkForward splits chronologically sorted dataset into folds+1 parts, every fold model is trained
+// on all previous parts and evaluated on the next one, metrics are computed on all out-of-sample predictions
+func WalkForward(factory func() Regressor, dataset Dataset, folds int) (WalkForwardResult, error) {
+	size := len(dataset.Y)
+	if folds <= 0 || size < (folds+1)*2 {
+		return WalkForwardResult{}, errors.New(fmt.Sprintf("dataset length %d is too small for %d folds", size, folds))
+	}
+
+	result := WalkForwardResult{Folds: folds}
+	squaredError := 0.00
+	actual := make([]float64, 0)
+
+	for fold := 1; fold <= folds; fold++ {
+		trainEnd := fold * size / (folds + 1)
+		testEnd := (fold + 1) * size / (folds + 1)
+
+		regressor := factory()
+		err := regressor.Fit(dataset.X[:trainEnd], dataset.Y[:trainEnd])
+		if err != nil {
+			return result, err
+		}
+
+		for i := trainEnd; i < testEnd; i++ {
+			diff := dataset.Y[i] - regressor.Predict(dataset.X[i])
+			squaredError += diff * diff
+			actual = append(actual, dataset.Y[i])
+		}
+
+		result.LastFoldModel = regressor
+		result.LastFoldStart = trainEnd
+	}
+
+	mean := 0.00
+	for _, value := range actual {
+		mean += value
+	}
+	mean /= float64(len(actual))
+
+	squaredTotal := 0.00
+	for _, value := range actual {
+		squaredTotal += (value - mean) * (value - mean)
+	}
+
+	result.Metrics.RMSE = math.Sqrt(squaredError / float64(len(actual)))
+	if squaredTotal > 0.00 {
+		result.Metrics.R2 = 1 - squaredError/squaredTotal
+	}
+
+	return result, nil
+}
+
+type PromotionDecision struct {
+	Promote        bool
+	Reason         string
+	Length         int
+	ChallengerRMSE float64
+	ActiveRMSE     float64
+}
+
+// ValidateChallenger compares new model with the active one on the latest rows which were not used to train
+// neither the active model nor the last walk-forward fold model of the challenger
+func ValidateChallenger(dataset Dataset, walkForward WalkForwardResult, active Regressor, activeEntry *model.MLModel) PromotionDecision {
+	if active == nil || activeEntry == nil {
+		return PromotionDecision{Promote: true, Reason: "no active model"}
+	}
+
+	if activeEntry.FormatVersion != ModelFormatVersion || !activeEntry.Features.IsSame(dataset.Features) || activeEntry.Horizon != dataset.Horizon {
+		return PromotionDecision{Promote: true, Reason: "active model format, feature set or horizon is outdated"}
+	}
+
+	x := make([][]float64, 0)
+	y := make([]float64, 0)
+	for i := walkForward.LastFoldStart; i < len(dataset.Y); i++ {
+		if dataset.Timestamps[i] > activeEntry.DatasetTo.Value() {
+			x = append(x, dataset.X[i])
+			y = append(y, dataset.Y[i])
+		}
+	}
+
+	if len(y) < MinValidationLength {
+		return PromotionDecision{
+			Promote: false,
+			Reason:  fmt.Sprintf("not enough unseen rows for validation: %d", len(y)),
+			Length:  len(y),
+		}
+	}
+
+	decision := PromotionDecision{
+		Length:         len(y),
+		ChallengerRMSE: Evaluate(walkForward.LastFoldModel, x, y).RMSE,
+		ActiveRMSE:     Evaluate(active, x, y).RMSE,
+	}
+	decision.Promote = decision.ChallengerRMSE < decision.ActiveRMSE
+	decision.Reason = fmt.Sprintf("out-of-sample RMSE %.8f vs active %.8f", decision.ChallengerRMSE, decision.ActiveRMSE)
+
+	return decision
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/notifier"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const NotificationQueueSize = 100
+
+// NotificationManager sends bot events to every channel subscribed to the event,
+// channels are called one by one, failed channel does not stop the others.
+// After Start notifications are sent from a bounded queue, trading does not wait for slow channels
+type NotificationManager struct {
+	Subscriptions []notifier.Subscription
+	queue         chan model.Notification
+	done          chan bool
+	isStopped     bool
+	mutex         sync.RWMutex
+}
+
+func (n *NotificationManager) Start() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.queue != nil {
+		return
+	}
+
+	n.queue = make(chan model.Notification, NotificationQueueSize)
+	n.done = make(chan bool)
+
+	go func(queue chan model.Notification, done chan bool) {
+		for notification := range queue {
+			n.send(notification)
+		}
+		close(done)
+	}(n.queue, n.done)
+}
+
+// Stop waits until queued notifications are sent, but not longer than timeout
+func (n *NotificationManager) Stop(timeout time.Duration) {
+	n.mutex.Lock()
+	if n.queue == nil || n.isStopped {
+		n.mutex.Unlock()
+
+		return
+	}
+	n.isStopped = true
+	close(n.queue)
+	n.mutex.Unlock()
+
+	select {
+	case <-n.done:
+	case <-time.After(timeout):
+		slog.Warn("Notification queue is not sent in time", "timeout", timeout.String())
+	}
+}
+
+func (n *NotificationManager) Error(bot model.Bot, code string, message string, stop bool) {
+	n.Notify(model.NewErrorNotification(bot, code, message, stop))
+}
+
+func (n *NotificationManager) SellOrder(order model.Order, bot model.Bot, details string) {
+	n.Notify(model.NewOrderNotification(model.NotificationEventSell, order, bot, details))
+}
+
+func (n *NotificationManager) BuyOrder(order model.Order, bot model.Bot, details string) {
+	n.Notify(model.NewOrderNotification(model.NotificationEventBuy, order, bot, details))
+}
+
+func (n *NotificationManager) Notify(notification model.Notification) {
+	if notification.DateTime == "" {
+		notification.DateTime = time.Now().Format(time.DateTime)
+	}
+
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+
+	if n.queue == nil {
+		n.send(notification)
+
+		return
+	}
+
+	if n.isStopped {
+		slog.Warn("Notification is dropped, bot is stopping", "event", notification.Event, logger.KeySymbol, notification.Symbol)
+
+		return
+	}
+
+	select {
+	case n.queue <- notification:
+	default:
+		slog.Warn("Notification is dropped, queue is full", "event", notification.Event, logger.KeySymbol, notification.Symbol)
+	}
+}
+
+func (n *NotificationManager) send(notification model.Notification) {
+	for _, subscription := range n.Subscriptions {
+		if !subscription.IsSubscribed(notification.Event) {
+			continue
+		}
+
+		channelLog := slog.With(
+			"channel", subscription.Channel,
+			"event", notification.Event,
+			logger.KeySymbol, notification.Symbol,
+			logger.KeyExchange, notification.Exchange,
+		)
+
+		err := subscription.Notifier.Notify(notification)
+		if err != nil {
+			channelLog.Error("Notification failed", logger.Error(err))
+			continue
+		}
+
+		channelLog.Debug("Notification sent")
+	}
+}
+
+func (n *NotificationManager) GetChannels() []string {
+	channels := make([]string, 0)
+	for _, subscription := range n.Subscriptions {
+		channels = append(channels, subscription.Channel)
+	}
+
+	return channels
+}
@@ -0,0 +1,19 @@
+package notifier
+
+import (
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+)
+
+const DiscordMessageLimit = 2000
+
+// DiscordNotifier sends messages to Discord channel webhook
+type DiscordNotifier struct {
+	WebhookUrl string
+}
+
+func (d *DiscordNotifier) Notify(notification model.Notification) error {
+	return postJson(d.WebhookUrl, map[string]string{
+		"content": truncate(fmt.Sprintf("**%s**\n%s", notification.Title, notification.Message), DiscordMessageLimit),
+	}, nil)
+}
@@ -0,0 +1,127 @@
+package notifier
+
+import (
+	"crypto/tls"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+const EmailDefaultTimeout = time.Second * 10
+
+type SendMailFunc func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+
+// EmailNotifier sends plain text email, STARTTLS is used when server supports it,
+// the whole SMTP session (dial, commands and message) is limited by Timeout
+type EmailNotifier struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	To       []string
+	Timeout  time.Duration
+	SendMail SendMailFunc
+}
+
+func (e *EmailNotifier) Notify(notification model.Notification) error {
+	sendMail := e.SendMail
+	if sendMail == nil {
+		sendMail = e.sendMail
+	}
+
+	var auth smtp.Auth
+	if e.Username != "" {
+		auth = smtp.PlainAuth("", e.Username, e.Password, e.Host)
+	}
+
+	return sendMail(fmt.Sprintf("%s:%s", e.Host, e.Port), auth, e.From, e.To, e.buildMessage(notification))
+}
+
+// sendMail is smtp.SendMail with connection deadline, stalled server must not block the caller
+func (e *EmailNotifier) sendMail(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+	timeout := e.Timeout
+	if timeout <= 0 {
+		timeout = EmailDefaultTimeout
+	}
+
+	connection, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	err = connection.SetDeadline(time.Now().Add(timeout))
+	if err != nil {
+		return err
+	}
+
+	client, err := smtp.NewClient(connection, e.Host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		err = client.StartTLS(&tls.Config{ServerName: e.Host})
+		if err != nil {
+			return err
+		}
+	}
+
+	if auth != nil {
+		err = client.Auth(auth)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.Mail(from)
+	if err != nil {
+		return err
+	}
+
+	for _, recipient := range to {
+		err = client.Rcpt(recipient)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(msg)
+	if err != nil {
+		return err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (e *EmailNotifier) buildMessage(notification model.Notification) []byte {
+	// header values must not contain line breaks
+	subject := strings.NewReplacer("\r", " ", "\n", " ").Replace(notification.Title)
+	body := fmt.Sprintf("%s\n\nBot: %s (%s)", notification.Message, notification.BotUuid, notification.Exchange)
+
+	return []byte(strings.Join([]string{
+		fmt.Sprintf("From: %s", e.From),
+		fmt.Sprintf("To: %s", strings.Join(e.To, ", ")),
+		fmt.Sprintf("Subject: %s", subject),
+		fmt.Sprintf("Date: %s", time.Now().Format(time.RFC1123Z)),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		strings.ReplaceAll(body, "\n", "\r\n"),
+	}, "\r\n"))
+}
@@ -0,0 +1,101 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const EventsAll = "all"
+const EventsNone = "none"
+
+type Notifier interface {
+	Notify(notification model.Notification) error
+}
+
+type Subscription struct {
+	Channel  string
+	Notifier Notifier
+	Events   []string
+}
+
+func (s Subscription) IsSubscribed(event string) bool {
+	return slices.Contains(s.Events, event)
+}
+
+// ParseEvents parses comma separated events, defaults are returned if value is empty
+func ParseEvents(value string, defaults []string) []string {
+	value = strings.TrimSpace(value)
+
+	switch strings.ToLower(value) {
+	case "":
+		return defaults
+	case EventsAll:
+		return model.NotificationEvents
+	case EventsNone:
+		return make([]string, 0)
+	}
+
+	events := make([]string, 0)
+	for _, event := range strings.Split(value, ",") {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if slices.Contains(model.NotificationEvents, event) && !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+var httpClient = &http.Client{Timeout: time.Second * 10}
+
+func postJson(url string, payload any, headers map[string]string) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return post(url, encoded, headers)
+}
+
+func post(url string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode >= 400 {
+		return errors.New(fmt.Sprintf("Request failed with error code: %d", res.StatusCode))
+	}
+
+	return nil
+}
+
+// truncate keeps message within chat limit, text is cut by runes to keep it valid
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+
+	return string(runes[0:limit-3]) + "..."
+}
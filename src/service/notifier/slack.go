@@ -0,0 +1,17 @@
+package notifier
+
+import (
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+)
+
+// SlackNotifier sends messages to Slack incoming webhook
+type SlackNotifier struct {
+	WebhookUrl string
+}
+
+func (s *SlackNotifier) Notify(notification model.Notification) error {
+	return postJson(s.WebhookUrl, map[string]string{
+		"text": fmt.Sprintf("*%s*\n%s", notification.Title, notification.Message),
+	}, nil)
+}
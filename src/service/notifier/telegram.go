@@ -0,0 +1,29 @@
+package notifier
+
+import (
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+)
+
+const TelegramApiHost = "https://api.telegram.org"
+const TelegramMessageLimit = 4096
+
+// TelegramNotifier sends messages with Bot API directly, bot must be added to the chat
+type TelegramNotifier struct {
+	ApiHost  string
+	BotToken string
+	ChatId   string
+}
+
+func (t *TelegramNotifier) Notify(notification model.Notification) error {
+	apiHost := t.ApiHost
+	if apiHost == "" {
+		apiHost = TelegramApiHost
+	}
+
+	return postJson(fmt.Sprintf("%s/bot%s/sendMessage", apiHost, t.BotToken), map[string]any{
+		"chat_id":                  t.ChatId,
+		"text":                     truncate(notification.GetText(), TelegramMessageLimit),
+		"disable_web_page_preview": true,
+	}, nil)
+}
@@ -0,0 +1,46 @@
+package notifier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"strconv"
+	"time"
+)
+
+const WebhookTimestampHeader = "X-Bot-Timestamp"
+const WebhookSignatureHeader = "X-Bot-Signature"
+
+// WebhookNotifier posts notification as JSON, body is signed if secret is set
+type WebhookNotifier struct {
+	Url    string
+	Secret string
+}
+
+func (w *WebhookNotifier) Notify(notification model.Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	headers := make(map[string]string)
+	if w.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		headers[WebhookTimestampHeader] = timestamp
+		headers[WebhookSignatureHeader] = Sign(w.Secret, timestamp, body)
+	}
+
+	return post(w.Url, body, headers)
+}
+
+// Sign returns "sha256=" + hex HMAC-SHA256 of "{timestamp}.{body}", receiver should reject old timestamps
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
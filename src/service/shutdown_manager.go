@@ -0,0 +1,67 @@
+package service
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+type ShutdownManagerInterface interface {
+	IsStopping() bool
+	Begin() bool
+	End()
+}
+
+// ShutdownManager tracks in-progress trade operations, new operations are not started after shutdown is requested
+type ShutdownManager struct {
+	stopping   bool
+	operations sync.WaitGroup
+	mutex      sync.Mutex
+}
+
+func (s *ShutdownManager) IsStopping() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.stopping
+}
+
+// Begin registers operation, false means shutdown is requested and operation must not be started
+func (s *ShutdownManager) Begin() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stopping {
+		return false
+	}
+
+	s.operations.Add(1)
+
+	return true
+}
+
+func (s *ShutdownManager) End() {
+	s.operations.Done()
+}
+
+// Shutdown waits in-progress operations, returns false if some of them are not finished in time
+func (s *ShutdownManager) Shutdown(timeout time.Duration) bool {
+	s.mutex.Lock()
+	s.stopping = true
+	s.mutex.Unlock()
+
+	done := make(chan bool)
+	go func() {
+		s.operations.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Printf("Shutdown: all operations are finished")
+		return true
+	case <-time.After(timeout):
+		log.Printf("Shutdown: operations are not finished in %s, state is saved and will be resumed", timeout)
+		return false
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
 	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/indicator"
 	"gitlab.com/open-soft/go-crypto-bot/src/utils"
 	"time"
 )
@@ -13,10 +14,31 @@ type BaseKLineStrategy struct {
 	ExchangeRepository *repository.ExchangeRepository
 	OrderRepository    *repository.OrderRepository
 	Formatter          *utils.Formatter
+	IndicatorService   indicator.IndicatorServiceInterface
 	MlEnabled          bool
 }
 
+func (k *BaseKLineStrategy) GetName() string {
+	return model.BaseKlineStrategyName
+}
+
+func (k *BaseKLineStrategy) GetInput() string {
+	return model.StrategyInputKLine
+}
+
 func (k *BaseKLineStrategy) Decide(kLine model.KLine) model.Decision {
+	decision := k.decide(kLine)
+
+	if k.IndicatorService != nil {
+		for name, value := range k.IndicatorService.GetValues(kLine.Symbol) {
+			decision.Params[name] = value
+		}
+	}
+
+	return decision
+}
+
+func (k *BaseKLineStrategy) decide(kLine model.KLine) model.Decision {
 	if kLine.IsPositive() && kLine.Close < (kLine.High+kLine.Open)/2 {
 		return model.Decision{
 			StrategyName: model.BaseKlineStrategyName,
@@ -24,7 +46,8 @@ func (k *BaseKLineStrategy) Decide(kLine model.KLine) model.Decision {
 			Operation:    "BUY",
 			Timestamp:    time.Now().Unix(),
 			Price:        kLine.Close.Value(),
-			Params:       [3]float64{0, 0, 0},
+			Reason:       "positive kline closed below middle of high and open",
+			Params:       model.DecisionParams{"open": kLine.Open.Value(), "high": kLine.High.Value(), "close": kLine.Close.Value()},
 		}
 	}
 
@@ -58,7 +81,8 @@ func (k *BaseKLineStrategy) Decide(kLine model.KLine) model.Decision {
 								Operation:    "BUY",
 								Timestamp:    time.Now().Unix(),
 								Price:        kLine.Close.Value(),
-								Params:       [3]float64{0, 0, 0},
+								Reason:       "positive prediction with high buy price points",
+								Params:       model.DecisionParams{"points": float64(points), "close": kLine.Close.Value()},
 							}
 						case points >= -5:
 							return model.Decision{
@@ -67,7 +91,8 @@ func (k *BaseKLineStrategy) Decide(kLine model.KLine) model.Decision {
 								Operation:    "BUY",
 								Timestamp:    time.Now().Unix(),
 								Price:        kLine.Close.Value(),
-								Params:       [3]float64{0, 0, 0},
+								Reason:       "positive prediction with buy price points",
+								Params:       model.DecisionParams{"points": float64(points), "close": kLine.Close.Value()},
 							}
 						}
 					}
@@ -81,7 +106,8 @@ func (k *BaseKLineStrategy) Decide(kLine model.KLine) model.Decision {
 			Operation:    "SELL",
 			Timestamp:    time.Now().Unix(),
 			Price:        kLine.Close.Value(),
-			Params:       [3]float64{0, 0, 0},
+			Reason:       "negative kline",
+			Params:       model.DecisionParams{"open": kLine.Open.Value(), "close": kLine.Close.Value()},
 		}
 	}
 
@@ -92,7 +118,8 @@ func (k *BaseKLineStrategy) Decide(kLine model.KLine) model.Decision {
 			Operation:    "BUY",
 			Timestamp:    time.Now().Unix(),
 			Price:        kLine.Close.Value(),
-			Params:       [3]float64{0, 0, 0},
+			Reason:       "positive prediction",
+			Params:       model.DecisionParams{"close": kLine.Close.Value()},
 		}
 	}
 
@@ -102,6 +129,7 @@ func (k *BaseKLineStrategy) Decide(kLine model.KLine) model.Decision {
 		Operation:    "HOLD",
 		Timestamp:    time.Now().Unix(),
 		Price:        kLine.Close.Value(),
-		Params:       [3]float64{0, 0, 0},
+		Reason:       "positive kline",
+		Params:       model.DecisionParams{"open": kLine.Open.Value(), "close": kLine.Close.Value()},
 	}
 }
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"github.com/gorilla/websocket"
 	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
-	"log"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"log/slog"
 	"os"
 	"strings"
 	"sync"
@@ -23,9 +25,9 @@ type ExchangeWSStreamer interface {
 }
 
 type BinanceWSStreamer struct {
-	ExchangeRepository  *repository.ExchangeRepository
-	SmaTradeStrategy    *SmaTradeStrategy
-	MarketDepthStrategy *MarketDepthStrategy
+	ExchangeRepository *repository.ExchangeRepository
+	StrategyRegistry   *exchange.StrategyRegistry
+	OrderBookKeeper    exchange.OrderBookKeeperInterface
 }
 
 func (b *BinanceWSStreamer) StartStream(
@@ -50,7 +52,7 @@ func (b *BinanceWSStreamer) StartStream(
 						klineChannel <- kLine.Update(ticker, model.KLineSourceTickerStream)
 					}
 				} else {
-					log.Printf("Stream: Mini ticker error: %s", err.Error())
+					slog.Error("Mini ticker error", logger.KeyExchange, "binance", logger.KeyStream, "market", logger.Error(err))
 				}
 
 				break
@@ -60,8 +62,9 @@ func (b *BinanceWSStreamer) StartStream(
 
 				if err == nil {
 					b.ExchangeRepository.AddTrade(tradeEvent.Trade)
-					smaDecision := b.SmaTradeStrategy.Decide(tradeEvent.Trade)
-					b.ExchangeRepository.SetDecision(smaDecision, tradeEvent.Trade.Symbol)
+					for _, decision := range b.StrategyRegistry.DecideTrade(tradeEvent.Trade) {
+						b.ExchangeRepository.SetDecision(decision, tradeEvent.Trade.Symbol)
+					}
 				}
 
 				break
@@ -75,19 +78,25 @@ func (b *BinanceWSStreamer) StartStream(
 					kLine.Source = model.KLineSourceKLineStream
 					klineChannel <- kLine
 				} else {
-					log.Printf("Stream: Kline error: %s", err.Error())
+					slog.Error("Kline error", logger.KeyExchange, "binance", logger.KeyStream, "market", logger.Error(err))
 				}
 
 				break
-			case strings.Contains(string(message), "depth20"):
-				var event model.OrderBookEvent
+			case strings.Contains(string(message), "depthUpdate"):
+				var event model.DiffDepthEvent
 				err := json.Unmarshal(message, &event)
 
 				if err == nil {
-					depth := event.Depth.ToOrderBookModel(strings.ToUpper(strings.ReplaceAll(event.Stream, "@depth20@100ms", "")))
-					depthDecision := b.MarketDepthStrategy.Decide(depth)
-					b.ExchangeRepository.SetDecision(depthDecision, depth.Symbol)
+					depth, synced := b.OrderBookKeeper.Apply(event.Data.ToOrderBookDiff())
+					if !synced {
+						break
+					}
+					for _, decision := range b.StrategyRegistry.DecideDepth(depth) {
+						b.ExchangeRepository.SetDecision(decision, depth.Symbol)
+					}
 					depthChannel <- depth
+				} else {
+					slog.Error("Diff depth error", logger.KeyExchange, "binance", logger.KeyStream, "market", logger.Error(err))
 				}
 				break
 			}
@@ -98,7 +107,7 @@ func (b *BinanceWSStreamer) StartStream(
 	lock := sync.Mutex{}
 	sWg := sync.WaitGroup{}
 
-	for index, streamBatchItem := range client.GetStreamBatch(tradeLimitCollection, []string{"@aggTrade", "@kline_1m@2000ms", "@depth20@100ms", "@miniTicker"}) {
+	for index, streamBatchItem := range client.GetStreamBatch(tradeLimitCollection, []string{"@aggTrade", "@kline_1m@2000ms", "@depth@100ms", "@miniTicker"}) {
 		sWg.Add(1)
 		go func(sbi []string, i int) {
 			defer sWg.Done()
@@ -109,7 +118,7 @@ func (b *BinanceWSStreamer) StartStream(
 				strings.Join(sbi, "/"),
 			), eventChannel, []string{}, int64(i)))
 			lock.Unlock()
-			log.Printf("Batch %d websocket: %d connected", i, len(sbi))
+			slog.Info("Websocket batch connected", logger.KeyExchange, "binance", logger.KeyStream, "market", "batch", i, "streams", len(sbi))
 		}(streamBatchItem, index)
 	}
 
@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"github.com/gorilla/websocket"
 	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
 	"gitlab.com/open-soft/go-crypto-bot/src/utils"
-	"log"
+	"log/slog"
 	"os"
 	"strings"
 	"sync"
@@ -15,10 +17,10 @@ import (
 )
 
 type ByBitWsStreamer struct {
-	ExchangeRepository  *repository.ExchangeRepository
-	SmaTradeStrategy    *SmaTradeStrategy
-	MarketDepthStrategy *MarketDepthStrategy
-	Formatter           *utils.Formatter
+	ExchangeRepository *repository.ExchangeRepository
+	StrategyRegistry   *exchange.StrategyRegistry
+	Formatter          *utils.Formatter
+	OrderBookKeeper    exchange.OrderBookKeeperInterface
 }
 
 func (b *ByBitWsStreamer) StartStream(
@@ -45,7 +47,7 @@ func (b *ByBitWsStreamer) StartStream(
 						klineChannel <- kLine.Update(ticker, model.KLineSourceTickerStream)
 					}
 				} else {
-					log.Printf("Ticker error bybit: %s", err.Error())
+					slog.Error("Ticker error", logger.KeyExchange, "bybit", logger.KeyStream, "market", logger.Error(err))
 				}
 
 				break
@@ -57,11 +59,12 @@ func (b *ByBitWsStreamer) StartStream(
 						trade := byBitTrade.ToBinanceTrade()
 
 						b.ExchangeRepository.AddTrade(trade)
-						smaDecision := b.SmaTradeStrategy.Decide(trade)
-						b.ExchangeRepository.SetDecision(smaDecision, trade.Symbol)
+						for _, decision := range b.StrategyRegistry.DecideTrade(trade) {
+							b.ExchangeRepository.SetDecision(decision, trade.Symbol)
+						}
 					}
 				} else {
-					log.Printf("Public trade error bybit: %s", err.Error())
+					slog.Error("Public trade error", logger.KeyExchange, "bybit", logger.KeyStream, "market", logger.Error(err))
 				}
 
 				break
@@ -80,7 +83,7 @@ func (b *ByBitWsStreamer) StartStream(
 						klineChannel <- kLine
 					}
 				} else {
-					log.Printf("Kline error bybit: %s", err.Error())
+					slog.Error("Kline error", logger.KeyExchange, "bybit", logger.KeyStream, "market", logger.Error(err))
 				}
 
 				break
@@ -88,12 +91,16 @@ func (b *ByBitWsStreamer) StartStream(
 				var event model.ByBitWsOrderBookEvent
 				err := json.Unmarshal(message, &event)
 				if err == nil {
-					depth := event.Data.ToOrderBookModel()
-					depthDecision := b.MarketDepthStrategy.Decide(depth)
-					b.ExchangeRepository.SetDecision(depthDecision, depth.Symbol)
+					depth, synced := b.OrderBookKeeper.Apply(event.ToOrderBookDiff())
+					if !synced {
+						break
+					}
+					for _, decision := range b.StrategyRegistry.DecideDepth(depth) {
+						b.ExchangeRepository.SetDecision(decision, depth.Symbol)
+					}
 					depthChannel <- depth
 				} else {
-					log.Printf("Order book error bybit: %s", err.Error())
+					slog.Error("Order book error", logger.KeyExchange, "bybit", logger.KeyStream, "market", logger.Error(err))
 				}
 				break
 			}
@@ -111,7 +118,7 @@ func (b *ByBitWsStreamer) StartStream(
 			lock.Lock()
 			websockets = append(websockets, client.ListenByBit(os.Getenv("BYBIT_STREAM_DSN"), eventChannel, sbi, int64(i)))
 			lock.Unlock()
-			log.Printf("Batch %d websocket: %d connected", i, len(sbi))
+			slog.Info("Websocket batch connected", logger.KeyExchange, "bybit", logger.KeyStream, "market", "batch", i, "streams", len(sbi))
 		}(streamBatchItem, index)
 	}
 
@@ -8,6 +8,14 @@ import (
 type MarketDepthStrategy struct {
 }
 
+func (m *MarketDepthStrategy) GetName() string {
+	return model.MarketDepthStrategyName
+}
+
+func (m *MarketDepthStrategy) GetInput() string {
+	return model.StrategyInputDepth
+}
+
 func (m *MarketDepthStrategy) Decide(depth model.OrderBookModel) model.Decision {
 	sellVolume := depth.GetAskVolume()
 	buyVolume := depth.GetBidVolume()
@@ -21,7 +29,8 @@ func (m *MarketDepthStrategy) Decide(depth model.OrderBookModel) model.Decision
 			Operation:    "SELL",
 			Timestamp:    time.Now().Unix(),
 			Price:        depth.GetBestAsk(),
-			Params:       [3]float64{buyVolume, sellVolume, 0},
+			Reason:       "ask volume dominates",
+			Params:       model.DecisionParams{"bidVolume": buyVolume, "askVolume": sellVolume},
 		}
 	}
 
@@ -35,7 +44,8 @@ func (m *MarketDepthStrategy) Decide(depth model.OrderBookModel) model.Decision
 			Operation:    "BUY",
 			Timestamp:    time.Now().Unix(),
 			Price:        depth.GetBestBid(),
-			Params:       [3]float64{buyVolume, sellVolume, 0},
+			Reason:       "bid volume dominates",
+			Params:       model.DecisionParams{"bidVolume": buyVolume, "askVolume": sellVolume},
 		}
 	}
 
@@ -45,6 +55,7 @@ func (m *MarketDepthStrategy) Decide(depth model.OrderBookModel) model.Decision
 		Operation:    "HOLD",
 		Timestamp:    time.Now().Unix(),
 		Price:        depth.GetBestBid(),
-		Params:       [3]float64{buyVolume, sellVolume, 0},
+		Reason:       "no depth imbalance",
+		Params:       model.DecisionParams{"bidVolume": buyVolume, "askVolume": sellVolume},
 	}
 }
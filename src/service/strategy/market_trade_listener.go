@@ -3,6 +3,8 @@ package strategy
 import (
 	"gitlab.com/open-soft/go-crypto-bot/src/client"
 	"gitlab.com/open-soft/go-crypto-bot/src/event"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/metrics"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
 	"gitlab.com/open-soft/go-crypto-bot/src/service"
@@ -10,6 +12,7 @@ import (
 	"gitlab.com/open-soft/go-crypto-bot/src/service/ml"
 	"gitlab.com/open-soft/go-crypto-bot/src/utils"
 	"log"
+	"log/slog"
 	"math"
 	"runtime"
 	"strings"
@@ -18,16 +21,14 @@ import (
 )
 
 type MarketTradeListener struct {
-	BaseKLineStrategy   *BaseKLineStrategy
-	OrderBasedStrategy  *OrderBasedStrategy
-	SmaTradeStrategy    *SmaTradeStrategy
-	MarketDepthStrategy *MarketDepthStrategy
-	ExchangeRepository  *repository.ExchangeRepository
-	TimeService         *utils.TimeHelper
-	Binance             client.ExchangeAPIInterface
-	PythonMLBridge      *ml.PythonMLBridge
-	PriceCalculator     *exchange.PriceCalculator
-	EventDispatcher     *service.EventDispatcher
+	StrategyRegistry   *exchange.StrategyRegistry
+	ExchangeRepository *repository.ExchangeRepository
+	TimeService        *utils.TimeHelper
+	Binance            client.ExchangeAPIInterface
+	MLService          *ml.MLService
+	PriceCalculator    *exchange.PriceCalculator
+	EventDispatcher    *service.EventDispatcher
+	CurrentBot         *model.Bot
 
 	ExchangeWSStreamer ExchangeWSStreamer
 }
@@ -45,12 +46,12 @@ func (m *MarketTradeListener) ListenAll() {
 			symbol := <-predictChannel
 
 			if status, ok := pMap.Load(symbol); ok {
-				log.Printf("[%s] Prediction status: %s, skip", symbol, status)
+				slog.Debug("Prediction is in progress, skip", logger.KeyExchange, m.CurrentBot.Exchange, logger.KeySymbol, symbol, "status", status)
 				continue
 			}
 			pMap.Store(symbol, "processing")
 
-			predicted, _ := m.PythonMLBridge.Predict(symbol)
+			predicted, _ := m.MLService.Predict(symbol)
 
 			kLine := m.ExchangeRepository.GetCurrentKline(symbol)
 			if predicted > 0.00 {
@@ -86,16 +87,18 @@ func (m *MarketTradeListener) ListenAll() {
 				lastKline := m.ExchangeRepository.GetCurrentKline(kLine.Symbol)
 
 				if lastKline != nil && (lastKline.Timestamp.Gt(kLine.Timestamp) || kLine.IsPriceNotActual() || kLine.IsPriceWrongTimestamp()) {
-					log.Printf(
-						"[%s] (%s) Exchange sent expired stream price. T = %d < %d, UpdAt: %d, Now: %d [%d]",
-						kLine.Symbol,
-						kLine.Source,
-						kLine.Timestamp.Value(),
-						lastKline.Timestamp.Value(),
-						kLine.UpdatedAt,
-						time.Now().Unix(),
-						model.TimestampMilli(time.Now().UnixMilli()).GetPeriodToMinute(),
+					slog.Warn(
+						"Exchange sent expired stream price",
+						logger.KeyExchange, m.CurrentBot.Exchange,
+						logger.KeySymbol, kLine.Symbol,
+						logger.KeyStream, kLine.Source,
+						"timestamp", kLine.Timestamp.Value(),
+						"lastTimestamp", lastKline.Timestamp.Value(),
+						"updatedAt", kLine.UpdatedAt,
+						"now", time.Now().Unix(),
+						"period", model.TimestampMilli(time.Now().UnixMilli()).GetPeriodToMinute(),
 					)
+					metrics.StalePrices.Inc(kLine.Symbol, kLine.Source)
 					afterEach()
 					continue
 				}
@@ -110,18 +113,27 @@ func (m *MarketTradeListener) ListenAll() {
 				}
 
 				predictChannel <- kLine.Symbol
-				m.ExchangeRepository.SetDecision(m.BaseKLineStrategy.Decide(kLine), kLine.Symbol)
-				m.ExchangeRepository.SetDecision(m.OrderBasedStrategy.Decide(kLine), kLine.Symbol)
+				for _, decision := range m.StrategyRegistry.DecideKLine(kLine) {
+					m.ExchangeRepository.SetDecision(decision, kLine.Symbol)
+				}
 				afterEach()
 			}
 		}()
 	}
 
 	go func() {
+		// deep book from local order book is saved not often than once per second
+		deepDepthSavedAt := make(map[string]int64)
+
 		for {
 			depth := <-depthChannel
 			depth.UpdatedAt = time.Now().Unix()
-			m.ExchangeRepository.SetDepth(depth, 20, 25)
+			m.ExchangeRepository.SetDepth(depth.Top(repository.DepthStreamLimit), repository.DepthStreamLimit, 25)
+
+			if (len(depth.Bids) > repository.DepthStreamLimit || len(depth.Asks) > repository.DepthStreamLimit) && deepDepthSavedAt[depth.Symbol] < depth.UpdatedAt {
+				deepDepthSavedAt[depth.Symbol] = depth.UpdatedAt
+				m.ExchangeRepository.SetDepth(depth.Top(exchange.LocalOrderBookDepth), exchange.LocalOrderBookDepth, 15)
+			}
 		}
 	}()
 
@@ -130,7 +142,7 @@ func (m *MarketTradeListener) ListenAll() {
 	hasEthUsdt := false
 
 	waitGroup := sync.WaitGroup{}
-	log.Printf("Price history recovery started")
+	slog.Info("Price history recovery started", logger.KeyExchange, m.CurrentBot.Exchange)
 	for _, limit := range m.ExchangeRepository.GetTradeLimits() {
 		waitGroup.Add(1)
 		tradeLimitCollection = append(tradeLimitCollection, limit)
@@ -148,7 +160,7 @@ func (m *MarketTradeListener) ListenAll() {
 				klineAmount++
 				m.ExchangeRepository.SaveKlineHistory(kline.ToKLine(l.GetSymbol()))
 			}
-			log.Printf("Loaded history %s -> %d klines", l.Symbol, klineAmount)
+			slog.Info("Price history is loaded", logger.KeyExchange, m.CurrentBot.Exchange, logger.KeySymbol, l.Symbol, "klines", klineAmount)
 		}(limit)
 
 		if "BTCUSDT" == limit.GetSymbol() {
@@ -160,9 +172,9 @@ func (m *MarketTradeListener) ListenAll() {
 	}
 	waitGroup.Wait()
 
-	log.Printf("Price history recovery finished")
+	slog.Info("Price history recovery finished", logger.KeyExchange, m.CurrentBot.Exchange)
 	m.EventDispatcher.Enabled = true
-	log.Printf("Event subscribers are enabled")
+	slog.Info("Event subscribers are enabled")
 
 	if !hasBtcUsdt {
 		tradeLimitCollection = append(tradeLimitCollection, model.DummySymbol{Symbol: "BTCUSDT"})
@@ -172,7 +184,7 @@ func (m *MarketTradeListener) ListenAll() {
 	}
 
 	m.ExchangeWSStreamer.StartStream(tradeLimitCollection, klineChannel, depthChannel)
-	log.Printf("WS Price stream started.")
+	slog.Info("Price stream started", logger.KeyExchange, m.CurrentBot.Exchange)
 
 	// Price recovery watcher
 	go func() {
@@ -187,7 +199,7 @@ func (m *MarketTradeListener) ListenAll() {
 			}
 
 			if len(invalidPriceSymbols) > 0 {
-				log.Printf("Price is invalid for: %s", strings.Join(invalidPriceSymbols, ", "))
+				slog.Warn("Price is not actual, fetch tickers", logger.KeyExchange, m.CurrentBot.Exchange, "symbols", strings.Join(invalidPriceSymbols, ","))
 				tickers := m.Binance.GetTickers(invalidPriceSymbols)
 				updated := make([]string, 0)
 
@@ -231,13 +243,13 @@ func (m *MarketTradeListener) ListenAll() {
 				}
 
 				if len(updated) > 0 {
-					log.Printf("Price updated for: %s", strings.Join(updated, ", "))
+					slog.Info("Price is recovered from tickers", logger.KeyExchange, m.CurrentBot.Exchange, "symbols", strings.Join(updated, ","))
 				}
 			}
 			m.TimeService.WaitSeconds(4)
 		}
 	}()
-	log.Printf("Price recovery watcher started")
+	slog.Info("Price recovery watcher started", logger.KeyExchange, m.CurrentBot.Exchange)
 
 	// todo: order book recovery watcher is needed!
 
@@ -0,0 +1,136 @@
+package strategy
+
+import (
+	"encoding/json"
+	"github.com/gorilla/websocket"
+	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+type OkxWsStreamer struct {
+	ExchangeRepository *repository.ExchangeRepository
+	StrategyRegistry   *exchange.StrategyRegistry
+	Formatter          *utils.Formatter
+}
+
+func (o *OkxWsStreamer) StartStream(
+	tradeLimitCollection []model.SymbolInterface,
+	klineChannel chan model.KLine,
+	depthChannel chan model.OrderBookModel,
+) {
+	eventChannel := make(chan []byte, 1000)
+
+	go func() {
+		for {
+			message := <-eventChannel
+
+			var event model.OkxWsEvent
+			err := json.Unmarshal(message, &event)
+			// pong, subscribe and error events are skipped
+			if err != nil || event.Event != "" {
+				continue
+			}
+
+			symbol := o.Formatter.OkxInstIdToBinanceSymbol(event.Argument.InstId)
+
+			switch event.Argument.Channel {
+			case model.OkxChannelTickers:
+				var tickerEvent model.OkxWsTickerEvent
+				err := json.Unmarshal(message, &tickerEvent)
+				if err == nil {
+					for _, okxTicker := range tickerEvent.Data {
+						ticker := okxTicker.ToBinanceMiniTicker(symbol)
+						kLine := o.ExchangeRepository.GetCurrentKline(ticker.Symbol)
+
+						if kLine != nil {
+							klineChannel <- kLine.Update(ticker, model.KLineSourceTickerStream)
+						}
+					}
+				} else {
+					slog.Error("Ticker error", logger.KeyExchange, "okx", logger.KeyStream, "market", logger.Error(err))
+				}
+
+				break
+			case model.OkxChannelTrades:
+				var tradeEvent model.OkxWsTradeEvent
+				err := json.Unmarshal(message, &tradeEvent)
+				if err == nil {
+					for _, okxTrade := range tradeEvent.Data {
+						trade := o.Formatter.OkxTradeToBinanceTrade(okxTrade)
+
+						o.ExchangeRepository.AddTrade(trade)
+						for _, decision := range o.StrategyRegistry.DecideTrade(trade) {
+							o.ExchangeRepository.SetDecision(decision, trade.Symbol)
+						}
+					}
+				} else {
+					slog.Error("Trade error", logger.KeyExchange, "okx", logger.KeyStream, "market", logger.Error(err))
+				}
+
+				break
+			case model.OkxChannelCandle:
+				var kLineEvent model.OkxWsKLineEvent
+				err := json.Unmarshal(message, &kLineEvent)
+				if err == nil {
+					if len(kLineEvent.Data) > 0 {
+						kLine := o.Formatter.OkxKLineToBinanceKline(kLineEvent.Data[0], symbol, "1m")
+						kLine.UpdatedAt = time.Now().Unix()
+
+						kLine.Source = model.KLineSourceKLineStream
+						klineChannel <- kLine
+					}
+				} else {
+					slog.Error("Kline error", logger.KeyExchange, "okx", logger.KeyStream, "market", logger.Error(err))
+				}
+
+				break
+			case model.OkxChannelBooks:
+				var orderBookEvent model.OkxWsOrderBookEvent
+				err := json.Unmarshal(message, &orderBookEvent)
+				if err == nil && len(orderBookEvent.Data) > 0 {
+					depth := orderBookEvent.Data[0].ToOrderBookModel(symbol)
+					for _, decision := range o.StrategyRegistry.DecideDepth(depth) {
+						o.ExchangeRepository.SetDecision(decision, depth.Symbol)
+					}
+					depthChannel <- depth
+				} else if err != nil {
+					slog.Error("Order book error", logger.KeyExchange, "okx", logger.KeyStream, "market", logger.Error(err))
+				}
+				break
+			}
+		}
+	}()
+	websockets := make([]*websocket.Conn, 0)
+
+	lock := sync.Mutex{}
+	sWg := sync.WaitGroup{}
+
+	// candles are streamed by business endpoint only
+	streams := map[string][]string{
+		os.Getenv("OKX_STREAM_DSN"):          {model.OkxChannelTrades, model.OkxChannelBooks, model.OkxChannelTickers},
+		os.Getenv("OKX_BUSINESS_STREAM_DSN"): {model.OkxChannelCandle},
+	}
+
+	for address, channels := range streams {
+		for index, streamBatchItem := range client.GetStreamBatchOkx(tradeLimitCollection, channels, o.Formatter) {
+			sWg.Add(1)
+			go func(address string, sbi []model.OkxWsArgument, i int) {
+				defer sWg.Done()
+				lock.Lock()
+				websockets = append(websockets, client.ListenOkx(address, eventChannel, sbi, int64(i)))
+				lock.Unlock()
+				slog.Info("Websocket batch connected", logger.KeyExchange, "okx", logger.KeyStream, "market", "batch", i, "streams", len(sbi))
+			}(address, streamBatchItem, index)
+		}
+	}
+
+	sWg.Wait()
+}
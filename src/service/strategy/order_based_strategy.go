@@ -12,10 +12,19 @@ type OrderBasedStrategy struct {
 	ExchangeRepository repository.ExchangeTradeInfoInterface
 	OrderRepository    repository.OrderStorageInterface
 	ProfitService      exchange.ProfitServiceInterface
+	StopLossService    exchange.StopLossServiceInterface
 	BotService         service.BotServiceInterface
 	SignalStorage      repository.SignalStorageInterface
 }
 
+func (o *OrderBasedStrategy) GetName() string {
+	return model.OrderBasedStrategyName
+}
+
+func (o *OrderBasedStrategy) GetInput() string {
+	return model.StrategyInputKLine
+}
+
 func (o *OrderBasedStrategy) Decide(kLine model.KLine) model.Decision {
 	tradeLimit, err := o.ExchangeRepository.GetTradeLimit(kLine.Symbol)
 
@@ -26,7 +35,8 @@ func (o *OrderBasedStrategy) Decide(kLine model.KLine) model.Decision {
 			Operation:    "HOLD",
 			Timestamp:    time.Now().Unix(),
 			Price:        kLine.Close.Value(),
-			Params:       [3]float64{0, 0, 0},
+			Reason:       "trade limit is not found",
+			Params:       model.DecisionParams{},
 		}
 	}
 
@@ -34,7 +44,7 @@ func (o *OrderBasedStrategy) Decide(kLine model.KLine) model.Decision {
 	hasBuyOrder := order != nil
 
 	if !hasBuyOrder {
-		binanceBuyOrder := o.OrderRepository.GetBinanceOrder(tradeLimit.Symbol, "BUY")
+		binanceBuyOrder := o.OrderRepository.GetExchangeOrder(tradeLimit.Symbol, "BUY")
 		if binanceBuyOrder != nil {
 			return model.Decision{
 				StrategyName: model.OrderBasedStrategyName,
@@ -42,7 +52,8 @@ func (o *OrderBasedStrategy) Decide(kLine model.KLine) model.Decision {
 				Operation:    "BUY",
 				Timestamp:    time.Now().Unix(),
 				Price:        binanceBuyOrder.Price,
-				Params:       [3]float64{0, 0, 0},
+				Reason:       "exchange BUY order is pending",
+				Params:       model.DecisionParams{"orderPrice": binanceBuyOrder.Price, "orderQuantity": binanceBuyOrder.OrigQty},
 			}
 		}
 
@@ -55,7 +66,8 @@ func (o *OrderBasedStrategy) Decide(kLine model.KLine) model.Decision {
 				Operation:    "BUY",
 				Timestamp:    time.Now().Unix(),
 				Price:        manualOrder.Price,
-				Params:       [3]float64{0, 0, 0},
+				Reason:       "manual BUY order",
+				Params:       model.DecisionParams{"manualPrice": manualOrder.Price},
 			}
 		}
 
@@ -68,7 +80,8 @@ func (o *OrderBasedStrategy) Decide(kLine model.KLine) model.Decision {
 				Operation:    "BUY",
 				Timestamp:    time.Now().Unix(),
 				Price:        signal.BuyPrice,
-				Params:       [3]float64{0, 0, 0},
+				Reason:       "BUY signal is active",
+				Params:       model.DecisionParams{"signalBuyPrice": signal.BuyPrice},
 			}
 		}
 
@@ -78,11 +91,12 @@ func (o *OrderBasedStrategy) Decide(kLine model.KLine) model.Decision {
 			Operation:    "BUY",
 			Timestamp:    time.Now().Unix(),
 			Price:        kLine.Close.Value(),
-			Params:       [3]float64{0, 0, 0},
+			Reason:       "no opened position",
+			Params:       model.DecisionParams{"closePrice": kLine.Close.Value()},
 		}
 	}
 
-	binanceSellOrder := o.OrderRepository.GetBinanceOrder(tradeLimit.Symbol, "SELL")
+	binanceSellOrder := o.OrderRepository.GetExchangeOrder(tradeLimit.Symbol, "SELL")
 	if binanceSellOrder != nil {
 		return model.Decision{
 			StrategyName: model.OrderBasedStrategyName,
@@ -90,13 +104,28 @@ func (o *OrderBasedStrategy) Decide(kLine model.KLine) model.Decision {
 			Operation:    "SELL",
 			Timestamp:    time.Now().Unix(),
 			Price:        binanceSellOrder.Price,
-			Params:       [3]float64{0, 0, 0},
+			Reason:       "exchange SELL order is pending",
+			Params:       model.DecisionParams{"orderPrice": binanceSellOrder.Price, "orderQuantity": binanceSellOrder.OrigQty},
 		}
 	}
 
 	profitPercent := order.GetProfitPercent(kLine.Close.Value(), o.BotService.UseSwapCapital())
 	extraChargePercent := tradeLimit.GetBuyOnFallPercent(*order, kLine, o.BotService.UseSwapCapital())
 
+	if o.StopLossService != nil {
+		if closeReason, isTriggered := o.StopLossService.Check(*order, kLine.Close.Value()); isTriggered {
+			return model.Decision{
+				StrategyName: model.OrderBasedStrategyName,
+				Score:        model.DecisionHighestPriorityScore,
+				Operation:    "SELL",
+				Timestamp:    time.Now().Unix(),
+				Price:        kLine.Close.Value(),
+				Reason:       closeReason + " is triggered",
+				Params:       model.DecisionParams{"profitPercent": profitPercent.Value(), "positionPrice": order.Price},
+			}
+		}
+	}
+
 	// ATTENTION: We can not do extra buy if CanBuy() is false
 	// It can be the reason of active SELL orders, cancel SELL order when extra buy is possible
 	if profitPercent.Lte(extraChargePercent) {
@@ -106,7 +135,8 @@ func (o *OrderBasedStrategy) Decide(kLine model.KLine) model.Decision {
 			Operation:    "BUY",
 			Timestamp:    time.Now().Unix(),
 			Price:        kLine.Close.Value(),
-			Params:       [3]float64{0, 0, 0},
+			Reason:       "extra charge percent is reached",
+			Params:       model.DecisionParams{"profitPercent": profitPercent.Value(), "extraChargePercent": extraChargePercent.Value()},
 		}
 	}
 
@@ -119,7 +149,8 @@ func (o *OrderBasedStrategy) Decide(kLine model.KLine) model.Decision {
 			Operation:    "SELL",
 			Timestamp:    time.Now().Unix(),
 			Price:        manualOrder.Price,
-			Params:       [3]float64{0, 0, 0},
+			Reason:       "manual SELL order",
+			Params:       model.DecisionParams{"manualPrice": manualOrder.Price, "positionPrice": order.Price},
 		}
 	}
 
@@ -130,7 +161,8 @@ func (o *OrderBasedStrategy) Decide(kLine model.KLine) model.Decision {
 			Operation:    "SELL",
 			Timestamp:    time.Now().Unix(),
 			Price:        kLine.Close.Value(),
-			Params:       [3]float64{0, 0, 0},
+			Reason:       "min profit percent is reached",
+			Params:       model.DecisionParams{"profitPercent": profitPercent.Value(), "minProfitPercent": o.ProfitService.GetMinProfitPercent(order).Value()},
 		}
 	}
 
@@ -141,7 +173,8 @@ func (o *OrderBasedStrategy) Decide(kLine model.KLine) model.Decision {
 			Operation:    "SELL",
 			Timestamp:    time.Now().Unix(),
 			Price:        kLine.Close.Value(),
-			Params:       [3]float64{0, 0, 0},
+			Reason:       "half of min profit percent is reached",
+			Params:       model.DecisionParams{"profitPercent": profitPercent.Value(), "minProfitPercent": o.ProfitService.GetMinProfitPercent(order).Value()},
 		}
 	}
 
@@ -154,7 +187,8 @@ func (o *OrderBasedStrategy) Decide(kLine model.KLine) model.Decision {
 			Operation:    "SELL",
 			Timestamp:    time.Now().Unix(),
 			Price:        sellPrice,
-			Params:       [3]float64{0, 0, 0},
+			Reason:       "price is above position price",
+			Params:       model.DecisionParams{"positionPrice": order.Price, "sellPrice": sellPrice},
 		}
 	}
 
@@ -164,6 +198,7 @@ func (o *OrderBasedStrategy) Decide(kLine model.KLine) model.Decision {
 		Operation:    "HOLD",
 		Timestamp:    time.Now().Unix(),
 		Price:        kLine.Close.Value(),
-		Params:       [3]float64{0, 0, 0},
+		Reason:       "position is waiting for profit",
+		Params:       model.DecisionParams{"profitPercent": profitPercent.Value(), "positionPrice": order.Price},
 	}
 }
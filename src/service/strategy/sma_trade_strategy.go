@@ -3,6 +3,7 @@ package strategy
 import (
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/repository"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/indicator"
 	"math"
 	"time"
 )
@@ -11,6 +12,14 @@ type SmaTradeStrategy struct {
 	ExchangeRepository *repository.ExchangeRepository
 }
 
+func (s *SmaTradeStrategy) GetName() string {
+	return model.SmaTradeStrategyName
+}
+
+func (s *SmaTradeStrategy) GetInput() string {
+	return model.StrategyInputTrade
+}
+
 // Decide todo: Refactoring is required
 func (s *SmaTradeStrategy) Decide(trade model.Trade) model.Decision {
 	sellPeriod := 15
@@ -26,7 +35,8 @@ func (s *SmaTradeStrategy) Decide(trade model.Trade) model.Decision {
 			Operation:    "HOLD",
 			Timestamp:    time.Now().Unix(),
 			Price:        trade.Price,
-			Params:       [3]float64{0, 0, 0},
+			Reason:       "not enough trades",
+			Params:       model.DecisionParams{"trades": float64(len(list))},
 		}
 	}
 
@@ -48,7 +58,8 @@ func (s *SmaTradeStrategy) Decide(trade model.Trade) model.Decision {
 			Operation:    "BUY",
 			Timestamp:    time.Now().Unix(),
 			Price:        trade.Price,
-			Params:       [3]float64{buyVolumeB, sellVolumeB, buySma},
+			Reason:       "buy volume and SMA are growing",
+			Params:       model.DecisionParams{"buyVolume": buyVolumeB, "sellVolume": sellVolumeB, "sma": buySma},
 		}
 	}
 
@@ -61,7 +72,8 @@ func (s *SmaTradeStrategy) Decide(trade model.Trade) model.Decision {
 			Operation:    "SELL",
 			Timestamp:    time.Now().Unix(),
 			Price:        trade.Price,
-			Params:       [3]float64{buyVolumeS, sellVolumeS, sellSma},
+			Reason:       "sell volume is growing and price is below SMA",
+			Params:       model.DecisionParams{"buyVolume": buyVolumeS, "sellVolume": sellVolumeS, "sma": sellSma},
 		}
 	}
 
@@ -71,20 +83,19 @@ func (s *SmaTradeStrategy) Decide(trade model.Trade) model.Decision {
 		Operation:    "HOLD",
 		Timestamp:    time.Now().Unix(),
 		Price:        trade.Price,
-		Params:       [3]float64{buyVolumeS, sellVolumeS, sellSma},
+		Reason:       "no SMA signal",
+		Params:       model.DecisionParams{"buyVolume": buyVolumeS, "sellVolume": sellVolumeS, "sma": sellSma},
 	}
 }
 
 func (s *SmaTradeStrategy) calculateSMA(trades []model.Trade) float64 {
-	var sum float64
-
-	slice := trades
+	sma := indicator.SMA{Period: int64(len(trades))}
 
-	for _, trade := range slice {
-		sum += trade.Price
+	for _, trade := range trades {
+		sma.Update(trade.Price)
 	}
 
-	return sum / float64(len(slice))
+	return sma.Value()
 }
 
 func (s *SmaTradeStrategy) getBuyAndSellVolume(trades []model.Trade) (float64, float64) {
@@ -1,12 +1,14 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"log"
 	"math"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Formatter struct {
@@ -155,7 +157,7 @@ func (m *Formatter) ByBitIntervalToBinanceInterval(interval string) string {
 	return ""
 }
 
-func (m *Formatter) ByBitStatusToBinanceStatus(status string) string {
+func (m *Formatter) ByBitStatusToExchangeStatus(status string) (model.ExchangeOrderStatus, error) {
 	// ByBit:
 	// - New
 	// - PartiallyFilled
@@ -166,32 +168,20 @@ func (m *Formatter) ByBitStatusToBinanceStatus(status string) string {
 	// - Cancelled
 	// - Triggered
 	// - Deactivated
-	// Binance:
-	// - NEW
-	// - PARTIALLY_FILLED
-	// - FILLED
-	// - CANCELED
-	// - PENDING_CANCEL
-	// - REJECTED
-	// - EXPIRED
 	switch status {
-	case "New":
-		return "NEW"
-	case "PartiallyFilled":
-	case "PartiallyFilledCanceled":
-		return "PARTIALLY_FILLED"
+	case "New", "Untriggered", "Triggered":
+		return model.ExchangeOrderStatusNew, nil
+	case "Deactivated", "Canceled", "Cancelled":
+		return model.ExchangeOrderStatusCanceled, nil
+	case "PartiallyFilled", "PartiallyFilledCanceled":
+		return model.ExchangeOrderStatusPartiallyFilled, nil
 	case "Rejected":
-		return "REJECTED"
+		return model.ExchangeOrderStatusRejected, nil
 	case "Filled":
-		return "FILLED"
-	case "Canceled":
-	case "Cancelled":
-		return "CANCELED"
-	default:
-		log.Panicf("Status %s is not supported by ByBitStatusToBinanceStatus", status)
+		return model.ExchangeOrderStatusFilled, nil
 	}
 
-	return ""
+	return "", errors.New(fmt.Sprintf("Status %s is not supported by ByBitStatusToExchangeStatus", status))
 }
 
 func (m *Formatter) ByBitSideToBinanceSide(side string) string {
@@ -224,6 +214,8 @@ func (m *Formatter) ByBitTypeToBinanceType(orderType string) string {
 	switch orderType {
 	case "Limit":
 		return "LIMIT"
+	case "Market":
+		return "MARKET"
 	default:
 		log.Panicf("Order type %s is not supported by ByBitTypeToBinanceType", orderType)
 	}
@@ -231,18 +223,33 @@ func (m *Formatter) ByBitTypeToBinanceType(orderType string) string {
 	return ""
 }
 
-func (m *Formatter) ByBitOrderToBinanceOrder(byBitOrder model.ByBitOrder) model.BinanceOrder {
-	return model.BinanceOrder{
-		OrderId:     byBitOrder.OrderId,
-		Symbol:      strings.ToUpper(byBitOrder.Symbol),
-		Price:       byBitOrder.Price,
-		OrigQty:     byBitOrder.OrigQty,
-		ExecutedQty: byBitOrder.ExecutedQty,
-		Status:      m.ByBitStatusToBinanceStatus(byBitOrder.Status),
-		Type:        m.ByBitTypeToBinanceType(byBitOrder.Type),
-		Side:        m.ByBitSideToBinanceSide(byBitOrder.Side),
-		Timestamp:   byBitOrder.Timestamp,
+func (m *Formatter) ByBitOrderToExchangeOrder(byBitOrder model.ByBitOrder) (model.ExchangeOrder, error) {
+	status, err := m.ByBitStatusToExchangeStatus(byBitOrder.Status)
+	if err != nil {
+		return model.ExchangeOrder{}, err
+	}
+
+	price := byBitOrder.Price
+	avgPrice, _ := strconv.ParseFloat(byBitOrder.AvgPrice, 64)
+	cumExecQuote, _ := strconv.ParseFloat(byBitOrder.CumExecQuote, 64)
+
+	// market orders have no price, average execution price is used
+	if price == 0.00 && avgPrice > 0.00 {
+		price = avgPrice
 	}
+
+	return model.ExchangeOrder{
+		OrderId:             byBitOrder.OrderId,
+		Symbol:              strings.ToUpper(byBitOrder.Symbol),
+		Price:               price,
+		OrigQty:             byBitOrder.OrigQty,
+		ExecutedQty:         byBitOrder.ExecutedQty,
+		CummulativeQuoteQty: cumExecQuote,
+		Status:              status,
+		Type:                m.ByBitTypeToBinanceType(byBitOrder.Type),
+		Side:                m.ByBitSideToBinanceSide(byBitOrder.Side),
+		Timestamp:           byBitOrder.Timestamp,
+	}, nil
 }
 
 func (m *Formatter) ByBitHistoryKlineToBinanceHistoryKline(kLine model.ByBitKLineHistory) model.KLineHistory {
@@ -271,51 +278,198 @@ func (m *Formatter) ByBitTradeToBinanceTrade(trade model.ByBitTrade) model.Trade
 	}
 }
 
-func (m *Formatter) ByBitSymbolStatusToBinanceSymbolStatus(status string) string {
+func (m *Formatter) ByBitTickerToExchangeTicker(ticker model.ByBitTicker) model.ExchangeTicker {
+	return model.ExchangeTicker{
+		Symbol: ticker.Symbol,
+		Price:  ticker.LastPrice,
+	}
+}
+
+func (m *Formatter) ByBitSymbolToExchangeSymbol(symbol model.ByBitExchangeSymbol) model.ExchangeSymbol {
+	return model.ExchangeSymbol{
+		Symbol:             symbol.Symbol,
+		IsTrading:          symbol.Status == "Trading",
+		BaseAsset:          symbol.BaseCoin,
+		QuoteAsset:         symbol.QuoteCoin,
+		BaseAssetPrecision: symbol.LotSizeFilter.BasePrecision,
+		QuotePrecision:     symbol.LotSizeFilter.QuotePrecision,
+		MinPrice:           symbol.PriceFilter.TickSize,
+		TickSize:           symbol.PriceFilter.TickSize,
+		MinQuantity:        symbol.LotSizeFilter.MinOrderQty,
+		MaxQuantity:        symbol.LotSizeFilter.MaxOrderQty,
+		MinNotional:        symbol.LotSizeFilter.MinOrderAmt,
+		MaxNotional:        symbol.LotSizeFilter.MaxOrderAmt,
+	}
+}
+
+// BinanceSymbolToOkxInstId OKX instrument has "-" separator (BTC-USDT), bot symbols have no separator
+func (m *Formatter) BinanceSymbolToOkxInstId(symbol string) string {
+	symbol = strings.ToUpper(symbol)
+	for _, quote := range model.QuoteAssets {
+		if strings.HasSuffix(symbol, quote) && len(symbol) > len(quote) {
+			return fmt.Sprintf("%s-%s", strings.TrimSuffix(symbol, quote), quote)
+		}
+	}
+
+	return symbol
+}
+
+func (m *Formatter) OkxInstIdToBinanceSymbol(instId string) string {
+	return strings.ToUpper(strings.ReplaceAll(instId, "-", ""))
+}
+
+func (m *Formatter) BinanceIntervalToOkxInterval(interval string) string {
+	// OKX:
+	// 1m 3m 5m 15m 30m 1H 2H 4H 6H 12H
+	// 1Dutc 1Wutc 1Mutc (UTC opening time, Binance klines are UTC too)
+	switch interval {
+	case "1m", "3m", "5m", "15m", "30m":
+		return interval
+	case "1h":
+		return "1H"
+	case "2h":
+		return "2H"
+	case "4h":
+		return "4H"
+	case "6h":
+		return "6H"
+	case "12h":
+		return "12H"
+	case "1d":
+		return "1Dutc"
+	case "1w":
+		return "1Wutc"
+	case "1M":
+		return "1Mutc"
+	default:
+		log.Panicf("Interval %s is not supported by BinanceIntervalToOkxInterval", interval)
+	}
+
+	return ""
+}
+
+func (m *Formatter) OkxStatusToExchangeStatus(status string) (model.ExchangeOrderStatus, error) {
 	switch status {
-	case "Trading":
-		return "TRADING"
+	case "live":
+		return model.ExchangeOrderStatusNew, nil
+	case "partially_filled":
+		return model.ExchangeOrderStatusPartiallyFilled, nil
+	case "filled":
+		return model.ExchangeOrderStatusFilled, nil
+	case "canceled", "mmp_canceled":
+		return model.ExchangeOrderStatusCanceled, nil
+	}
+
+	return "", errors.New(fmt.Sprintf("Status %s is not supported by OkxStatusToExchangeStatus", status))
+}
+
+func (m *Formatter) OkxSideToBinanceSide(side string) string {
+	return strings.ToUpper(side)
+}
+
+func (m *Formatter) BinanceSideToOkxSide(side string) string {
+	return strings.ToLower(side)
+}
+
+func (m *Formatter) OkxTypeToBinanceType(orderType string) string {
+	switch orderType {
+	case "limit", "ioc", "fok":
+		return model.OrderTypeLimit
+	case "post_only":
+		return model.OrderTypeLimitMaker
+	case "market":
+		return model.OrderTypeMarket
+	default:
+		log.Panicf("Order type %s is not supported by OkxTypeToBinanceType", orderType)
 	}
 
 	return ""
 }
 
-func (m *Formatter) ByBitTickerToBinanceTicker(ticker model.ByBitTicker) model.WSTickerPrice {
-	return model.WSTickerPrice{
-		Symbol: ticker.Symbol,
-		Price:  ticker.LastPrice,
+func (m *Formatter) OkxOrderToExchangeOrder(okxOrder model.OkxOrder) (model.ExchangeOrder, error) {
+	status, err := m.OkxStatusToExchangeStatus(okxOrder.State)
+	if err != nil {
+		return model.ExchangeOrder{}, err
+	}
+
+	price, _ := strconv.ParseFloat(okxOrder.Price, 64)
+	avgPrice, _ := strconv.ParseFloat(okxOrder.AvgPrice, 64)
+	quantity, _ := strconv.ParseFloat(okxOrder.Size, 64)
+	executedQty, _ := strconv.ParseFloat(okxOrder.AccFillSize, 64)
+
+	// market orders have no price, average execution price is used
+	if price == 0.00 && avgPrice > 0.00 {
+		price = avgPrice
 	}
+
+	// size of market order by quote amount is given in quote currency
+	if okxOrder.TargetCurrency == "quote_ccy" {
+		quantity = executedQty
+	}
+
+	return model.ExchangeOrder{
+		OrderId:             okxOrder.OrderId,
+		Symbol:              m.OkxInstIdToBinanceSymbol(okxOrder.InstId),
+		Price:               price,
+		OrigQty:             quantity,
+		ExecutedQty:         executedQty,
+		CummulativeQuoteQty: executedQty * avgPrice,
+		Status:              status,
+		Type:                m.OkxTypeToBinanceType(okxOrder.OrderType),
+		Side:                m.OkxSideToBinanceSide(okxOrder.Side),
+		Timestamp:           okxOrder.CreatedTime,
+	}, nil
 }
 
-func (m *Formatter) ByBitExchangeSymbolToBinanceExchangeSymbol(symbol model.ByBitExchangeSymbol) model.ExchangeSymbol {
-	filters := make([]model.ExchangeFilter, 0)
+func (m *Formatter) OkxKLineToBinanceHistoryKline(kLine model.OkxKLine) model.KLineHistory {
+	openTime, _ := strconv.ParseInt(kLine.OpenTime, 10, 64)
+
+	return model.KLineHistory{
+		OpenTime:         model.TimestampMilli(openTime),
+		Open:             kLine.Open,
+		High:             kLine.High,
+		Low:              kLine.Low,
+		Close:            kLine.Close,
+		Volume:           kLine.Volume,
+		CloseTime:        model.TimestampMilli(model.TimestampMilli(openTime).GetPeriodToMinute()),
+		QuoteAssetVolume: kLine.VolumeQuote,
+	}
+}
 
-	filters = append(filters, model.ExchangeFilter{
-		FilterType: model.BinanceExchangeFilterTypePrice,
-		MinPrice:   &symbol.PriceFilter.TickSize,
-		MaxPrice:   nil,
-		TickSize:   &symbol.PriceFilter.TickSize,
-	})
+func (m *Formatter) OkxKLineToBinanceKline(kLine model.OkxKLine, symbol string, interval string) model.KLine {
+	history := m.OkxKLineToBinanceHistoryKline(kLine)
+	kline := history.ToKLine(symbol)
+	kline.Interval = interval
+	kline.OpenTime = history.OpenTime
+	kline.Timestamp = model.TimestampMilli(model.TimestampMilli(time.Now().UnixMilli()).GetPeriodToMinute())
 
-	filters = append(filters, model.ExchangeFilter{
-		FilterType:  model.BinanceExchangeFilterTypeLotSize,
-		MinQuantity: &symbol.LotSizeFilter.MinOrderQty,
-		MaxQuantity: &symbol.LotSizeFilter.MaxOrderQty,
-	})
+	return kline
+}
 
-	filters = append(filters, model.ExchangeFilter{
-		FilterType:  model.BinanceExchangeFilterTypeNotional,
-		MinNotional: &symbol.LotSizeFilter.MinOrderAmt,
-		MaxNotional: &symbol.LotSizeFilter.MaxOrderAmt,
-	})
+func (m *Formatter) OkxTradeToBinanceTrade(trade model.OkxTrade) model.Trade {
+	return model.Trade{
+		AggregateTradeId: trade.TradeId,
+		Price:            trade.Price,
+		Symbol:           m.OkxInstIdToBinanceSymbol(trade.InstId),
+		Quantity:         trade.Size,
+		IsBuyerMaker:     trade.Side == model.OkxTradeSideSell,
+		Timestamp:        trade.Timestamp,
+	}
+}
 
+// OkxInstrumentToExchangeSymbol OKX has no min notional filter, trade limit value is kept
+func (m *Formatter) OkxInstrumentToExchangeSymbol(instrument model.OkxInstrument) model.ExchangeSymbol {
 	return model.ExchangeSymbol{
-		Symbol:             symbol.Symbol,
-		Status:             m.ByBitSymbolStatusToBinanceSymbolStatus(symbol.Status),
-		BaseAsset:          symbol.BaseCoin,
-		QuoteAsset:         symbol.QuoteCoin,
-		BaseAssetPrecision: symbol.LotSizeFilter.BasePrecision,
-		QuotePrecision:     symbol.LotSizeFilter.QuotePrecision,
-		Filters:            filters,
+		Symbol:             m.OkxInstIdToBinanceSymbol(instrument.InstId),
+		IsTrading:          instrument.State == "live",
+		BaseAsset:          instrument.BaseCcy,
+		QuoteAsset:         instrument.QuoteCcy,
+		BaseAssetPrecision: instrument.LotSize,
+		QuotePrecision:     instrument.TickSize,
+		MinPrice:           instrument.TickSize,
+		TickSize:           instrument.TickSize,
+		MinQuantity:        instrument.MinSize,
+		MaxQuantity:        instrument.MaxLimitSz,
+		StepSize:           instrument.LotSize,
 	}
 }
@@ -19,10 +19,39 @@ func (v *ProfitOptionsValidator) Validate(ProfitOptions model.ProfitOptions) err
 		model.ProfitOptionUnitMonth,
 	}
 
+	validProfitTypes := []string{
+		"",
+		model.ProfitOptionTypeTakeProfit,
+		model.ProfitOptionTypeStopLoss,
+		model.ProfitOptionTypeTrailingStop,
+	}
+
 	hasInvalidProfitPercent := false
+	stopLossCount := 0
+	trailingStopCount := 0
 
 	invalidUnits := make([]string, 0)
 	for _, option := range ProfitOptions {
+		if !slices.Contains(validProfitTypes, option.OptionType) {
+			return errors.New(fmt.Sprintf("ProfitOptions type: %s is invalid", option.OptionType))
+		}
+
+		if option.IsStopLoss() {
+			stopLossCount++
+			if option.OptionPercent >= 0.00 {
+				return errors.New("ProfitOptions stop loss `optionPercent` should be negative")
+			}
+			continue
+		}
+
+		if option.IsTrailingStop() {
+			trailingStopCount++
+			if option.OptionPercent <= 0.00 || option.ActivationPercent < 0.00 {
+				return errors.New("ProfitOptions trailing stop `optionPercent` should be positive, `activationPercent` can't be negative")
+			}
+			continue
+		}
+
 		if !slices.Contains(validProfitUnits, option.OptionUnit) {
 			invalidUnits = append(invalidUnits, option.OptionUnit)
 		}
@@ -32,6 +61,10 @@ func (v *ProfitOptionsValidator) Validate(ProfitOptions model.ProfitOptions) err
 		}
 	}
 
+	if stopLossCount > 1 || trailingStopCount > 1 {
+		return errors.New("ProfitOptions can contain only one stop loss and one trailing stop option")
+	}
+
 	if len(invalidUnits) > 0 {
 		return errors.New(fmt.Sprintf("ProfitOptions units: %s are invalid", strings.Join(invalidUnits, ", ")))
 	}
@@ -1,17 +1,49 @@
 package validator
 
-import "gitlab.com/open-soft/go-crypto-bot/src/model"
+import (
+	"errors"
+	"fmt"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"slices"
+)
 
 type TradeLimitValidator struct {
 	ProfitOptionsValidator *ProfitOptionsValidator
 }
 
 func (v *TradeLimitValidator) Validate(limit model.TradeLimit) error {
+	if limit.GetBaseAsset()+limit.GetQuoteAsset() != limit.Symbol {
+		return errors.New(fmt.Sprintf(
+			"Symbol %s doesn't match base asset %s and quote asset %s",
+			limit.Symbol,
+			limit.GetBaseAsset(),
+			limit.GetQuoteAsset(),
+		))
+	}
+
 	violation := v.ProfitOptionsValidator.Validate(limit.ProfitOptions)
 
 	if violation != nil {
 		return violation
 	}
 
+	return v.validateMLConfig(limit.MLConfig)
+}
+
+func (v *TradeLimitValidator) validateMLConfig(config model.MLConfig) error {
+	for index, feature := range config.Features {
+		if !slices.Contains(model.MLFeatures, feature) {
+			return errors.New(fmt.Sprintf("ML feature %s is not supported", feature))
+		}
+
+		if slices.Contains(config.Features[:index], feature) {
+			return errors.New(fmt.Sprintf("ML feature %s is duplicated", feature))
+		}
+	}
+
+	if config.Horizon < 0 || config.Horizon > model.MLMaxHorizon {
+		return errors.New(fmt.Sprintf("ML horizon should be between 0 and %d minutes", model.MLMaxHorizon))
+	}
+
 	return nil
 }
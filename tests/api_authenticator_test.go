@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/open-soft/go-crypto-bot/src/controller"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestApiTokenScopes(t *testing.T) {
+	assertion := assert.New(t)
+
+	tokens := controller.ParseApiTokens("admin:secret-admin, read:secret-read,unknown:secret,trading:")
+	assertion.Len(tokens, 2)
+	assertion.True(tokens[0].HasScope(model.ApiScopeTrading))
+	assertion.True(tokens[1].HasScope(model.ApiScopeRead))
+	assertion.False(tokens[1].HasScope(model.ApiScopeTrading))
+}
+
+func TestApiAuthenticatorProtect(t *testing.T) {
+	assertion := assert.New(t)
+
+	authenticator := controller.ApiAuthenticator{
+		Tokens:         controller.ParseApiTokens("trading:secret-trading,read:secret-read"),
+		AllowedOrigins: controller.ParseAllowedOrigins("https://bot.example.com/"),
+	}
+	handler := authenticator.Protect(model.ApiScopeTrading, func(w http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprintf(w, "handled")
+	})
+
+	request := func(token string, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/trade/signal?botUuid=uuid", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+
+		return recorder
+	}
+
+	assertion.Equal(http.StatusUnauthorized, request("", "").Code)
+	assertion.Equal(http.StatusUnauthorized, request("wrong", "").Code)
+	assertion.Equal(http.StatusForbidden, request("secret-read", "").Code)
+
+	response := request("secret-trading", "https://bot.example.com")
+	assertion.Equal(http.StatusOK, response.Code)
+	assertion.Equal("handled", response.Body.String())
+	assertion.Equal("https://bot.example.com", response.Header().Get("Access-Control-Allow-Origin"))
+
+	response = request("secret-trading", "https://evil.example.com")
+	assertion.Equal(http.StatusOK, response.Code)
+	assertion.Equal("", response.Header().Get("Access-Control-Allow-Origin"))
+
+	// preflight request is not authorized
+	preflight := httptest.NewRequest("OPTIONS", "/trade/signal", nil)
+	recorder := httptest.NewRecorder()
+	handler(recorder, preflight)
+	assertion.Equal(http.StatusOK, recorder.Code)
+	assertion.Equal("OK", recorder.Body.String())
+}
+
+func TestApiAuthenticatorDisabled(t *testing.T) {
+	assertion := assert.New(t)
+
+	authenticator := controller.ApiAuthenticator{
+		Tokens:         controller.ParseApiTokens(""),
+		AllowedOrigins: controller.ParseAllowedOrigins(""),
+	}
+	handler := authenticator.Protect(model.ApiScopeAdmin, func(w http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprintf(w, "handled")
+	})
+
+	request := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("PUT", "/bot/update", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("Origin", "https://evil.example.com")
+		req.Header.Set("X-Forwarded-For", "127.0.0.1")
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+
+		return recorder
+	}
+
+	assertion.False(authenticator.IsEnabled())
+	assertion.Equal(http.StatusForbidden, request("192.0.2.1:1234").Code)
+
+	response := request("127.0.0.1:1234")
+	assertion.Equal(http.StatusOK, response.Code)
+	assertion.Equal("", response.Header().Get("Access-Control-Allow-Origin"))
+	assertion.Equal(http.StatusOK, request("[::1]:1234").Code)
+
+	// any origin is allowed explicitly only
+	assertion.Equal([]string{"*"}, controller.ParseAllowedOrigins("*"))
+}
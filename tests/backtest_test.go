@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/backtest"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"testing"
+	"time"
+)
+
+func getBacktestHistory(prices []float64) []model.TradeStat {
+	history := make([]model.TradeStat, 0)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+	for index, price := range prices {
+		open := price
+		if index > 0 {
+			open = prices[index-1]
+		}
+
+		history = append(history, model.TradeStat{
+			Symbol:    "ETHUSDT",
+			Timestamp: model.TimestampMilli(start + int64(index)*60*1000),
+			Open:      open,
+			Close:     price,
+			High:      price * 1.001,
+			Low:       price * 0.999,
+			Volume:    100,
+			BuyQty:    60,
+			SellQty:   40,
+			OrderBookStat: model.OrderBookStat{
+				FirstBuyPrice:  price * 0.9999,
+				FirstSellPrice: price * 1.0001,
+				BuyQtySum:      300,
+				SellQtySum:     100,
+			},
+		})
+	}
+
+	return history
+}
+
+func getBacktestTradeLimit() model.TradeLimit {
+	return model.TradeLimit{
+		Symbol:                       "ETHUSDT",
+		USDTLimit:                    100,
+		MinPrice:                     0.01,
+		MinQuantity:                  0.0001,
+		MinNotional:                  5,
+		IsEnabled:                    true,
+		MinPriceMinutesPeriod:        1,
+		FrameInterval:                "1h",
+		FramePeriod:                  20,
+		BuyPriceHistoryCheckInterval: "1h",
+		BuyPriceHistoryCheckPeriod:   10,
+		ProfitOptions: model.ProfitOptions{
+			model.ProfitOption{
+				Index:           0,
+				OptionValue:     1,
+				OptionUnit:      model.ProfitOptionUnitHour,
+				OptionPercent:   1.00,
+				IsTriggerOption: true,
+			},
+		},
+	}
+}
+
+func TestBacktestReplayBuyAndSell(t *testing.T) {
+	assertion := assert.New(t)
+
+	prices := make([]float64, 0)
+	price := 2000.00
+	for i := 0; i < 60; i++ {
+		if i%2 == 0 {
+			price -= 6
+		} else {
+			price += 2
+		}
+
+		if i >= 30 {
+			price += 6
+		}
+
+		prices = append(prices, price)
+	}
+
+	formatter := utils.Formatter{}
+	backtester := backtest.Backtester{
+		Formatter: &formatter,
+		CurrentBot: &model.Bot{
+			BotUuid: "backtest",
+		},
+	}
+
+	report := backtester.Replay(model.BacktestRequest{
+		TradeLimit: getBacktestTradeLimit(),
+	}, getBacktestHistory(prices))
+
+	assertion.Equal("ETHUSDT", report.Symbol)
+	assertion.Equal(int64(60), report.KLineCount)
+	assertion.Equal(model.BacktestDefaultBalance, report.InitialBalance)
+	assertion.Greater(report.BuyCount, int64(0))
+	assertion.Greater(report.SellCount, int64(0))
+	assertion.Greater(report.ClosedPositions, int64(0))
+	assertion.Greater(report.RealizedProfit, 0.00)
+	assertion.Equal(int64(len(report.Orders)), report.BuyCount+report.ExtraBuyCount+report.SellCount)
+	assertion.Equal(report.FinalEquity, formatter.ToFixed(report.InitialBalance+report.RealizedProfit+report.UnrealizedProfit, 2))
+	assertion.GreaterOrEqual(report.MaxDrawdown, 0.00)
+	assertion.Equal([]string{model.MarketDepthStrategyName}, report.SkippedStrategies)
+
+	for _, order := range report.Orders {
+		assertion.Greater(order.Price, 0.00)
+		assertion.Greater(order.Quantity, 0.00)
+		assertion.Greater(order.Timestamp.Value(), int64(0))
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"testing"
 )
 
@@ -26,7 +27,7 @@ func TestShouldRecoverExchangeOrderIfNotFetched(t *testing.T) {
 	assertion.Nil(err)
 	assertion.Equal("BTCUSDT", exchangeOrder.Symbol)
 	assertion.Equal("111999", exchangeOrder.OrderId)
-	assertion.Equal("NEW", exchangeOrder.Status)
+	assertion.Equal(model.ExchangeOrderStatusNew, exchangeOrder.Status)
 	assertion.Equal("LIMIT", exchangeOrder.Type)
 	assertion.Equal(float64(0.004), exchangeOrder.OrigQty)
 	assertion.Equal(float64(0.00), exchangeOrder.ExecutedQty)
@@ -35,3 +36,16 @@ func TestShouldRecoverExchangeOrderIfNotFetched(t *testing.T) {
 	assertion.Equal(int64(0), exchangeOrder.TransactTime)
 	assertion.Equal(float64(50000.00), exchangeOrder.Price)
 }
+
+func TestByBitHasNoExchangeProtection(t *testing.T) {
+	assertion := assert.New(t)
+
+	// two independent conditional orders can't cancel each other, exchange protection is refused for ByBit
+	var exchangeApi client.ExchangeAPIInterface = &client.ByBit{}
+	_, isProtected := exchangeApi.(client.ExchangeProtectionAPIInterface)
+	assertion.False(isProtected)
+
+	exchangeApi = &client.Binance{}
+	_, isProtected = exchangeApi.(client.ExchangeProtectionAPIInterface)
+	assertion.True(isProtected)
+}
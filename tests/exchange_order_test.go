@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"testing"
+)
+
+func TestExchangeOrderStatusParse(t *testing.T) {
+	assertion := assert.New(t)
+
+	status, err := model.ParseExchangeOrderStatus("PARTIALLY_FILLED")
+	assertion.Nil(err)
+	assertion.Equal(model.ExchangeOrderStatusPartiallyFilled, status)
+
+	_, err = model.ParseExchangeOrderStatus("UNKNOWN")
+	assertion.NotNil(err)
+
+	legacy := model.BinanceOrderLegacy{OrderId: 1, Status: "TRIGGERED"}
+	_, err = legacy.ToModern()
+	assertion.NotNil(err)
+}
+
+func TestExchangeOrderStatusFromAdapters(t *testing.T) {
+	assertion := assert.New(t)
+	formatter := utils.Formatter{}
+
+	status, err := formatter.ByBitStatusToExchangeStatus("PartiallyFilled")
+	assertion.Nil(err)
+	assertion.Equal(model.ExchangeOrderStatusPartiallyFilled, status)
+
+	status, err = formatter.ByBitStatusToExchangeStatus("Cancelled")
+	assertion.Nil(err)
+	assertion.Equal(model.ExchangeOrderStatusCanceled, status)
+
+	_, err = formatter.ByBitStatusToExchangeStatus("Unknown")
+	assertion.NotNil(err)
+
+	status, err = formatter.OkxStatusToExchangeStatus("mmp_canceled")
+	assertion.Nil(err)
+	assertion.Equal(model.ExchangeOrderStatusCanceled, status)
+
+	_, err = formatter.OkxOrderToExchangeOrder(model.OkxOrder{State: "unknown"})
+	assertion.NotNil(err)
+}
+
+func TestExchangeOrderFill(t *testing.T) {
+	assertion := assert.New(t)
+
+	order := model.ExchangeOrder{
+		Price:       100.00,
+		ExecutedQty: 0.5,
+	}
+	fill := order.GetFill()
+	assertion.Equal(100.00, fill.Price)
+	assertion.Equal(0.5, fill.Quantity)
+	assertion.Equal(50.00, fill.QuoteQuantity)
+
+	order.CummulativeQuoteQty = 49.95
+	assertion.Equal(49.95, order.GetFill().QuoteQuantity)
+}
@@ -0,0 +1,216 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/indicator"
+	"testing"
+	"time"
+)
+
+func getIndicatorKLines(symbol string, amount int, step float64) []model.KLine {
+	kLines := make([]model.KLine, 0)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	price := 100.00
+
+	for i := 0; i < amount; i++ {
+		price += step
+		kLines = append(kLines, model.KLine{
+			Symbol:    symbol,
+			Open:      model.Price(price - step),
+			Close:     model.Price(price),
+			High:      model.Price(price + 1),
+			Low:       model.Price(price - 1),
+			Volume:    10,
+			Timestamp: model.TimestampMilli(start + int64(i+1)*60*1000 - 1),
+			OpenTime:  model.TimestampMilli(start + int64(i)*60*1000),
+		})
+	}
+
+	return kLines
+}
+
+func TestIndicatorCalculation(t *testing.T) {
+	assertion := assert.New(t)
+
+	ema := indicator.EMA{Period: 3}
+	for _, value := range []float64{1, 2, 3, 4, 5} {
+		ema.Update(value)
+	}
+	assertion.True(ema.IsReady())
+	assertion.Equal(4.00, ema.Value())
+
+	rsi := indicator.RSI{Period: 2}
+	for _, value := range []float64{1, 2, 1} {
+		rsi.Update(value)
+	}
+	assertion.True(rsi.IsReady())
+	assertion.Equal(50.00, rsi.Value())
+	rsi.Update(2)
+	rsi.Update(3)
+	assertion.Greater(rsi.Value(), 70.00)
+
+	bollinger := indicator.Bollinger{Period: 2, Multiplier: 2}
+	bollinger.Update(1)
+	bollinger.Update(3)
+	assertion.Equal(2.00, bollinger.Middle())
+	assertion.Equal(4.00, bollinger.Upper())
+	assertion.Equal(0.00, bollinger.Lower())
+	assertion.Equal(0.75, bollinger.Percent())
+
+	atr := indicator.ATR{Period: 2}
+	atr.Update(10, 8, 9)
+	atr.Update(12, 9, 11)
+	assertion.True(atr.IsReady())
+	assertion.Equal(2.50, atr.Value())
+
+	vwap := indicator.VWAP{Period: 2}
+	vwap.Update(3, 3, 3, 1)
+	vwap.Update(6, 6, 6, 2)
+	assertion.Equal(5.00, vwap.Value())
+	vwap.Update(9, 9, 9, 0)
+	assertion.Equal(6.00, vwap.Value())
+
+	macd := indicator.MACD{FastPeriod: 12, SlowPeriod: 26, SignalPeriod: 9}
+	for i := 0; i < 33; i++ {
+		macd.Update(10)
+	}
+	assertion.False(macd.IsReady())
+	macd.Update(10)
+	assertion.True(macd.IsReady())
+	assertion.Equal(0.00, macd.Histogram())
+}
+
+func TestIndicatorServiceWarmUpAndUpdate(t *testing.T) {
+	assertion := assert.New(t)
+
+	kLines := getIndicatorKLines("ETHUSDT", 40, 1.00)
+	current := kLines[len(kLines)-1]
+
+	history := new(KLineHistoryMock)
+	history.On("GetCurrentKline", "ETHUSDT").Return(&current)
+	history.On("KLineList", "ETHUSDT", true, int64(indicator.IndicatorHistorySize)).Return(kLines)
+	statReader := new(TradeStatReaderMock)
+	statReader.On("GetTradeStatList", "ETHUSDT", mock.Anything, mock.Anything).Return(make([]model.TradeStat, 0), nil)
+
+	indicatorService := indicator.IndicatorService{
+		ExchangeRepository: history,
+		StatRepository:     statReader,
+	}
+
+	values := indicatorService.GetValues("ETHUSDT")
+	// current kline is not closed and skipped, VWAP needs 60 bars
+	assertion.Len(values, 12)
+	_, hasVwap := values.Get(model.IndicatorVwap)
+	assertion.False(hasVwap)
+	assertion.Equal(100.00, values[model.IndicatorRsi14])
+	assertion.Equal(1.00, values[model.IndicatorEmaCross])
+	assertion.Greater(values[model.IndicatorMacd], 0.00)
+	assertion.Equal(2.00, values[model.IndicatorAtr14])
+
+	ema9, _ := indicatorService.GetValue("ETHUSDT", model.IndicatorEma9)
+
+	// duplicated bar is ignored
+	indicatorService.Update(kLines[len(kLines)-2])
+	value, _ := indicatorService.GetValue("ETHUSDT", model.IndicatorEma9)
+	assertion.Equal(ema9, value)
+
+	indicatorService.Update(current)
+	value, _ = indicatorService.GetValue("ETHUSDT", model.IndicatorEma9)
+	assertion.Greater(value, ema9)
+
+	history.AssertNumberOfCalls(t, "KLineList", 1)
+	statReader.AssertNumberOfCalls(t, "GetTradeStatList", 1)
+}
+
+func TestIndicatorServiceWarmUpDoesNotBlockOtherSymbols(t *testing.T) {
+	assertion := assert.New(t)
+
+	ethKLines := getIndicatorKLines("ETHUSDT", 40, 1.00)
+	started := make(chan bool)
+	release := make(chan bool)
+	history := new(KLineHistoryMock)
+	history.On("GetCurrentKline", mock.Anything).Return(nil)
+	history.On("KLineList", "ETHUSDT", true, int64(indicator.IndicatorHistorySize)).Return(ethKLines)
+	history.On("KLineList", "BTCUSDT", true, int64(indicator.IndicatorHistorySize)).Run(func(args mock.Arguments) {
+		close(started)
+		<-release
+	}).Return(getIndicatorKLines("BTCUSDT", 40, 1.00))
+
+	indicatorService := indicator.IndicatorService{ExchangeRepository: history}
+	assertion.NotEmpty(indicatorService.GetValues("ETHUSDT"))
+
+	btcValues := make(chan model.IndicatorValues)
+	go func() {
+		btcValues <- indicatorService.GetValues("BTCUSDT")
+	}()
+	<-started
+
+	// BTCUSDT history is still loading
+	done := make(chan bool)
+	go func() {
+		indicatorService.Update(ethKLines[len(ethKLines)-1])
+		indicatorService.GetValues("ETHUSDT")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		assertion.Fail("ETHUSDT indicators are blocked by BTCUSDT warm up")
+	}
+
+	close(release)
+	assertion.NotEmpty(<-btcValues)
+	history.AssertNumberOfCalls(t, "KLineList", 2)
+}
+
+func TestTradeFilterIndicatorParameters(t *testing.T) {
+	assertion := assert.New(t)
+
+	kLines := getIndicatorKLines("ETHUSDT", 40, -1.00)
+	history := new(KLineHistoryMock)
+	history.On("GetCurrentKline", "ETHUSDT").Return(nil)
+	history.On("KLineList", "ETHUSDT", true, int64(indicator.IndicatorHistorySize)).Return(kLines)
+
+	filterService := exchange.TradeFilterService{
+		IndicatorService: &indicator.IndicatorService{
+			ExchangeRepository: history,
+		},
+	}
+
+	tradeLimit := model.TradeLimit{
+		TradeFiltersBuy: model.TradeFilters{
+			{
+				Symbol:    "ETHUSDT",
+				Parameter: model.IndicatorRsi14,
+				Condition: model.TradeFilterConditionLt,
+				Value:     "30",
+				Type:      model.TradeFilterConditionTypeAnd,
+				Children:  make(model.TradeFilters, 0),
+			},
+			{
+				Symbol:    "ETHUSDT",
+				Parameter: model.IndicatorEmaCross,
+				Condition: model.TradeFilterConditionEq,
+				Value:     "-1",
+				Type:      model.TradeFilterConditionTypeAnd,
+				Children:  make(model.TradeFilters, 0),
+			},
+		},
+		TradeFiltersSell: model.TradeFilters{
+			{
+				Symbol:    "ETHUSDT",
+				Parameter: model.IndicatorRsi14,
+				Condition: model.TradeFilterConditionGt,
+				Value:     "70",
+				Type:      model.TradeFilterConditionTypeAnd,
+				Children:  make(model.TradeFilters, 0),
+			},
+		},
+	}
+
+	assertion.True(filterService.CanBuy(tradeLimit))
+	assertion.False(filterService.CanSell(tradeLimit))
+}
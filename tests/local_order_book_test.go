@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"testing"
+	"time"
+)
+
+func getOrderBookLevels(levels ...float64) [][2]model.Number {
+	result := make([][2]model.Number, 0)
+	for i := 0; i+1 < len(levels); i += 2 {
+		result = append(result, [2]model.Number{{Value: levels[i]}, {Value: levels[i+1]}})
+	}
+
+	return result
+}
+
+func TestLocalOrderBookAppliesDiff(t *testing.T) {
+	assertion := assert.New(t)
+
+	book := model.NewLocalOrderBook("BTCUSDT")
+	assertion.NotNil(book.Apply(model.OrderBookDiff{Symbol: "BTCUSDT", FirstUpdateId: 1, LastUpdateId: 2}))
+
+	book.ApplySnapshot(100, getOrderBookLevels(99.0, 1.0, 98.0, 2.0), getOrderBookLevels(101.0, 1.0, 102.0, 2.0))
+
+	// stale update is ignored
+	assertion.Nil(book.Apply(model.OrderBookDiff{FirstUpdateId: 90, LastUpdateId: 100, Bids: getOrderBookLevels(99.0, 0.0)}))
+	// first update after snapshot overlaps last update id
+	assertion.Nil(book.Apply(model.OrderBookDiff{
+		FirstUpdateId: 95,
+		LastUpdateId:  105,
+		Bids:          getOrderBookLevels(99.0, 0.0, 99.5, 3.0),
+		Asks:          getOrderBookLevels(100.5, 4.0),
+	}))
+	assertion.Nil(book.Apply(model.OrderBookDiff{FirstUpdateId: 106, LastUpdateId: 106, Asks: getOrderBookLevels(102.0, 0.0)}))
+	assertion.Equal(int64(106), book.LastUpdateId)
+
+	depth := book.ToOrderBookModel(10, 0)
+	assertion.Equal(getOrderBookLevels(99.5, 3.0, 98.0, 2.0), depth.Bids)
+	assertion.Equal(getOrderBookLevels(100.5, 4.0, 101.0, 1.0), depth.Asks)
+	assertion.Len(book.ToOrderBookModel(1, 0).Bids, 1)
+
+	err := book.Apply(model.OrderBookDiff{FirstUpdateId: 108, LastUpdateId: 110})
+	assertion.NotNil(err)
+	assertion.Equal("Order book sequence gap: expected 107, got 108..110", err.Error())
+	assertion.False(book.Synced)
+}
+
+func TestOrderBookKeeperResyncsFromSnapshot(t *testing.T) {
+	assertion := assert.New(t)
+
+	binance := new(ExchangePriceAPIMock)
+	binance.On("GetDepth", "ETHUSDT", int64(exchange.OrderBookSnapshotLimit)).Return(&model.OrderBook{
+		LastUpdateId: 200,
+		Bids:         getOrderBookLevels(1999.0, 1.0),
+		Asks:         getOrderBookLevels(2001.0, 1.0),
+	})
+	keeper := exchange.OrderBookKeeper{Binance: binance}
+
+	// book is not synced, the update is buffered until snapshot is loaded
+	_, synced := keeper.Apply(model.OrderBookDiff{
+		Symbol:        "ETHUSDT",
+		FirstUpdateId: 199,
+		LastUpdateId:  201,
+		Bids:          getOrderBookLevels(1999.5, 2.0),
+	})
+	assertion.False(synced)
+
+	var depth model.OrderBookModel
+	assertion.Eventually(func() bool {
+		depth, synced = keeper.Apply(model.OrderBookDiff{
+			Symbol:        "ETHUSDT",
+			FirstUpdateId: 202,
+			LastUpdateId:  202,
+			Asks:          getOrderBookLevels(2000.5, 3.0),
+		})
+
+		return synced
+	}, time.Second, time.Millisecond*10)
+
+	assertion.Equal(getOrderBookLevels(1999.5, 2.0, 1999.0, 1.0), depth.Bids)
+	assertion.Equal(getOrderBookLevels(2000.5, 3.0, 2001.0, 1.0), depth.Asks)
+	top := depth.Top(1)
+	assertion.Len(top.Asks, 1)
+	assertion.Len(depth.Asks, 2)
+
+	// gap makes book out of sync
+	_, synced = keeper.Apply(model.OrderBookDiff{Symbol: "ETHUSDT", FirstUpdateId: 210, LastUpdateId: 211})
+	assertion.False(synced)
+}
+
+func TestOrderBookKeeperTrimsStreamDepthSnapshot(t *testing.T) {
+	assertion := assert.New(t)
+
+	byBit := new(ExchangePriceAPIMock)
+	// REST snapshot is deeper than the stream and its update id is from another sequence
+	byBit.On("GetDepth", "BTCUSDT", int64(2)).Return(&model.OrderBook{
+		LastUpdateId: 900000,
+		Bids:         getOrderBookLevels(99.0, 1.0, 98.0, 1.0, 97.0, 1.0),
+		Asks:         getOrderBookLevels(101.0, 1.0, 102.0, 1.0, 103.0, 1.0),
+	})
+	keeper := exchange.OrderBookKeeper{Binance: byBit, StreamDepth: 2}
+
+	_, synced := keeper.Apply(model.OrderBookDiff{Symbol: "BTCUSDT", FirstUpdateId: 15, LastUpdateId: 15})
+	assertion.False(synced)
+
+	var depth model.OrderBookModel
+	assertion.Eventually(func() bool {
+		depth, synced = keeper.Apply(model.OrderBookDiff{
+			Symbol:        "BTCUSDT",
+			FirstUpdateId: 16,
+			LastUpdateId:  16,
+			Bids:          getOrderBookLevels(99.5, 2.0),
+		})
+
+		return synced
+	}, time.Second, time.Millisecond*10)
+
+	assertion.Equal(getOrderBookLevels(99.5, 2.0, 99.0, 1.0), depth.Bids)
+	assertion.Equal(getOrderBookLevels(101.0, 1.0, 102.0, 1.0), depth.Asks)
+}
+
+func TestByBitOrderBookDiff(t *testing.T) {
+	assertion := assert.New(t)
+
+	event := model.ByBitWsOrderBookEvent{Type: "delta", Data: model.ByBitWsOrderBook{Symbol: "BTCUSDT", U: 15}}
+	diff := event.ToOrderBookDiff()
+	assertion.False(diff.IsSnapshot)
+	assertion.Equal(int64(15), diff.FirstUpdateId)
+	assertion.Equal(int64(15), diff.LastUpdateId)
+
+	event.Data.U = 1
+	assertion.True(event.ToOrderBookDiff().IsSnapshot)
+}
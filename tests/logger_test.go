@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/open-soft/go-crypto-bot/src/logger"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLoggerParseLevel(t *testing.T) {
+	assertion := assert.New(t)
+
+	assertion.Equal(slog.LevelDebug, logger.ParseLevel("debug"))
+	assertion.Equal(slog.LevelWarn, logger.ParseLevel("WARN"))
+	assertion.Equal(slog.LevelError, logger.ParseLevel("error"))
+	assertion.Equal(slog.LevelInfo, logger.ParseLevel(""))
+	assertion.Equal(slog.LevelInfo, logger.ParseLevel("verbose"))
+}
+
+func TestLoggerJsonOutput(t *testing.T) {
+	assertion := assert.New(t)
+
+	buffer := bytes.Buffer{}
+	log := logger.New(&buffer, "info", "").With(
+		logger.KeySymbol, "BTCUSDT",
+		logger.KeyOrderId, int64(15),
+		logger.KeyCorrelationId, "a1b2c3",
+	)
+
+	log.Debug("Hidden")
+	log.Error("SELL", logger.Error(errors.New("insufficient balance")))
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assertion.Len(lines, 1)
+
+	var record map[string]any
+	assertion.Nil(json.Unmarshal([]byte(lines[0]), &record))
+	assertion.Equal("ERROR", record["level"])
+	assertion.Equal("SELL", record["msg"])
+	assertion.Equal("BTCUSDT", record[logger.KeySymbol])
+	assertion.Equal(15.00, record[logger.KeyOrderId])
+	assertion.Equal("a1b2c3", record[logger.KeyCorrelationId])
+	assertion.Equal("insufficient balance", record[logger.KeyError])
+
+	buffer.Reset()
+	logger.New(&buffer, "debug", logger.FormatText).Debug("Order status", logger.KeyExchangeOrderId, "100")
+	assertion.Contains(buffer.String(), "level=DEBUG")
+	assertion.Contains(buffer.String(), "exchange_order_id=100")
+}
+
+func TestLoggerCorrelationId(t *testing.T) {
+	assertion := assert.New(t)
+
+	first := logger.NewCorrelationId()
+	assertion.Len(first, 16)
+	assertion.NotEqual(first, logger.NewCorrelationId())
+
+	explanation := model.DecisionExplanation{
+		Strategies: []model.StrategyExplanation{
+			{StrategyName: model.BaseKlineStrategyName, Operation: "BUY"},
+			{StrategyName: model.MarketDepthStrategyName, Operation: "SELL"},
+			{StrategyName: model.SmaTradeStrategyName, Operation: "BUY"},
+		},
+		CorrelationId: first,
+	}
+	assertion.Equal([]string{model.BaseKlineStrategyName, model.SmaTradeStrategyName}, explanation.GetStrategyNames("BUY"))
+	assertion.Equal([]string{}, explanation.GetStrategyNames("HOLD"))
+
+	// correlation id is stored with order explanation and restored on resume
+	encoded, _ := json.Marshal(explanation)
+	var decoded model.DecisionExplanation
+	assertion.Nil(json.Unmarshal(encoded, &decoded))
+	assertion.Equal(first, decoded.CorrelationId)
+}
+
+func TestLoggerBindOperation(t *testing.T) {
+	assertion := assert.New(t)
+
+	buffer := bytes.Buffer{}
+	opLog := logger.New(&buffer, "info", "").With(logger.KeySymbol, "ETHUSDT", logger.KeyCorrelationId, "d4e5f6")
+
+	unbind := logger.BindOperation("ETHUSDT", opLog)
+	// exchange client logs the error of the order operation with its correlation id
+	logger.ForSymbol("binance", "ETHUSDT").Error("Order is not placed", logger.Error(errors.New("insufficient balance")))
+	assertion.NotSame(opLog, logger.ForSymbol("binance", "BTCUSDT"))
+
+	var record map[string]any
+	assertion.Nil(json.Unmarshal(buffer.Bytes(), &record))
+	assertion.Equal("d4e5f6", record[logger.KeyCorrelationId])
+	assertion.Equal("insufficient balance", record[logger.KeyError])
+
+	// nested operation of the same symbol doesn't unbind the new one
+	newLog := opLog.With(logger.KeyOperation, "sell")
+	unbindNew := logger.BindOperation("ETHUSDT", newLog)
+	unbind()
+	assertion.Same(newLog, logger.ForSymbol("binance", "ETHUSDT"))
+
+	unbindNew()
+	assertion.NotSame(newLog, logger.ForSymbol("binance", "ETHUSDT"))
+}
@@ -77,7 +77,9 @@ func TestProcessSwap(t *testing.T) {
 		Buy:  0.00,
 	}, nil)
 	order := model.Order{
-		Symbol: "BTCUSDT",
+		Symbol:     "BTCUSDT",
+		BaseAsset:  "BTC",
+		QuoteAsset: "USDT",
 	}
 	orderRepository.On("GetOpenedOrderCached", "BTCUSDT", "BUY").Return(&order)
 	orderExecutor.On("ProcessSwap", order).Return(true)
@@ -120,7 +122,9 @@ func TestHoldDecision(t *testing.T) {
 		Buy:  0.00,
 	}, nil)
 	order := model.Order{
-		Symbol: "BTCUSDT",
+		Symbol:     "BTCUSDT",
+		BaseAsset:  "BTC",
+		QuoteAsset: "USDT",
 	}
 	orderRepository.On("GetOpenedOrderCached", "BTCUSDT", "BUY").Return(&order)
 	orderExecutor.On("ProcessSwap", order).Return(false)
@@ -163,7 +167,7 @@ func TestSellOperation(t *testing.T) {
 	tradeLimit := model.TradeLimit{
 		Symbol: "BTCUSDT",
 	}
-	orderRepository.On("GetBinanceOrder", "BTCUSDT", "SELL").Return(nil)
+	orderRepository.On("GetExchangeOrder", "BTCUSDT", "SELL").Return(nil)
 	tradeFilterService.On("CanSell", tradeLimit).Return(true)
 	strategyFacade.On("Decide", "BTCUSDT").Return(model.FacadeResponse{
 		Hold: 40.00,
@@ -171,9 +175,11 @@ func TestSellOperation(t *testing.T) {
 		Buy:  40.00,
 	}, nil)
 	order := model.Order{
-		Symbol:   "BTCUSDT",
-		Price:    50000.00,
-		Quantity: 1.00,
+		Symbol:     "BTCUSDT",
+		BaseAsset:  "BTC",
+		QuoteAsset: "USDT",
+		Price:      50000.00,
+		Quantity:   1.00,
 	}
 	orderRepository.On("GetOpenedOrderCached", "BTCUSDT", "BUY").Return(&order)
 	orderExecutor.On("ProcessSwap", order).Return(false)
@@ -184,7 +190,7 @@ func TestSellOperation(t *testing.T) {
 	}
 	exchangeRepository.On("GetCurrentKline", "BTCUSDT").Return(&kline)
 	orderRepository.On("GetManualOrder", "BTCUSDT").Return(nil)
-	priceCalculator.On("GetDepth", "BTCUSDT", int64(20)).Return(model.OrderBookModel{
+	priceCalculator.On("GetDepth", "BTCUSDT", int64(exchange.LocalOrderBookDepth)).Return(model.OrderBookModel{
 		Asks: [][2]model.Number{
 			{
 				{
@@ -222,7 +228,7 @@ func TestSellOperation(t *testing.T) {
 	})
 	priceCalculator.On("CalculateSell", tradeLimit, order).Return(65005.00, nil)
 	orderExecutor.On("CalculateSellQuantity", order).Return(1.00)
-	orderExecutor.On("Sell", tradeLimit, order, 65005.00, 1.00, false).Return(nil)
+	orderExecutor.On("Sell", tradeLimit, order, 65005.00, 1.00, model.OrderCloseReasonTakeProfit).Return(nil)
 
 	maker.Make("BTCUSDT")
 	orderExecutor.AssertNumberOfCalls(t, "Sell", 1)
@@ -264,7 +270,7 @@ func TestBuyOperation(t *testing.T) {
 		Sell: 40.00,
 		Buy:  50.00,
 	}, nil)
-	orderRepository.On("GetBinanceOrder", "BTCUSDT", "BUY").Return(nil)
+	orderRepository.On("GetExchangeOrder", "BTCUSDT", "BUY").Return(nil)
 	orderRepository.On("GetOpenedOrderCached", "BTCUSDT", "BUY").Return(nil)
 	tradeLimit := model.TradeLimit{
 		Symbol:      "BTCUSDT",
@@ -284,7 +290,7 @@ func TestBuyOperation(t *testing.T) {
 	exchangeRepository.On("GetCurrentKline", "BTCUSDT").Return(&kline)
 	orderExecutor.On("CheckMinBalance", tradeLimit, kline).Return(nil)
 	orderRepository.On("GetManualOrder", "BTCUSDT").Return(nil)
-	priceCalculator.On("GetDepth", "BTCUSDT", int64(20)).Return(model.OrderBookModel{
+	priceCalculator.On("GetDepth", "BTCUSDT", int64(exchange.LocalOrderBookDepth)).Return(model.OrderBookModel{
 		Bids: [][2]model.Number{
 			{
 				{
@@ -370,6 +376,8 @@ func TestExtraBuyOperation(t *testing.T) {
 	}, nil)
 	order := model.Order{
 		Symbol:           "BTCUSDT",
+		BaseAsset:        "BTC",
+		QuoteAsset:       "USDT",
 		Price:            50000.00,
 		ExecutedQuantity: 1.00,
 		UsedExtraBudget:  0.00,
@@ -381,7 +389,7 @@ func TestExtraBuyOperation(t *testing.T) {
 			},
 		},
 	}
-	orderRepository.On("GetBinanceOrder", "BTCUSDT", "BUY").Return(nil)
+	orderRepository.On("GetExchangeOrder", "BTCUSDT", "BUY").Return(nil)
 	orderRepository.On("GetOpenedOrderCached", "BTCUSDT", "BUY").Return(&order)
 	orderExecutor.On("ProcessSwap", order).Return(false)
 	tradeLimit := model.TradeLimit{
@@ -402,7 +410,7 @@ func TestExtraBuyOperation(t *testing.T) {
 	exchangeRepository.On("GetCurrentKline", "BTCUSDT").Return(&kline)
 	orderExecutor.On("CheckMinBalance", tradeLimit, kline).Return(nil)
 	orderRepository.On("GetManualOrder", "BTCUSDT").Return(nil)
-	priceCalculator.On("GetDepth", "BTCUSDT", int64(20)).Return(model.OrderBookModel{
+	priceCalculator.On("GetDepth", "BTCUSDT", int64(exchange.LocalOrderBookDepth)).Return(model.OrderBookModel{
 		Bids: [][2]model.Number{
 			{
 				{
@@ -453,3 +461,33 @@ func TestExtraBuyOperation(t *testing.T) {
 	orderRepository.AssertNumberOfCalls(t, "GetOpenedOrderCached", 1)
 	orderExecutor.AssertNumberOfCalls(t, "ProcessSwap", 1)
 }
+
+func TestUpdateLimitsAssetsFromExchange(t *testing.T) {
+	exchangeRepository := new(BaseTradeStorageMock)
+	binance := new(ExchangePriceAPIMock)
+
+	maker := exchange.MakerService{
+		ExchangeRepository: exchangeRepository,
+		Binance:            binance,
+		Formatter:          &utils.Formatter{},
+		CurrentBot:         &model.Bot{Id: 1},
+	}
+
+	exchangeRepository.On("GetTradeLimits").Return([]model.TradeLimit{{Symbol: "XUSDTEUR", MinNotional: 5.00}})
+	binance.On("GetExchangeData", []string{}).Return(&model.ExchangeInfo{
+		Symbols: []model.ExchangeSymbol{
+			{Symbol: "XUSDTEUR", BaseAsset: "XUSDT", QuoteAsset: "EUR", MinPrice: 0.0001, MinQuantity: 0.1},
+		},
+	}, nil)
+	exchangeRepository.On("UpdateTradeLimit", model.TradeLimit{
+		Symbol:      "XUSDTEUR",
+		BaseAsset:   "XUSDT",
+		QuoteAsset:  "EUR",
+		MinPrice:    0.0001,
+		MinQuantity: 0.1,
+		MinNotional: 5.00,
+	}).Return(nil)
+
+	maker.UpdateLimits()
+	exchangeRepository.AssertNumberOfCalls(t, "UpdateTradeLimit", 1)
+}
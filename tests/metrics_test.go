@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/metrics"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsExposition(t *testing.T) {
+	assertion := assert.New(t)
+
+	registry := &metrics.Registry{}
+	counter := registry.Counter("test_orders_total", "Orders", "symbol")
+	gauge := registry.Gauge("test_pnl", "PnL", "symbol")
+	histogram := registry.Histogram("test_latency_seconds", "Latency", []float64{1, 5}, "symbol")
+
+	collected := 0
+	registry.OnCollect(func() {
+		collected++
+		gauge.Set(-2.5, "ETHUSDT")
+	})
+
+	counter.Inc("BTCUSDT")
+	counter.Add(2, "BTCUSDT")
+	counter.Add(-1, "BTCUSDT")
+	counter.Inc("say \"hi\"\n")
+	histogram.Observe(0.5, "BTCUSDT")
+	histogram.Observe(3, "BTCUSDT")
+	histogram.Observe(10, "BTCUSDT")
+
+	buffer := bytes.Buffer{}
+	registry.Write(&buffer)
+	output := buffer.String()
+
+	assertion.Equal(1, collected)
+	assertion.Contains(output, "# HELP test_orders_total Orders\n# TYPE test_orders_total counter\n")
+	assertion.Contains(output, "test_orders_total{symbol=\"BTCUSDT\"} 3\n")
+	assertion.Contains(output, "test_orders_total{symbol=\"say \\\"hi\\\"\\n\"} 1\n")
+	assertion.Contains(output, "# TYPE test_pnl gauge\ntest_pnl{symbol=\"ETHUSDT\"} -2.5\n")
+	assertion.Contains(output, "test_latency_seconds_bucket{symbol=\"BTCUSDT\",le=\"1\"} 1\n")
+	assertion.Contains(output, "test_latency_seconds_bucket{symbol=\"BTCUSDT\",le=\"5\"} 2\n")
+	assertion.Contains(output, "test_latency_seconds_bucket{symbol=\"BTCUSDT\",le=\"+Inf\"} 3\n")
+	assertion.Contains(output, "test_latency_seconds_sum{symbol=\"BTCUSDT\"} 13.5\n")
+	assertion.Contains(output, "test_latency_seconds_count{symbol=\"BTCUSDT\"} 3\n")
+
+	recorder := httptest.NewRecorder()
+	metrics.Handler(registry).ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	assertion.Equal(http.StatusOK, recorder.Code)
+	assertion.True(strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/plain"))
+	assertion.Equal(2, collected)
+
+	assertion.Panics(func() {
+		registry.Counter("test_orders_total", "Duplicate")
+	})
+}
+
+func TestMetricsApiErrors(t *testing.T) {
+	assertion := assert.New(t)
+
+	responses := map[string]string{
+		"/ok":     `{"retCode":0,"result":{}}`,
+		"/bybit":  `{"retCode":170131,"retMsg":"Insufficient balance."}`,
+		"/okx":    `{"code":"51008","msg":"Order failed"}`,
+		"/status": `<html>Bad Gateway</html>`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/status" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+		_, _ = w.Write([]byte(responses[req.URL.Path]))
+	}))
+	defer server.Close()
+
+	httpClient := client.HttpClient{Exchange: "metrics_test"}
+	for path := range responses {
+		_, _ = httpClient.Get(server.URL+path, map[string]string{})
+	}
+
+	buffer := bytes.Buffer{}
+	metrics.Default.Write(&buffer)
+	output := buffer.String()
+
+	assertion.Contains(output, "bot_exchange_api_errors_total{exchange=\"metrics_test\",code=\"170131\"} 1\n")
+	assertion.Contains(output, "bot_exchange_api_errors_total{exchange=\"metrics_test\",code=\"51008\"} 1\n")
+	assertion.Contains(output, "bot_exchange_api_errors_total{exchange=\"metrics_test\",code=\"http_502\"} 1\n")
+	assertion.NotContains(output, "bot_exchange_api_errors_total{exchange=\"metrics_test\",code=\"0\"}")
+}
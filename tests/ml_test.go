@@ -0,0 +1,328 @@
+package tests
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/ml"
+	"math"
+	"testing"
+)
+
+func getLinearDataset() ([][]float64, []float64) {
+	x := make([][]float64, 0)
+	y := make([]float64, 0)
+	for i := 0; i < 50; i++ {
+		a := float64(i)
+		b := math.Sin(float64(i)) * 10
+		x = append(x, []float64{a, b})
+		y = append(y, 3*a-2*b+5)
+	}
+
+	return x, y
+}
+
+func TestLinearRegressionFit(t *testing.T) {
+	assertion := assert.New(t)
+	x, y := getLinearDataset()
+
+	regression := ml.LinearRegression{}
+	assertion.Nil(regression.Fit(x, y))
+	assertion.Equal(ml.ModelTypeLinear, regression.GetType())
+	assertion.InDelta(3*100-2*4+5, regression.Predict([]float64{100, 4}), 0.000001)
+
+	metrics := ml.Evaluate(&regression, x, y)
+	assertion.InDelta(0.00, metrics.RMSE, 0.000001)
+	assertion.InDelta(1.00, metrics.R2, 0.000001)
+
+	ridge := ml.LinearRegression{Alpha: 10.00}
+	assertion.Nil(ridge.Fit(x, y))
+	assertion.Equal(ml.ModelTypeRidge, ridge.GetType())
+	assertion.Greater(ml.Evaluate(&ridge, x, y).RMSE, 0.00)
+
+	// collinear features can be solved by ridge only
+	collinear := [][]float64{{1, 2}, {2, 4}, {3, 6}, {4, 8}}
+	labels := []float64{1, 2, 3, 4}
+	assertion.NotNil((&ml.LinearRegression{}).Fit(collinear, labels))
+	assertion.Nil((&ml.LinearRegression{Alpha: 0.1}).Fit(collinear, labels))
+}
+
+func TestGradientBoostingFit(t *testing.T) {
+	assertion := assert.New(t)
+
+	x := make([][]float64, 0)
+	y := make([]float64, 0)
+	for i := 0; i < 100; i++ {
+		x = append(x, []float64{float64(i)})
+		if i < 50 {
+			y = append(y, 10.00)
+		} else {
+			y = append(y, 20.00)
+		}
+	}
+
+	boosting := ml.GradientBoosting{Estimators: 50, LearningRate: 0.3, MaxDepth: 2, MinSamplesLeaf: 2}
+	assertion.Nil(boosting.Fit(x, y))
+	assertion.Len(boosting.Trees, 50)
+	assertion.InDelta(10.00, boosting.Predict([]float64{10}), 0.01)
+	assertion.InDelta(20.00, boosting.Predict([]float64{90}), 0.01)
+
+	assertion.NotNil((&ml.GradientBoosting{}).Fit(x, y))
+}
+
+func TestModelEncodeDecode(t *testing.T) {
+	assertion := assert.New(t)
+	x, y := getLinearDataset()
+
+	for _, regressor := range []ml.Regressor{
+		&ml.LinearRegression{},
+		&ml.GradientBoosting{Estimators: 10, LearningRate: 0.1, MaxDepth: 3},
+	} {
+		assertion.Nil(regressor.Fit(x, y))
+		encoded, err := ml.EncodeModel(regressor, ml.SerializedModel{Symbol: "BTCUSDT", Features: []string{"a", "b"}})
+		assertion.Nil(err)
+
+		decoded, meta, err := ml.DecodeModel(encoded)
+		assertion.Nil(err)
+		assertion.Equal(ml.ModelFormatVersion, meta.Version)
+		assertion.Equal(regressor.GetType(), meta.Type)
+		assertion.Equal("BTCUSDT", meta.Symbol)
+		assertion.Equal(regressor.Predict([]float64{7, 1}), decoded.Predict([]float64{7, 1}))
+	}
+
+	_, _, err := ml.DecodeModel([]byte(`{"version":0,"type":"linear","payload":{}}`))
+	assertion.NotNil(err)
+	_, _, err = ml.DecodeModel([]byte(`{"version":1,"type":"unknown","payload":{}}`))
+	assertion.NotNil(err)
+}
+
+func getMLTradeStats(symbol string, length int, gapIndex int) []model.TradeStat {
+	stats := make([]model.TradeStat, 0)
+	minute := int64(0)
+	for i := 0; i < length; i++ {
+		if i == gapIndex {
+			minute++
+		}
+		stats = append(stats, model.TradeStat{
+			Symbol:     symbol,
+			Timestamp:  model.TimestampMilli(1699999980000 + minute*60000),
+			Close:      float64(100 + i),
+			BuyVolume:  float64(i),
+			SellVolume: 0.00,
+			OrderBookStat: model.OrderBookStat{
+				BuyLength:   1,
+				SellLength:  1,
+				FirstBuyQty: float64(i),
+			},
+		})
+		minute++
+	}
+
+	return stats
+}
+
+func TestDatasetBuilder(t *testing.T) {
+	assertion := assert.New(t)
+
+	storage := new(TradeStatReaderMock)
+	storage.On("GetTradeStatList", "ETHUSDT", mock.Anything, mock.Anything).Return(getMLTradeStats("ETHUSDT", 40, 20), nil)
+	storage.On("GetTradeStatList", "BTCUSDT", mock.Anything, mock.Anything).Return(getMLTradeStats("BTCUSDT", 40, 20), nil)
+	storage.On("GetTradeStatList", "SOLUSDT", mock.Anything, mock.Anything).Return(getMLTradeStats("SOLUSDT", 40, 20)[:10], nil)
+	builder := ml.DataSetBuilder{StatRepository: storage}
+
+	dataset, err := builder.PrepareDataset("ETHUSDT", model.MLConfig{
+		Features: []string{
+			model.MLFeatureReturn1m,
+			model.MLFeatureTradeFlowImbalance,
+			model.MLFeatureSecondaryPrice,
+			model.MLFeatureOrderBookBuyFirstQty,
+		},
+		Horizon: 2,
+	})
+	assertion.Nil(err)
+	assertion.Equal(int64(2), dataset.Horizon)
+	// rows without 1 minute history, with history gap or with label gap are skipped
+	assertion.Len(dataset.X, 34)
+	assertion.Len(dataset.X[0], len(dataset.Features))
+	assertion.InDelta(101.00/100.00-1, dataset.X[0][0], 0.000001)
+	assertion.Equal(1.00, dataset.X[0][1])
+	assertion.Equal(101.00, dataset.X[0][2])
+	assertion.Equal(1.00, dataset.X[0][3])
+	assertion.Equal(103.00, dataset.Y[0])
+	assertion.Equal(int64(1699999980000+60000), dataset.GetFrom())
+
+	dataset, err = builder.PrepareDataset("SOLUSDT", model.MLConfig{})
+	assertion.NotNil(err)
+	assertion.Equal(model.DefaultMLFeatures, dataset.Features)
+}
+
+func TestExtractFeatures(t *testing.T) {
+	assertion := assert.New(t)
+
+	history := make([]ml.FeatureSource, 0)
+	for _, stat := range getMLTradeStats("ETHUSDT", 16, -1) {
+		history = append(history, ml.FeatureSource{Stat: stat})
+	}
+	history[15].Stat.SellVolume = 45.00
+	history[15].Stat.MaxPSC = 1.5
+	history[15].Interpolation = &model.Interpolation{BtcInterpolationUsdt: 138.00}
+	history[15].Capitalization = &model.MCObject{Capitalization: 1000000.00}
+
+	features, err := ml.ExtractFeatures([]string{
+		model.MLFeatureReturn5m,
+		model.MLFeatureReturn15m,
+		model.MLFeatureTradeFlowImbalance,
+		model.MLFeatureMaxPriceChangeSpeed,
+		model.MLFeatureBtcInterpolation,
+		model.MLFeatureBtcCapitalization,
+	}, history)
+	assertion.Nil(err)
+	assertion.InDelta(115.00/110.00-1, features[0], 0.000001)
+	assertion.InDelta(115.00/100.00-1, features[1], 0.000001)
+	assertion.InDelta(-0.5, features[2], 0.000001)
+	assertion.Equal(1.5, features[3])
+	assertion.InDelta(0.2, features[4], 0.000001)
+	assertion.Equal(1000000.00, features[5])
+
+	_, err = ml.ExtractFeatures([]string{model.MLFeatureReturn15m}, history[1:])
+	assertion.NotNil(err)
+	_, err = ml.ExtractFeatures([]string{model.MLFeatureEthInterpolation}, history)
+	assertion.NotNil(err)
+	_, err = ml.ExtractFeatures([]string{"unknown"}, history)
+	assertion.NotNil(err)
+}
+
+func getTimeSeriesDataset(length int) ml.Dataset {
+	dataset := ml.Dataset{Symbol: "ETHUSDT", Features: []string{"a", "b"}}
+	x, y := getLinearDataset()
+	for i := 0; i < length; i++ {
+		dataset.Timestamps = append(dataset.Timestamps, int64(i*60000))
+		dataset.X = append(dataset.X, x[i%len(x)])
+		dataset.Y = append(dataset.Y, y[i%len(y)])
+	}
+
+	return dataset
+}
+
+func TestWalkForward(t *testing.T) {
+	assertion := assert.New(t)
+	dataset := getTimeSeriesDataset(50)
+
+	result, err := ml.WalkForward(func() ml.Regressor {
+		return &ml.LinearRegression{}
+	}, dataset, 4)
+	assertion.Nil(err)
+	assertion.Equal(4, result.Folds)
+	assertion.Equal(40, result.LastFoldStart)
+	assertion.InDelta(0.00, result.Metrics.RMSE, 0.000001)
+	assertion.NotNil(result.LastFoldModel)
+
+	_, err = ml.WalkForward(func() ml.Regressor {
+		return &ml.LinearRegression{}
+	}, getTimeSeriesDataset(5), 4)
+	assertion.NotNil(err)
+}
+
+func TestValidateChallenger(t *testing.T) {
+	assertion := assert.New(t)
+	dataset := getTimeSeriesDataset(50)
+	result, _ := ml.WalkForward(func() ml.Regressor {
+		return &ml.LinearRegression{}
+	}, dataset, 4)
+
+	decision := ml.ValidateChallenger(dataset, result, nil, nil)
+	assertion.True(decision.Promote)
+
+	// active model predicts mean price only
+	active := &ml.GradientBoosting{Estimators: 1, LearningRate: 0.01, MaxDepth: 1}
+	assertion.Nil(active.Fit(dataset.X, dataset.Y))
+	activeEntry := model.MLModel{
+		FormatVersion: ml.ModelFormatVersion,
+		Features:      model.MLFeatureSet{"a", "b"},
+		DatasetTo:     model.TimestampMilli(dataset.Timestamps[44]),
+	}
+
+	decision = ml.ValidateChallenger(dataset, result, active, &activeEntry)
+	assertion.False(decision.Promote)
+	assertion.Equal(5, decision.Length)
+
+	activeEntry.DatasetTo = model.TimestampMilli(dataset.Timestamps[30])
+	decision = ml.ValidateChallenger(dataset, result, active, &activeEntry)
+	assertion.True(decision.Promote)
+	assertion.Equal(10, decision.Length)
+	assertion.Less(decision.ChallengerRMSE, decision.ActiveRMSE)
+
+	// better active model is kept
+	decision = ml.ValidateChallenger(dataset, result, result.LastFoldModel, &activeEntry)
+	assertion.False(decision.Promote)
+
+	activeEntry.Features = model.MLFeatureSet{"a"}
+	assertion.True(ml.ValidateChallenger(dataset, result, active, &activeEntry).Promote)
+}
+
+func TestMLServiceLearnModelPromotesFirstModel(t *testing.T) {
+	assertion := assert.New(t)
+
+	stats := getMLTradeStats("ETHUSDT", 100, -1)
+	for i := range stats {
+		stats[i].Close = 10 + float64(i)*2 + math.Sin(float64(i))
+	}
+	storage := new(TradeStatReaderMock)
+	storage.On("GetTradeStatList", "ETHUSDT", mock.Anything, mock.Anything).Return(stats, nil)
+
+	exchangeRepository := new(MLFeatureStorageMock)
+	exchangeRepository.On("GetTradeLimit", "ETHUSDT").Return(model.TradeLimit{
+		Symbol: "ETHUSDT",
+		MLConfig: model.MLConfig{
+			Features: []string{model.MLFeatureOrderBookBuyFirstQty, model.MLFeatureReturn1m},
+			Horizon:  1,
+		},
+	}, nil)
+
+	id := int64(7)
+	registry := new(MLModelRepositoryMock)
+	registry.On("GetActive", "ETHUSDT").Return(model.MLModel{}, errors.New("sql: no rows in result set"))
+	registry.On("Create", mock.Anything).Return(&id, nil)
+	registry.On("Activate", mock.Anything).Return(nil)
+	registry.On("DeleteOutdated", "ETHUSDT", int64(ml.MLModelHistoryLength)).Return(nil)
+
+	service := ml.MLService{
+		DataSetBuilder:     &ml.DataSetBuilder{StatRepository: storage, ExchangeRepository: exchangeRepository},
+		ExchangeRepository: exchangeRepository,
+		MLModelRepository:  registry,
+	}
+	assertion.Nil(service.LearnModel("ETHUSDT"))
+	assertion.False(service.IsLearning())
+
+	created := registry.Calls[1].Arguments.Get(0).(model.MLModel)
+	assertion.Equal("ETHUSDT", created.Symbol)
+	assertion.Equal(int64(98), created.DatasetLength)
+	assertion.Equal(int64(1), created.Horizon)
+	assertion.Equal(model.MLFeatureSet{model.MLFeatureOrderBookBuyFirstQty, model.MLFeatureReturn1m}, created.Features)
+	assertion.Equal(stats[98].Timestamp, created.DatasetTo)
+	assertion.Equal(int64(ml.WalkForwardFolds), created.Metrics.WalkForwardFolds)
+	assertion.Equal("no active model", created.PromotionReason)
+
+	activated := registry.Calls[2].Arguments.Get(0).(model.MLModel)
+	assertion.Equal(int64(7), activated.Id)
+
+	_, meta, err := ml.DecodeModel([]byte(created.Payload))
+	assertion.Nil(err)
+	assertion.Equal(int64(1), meta.Horizon)
+
+	// prediction uses the feature set of the promoted model
+	current := model.KLine{Symbol: "ETHUSDT", Timestamp: stats[99].Timestamp, Close: model.Price(stats[99].Close)}
+	exchangeRepository.On("GetCurrentKline", "ETHUSDT").Return(&current)
+	exchangeRepository.On("KLineList", "ETHUSDT", true, int64(ml.MaxFeatureLag+1)).Return([]model.KLine{
+		{Symbol: "ETHUSDT", Timestamp: stats[98].Timestamp, Close: model.Price(stats[98].Close)},
+		current,
+	})
+	statService := new(TradeStatProviderMock)
+	statService.On("GetTradeStat", current, true, true).Return(stats[99])
+	service.StatService = statService
+
+	predicted, err := service.Predict("ETHUSDT")
+	assertion.Nil(err)
+	assertion.Greater(predicted, 0.00)
+}
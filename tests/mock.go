@@ -137,21 +137,33 @@ type ExchangeOrderAPIMock struct {
 	mock.Mock
 }
 
-func (b *ExchangeOrderAPIMock) GetOpenedOrders() ([]model.BinanceOrder, error) {
+func (b *ExchangeOrderAPIMock) GetOpenedOrders() ([]model.ExchangeOrder, error) {
 	args := b.Called()
-	return args.Get(0).([]model.BinanceOrder), args.Error(1)
+	return args.Get(0).([]model.ExchangeOrder), args.Error(1)
 }
-func (b *ExchangeOrderAPIMock) LimitOrder(symbol string, quantity float64, price float64, operation string, timeInForce string) (model.BinanceOrder, error) {
+func (b *ExchangeOrderAPIMock) LimitOrder(symbol string, quantity float64, price float64, operation string, timeInForce string) (model.ExchangeOrder, error) {
 	args := b.Called(symbol, quantity, price, operation, timeInForce)
-	return args.Get(0).(model.BinanceOrder), args.Error(1)
+	return args.Get(0).(model.ExchangeOrder), args.Error(1)
 }
-func (b *ExchangeOrderAPIMock) QueryOrder(symbol string, orderId string) (model.BinanceOrder, error) {
+func (b *ExchangeOrderAPIMock) MarketOrder(symbol string, quantity float64, operation string) (model.ExchangeOrder, error) {
+	args := b.Called(symbol, quantity, operation)
+	return args.Get(0).(model.ExchangeOrder), args.Error(1)
+}
+func (b *ExchangeOrderAPIMock) QuoteMarketOrder(symbol string, quoteQuantity float64, operation string) (model.ExchangeOrder, error) {
+	args := b.Called(symbol, quoteQuantity, operation)
+	return args.Get(0).(model.ExchangeOrder), args.Error(1)
+}
+func (b *ExchangeOrderAPIMock) PostOnlyOrder(symbol string, quantity float64, price float64, operation string) (model.ExchangeOrder, error) {
+	args := b.Called(symbol, quantity, price, operation)
+	return args.Get(0).(model.ExchangeOrder), args.Error(1)
+}
+func (b *ExchangeOrderAPIMock) QueryOrder(symbol string, orderId string) (model.ExchangeOrder, error) {
 	args := b.Called(symbol, orderId)
-	return args.Get(0).(model.BinanceOrder), args.Error(1)
+	return args.Get(0).(model.ExchangeOrder), args.Error(1)
 }
-func (b *ExchangeOrderAPIMock) CancelOrder(symbol string, orderId string) (model.BinanceOrder, error) {
+func (b *ExchangeOrderAPIMock) CancelOrder(symbol string, orderId string) (model.ExchangeOrder, error) {
 	args := b.Called(symbol, orderId)
-	return args.Get(0).(model.BinanceOrder), args.Error(1)
+	return args.Get(0).(model.ExchangeOrder), args.Error(1)
 }
 
 type TimeServiceMock struct {
@@ -247,9 +259,9 @@ type ExchangePriceAPIMock struct {
 	mock.Mock
 }
 
-func (e *ExchangePriceAPIMock) GetOpenedOrders() ([]model.BinanceOrder, error) {
+func (e *ExchangePriceAPIMock) GetOpenedOrders() ([]model.ExchangeOrder, error) {
 	args := e.Called()
-	return args.Get(0).([]model.BinanceOrder), args.Error(1)
+	return args.Get(0).([]model.ExchangeOrder), args.Error(1)
 }
 func (e *ExchangePriceAPIMock) GetDepth(symbol string, limit int64) *model.OrderBook {
 	args := e.Called(symbol, limit)
@@ -301,7 +313,7 @@ func (e *OrderStorageMock) GetClosesOrderList(buyOrder model.Order) []model.Orde
 	args := e.Called(buyOrder)
 	return args.Get(0).([]model.Order)
 }
-func (e *OrderStorageMock) DeleteBinanceOrder(order model.BinanceOrder) {
+func (e *OrderStorageMock) DeleteExchangeOrder(order model.ExchangeOrder) {
 	_ = e.Called(order)
 }
 func (e *OrderStorageMock) GetTodayExtraOrderMap() *sync.Map {
@@ -328,10 +340,10 @@ func (e *OrderStorageMock) GetManualOrder(symbol string) *model.ManualOrder {
 
 	return manual.(*model.ManualOrder)
 }
-func (e *OrderStorageMock) SetBinanceOrder(order model.BinanceOrder) {
+func (e *OrderStorageMock) SetExchangeOrder(order model.ExchangeOrder) {
 	_ = e.Called(order)
 }
-func (e *OrderStorageMock) GetBinanceOrder(symbol string, operation string) *model.BinanceOrder {
+func (e *OrderStorageMock) GetExchangeOrder(symbol string, operation string) *model.ExchangeOrder {
 	args := e.Called(symbol, operation)
 
 	order := args.Get(0)
@@ -340,7 +352,7 @@ func (e *OrderStorageMock) GetBinanceOrder(symbol string, operation string) *mod
 		return nil
 	}
 
-	return order.(*model.BinanceOrder)
+	return order.(*model.ExchangeOrder)
 }
 func (e *OrderStorageMock) LockBuy(symbol string, seconds int64) {
 	_ = e.Called(symbol, seconds)
@@ -440,13 +452,25 @@ func (s *TelegramNotificatorMock) SellOrder(order model.Order, bot model.Bot, de
 func (s *TelegramNotificatorMock) BuyOrder(order model.Order, bot model.Bot, details string) {
 	_ = s.Called(order, bot, details)
 }
+func (s *TelegramNotificatorMock) Notify(notification model.Notification) {
+	_ = s.Called(notification)
+}
+
+type NotifierMock struct {
+	mock.Mock
+}
+
+func (n *NotifierMock) Notify(notification model.Notification) error {
+	args := n.Called(notification)
+	return args.Error(0)
+}
 
 type LossSecurityMock struct {
 	mock.Mock
 }
 
-func (l *LossSecurityMock) IsRiskyBuy(binanceOrder model.BinanceOrder, limit model.TradeLimit) bool {
-	args := l.Called(binanceOrder, limit)
+func (l *LossSecurityMock) IsRiskyBuy(exchangeOrder model.ExchangeOrder, limit model.TradeLimit) bool {
+	args := l.Called(exchangeOrder, limit)
 	return args.Get(0).(bool)
 }
 func (l *LossSecurityMock) BuyPriceCorrection(price float64, limit model.TradeLimit) float64 {
@@ -592,16 +616,16 @@ type OrderExecutorMock struct {
 	mock.Mock
 }
 
-func (o *OrderExecutorMock) BuyExtra(tradeLimit model.TradeLimit, order model.Order, price float64) error {
+func (o *OrderExecutorMock) BuyExtra(tradeLimit model.TradeLimit, order model.Order, price float64, explanation *model.DecisionExplanation) error {
 	args := o.Called(tradeLimit, order, price)
 	return args.Error(0)
 }
-func (o *OrderExecutorMock) Buy(tradeLimit model.TradeLimit, price float64, quantity float64, signal *model.Signal) error {
+func (o *OrderExecutorMock) Buy(tradeLimit model.TradeLimit, price float64, quantity float64, signal *model.Signal, explanation *model.DecisionExplanation) error {
 	args := o.Called(tradeLimit, price, quantity, signal)
 	return args.Error(0)
 }
-func (o *OrderExecutorMock) Sell(tradeLimit model.TradeLimit, opened model.Order, price float64, quantity float64, isManual bool) error {
-	args := o.Called(tradeLimit, opened, price, quantity, isManual)
+func (o *OrderExecutorMock) Sell(tradeLimit model.TradeLimit, opened model.Order, price float64, quantity float64, closeReason string, explanation *model.DecisionExplanation) error {
+	args := o.Called(tradeLimit, opened, price, quantity, closeReason)
 	return args.Error(0)
 }
 func (o *OrderExecutorMock) ProcessSwap(order model.Order) bool {
@@ -615,6 +639,9 @@ func (o *OrderExecutorMock) CheckMinBalance(limit model.TradeLimit, kLine model.
 	args := o.Called(limit, kLine)
 	return args.Error(0)
 }
+func (o *OrderExecutorMock) Resume(symbol string) {
+	_ = o.Called(symbol)
+}
 func (o *OrderExecutorMock) CalculateSellQuantity(order model.Order) float64 {
 	args := o.Called(order)
 	return args.Get(0).(float64)
@@ -636,3 +663,235 @@ func (o *TradeFilterServiceMock) CanSell(limit model.TradeLimit) bool {
 	args := o.Called(limit)
 	return args.Get(0).(bool)
 }
+
+type ExchangeAPIMock struct {
+	ExchangePriceAPIMock
+}
+
+func (e *ExchangeAPIMock) QueryOrder(symbol string, orderId string) (model.ExchangeOrder, error) {
+	args := e.Called(symbol, orderId)
+	return args.Get(0).(model.ExchangeOrder), args.Error(1)
+}
+func (e *ExchangeAPIMock) CancelOrder(symbol string, orderId string) (model.ExchangeOrder, error) {
+	args := e.Called(symbol, orderId)
+	return args.Get(0).(model.ExchangeOrder), args.Error(1)
+}
+func (e *ExchangeAPIMock) TradesAggregate(symbol string, limit int64, startTime int64, endTime int64) []model.Trade {
+	args := e.Called(symbol, limit, startTime, endTime)
+	return args.Get(0).([]model.Trade)
+}
+func (e *ExchangeAPIMock) GetAccountStatus() (*model.AccountStatus, error) {
+	args := e.Called()
+	return args.Get(0).(*model.AccountStatus), args.Error(1)
+}
+func (e *ExchangeAPIMock) GetTickers(symbols []string) []model.ExchangeTicker {
+	args := e.Called(symbols)
+	return args.Get(0).([]model.ExchangeTicker)
+}
+func (e *ExchangeAPIMock) LimitOrder(symbol string, quantity float64, price float64, operation string, timeInForce string) (model.ExchangeOrder, error) {
+	args := e.Called(symbol, quantity, price, operation, timeInForce)
+	return args.Get(0).(model.ExchangeOrder), args.Error(1)
+}
+func (e *ExchangeAPIMock) MarketOrder(symbol string, quantity float64, operation string) (model.ExchangeOrder, error) {
+	args := e.Called(symbol, quantity, operation)
+	return args.Get(0).(model.ExchangeOrder), args.Error(1)
+}
+func (e *ExchangeAPIMock) QuoteMarketOrder(symbol string, quoteQuantity float64, operation string) (model.ExchangeOrder, error) {
+	args := e.Called(symbol, quoteQuantity, operation)
+	return args.Get(0).(model.ExchangeOrder), args.Error(1)
+}
+func (e *ExchangeAPIMock) PostOnlyOrder(symbol string, quantity float64, price float64, operation string) (model.ExchangeOrder, error) {
+	args := e.Called(symbol, quantity, price, operation)
+	return args.Get(0).(model.ExchangeOrder), args.Error(1)
+}
+func (e *ExchangeAPIMock) IsConnected() bool {
+	args := e.Called()
+	return args.Bool(0)
+}
+func (e *ExchangeAPIMock) IsWaitMode() bool {
+	args := e.Called()
+	return args.Bool(0)
+}
+func (e *ExchangeAPIMock) IsAPIKeyCheckCompleted() bool {
+	args := e.Called()
+	return args.Bool(0)
+}
+func (e *ExchangeAPIMock) SetAPIKeyCheckCompleted(completed bool) {
+	_ = e.Called(completed)
+}
+
+type KLineHistoryMock struct {
+	mock.Mock
+}
+
+func (k *KLineHistoryMock) GetCurrentKline(symbol string) *model.KLine {
+	args := k.Called(symbol)
+	kLine := args.Get(0)
+	if kLine == nil {
+		return nil
+	}
+
+	return kLine.(*model.KLine)
+}
+func (k *KLineHistoryMock) KLineList(symbol string, reverse bool, size int64) []model.KLine {
+	args := k.Called(symbol, reverse, size)
+	return args.Get(0).([]model.KLine)
+}
+
+type TradeStatReaderMock struct {
+	mock.Mock
+}
+
+func (t *TradeStatReaderMock) GetTradeStatList(symbol string, from model.TimestampMilli, to model.TimestampMilli) ([]model.TradeStat, error) {
+	args := t.Called(symbol, from, to)
+	return args.Get(0).([]model.TradeStat), args.Error(1)
+}
+
+type OrderProfitReaderMock struct {
+	mock.Mock
+}
+
+func (o *OrderProfitReaderMock) GetRealizedProfitSince(from string) map[string]float64 {
+	args := o.Called(from)
+	return args.Get(0).(map[string]float64)
+}
+
+type ExchangeProtectionAPIMock struct {
+	mock.Mock
+}
+
+func (e *ExchangeProtectionAPIMock) ProtectionOrder(symbol string, quantity float64, takeProfitPrice float64, stopPrice float64) (model.ProtectionOrder, error) {
+	args := e.Called(symbol, quantity, takeProfitPrice, stopPrice)
+	return args.Get(0).(model.ProtectionOrder), args.Error(1)
+}
+func (e *ExchangeProtectionAPIMock) CancelProtectionOrder(symbol string, protection model.ProtectionOrder) error {
+	args := e.Called(symbol, protection)
+	return args.Error(0)
+}
+
+type MLModelRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *MLModelRepositoryMock) Create(entity model.MLModel) (*int64, error) {
+	args := m.Called(entity)
+	return args.Get(0).(*int64), args.Error(1)
+}
+func (m *MLModelRepositoryMock) Find(id int64) (model.MLModel, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.MLModel), args.Error(1)
+}
+func (m *MLModelRepositoryMock) GetActive(symbol string) (model.MLModel, error) {
+	args := m.Called(symbol)
+	return args.Get(0).(model.MLModel), args.Error(1)
+}
+func (m *MLModelRepositoryMock) GetList(symbol string) []model.MLModel {
+	args := m.Called(symbol)
+	return args.Get(0).([]model.MLModel)
+}
+func (m *MLModelRepositoryMock) Activate(entity model.MLModel) error {
+	args := m.Called(entity)
+	return args.Error(0)
+}
+func (m *MLModelRepositoryMock) DeleteOutdated(symbol string, keep int64) error {
+	args := m.Called(symbol, keep)
+	return args.Error(0)
+}
+
+type MLFeatureStorageMock struct {
+	mock.Mock
+}
+
+func (m *MLFeatureStorageMock) GetTradeLimits() []model.TradeLimit {
+	args := m.Called()
+	return args.Get(0).([]model.TradeLimit)
+}
+func (m *MLFeatureStorageMock) GetTradeLimit(symbol string) (model.TradeLimit, error) {
+	args := m.Called(symbol)
+	return args.Get(0).(model.TradeLimit), args.Error(1)
+}
+func (m *MLFeatureStorageMock) GetCurrentKline(symbol string) *model.KLine {
+	args := m.Called(symbol)
+	return args.Get(0).(*model.KLine)
+}
+func (m *MLFeatureStorageMock) KLineList(symbol string, reverse bool, size int64) []model.KLine {
+	args := m.Called(symbol, reverse, size)
+	return args.Get(0).([]model.KLine)
+}
+func (m *MLFeatureStorageMock) GetInterpolation(kLine model.KLine) (model.Interpolation, error) {
+	args := m.Called(kLine)
+	return args.Get(0).(model.Interpolation), args.Error(1)
+}
+func (m *MLFeatureStorageMock) GetCapitalization(symbol string, timestamp model.TimestampMilli) *model.MCObject {
+	args := m.Called(symbol, timestamp)
+	return args.Get(0).(*model.MCObject)
+}
+
+type TradeStatProviderMock struct {
+	mock.Mock
+}
+
+func (m *TradeStatProviderMock) GetTradeStat(kLine model.KLine, cache bool, full bool) model.TradeStat {
+	args := m.Called(kLine, cache, full)
+	return args.Get(0).(model.TradeStat)
+}
+
+type BalanceUpdaterMock struct {
+	mock.Mock
+}
+
+func (m *BalanceUpdaterMock) UpdateBalances(balances []model.Balance) {
+	_ = m.Called(balances)
+}
+
+type OrderLifecycleStorageMock struct {
+	mock.Mock
+}
+
+func (m *OrderLifecycleStorageMock) SaveOrderLifecycle(lifecycle model.OrderLifecycle) error {
+	args := m.Called(lifecycle)
+	return args.Error(0)
+}
+func (m *OrderLifecycleStorageMock) GetOrderLifecycle(symbol string, side string) *model.OrderLifecycle {
+	args := m.Called(symbol, side)
+	lifecycle := args.Get(0)
+	if lifecycle == nil {
+		return nil
+	}
+
+	return lifecycle.(*model.OrderLifecycle)
+}
+func (m *OrderLifecycleStorageMock) DeleteOrderLifecycle(symbol string, side string) {
+	_ = m.Called(symbol, side)
+}
+
+type SwapRecoveryRepositoryMock struct {
+	mock.Mock
+	swapAction model.SwapAction
+	decisions  []model.SwapRecoveryDecision
+}
+
+func (m *SwapRecoveryRepositoryMock) GetProcessingSwapActions() []model.SwapAction {
+	args := m.Called()
+	return args.Get(0).([]model.SwapAction)
+}
+func (m *SwapRecoveryRepositoryMock) GetSwapActionById(id int64) (model.SwapAction, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.SwapAction), args.Error(1)
+}
+func (m *SwapRecoveryRepositoryMock) UpdateSwapAction(action model.SwapAction) error {
+	m.swapAction = action
+	args := m.Called(action)
+	return args.Error(0)
+}
+func (m *SwapRecoveryRepositoryMock) GetSwapChainById(id int64) (model.SwapChainEntity, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.SwapChainEntity), args.Error(1)
+}
+func (m *SwapRecoveryRepositoryMock) GetSwapRecoveryDecisions(swapActionId int64) []model.SwapRecoveryDecision {
+	return m.decisions
+}
+func (m *SwapRecoveryRepositoryMock) AddSwapRecoveryDecision(decision model.SwapRecoveryDecision) error {
+	m.decisions = append(m.decisions, decision)
+	return nil
+}
@@ -0,0 +1,262 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/notifier"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+)
+
+type notifierRequest struct {
+	Path    string
+	Headers http.Header
+	Body    []byte
+}
+
+func getNotifierServer() (*httptest.Server, *[]notifierRequest) {
+	requests := make([]notifierRequest, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, notifierRequest{Path: r.URL.Path, Headers: r.Header, Body: body})
+
+		if strings.Contains(r.URL.Path, "fail") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+
+	return server, &requests
+}
+
+func TestNotificationParseEvents(t *testing.T) {
+	assertion := assert.New(t)
+
+	defaults := []string{model.NotificationEventError}
+	assertion.Equal(defaults, notifier.ParseEvents("", defaults))
+	assertion.Equal(model.NotificationEvents, notifier.ParseEvents("all", defaults))
+	assertion.Equal([]string{}, notifier.ParseEvents("none", defaults))
+	assertion.Equal(
+		[]string{model.NotificationEventBuy, model.NotificationEventSwapFailed},
+		notifier.ParseEvents(" BUY, swap_failed,unknown,buy", defaults),
+	)
+}
+
+func TestNotificationManagerSubscriptions(t *testing.T) {
+	assertion := assert.New(t)
+
+	telegram := new(NotifierMock)
+	telegram.On("Notify", mock.Anything).Return(errors.New("Request failed with error code: 400"))
+	webhook := new(NotifierMock)
+	webhook.On("Notify", mock.Anything).Return(nil)
+
+	manager := service.NotificationManager{
+		Subscriptions: []notifier.Subscription{
+			{Channel: "telegram", Notifier: telegram, Events: []string{model.NotificationEventBuy, model.NotificationEventError}},
+			{Channel: "webhook", Notifier: webhook, Events: model.NotificationEvents},
+		},
+	}
+	assertion.Equal([]string{"telegram", "webhook"}, manager.GetChannels())
+
+	bot := model.Bot{BotUuid: "uuid", Exchange: "binance"}
+	order := model.Order{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT", Operation: "buy", Price: 50000.00, Quantity: 0.002, CreatedAt: "2024-05-10 12:00:00"}
+
+	manager.BuyOrder(order, bot, "Sell when price will be around: 51000.000000 USDT")
+	manager.SellOrder(order, bot, "Profit is: 2.00 USDT")
+	manager.Error(bot, "api_key", "Invalid API key", true)
+
+	// failed channel does not stop the others
+	telegram.AssertNumberOfCalls(t, "Notify", 2)
+	webhook.AssertNumberOfCalls(t, "Notify", 3)
+
+	buy := telegram.Calls[0].Arguments.Get(0).(model.Notification)
+	assertion.Equal(model.NotificationEventBuy, buy.Event)
+	assertion.Equal("BUY BTCUSDT", buy.Title)
+	assertion.Equal("Price: 50000.000000 USDT\nQuantity: 0.002000 BTC\nSell when price will be around: 51000.000000 USDT", buy.Message)
+	assertion.Equal("2024-05-10 12:00:00", buy.DateTime)
+	assertion.Equal("binance", buy.Exchange)
+
+	failure := webhook.Calls[2].Arguments.Get(0).(model.Notification)
+	assertion.Equal(model.NotificationEventError, failure.Event)
+	assertion.Equal("api_key", failure.Code)
+	assertion.True(failure.Stop)
+	assertion.NotEmpty(failure.DateTime)
+}
+
+func TestNotificationChannels(t *testing.T) {
+	assertion := assert.New(t)
+
+	server, requests := getNotifierServer()
+	defer server.Close()
+
+	order := model.Order{Symbol: "ETHUSDT", BaseAsset: "ETH", QuoteAsset: "USDT", Operation: "sell", Price: 3000.00, Quantity: 0.5, CreatedAt: "2024-05-10 12:00:00"}
+	notification := model.NewOrderNotification(model.NotificationEventSell, order, model.Bot{BotUuid: "uuid", Exchange: "bybit"}, "Profit is: 5.00 USDT")
+
+	assertion.Nil((&notifier.TelegramNotifier{ApiHost: server.URL, BotToken: "123:abc", ChatId: "-100"}).Notify(notification))
+	assertion.Nil((&notifier.SlackNotifier{WebhookUrl: server.URL + "/slack"}).Notify(notification))
+	assertion.Nil((&notifier.DiscordNotifier{WebhookUrl: server.URL + "/discord"}).Notify(notification))
+	assertion.Nil((&notifier.WebhookNotifier{Url: server.URL + "/hook", Secret: "secret"}).Notify(notification))
+	assertion.Nil((&service.CallbackManager{AutoTradeHost: server.URL}).Notify(notification))
+	assertion.NotNil((&notifier.SlackNotifier{WebhookUrl: server.URL + "/fail"}).Notify(notification))
+	assertion.Len(*requests, 6)
+
+	telegram := (*requests)[0]
+	assertion.Equal("/bot123:abc/sendMessage", telegram.Path)
+	var telegramBody map[string]any
+	assertion.Nil(json.Unmarshal(telegram.Body, &telegramBody))
+	assertion.Equal("-100", telegramBody["chat_id"])
+	assertion.Equal("SELL ETHUSDT\nPrice: 3000.000000 USDT\nQuantity: 0.500000 ETH\nProfit is: 5.00 USDT", telegramBody["text"])
+
+	assertion.Contains(string((*requests)[1].Body), `"text":"*SELL ETHUSDT*\nPrice`)
+	assertion.Contains(string((*requests)[2].Body), `"content":"**SELL ETHUSDT**\nPrice`)
+
+	hook := (*requests)[3]
+	timestamp := hook.Headers.Get(notifier.WebhookTimestampHeader)
+	assertion.NotEmpty(timestamp)
+	assertion.Equal(notifier.Sign("secret", timestamp, hook.Body), hook.Headers.Get(notifier.WebhookSignatureHeader))
+	assertion.NotEqual(notifier.Sign("other", timestamp, hook.Body), hook.Headers.Get(notifier.WebhookSignatureHeader))
+	var hookBody model.Notification
+	assertion.Nil(json.Unmarshal(hook.Body, &hookBody))
+	assertion.Equal(model.NotificationEventSell, hookBody.Event)
+	assertion.Equal(3000.00, hookBody.Order.Price)
+
+	callback := (*requests)[4]
+	assertion.Equal("/public/callback/telegram", callback.Path)
+	var callbackBody model.TgOrderNotification
+	assertion.Nil(json.Unmarshal(callback.Body, &callbackBody))
+	assertion.Equal("SELL", callbackBody.Operation)
+	assertion.Equal("Profit is: 5.00 USDT", callbackBody.Details)
+
+	// swap events are not supported by autotrade.cloud callback
+	assertion.Nil((&service.CallbackManager{AutoTradeHost: server.URL}).Notify(model.NewSwapNotification(
+		model.NotificationEventSwapStarted,
+		model.SwapAction{Id: 7, Asset: "ETH", SwapOneSymbol: "ETHBTC", SwapTwoSymbol: "XRPBTC", SwapThreeSymbol: "XRPETH"},
+		model.Bot{},
+		"",
+	)))
+	assertion.Len(*requests, 6)
+}
+
+func TestNotificationEmail(t *testing.T) {
+	assertion := assert.New(t)
+
+	var address string
+	var recipients []string
+	var message string
+	email := notifier.EmailNotifier{
+		Host: "smtp.example.com",
+		Port: "587",
+		From: "bot@example.com",
+		To:   []string{"one@example.com", "two@example.com"},
+		SendMail: func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+			address = addr
+			recipients = to
+			message = string(msg)
+			assertion.Nil(auth)
+
+			return nil
+		},
+	}
+
+	assertion.Nil(email.Notify(model.NewRiskLimitNotification(model.Bot{BotUuid: "uuid", Exchange: "okx"}, model.RiskErrorCodeMaxPositions, "Max positions 2 reached")))
+	assertion.Equal("smtp.example.com:587", address)
+	assertion.Equal([]string{"one@example.com", "two@example.com"}, recipients)
+	assertion.Contains(message, "To: one@example.com, two@example.com\r\n")
+	assertion.Contains(message, "Subject: Risk limit: risk_max_positions\r\n")
+	assertion.True(strings.HasSuffix(message, "\r\n\r\nMax positions 2 reached\r\n\r\nBot: uuid (okx)"))
+}
+
+func TestNotificationEmailTimeout(t *testing.T) {
+	assertion := assert.New(t)
+
+	// server accepts connection, but never sends SMTP greeting
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assertion.Nil(err)
+	defer listener.Close()
+	go func() {
+		connection, err := listener.Accept()
+		if err == nil {
+			defer connection.Close()
+			time.Sleep(time.Second * 2)
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	email := notifier.EmailNotifier{Host: host, Port: port, From: "bot@example.com", To: []string{"me@example.com"}, Timeout: time.Millisecond * 100}
+
+	startedAt := time.Now()
+	assertion.NotNil(email.Notify(model.NewErrorNotification(model.Bot{}, "api_key", "Invalid API key", false)))
+	assertion.Less(time.Since(startedAt), time.Second)
+}
+
+func TestNotificationManagerQueue(t *testing.T) {
+	assertion := assert.New(t)
+
+	release := make(chan bool)
+	slow := new(NotifierMock)
+	slow.On("Notify", mock.Anything).Run(func(args mock.Arguments) { <-release }).Return(nil)
+
+	manager := service.NotificationManager{
+		Subscriptions: []notifier.Subscription{{Channel: "email", Notifier: slow, Events: model.NotificationEvents}},
+	}
+	manager.Start()
+
+	// caller does not wait for the channel, queued notifications are sent on stop
+	startedAt := time.Now()
+	manager.Error(model.Bot{}, "api_key", "Invalid API key", false)
+	manager.Error(model.Bot{}, "api_key", "Invalid API key", false)
+	assertion.Less(time.Since(startedAt), time.Millisecond*100)
+
+	close(release)
+	manager.Stop(time.Second)
+	slow.AssertNumberOfCalls(t, "Notify", 2)
+
+	// notification after stop is dropped
+	manager.Error(model.Bot{}, "api_key", "Invalid API key", false)
+	slow.AssertNumberOfCalls(t, "Notify", 2)
+}
+
+func TestNotificationDailySummary(t *testing.T) {
+	assertion := assert.New(t)
+
+	riskManager, _ := getRiskManager(model.RiskConfig{}, -5.00)
+	callbackManager := new(TelegramNotificatorMock)
+	callbackManager.On("Notify", mock.Anything).Return()
+	botService := new(BotServiceMock)
+	botService.On("GetBot").Return(model.Bot{BotUuid: "uuid"})
+	timeService := new(TimeServiceMock)
+	timeService.On("GetNowDateTimeString").Return("2024-05-10 22:59:00").Once()
+	timeService.On("GetNowDateTimeString").Return("2024-05-10 23:00:00").Once()
+	timeService.On("GetNowDateTimeString").Return("2024-05-10 23:01:00").Once()
+	timeService.On("GetNowDateTimeString").Return("2024-05-11 23:00:00").Once()
+
+	summary := exchange.DailySummaryService{
+		RiskManager:     riskManager,
+		BotService:      botService,
+		CallbackManager: callbackManager,
+		TimeService:     timeService,
+		Hour:            23,
+	}
+
+	assertion.False(summary.Check())
+	assertion.True(summary.Check())
+	assertion.False(summary.Check())
+	assertion.True(summary.Check())
+	callbackManager.AssertNumberOfCalls(t, "Notify", 2)
+
+	notification := callbackManager.Calls[0].Arguments.Get(0).(model.Notification)
+	assertion.Equal(model.NotificationEventDailySummary, notification.Event)
+	assertion.Equal("Daily summary 2024-05-10", notification.Title)
+	assertion.Equal(int64(2), notification.RiskStatus.Positions)
+	assertion.Contains(notification.Message, "Daily PnL: -5.00 USDT\nOpened positions: 2")
+}
@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"os"
+	"testing"
+)
+
+func getOkxClient() (*client.Okx, *HttpClientMock) {
+	httpClientMock := new(HttpClientMock)
+
+	return &client.Okx{
+		HttpClient: httpClientMock,
+		DSN:        "https://fake.url",
+		Formatter:  &utils.Formatter{},
+	}, httpClientMock
+}
+
+func readOkxFixture(name string) []byte {
+	content, _ := os.ReadFile("example/" + name)
+
+	return content
+}
+
+func TestOkxSymbolMapping(t *testing.T) {
+	assertion := assert.New(t)
+	formatter := utils.Formatter{}
+
+	assertion.Equal("BTC-USDT", formatter.BinanceSymbolToOkxInstId("BTCUSDT"))
+	assertion.Equal("ETH-BTC", formatter.BinanceSymbolToOkxInstId("ETHBTC"))
+	assertion.Equal("SOL-USDC", formatter.BinanceSymbolToOkxInstId("SOLUSDC"))
+	assertion.Equal("BTCUSDT", formatter.OkxInstIdToBinanceSymbol("BTC-USDT"))
+	assertion.Equal("1H", formatter.BinanceIntervalToOkxInterval("1h"))
+	assertion.Equal("1Dutc", formatter.BinanceIntervalToOkxInterval("1d"))
+}
+
+func TestOkxQuoteMarketOrder(t *testing.T) {
+	assertion := assert.New(t)
+	okx, httpClientMock := getOkxClient()
+
+	httpClientMock.On("Post", "https://fake.url/api/v5/trade/order", mock.Anything, mock.Anything).Return(readOkxFixture("okx_order_create.json"), nil)
+	httpClientMock.On("Get", "https://fake.url/api/v5/trade/order?instId=BTC-USDT&ordId=312269865356374016", mock.Anything).Return(readOkxFixture("okx_order.json"), nil)
+
+	order, err := okx.QuoteMarketOrder("BTCUSDT", 200.482, "BUY")
+	assertion.Nil(err)
+	assertion.Equal("312269865356374016", order.OrderId)
+	assertion.Equal("BTCUSDT", order.Symbol)
+	assertion.Equal(model.ExchangeOrderStatusFilled, order.Status)
+	assertion.Equal("MARKET", order.Type)
+	assertion.Equal("BUY", order.Side)
+	assertion.Equal(50120.5, order.Price)
+	assertion.Equal(0.004, order.OrigQty)
+	assertion.Equal(0.004, order.ExecutedQty)
+	assertion.Equal(int64(1695190491421), order.Timestamp)
+
+	body := httpClientMock.Calls[0].Arguments.Get(1).([]byte)
+	var request map[string]string
+	_ = json.Unmarshal(body, &request)
+	assertion.Equal("BTC-USDT", request["instId"])
+	assertion.Equal("cash", request["tdMode"])
+	assertion.Equal("quote_ccy", request["tgtCcy"])
+	assertion.Equal("buy", request["side"])
+}
+
+func TestOkxOrderRejected(t *testing.T) {
+	assertion := assert.New(t)
+	okx, httpClientMock := getOkxClient()
+
+	response := []byte(`{"code": "1", "msg": "All operations failed", "data": [{"ordId": "", "sCode": "51008", "sMsg": "Order failed. Insufficient USDT balance in account."}]}`)
+	httpClientMock.On("Post", "https://fake.url/api/v5/trade/order", mock.Anything, mock.Anything).Return(response, nil)
+
+	_, err := okx.LimitOrder("BTCUSDT", 0.004, 50000.00, "BUY", "GTC")
+	assertion.Equal("Order failed. Insufficient USDT balance in account.", err.Error())
+}
+
+func TestOkxMarketData(t *testing.T) {
+	assertion := assert.New(t)
+	okx, httpClientMock := getOkxClient()
+
+	httpClientMock.On("Get", "https://fake.url/api/v5/market/books?instId=BTC-USDT&sz=20", mock.Anything).Return(readOkxFixture("okx_order_book.json"), nil)
+	httpClientMock.On("Get", "https://fake.url/api/v5/market/candles?instId=BTC-USDT&bar=1m&limit=2", mock.Anything).Return(readOkxFixture("okx_candles.json"), nil)
+	httpClientMock.On("Get", "https://fake.url/api/v5/public/instruments?instType=SPOT", mock.Anything).Return(readOkxFixture("okx_instruments.json"), nil)
+	httpClientMock.On("Get", "https://fake.url/api/v5/account/balance", mock.Anything).Return(readOkxFixture("okx_balance.json"), nil)
+
+	depth := okx.GetDepth("BTCUSDT", 20)
+	assertion.Len(depth.Asks, 2)
+	assertion.Equal(41006.8, depth.Asks[0][0].Value)
+	assertion.Equal(0.30178218, depth.Bids[0][1].Value)
+
+	kLines := okx.GetKLines("BTCUSDT", "1m", 2)
+	assertion.Len(kLines, 2)
+	// oldest candle is the first one
+	assertion.Equal(model.TimestampMilli(1597026383085), kLines[0].OpenTime)
+	assertion.Equal("3.72", kLines[0].Close)
+
+	exchangeInfo, err := okx.GetExchangeData([]string{"BTCUSDT"})
+	assertion.Nil(err)
+	assertion.Len(exchangeInfo.Symbols, 1)
+	assertion.Equal("BTCUSDT", exchangeInfo.Symbols[0].Symbol)
+	assertion.True(exchangeInfo.Symbols[0].IsTrading)
+	assertion.Equal(0.1, exchangeInfo.Symbols[0].MinPrice)
+	assertion.Equal(0.00001, exchangeInfo.Symbols[0].MinQuantity)
+
+	account, err := okx.GetAccountStatus()
+	assertion.Nil(err)
+	assertion.Equal("USDT", account.Balances[0].Asset)
+	assertion.Equal(1000.25, account.Balances[0].Free)
+	assertion.Equal(50.00, account.Balances[0].Locked)
+}
+
+func TestOkxWsEvents(t *testing.T) {
+	assertion := assert.New(t)
+	formatter := utils.Formatter{}
+
+	var tickerEvent model.OkxWsTickerEvent
+	assertion.Nil(json.Unmarshal(readOkxFixture("okx_ws_ticker.json"), &tickerEvent))
+	ticker := tickerEvent.Data[0].ToBinanceMiniTicker(formatter.OkxInstIdToBinanceSymbol(tickerEvent.Argument.InstId))
+	assertion.Equal("BTCUSDT", ticker.Symbol)
+	assertion.Equal(model.Price(9999.99), ticker.Close)
+
+	var kLineEvent model.OkxWsKLineEvent
+	assertion.Nil(json.Unmarshal(readOkxFixture("okx_ws_candle.json"), &kLineEvent))
+	kLine := formatter.OkxKLineToBinanceKline(kLineEvent.Data[0], "BTCUSDT", "1m")
+	assertion.Equal(model.OkxChannelCandle, kLineEvent.Argument.Channel)
+	assertion.Equal(model.Price(8533.02), kLine.Open)
+	assertion.Equal(model.Price(8548.26), kLine.Close)
+	assertion.Equal(model.TimestampMilli(1597026383085), kLine.OpenTime)
+}
+
+func TestOkxAPIKeyCheckCompleted(t *testing.T) {
+	assertion := assert.New(t)
+
+	var exchangeApi client.ExchangeAPIInterface = &client.Okx{}
+	assertion.False(exchangeApi.IsAPIKeyCheckCompleted())
+	exchangeApi.SetAPIKeyCheckCompleted(true)
+	assertion.True(exchangeApi.IsAPIKeyCheckCompleted())
+}
@@ -68,7 +68,7 @@ func TestHasOpenedBuyOrder(t *testing.T) {
 		Symbol: "BTCUSDT",
 	}, nil)
 	orderStorage.On("GetOpenedOrderCached", "BTCUSDT", "BUY").Return(nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "BUY").Return(&model.BinanceOrder{
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "BUY").Return(&model.ExchangeOrder{
 		Price: 40001.00,
 	})
 
@@ -106,7 +106,7 @@ func TestNoOrderAndCanBuyHasManualBuy(t *testing.T) {
 
 	signalStorage.On("GetSignal", "BTCUSDT").Times(0)
 	exchangeRepository.On("GetTradeLimit", "BTCUSDT").Return(tradeLimit, nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "BUY").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "BUY").Return(nil)
 	orderStorage.On("GetOpenedOrderCached", "BTCUSDT", "BUY").Return(nil)
 	orderStorage.On("GetManualOrder", "BTCUSDT").Return(&model.ManualOrder{
 		Operation: "BUY",
@@ -147,7 +147,7 @@ func TestNoOrderAndCanBuyNoManual(t *testing.T) {
 
 	signalStorage.On("GetSignal", "BTCUSDT").Return(nil).Times(1)
 	exchangeRepository.On("GetTradeLimit", "BTCUSDT").Return(tradeLimit, nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "BUY").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "BUY").Return(nil)
 	orderStorage.On("GetOpenedOrderCached", "BTCUSDT", "BUY").Return(nil)
 	orderStorage.On("GetManualOrder", "BTCUSDT").Return(nil)
 
@@ -190,7 +190,7 @@ func TestNoOrderAndCanBuyNoManualHasSignal(t *testing.T) {
 
 	signalStorage.On("GetSignal", "BTCUSDT").Return(&signal).Times(1)
 	exchangeRepository.On("GetTradeLimit", "BTCUSDT").Return(tradeLimit, nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "BUY").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "BUY").Return(nil)
 	orderStorage.On("GetOpenedOrderCached", "BTCUSDT", "BUY").Return(nil)
 	orderStorage.On("GetManualOrder", "BTCUSDT").Return(nil)
 
@@ -228,8 +228,8 @@ func TestHasOrderAndHasBinanceSellOrder(t *testing.T) {
 
 	signalStorage.On("GetSignal", "BTCUSDT").Times(0)
 	exchangeRepository.On("GetTradeLimit", "BTCUSDT").Return(tradeLimit, nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "BUY").Return(nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "SELL").Return(&model.BinanceOrder{
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "BUY").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "SELL").Return(&model.ExchangeOrder{
 		Side:  "SELL",
 		Price: 40005.00,
 	})
@@ -271,8 +271,8 @@ func TestHasOrderAndHasManualSell(t *testing.T) {
 
 	signalStorage.On("GetSignal", "BTCUSDT").Times(0)
 	exchangeRepository.On("GetTradeLimit", "BTCUSDT").Return(tradeLimit, nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "BUY").Return(nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "SELL").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "BUY").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "SELL").Return(nil)
 	botService.On("UseSwapCapital").Return(false)
 	orderStorage.On("GetOpenedOrderCached", "BTCUSDT", "BUY").Return(&model.Order{
 		Price:            38000.00,
@@ -317,8 +317,8 @@ func TestHasOrderAndTimeToExtraBuy(t *testing.T) {
 
 	signalStorage.On("GetSignal", "BTCUSDT").Times(0)
 	exchangeRepository.On("GetTradeLimit", "BTCUSDT").Return(tradeLimit, nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "BUY").Return(nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "SELL").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "BUY").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "SELL").Return(nil)
 	orderStorage.On("GetOpenedOrderCached", "BTCUSDT", "BUY").Return(&model.Order{
 		Price:            100.00,
 		ExecutedQuantity: 1,
@@ -367,8 +367,8 @@ func TestHasOrderAndProfitPercentReached(t *testing.T) {
 
 	signalStorage.On("GetSignal", "BTCUSDT").Times(0)
 	exchangeRepository.On("GetTradeLimit", "BTCUSDT").Return(tradeLimit, nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "BUY").Return(nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "SELL").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "BUY").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "SELL").Return(nil)
 	order := model.Order{
 		CreatedAt:        time.Now().Format("2006-01-02 15:04:05"),
 		Price:            100.00,
@@ -428,8 +428,8 @@ func TestHasOrderAndHalfOfProfitPercentReached(t *testing.T) {
 
 	signalStorage.On("GetSignal", "BTCUSDT").Times(0)
 	exchangeRepository.On("GetTradeLimit", "BTCUSDT").Return(tradeLimit, nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "BUY").Return(nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "SELL").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "BUY").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "SELL").Return(nil)
 	order := model.Order{
 		CreatedAt:        time.Now().Format("2006-01-02 15:04:05"),
 		Price:            100.00,
@@ -489,8 +489,8 @@ func TestHasOrderAndCurrentPriceIsGreaterThenOrderPrice(t *testing.T) {
 
 	signalStorage.On("GetSignal", "BTCUSDT").Times(0)
 	exchangeRepository.On("GetTradeLimit", "BTCUSDT").Return(tradeLimit, nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "BUY").Return(nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "SELL").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "BUY").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "SELL").Return(nil)
 	order := model.Order{
 		CreatedAt:        time.Now().Format("2006-01-02 15:04:05"),
 		Price:            100.00,
@@ -553,8 +553,8 @@ func TestHasOrderAndCurrentPriceIsLessOrEqualOrderPrice(t *testing.T) {
 	signalStorage.On("GetSignal", "BTCUSDT").Times(0)
 	tradeStack.On("CanBuy", tradeLimit).Return(true)
 	exchangeRepository.On("GetTradeLimit", "BTCUSDT").Return(tradeLimit, nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "BUY").Return(nil)
-	orderStorage.On("GetBinanceOrder", "BTCUSDT", "SELL").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "BUY").Return(nil)
+	orderStorage.On("GetExchangeOrder", "BTCUSDT", "SELL").Return(nil)
 	order := model.Order{
 		CreatedAt:        time.Now().Format("2006-01-02 15:04:05"),
 		Price:            100.00,
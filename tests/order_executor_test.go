@@ -93,7 +93,7 @@ func TestSellAction(t *testing.T) {
 		}
 	}(&orderExecutor)
 
-	initialBinanceOrder := model.BinanceOrder{
+	initialExchangeOrder := model.ExchangeOrder{
 		OrderId:     "999",
 		Symbol:      "ETHUSDT",
 		Side:        "SELL",
@@ -103,12 +103,12 @@ func TestSellAction(t *testing.T) {
 		Price:       2212.92,
 	}
 	timeService.On("GetNowDateTimeString").Return("2023-12-28 00:52:00")
-	orderRepository.On("GetBinanceOrder", "ETHUSDT", "SELL").Return(nil)
-	binance.On("GetOpenedOrders").Return([]model.BinanceOrder{
-		initialBinanceOrder,
+	orderRepository.On("GetExchangeOrder", "ETHUSDT", "SELL").Return(nil)
+	binance.On("GetOpenedOrders").Return([]model.ExchangeOrder{
+		initialExchangeOrder,
 	}, nil)
-	orderRepository.On("SetBinanceOrder", mock.Anything).Times(2)
-	priceCalculator.On("GetDepth", "ETHUSDT", int64(20)).Return(model.OrderBookModel{
+	orderRepository.On("SetExchangeOrder", mock.Anything).Times(2)
+	priceCalculator.On("GetDepth", "ETHUSDT", int64(exchange.LocalOrderBookDepth)).Return(model.OrderBookModel{
 		Symbol: "ETHUSDT",
 		Asks: [][2]model.Number{
 			{
@@ -146,6 +146,8 @@ func TestSellAction(t *testing.T) {
 		ExternalId:       &openedExternalId,
 		Status:           "opened",
 		Symbol:           "ETHUSDT",
+		BaseAsset:        "ETH",
+		QuoteAsset:       "USDT",
 		Quantity:         0.009,
 		Price:            2212.92,
 		CreatedAt:        time.Now().Format("2006-01-02 15:04:05"),
@@ -155,7 +157,7 @@ func TestSellAction(t *testing.T) {
 	orderRepository.On("GetOpenedOrderCached", "ETHUSDT", "BUY").Return(&openedOrder)
 	orderRepository.On("GetManualOrder", "ETHUSDT").Return(nil)
 	timeService.On("WaitMilliseconds", int64(20)).Maybe()
-	filledOrder := model.BinanceOrder{
+	filledOrder := model.ExchangeOrder{
 		OrderId:             "999",
 		Symbol:              "ETHUSDT",
 		Side:                "SELL",
@@ -185,9 +187,9 @@ func TestSellAction(t *testing.T) {
 
 	profitServiceMock.On("GetMinClosePrice", openedOrder, openedOrder.Price).Return(openedOrder.Price * (100 + 3.1) / 100)
 	priceCalculator.On("CalculateSell", tradeLimit, openedOrder).Return(2281.52, nil)
-	orderRepository.On("DeleteBinanceOrder", filledOrder).Times(1)
+	orderRepository.On("DeleteExchangeOrder", filledOrder).Times(1)
 
-	err := orderExecutor.Sell(tradeLimit, openedOrder, 2281.52, 0.0089, false)
+	err := orderExecutor.Sell(tradeLimit, openedOrder, 2281.52, 0.0089, model.OrderCloseReasonTakeProfit, nil)
 	assertion.Nil(err)
 	assertion.Equal("closed", orderRepository.Updated.Status)
 	assertion.Equal(2212.92, orderRepository.Updated.Price)
@@ -272,7 +274,7 @@ func TestSellFoundFilled(t *testing.T) {
 		}
 	}(&orderExecutor)
 
-	initialBinanceOrder := model.BinanceOrder{
+	initialExchangeOrder := model.ExchangeOrder{
 		OrderId:             "999",
 		Symbol:              "ETHUSDT",
 		Side:                "SELL",
@@ -283,12 +285,12 @@ func TestSellFoundFilled(t *testing.T) {
 		CummulativeQuoteQty: 0.009 * 2212.92,
 	}
 	timeService.On("GetNowDateTimeString").Return("2023-12-28 00:52:00")
-	orderRepository.On("GetBinanceOrder", "ETHUSDT", "SELL").Return(nil)
-	binance.On("GetOpenedOrders").Return([]model.BinanceOrder{
-		initialBinanceOrder,
+	orderRepository.On("GetExchangeOrder", "ETHUSDT", "SELL").Return(nil)
+	binance.On("GetOpenedOrders").Return([]model.ExchangeOrder{
+		initialExchangeOrder,
 	}, nil)
-	orderRepository.On("SetBinanceOrder", mock.Anything).Times(2)
-	priceCalculator.On("GetDepth", "ETHUSDT", int64(20)).Return(model.OrderBookModel{
+	orderRepository.On("SetExchangeOrder", mock.Anything).Times(2)
+	priceCalculator.On("GetDepth", "ETHUSDT", int64(exchange.LocalOrderBookDepth)).Return(model.OrderBookModel{
 		Symbol: "ETHUSDT",
 		Asks: [][2]model.Number{
 			{
@@ -326,6 +328,8 @@ func TestSellFoundFilled(t *testing.T) {
 		ExternalId:       &openedExternalId,
 		Status:           "opened",
 		Symbol:           "ETHUSDT",
+		BaseAsset:        "ETH",
+		QuoteAsset:       "USDT",
 		Quantity:         0.009,
 		Price:            2212.92,
 		CreatedAt:        time.Now().Format("2006-01-02 15:04:05"),
@@ -336,7 +340,7 @@ func TestSellFoundFilled(t *testing.T) {
 	orderRepository.On("GetManualOrder", "ETHUSDT").Return(nil)
 	timeService.On("WaitMilliseconds", int64(20)).Unset()
 	binance.On("QueryOrder", "ETHUSDT", "999").Unset()
-	orderRepository.On("DeleteBinanceOrder", initialBinanceOrder).Times(1)
+	orderRepository.On("DeleteExchangeOrder", initialExchangeOrder).Times(1)
 	orderId := int64(100)
 	orderRepository.On("Create", mock.Anything).Return(&orderId, nil)
 	orderRepository.On("DeleteManualOrder", "ETHUSDT").Times(1)
@@ -356,7 +360,7 @@ func TestSellFoundFilled(t *testing.T) {
 	profitServiceMock.On("GetMinClosePrice", openedOrder, openedOrder.Price).Return(openedOrder.Price * (100 + 3.1) / 100)
 	priceCalculator.On("CalculateSell", tradeLimit, openedOrder).Return(2281.52, nil)
 
-	err := orderExecutor.Sell(tradeLimit, openedOrder, 2281.52, 0.0089, false)
+	err := orderExecutor.Sell(tradeLimit, openedOrder, 2281.52, 0.0089, model.OrderCloseReasonTakeProfit, nil)
 	assertion.Nil(err)
 	assertion.Equal("closed", orderRepository.Updated.Status)
 	assertion.Equal(2212.92, orderRepository.Updated.Price)
@@ -441,7 +445,7 @@ func TestSellCancelledInProcess(t *testing.T) {
 		}
 	}(&orderExecutor)
 
-	initialBinanceOrder := model.BinanceOrder{
+	initialExchangeOrder := model.ExchangeOrder{
 		OrderId:     "999",
 		Symbol:      "ETHUSDT",
 		Side:        "SELL",
@@ -451,12 +455,12 @@ func TestSellCancelledInProcess(t *testing.T) {
 		Price:       2212.92,
 	}
 	timeService.On("GetNowDateTimeString").Return("2023-12-28 00:52:00")
-	orderRepository.On("GetBinanceOrder", "ETHUSDT", "SELL").Return(nil)
-	binance.On("GetOpenedOrders").Return([]model.BinanceOrder{
-		initialBinanceOrder,
+	orderRepository.On("GetExchangeOrder", "ETHUSDT", "SELL").Return(nil)
+	binance.On("GetOpenedOrders").Return([]model.ExchangeOrder{
+		initialExchangeOrder,
 	}, nil)
-	orderRepository.On("SetBinanceOrder", mock.Anything).Times(2)
-	priceCalculator.On("GetDepth", "ETHUSDT", int64(20)).Return(model.OrderBookModel{
+	orderRepository.On("SetExchangeOrder", mock.Anything).Times(2)
+	priceCalculator.On("GetDepth", "ETHUSDT", int64(exchange.LocalOrderBookDepth)).Return(model.OrderBookModel{
 		Symbol: "ETHUSDT",
 		Asks: [][2]model.Number{
 			{
@@ -494,6 +498,8 @@ func TestSellCancelledInProcess(t *testing.T) {
 		ExternalId:       &openedExternalId,
 		Status:           "opened",
 		Symbol:           "ETHUSDT",
+		BaseAsset:        "ETH",
+		QuoteAsset:       "USDT",
 		Quantity:         0.009,
 		Price:            2212.92,
 		CreatedAt:        time.Now().Format("2006-01-02 15:04:05"),
@@ -503,7 +509,7 @@ func TestSellCancelledInProcess(t *testing.T) {
 	orderRepository.On("GetOpenedOrderCached", "ETHUSDT", "BUY").Return(&openedOrder)
 	orderRepository.On("GetManualOrder", "ETHUSDT").Return(nil)
 	timeService.On("WaitMilliseconds", int64(20)).Maybe()
-	canceled := model.BinanceOrder{
+	canceled := model.ExchangeOrder{
 		OrderId:             "999",
 		Symbol:              "ETHUSDT",
 		Side:                "SELL",
@@ -514,7 +520,7 @@ func TestSellCancelledInProcess(t *testing.T) {
 		CummulativeQuoteQty: 0.00,
 	}
 	binance.On("QueryOrder", "ETHUSDT", "999").Return(canceled, nil)
-	orderRepository.On("DeleteBinanceOrder", canceled).Times(1)
+	orderRepository.On("DeleteExchangeOrder", canceled).Times(1)
 	orderId := int64(100)
 	orderRepository.On("Create", mock.Anything).Return(&orderId, nil).Unset()
 	orderRepository.On("DeleteManualOrder", "ETHUSDT").Unset()
@@ -524,7 +530,7 @@ func TestSellCancelledInProcess(t *testing.T) {
 	profitServiceMock.On("GetMinClosePrice", openedOrder, openedOrder.Price).Return(openedOrder.Price * (100 + 3.1) / 100)
 	priceCalculator.On("CalculateSell", tradeLimit, openedOrder).Return(2281.52, nil)
 
-	err := orderExecutor.Sell(tradeLimit, openedOrder, 2281.52, 0.0089, false)
+	err := orderExecutor.Sell(tradeLimit, openedOrder, 2281.52, 0.0089, model.OrderCloseReasonTakeProfit, nil)
 	assertion.Error(errors.New("Order was CANCELED"), err)
 }
 
@@ -606,7 +612,7 @@ func TestSellQueryFail(t *testing.T) {
 		}
 	}(&orderExecutor)
 
-	initialBinanceOrder := model.BinanceOrder{
+	initialExchangeOrder := model.ExchangeOrder{
 		OrderId:     "999",
 		Symbol:      "ETHUSDT",
 		Side:        "SELL",
@@ -616,12 +622,12 @@ func TestSellQueryFail(t *testing.T) {
 		Price:       2212.92,
 	}
 	timeService.On("GetNowDateTimeString").Return("2023-12-28 00:52:00")
-	orderRepository.On("GetBinanceOrder", "ETHUSDT", "SELL").Return(nil)
-	binance.On("GetOpenedOrders").Return([]model.BinanceOrder{
-		initialBinanceOrder,
+	orderRepository.On("GetExchangeOrder", "ETHUSDT", "SELL").Return(nil)
+	binance.On("GetOpenedOrders").Return([]model.ExchangeOrder{
+		initialExchangeOrder,
 	}, nil)
-	orderRepository.On("SetBinanceOrder", mock.Anything).Times(2)
-	priceCalculator.On("GetDepth", "ETHUSDT", int64(20)).Return(model.OrderBookModel{
+	orderRepository.On("SetExchangeOrder", mock.Anything).Times(2)
+	priceCalculator.On("GetDepth", "ETHUSDT", int64(exchange.LocalOrderBookDepth)).Return(model.OrderBookModel{
 		Symbol: "ETHUSDT",
 		Asks: [][2]model.Number{
 			{
@@ -659,6 +665,8 @@ func TestSellQueryFail(t *testing.T) {
 		ExternalId:       &openedExternalId,
 		Status:           "opened",
 		Symbol:           "ETHUSDT",
+		BaseAsset:        "ETH",
+		QuoteAsset:       "USDT",
 		Quantity:         0.009,
 		Price:            2212.92,
 		CreatedAt:        time.Now().Format("2006-01-02 15:04:05"),
@@ -668,8 +676,8 @@ func TestSellQueryFail(t *testing.T) {
 	orderRepository.On("GetOpenedOrderCached", "ETHUSDT", "BUY").Return(&openedOrder)
 	orderRepository.On("GetManualOrder", "ETHUSDT").Return(nil)
 	timeService.On("WaitMilliseconds", int64(20)).Maybe()
-	binance.On("QueryOrder", "ETHUSDT", "999").Return(model.BinanceOrder{}, errors.New("Order was canceled or expired"))
-	orderRepository.On("DeleteBinanceOrder", initialBinanceOrder).Times(1)
+	binance.On("QueryOrder", "ETHUSDT", "999").Return(model.ExchangeOrder{}, errors.New("Order was canceled or expired"))
+	orderRepository.On("DeleteExchangeOrder", initialExchangeOrder).Times(1)
 	orderId := int64(100)
 	orderRepository.On("Create", mock.Anything).Return(&orderId, nil).Unset()
 	orderRepository.On("DeleteManualOrder", "ETHUSDT").Unset()
@@ -679,7 +687,7 @@ func TestSellQueryFail(t *testing.T) {
 	profitServiceMock.On("GetMinClosePrice", openedOrder, openedOrder.Price).Return(openedOrder.Price * (100 + 3.1) / 100)
 	priceCalculator.On("CalculateSell", tradeLimit, openedOrder).Return(2281.52, nil)
 
-	err := orderExecutor.Sell(tradeLimit, openedOrder, 2281.52, 0.0089, false)
+	err := orderExecutor.Sell(tradeLimit, openedOrder, 2281.52, 0.0089, model.OrderCloseReasonTakeProfit, nil)
 	assertion.Equal(errors.New("Order 999 was CANCELED or EXPIRED"), err)
 }
 
@@ -761,7 +769,7 @@ func TestSellClosingAction(t *testing.T) {
 		}
 	}(&orderExecutor)
 
-	initialBinanceOrder := model.BinanceOrder{
+	initialExchangeOrder := model.ExchangeOrder{
 		OrderId:     "999",
 		Symbol:      "BTCUSDT",
 		Side:        "SELL",
@@ -771,12 +779,12 @@ func TestSellClosingAction(t *testing.T) {
 		Price:       43496.99,
 	}
 	timeService.On("GetNowDateTimeString").Return("2023-12-28 00:52:00")
-	orderRepository.On("GetBinanceOrder", "BTCUSDT", "SELL").Return(nil)
-	binance.On("GetOpenedOrders").Return([]model.BinanceOrder{
-		initialBinanceOrder,
+	orderRepository.On("GetExchangeOrder", "BTCUSDT", "SELL").Return(nil)
+	binance.On("GetOpenedOrders").Return([]model.ExchangeOrder{
+		initialExchangeOrder,
 	}, nil)
-	orderRepository.On("SetBinanceOrder", mock.Anything).Times(2)
-	priceCalculator.On("GetDepth", "BTCUSDT", int64(20)).Return(model.OrderBookModel{
+	orderRepository.On("SetExchangeOrder", mock.Anything).Times(2)
+	priceCalculator.On("GetDepth", "BTCUSDT", int64(exchange.LocalOrderBookDepth)).Return(model.OrderBookModel{
 		Symbol: "BTCUSDT",
 		Asks: [][2]model.Number{
 			{
@@ -814,6 +822,8 @@ func TestSellClosingAction(t *testing.T) {
 		ExternalId:       &openedExternalId,
 		Status:           "opened",
 		Symbol:           "BTCUSDT",
+		BaseAsset:        "BTC",
+		QuoteAsset:       "USDT",
 		Quantity:         0.00047,
 		Price:            42026.08,
 		CreatedAt:        time.Now().Format("2006-01-02 15:04:05"),
@@ -823,7 +833,7 @@ func TestSellClosingAction(t *testing.T) {
 	orderRepository.On("GetOpenedOrderCached", "BTCUSDT", "BUY").Return(&openedOrder)
 	orderRepository.On("GetManualOrder", "BTCUSDT").Return(nil)
 	timeService.On("WaitMilliseconds", int64(20)).Maybe()
-	filledOrder := model.BinanceOrder{
+	filledOrder := model.ExchangeOrder{
 		OrderId:             "999",
 		Symbol:              "BTCUSDT",
 		Side:                "SELL",
@@ -853,9 +863,9 @@ func TestSellClosingAction(t *testing.T) {
 
 	profitServiceMock.On("GetMinClosePrice", openedOrder, openedOrder.Price).Return(openedOrder.Price * (100 + 3.1) / 100)
 	priceCalculator.On("CalculateSell", tradeLimit, openedOrder).Return(43496.99, nil)
-	orderRepository.On("DeleteBinanceOrder", filledOrder).Times(1)
+	orderRepository.On("DeleteExchangeOrder", filledOrder).Times(1)
 
-	err := orderExecutor.Sell(tradeLimit, openedOrder, 43496.99, 0.00046, false)
+	err := orderExecutor.Sell(tradeLimit, openedOrder, 43496.99, 0.00046, model.OrderCloseReasonTakeProfit, nil)
 	assertion.Nil(err)
 	assertion.Equal("closed", orderRepository.Updated.Status)
 	assertion.Equal(42026.08, orderRepository.Updated.Price)
@@ -940,7 +950,7 @@ func TestSellClosingTrxAction(t *testing.T) {
 		}
 	}(&orderExecutor)
 
-	initialBinanceOrder := model.BinanceOrder{
+	initialExchangeOrder := model.ExchangeOrder{
 		OrderId:     "999",
 		Symbol:      "TRXUSDT",
 		Side:        "SELL",
@@ -950,12 +960,12 @@ func TestSellClosingTrxAction(t *testing.T) {
 		Status:      "NEW",
 	}
 	timeService.On("GetNowDateTimeString").Return("2023-12-28 00:52:00")
-	orderRepository.On("GetBinanceOrder", "TRXUSDT", "SELL").Return(nil)
-	binance.On("GetOpenedOrders").Return([]model.BinanceOrder{
-		initialBinanceOrder,
+	orderRepository.On("GetExchangeOrder", "TRXUSDT", "SELL").Return(nil)
+	binance.On("GetOpenedOrders").Return([]model.ExchangeOrder{
+		initialExchangeOrder,
 	}, nil)
-	orderRepository.On("SetBinanceOrder", mock.Anything).Times(2)
-	priceCalculator.On("GetDepth", "TRXUSDT", int64(20)).Return(model.OrderBookModel{
+	orderRepository.On("SetExchangeOrder", mock.Anything).Times(2)
+	priceCalculator.On("GetDepth", "TRXUSDT", int64(exchange.LocalOrderBookDepth)).Return(model.OrderBookModel{
 		Symbol: "TRXUSDT",
 		Asks: [][2]model.Number{
 			{
@@ -993,6 +1003,8 @@ func TestSellClosingTrxAction(t *testing.T) {
 		ExternalId:       &openedExternalId,
 		Status:           "opened",
 		Symbol:           "TRXUSDT",
+		BaseAsset:        "TRX",
+		QuoteAsset:       "USDT",
 		Quantity:         382.5,
 		Price:            0.10457,
 		CreatedAt:        time.Now().Format("2006-01-02 15:04:05"),
@@ -1002,7 +1014,7 @@ func TestSellClosingTrxAction(t *testing.T) {
 	orderRepository.On("GetOpenedOrderCached", "TRXUSDT", "BUY").Return(&openedOrder)
 	orderRepository.On("GetManualOrder", "TRXUSDT").Return(nil)
 	timeService.On("WaitMilliseconds", int64(20)).Maybe()
-	filledOrder := model.BinanceOrder{
+	filledOrder := model.ExchangeOrder{
 		OrderId:             "999",
 		Symbol:              "TRXUSDT",
 		Side:                "SELL",
@@ -1032,9 +1044,9 @@ func TestSellClosingTrxAction(t *testing.T) {
 
 	profitServiceMock.On("GetMinClosePrice", openedOrder, openedOrder.Price).Return(openedOrder.Price * (100 + 2.25) / 100)
 	priceCalculator.On("CalculateSell", tradeLimit, openedOrder).Return(0.10692, nil)
-	orderRepository.On("DeleteBinanceOrder", filledOrder).Times(1)
+	orderRepository.On("DeleteExchangeOrder", filledOrder).Times(1)
 
-	err := orderExecutor.Sell(tradeLimit, openedOrder, 0.10692, 382.1, false)
+	err := orderExecutor.Sell(tradeLimit, openedOrder, 0.10692, 382.1, model.OrderCloseReasonTakeProfit, nil)
 	assertion.Nil(err)
 	assertion.Equal("closed", orderRepository.Updated.Status)
 	assertion.Equal(0.10457, orderRepository.Updated.Price)
@@ -1087,6 +1099,8 @@ func TestCreateSwapAction(t *testing.T) {
 	order := model.Order{
 		Id:               777,
 		Symbol:           "BTCUSDT",
+		BaseAsset:        "BTC",
+		QuoteAsset:       "USDT",
 		ExecutedQuantity: 1002.00,
 	}
 
@@ -1179,6 +1193,8 @@ func TestCreateSwapActionLessBalance(t *testing.T) {
 	order := model.Order{
 		Id:               777,
 		Symbol:           "BTCUSDT",
+		BaseAsset:        "BTC",
+		QuoteAsset:       "USDT",
 		ExecutedQuantity: 1002.00,
 	}
 
@@ -1272,7 +1288,7 @@ func TestCheckIsTimeToCancel(t *testing.T) {
 	limit := model.TradeLimit{
 		MinPrice: 0.01,
 	}
-	binanceOrder := model.BinanceOrder{
+	exchangeOrder := model.ExchangeOrder{
 		Status: "NEW",
 		Side:   "SELL",
 		Price:  100.00,
@@ -1304,8 +1320,8 @@ func TestCheckIsTimeToCancel(t *testing.T) {
 	orderRepository.On("GetOpenedOrderCached", "SOLUSDT", "BUY").Return(&openedPosition)
 	priceCalculator.On("CalculateSell", limit, openedPosition).Return(99.00, nil)
 
-	assertion.True(orderExecutor.CheckIsTimeToCancel(limit, &binanceOrder, orderManageChannel, control))
-	assertion.False(orderExecutor.CheckIsTimeToCancel(limit, &binanceOrder, orderManageChannel, control))
+	assertion.True(orderExecutor.CheckIsTimeToCancel(limit, &exchangeOrder, orderManageChannel, control))
+	assertion.False(orderExecutor.CheckIsTimeToCancel(limit, &exchangeOrder, orderManageChannel, control))
 }
 
 func TestCheckIsTimeToCancelSamePrice(t *testing.T) {
@@ -1355,7 +1371,7 @@ func TestCheckIsTimeToCancelSamePrice(t *testing.T) {
 	limit := model.TradeLimit{
 		MinPrice: 0.01,
 	}
-	binanceOrder := model.BinanceOrder{
+	exchangeOrder := model.ExchangeOrder{
 		Status: "NEW",
 		Side:   "SELL",
 		Price:  100.00,
@@ -1375,7 +1391,7 @@ func TestCheckIsTimeToCancelSamePrice(t *testing.T) {
 	orderRepository.On("GetOpenedOrderCached", "SOLUSDT", "BUY").Return(&openedPosition)
 	priceCalculator.On("CalculateSell", limit, openedPosition).Return(100.00, nil)
 
-	assertion.False(orderExecutor.CheckIsTimeToCancel(limit, &binanceOrder, orderManageChannel, control))
+	assertion.False(orderExecutor.CheckIsTimeToCancel(limit, &exchangeOrder, orderManageChannel, control))
 }
 
 func TestCheckIsTimeToCancelPriceIsMoreThanOrder(t *testing.T) {
@@ -1425,7 +1441,7 @@ func TestCheckIsTimeToCancelPriceIsMoreThanOrder(t *testing.T) {
 	limit := model.TradeLimit{
 		MinPrice: 0.01,
 	}
-	binanceOrder := model.BinanceOrder{
+	exchangeOrder := model.ExchangeOrder{
 		Status: "NEW",
 		Side:   "SELL",
 		Price:  100.00,
@@ -1444,7 +1460,7 @@ func TestCheckIsTimeToCancelPriceIsMoreThanOrder(t *testing.T) {
 
 	orderRepository.On("GetOpenedOrderCached", "SOLUSDT", "BUY").Return(&openedPosition)
 
-	assertion.False(orderExecutor.CheckIsTimeToCancel(limit, &binanceOrder, orderManageChannel, control))
+	assertion.False(orderExecutor.CheckIsTimeToCancel(limit, &exchangeOrder, orderManageChannel, control))
 }
 
 func TestAvgPriceCalculation(t *testing.T) {
@@ -1467,3 +1483,107 @@ func TestAvgPriceCalculation(t *testing.T) {
 	avgPrice = orderExecutor.GetAvgPrice(opened, extra)
 	assertion.Equal(86.66666666666667, avgPrice)
 }
+
+func TestSellStopLossByMarketOrder(t *testing.T) {
+	assertion := assert.New(t)
+
+	profitServiceMock := new(ProfitServiceMock)
+	balanceService := new(BalanceServiceMock)
+	binance := new(ExchangeOrderAPIMock)
+	orderRepository := new(OrderStorageMock)
+	exchangeRepository := new(ExchangeTradeInfoMock)
+	timeService := new(TimeServiceMock)
+	telegramNotificatorMock := new(TelegramNotificatorMock)
+	botServiceMock := new(BotServiceMock)
+	botServiceMock.On("UseSwapCapital").Return(false)
+
+	lockChannel := make(chan model.Lock)
+	orderExecutor := exchange.OrderExecutor{
+		CurrentBot: &model.Bot{
+			Id:      999,
+			BotUuid: uuid.New().String(),
+		},
+		TimeService:        timeService,
+		BalanceService:     balanceService,
+		Binance:            binance,
+		OrderRepository:    orderRepository,
+		ExchangeRepository: exchangeRepository,
+		ProfitService:      profitServiceMock,
+		Formatter:          &utils.Formatter{},
+		BotService:         botServiceMock,
+		LockChannel:        &lockChannel,
+		Lock:               make(map[string]bool),
+		TradeLockMutex:     sync.RWMutex{},
+		CallbackManager:    telegramNotificatorMock,
+	}
+
+	go func(orderExecutor *exchange.OrderExecutor) {
+		for {
+			lock := <-lockChannel
+			orderExecutor.TradeLockMutex.Lock()
+			orderExecutor.Lock[lock.Symbol] = lock.IsLocked
+			orderExecutor.TradeLockMutex.Unlock()
+		}
+	}(&orderExecutor)
+
+	tradeLimit := model.TradeLimit{
+		Symbol:      "ETHUSDT",
+		MinPrice:    0.01,
+		MinQuantity: 0.0001,
+	}
+	openedOrder := model.Order{
+		Id:               9998,
+		Status:           "opened",
+		Symbol:           "ETHUSDT",
+		BaseAsset:        "ETH",
+		QuoteAsset:       "USDT",
+		Quantity:         0.009,
+		Price:            2212.92,
+		CreatedAt:        time.Now().Format("2006-01-02 15:04:05"),
+		ExecutedQuantity: 0.009,
+	}
+	marketOrder := model.ExchangeOrder{
+		OrderId:             "1000",
+		Symbol:              "ETHUSDT",
+		Side:                "SELL",
+		Type:                model.OrderTypeMarket,
+		ExecutedQty:         0.009,
+		OrigQty:             0.009,
+		Status:              "FILLED",
+		Price:               2100.10,
+		CummulativeQuoteQty: 0.009 * 2100.10,
+	}
+
+	timeService.On("GetNowDateTimeString").Return("2023-12-28 00:52:00")
+	orderRepository.On("GetExchangeOrder", "ETHUSDT", "SELL").Return(nil)
+	binance.On("GetOpenedOrders").Return([]model.ExchangeOrder{}, nil)
+	binance.On("MarketOrder", "ETHUSDT", 0.009, "SELL").Return(marketOrder, nil)
+	orderRepository.On("SetExchangeOrder", marketOrder)
+	orderRepository.On("GetManualOrder", "ETHUSDT").Return(nil)
+	orderId := int64(100)
+	orderRepository.On("Create", mock.Anything).Return(&orderId, nil)
+	orderRepository.On("DeleteManualOrder", "ETHUSDT")
+	orderRepository.On("Find", orderId).Return(model.Order{}, nil)
+	orderRepository.On("Find", int64(9998)).Return(openedOrder, nil)
+	orderRepository.On("GetClosesOrderList", openedOrder).Return([]model.Order{
+		{
+			Status:           "closed",
+			ExecutedQuantity: 0.009,
+			Price:            2100.10,
+		},
+	})
+	orderRepository.On("Update", mock.Anything).Return(nil)
+	orderRepository.On("DeleteExchangeOrder", marketOrder)
+	balanceService.On("InvalidateBalanceCache", "USDT")
+	balanceService.On("InvalidateBalanceCache", "ETH")
+	telegramNotificatorMock.On("SellOrder", mock.Anything, mock.Anything, mock.Anything)
+	profitServiceMock.On("GetMinClosePrice", openedOrder, openedOrder.Price).Return(openedOrder.Price * 1.01)
+
+	err := orderExecutor.Sell(tradeLimit, openedOrder, 2100.00, 0.009, model.OrderCloseReasonStopLoss, nil)
+	assertion.Nil(err)
+	binance.AssertNumberOfCalls(t, "MarketOrder", 1)
+	binance.AssertNumberOfCalls(t, "LimitOrder", 0)
+	assertion.Equal(2100.10, orderRepository.Created.Price)
+	assertion.Equal(model.OrderCloseReasonStopLoss, *orderRepository.Created.CloseReason)
+	assertion.Equal("closed", orderRepository.Updated.Status)
+}
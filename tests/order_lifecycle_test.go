@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOrderLifecycleTransitions(t *testing.T) {
+	assertion := assert.New(t)
+
+	lifecycle := model.OrderLifecycle{
+		Symbol: "ETHUSDT",
+		Side:   "BUY",
+		State:  model.OrderLifecycleStatePending,
+	}
+
+	assertion.NotNil(lifecycle.Transit(model.OrderLifecycleStateCompleted, 100))
+	assertion.Equal(model.OrderLifecycleStatePending, lifecycle.State)
+
+	assertion.Nil(lifecycle.Transit(model.OrderLifecycleStatePlaced, 100))
+	assertion.Nil(lifecycle.Transit(model.OrderLifecycleStatePlaced, 101))
+	assertion.Equal(int64(100), lifecycle.UpdatedAt)
+	assertion.Nil(lifecycle.Transit(model.OrderLifecycleStateCancelling, 102))
+	assertion.Nil(lifecycle.Transit(model.OrderLifecycleStateExecuted, 103))
+	assertion.False(lifecycle.IsFinal())
+	assertion.NotNil(lifecycle.Transit(model.OrderLifecycleStateFailed, 104))
+	assertion.Nil(lifecycle.Transit(model.OrderLifecycleStateCompleted, 105))
+	assertion.True(lifecycle.IsFinal())
+	assertion.Equal(int64(105), lifecycle.UpdatedAt)
+}
+
+func TestShutdownManager(t *testing.T) {
+	assertion := assert.New(t)
+
+	shutdownManager := service.ShutdownManager{}
+	assertion.False(shutdownManager.IsStopping())
+	assertion.True(shutdownManager.Begin())
+
+	assertion.False(shutdownManager.Shutdown(time.Millisecond * 50))
+	assertion.True(shutdownManager.IsStopping())
+	assertion.False(shutdownManager.Begin())
+
+	shutdownManager.End()
+	assertion.True(shutdownManager.Shutdown(time.Millisecond * 50))
+}
+
+func TestCheckIsShutdown(t *testing.T) {
+	assertion := assert.New(t)
+
+	shutdownManager := service.ShutdownManager{}
+	orderExecutor := exchange.OrderExecutor{
+		CurrentBot:      &model.Bot{Id: 999, BotUuid: uuid.New().String()},
+		ShutdownManager: &shutdownManager,
+		Formatter:       &utils.Formatter{},
+	}
+
+	exchangeOrder := model.ExchangeOrder{
+		OrderId:     "100",
+		Status:      "PARTIALLY_FILLED",
+		Side:        "BUY",
+		Price:       100.00,
+		OrigQty:     1.00,
+		ExecutedQty: 0.40,
+		Symbol:      "SOLUSDT",
+	}
+	orderManageChannel := make(chan string)
+	control := make(chan string)
+
+	assertion.False(orderExecutor.CheckIsShutdown(&exchangeOrder, orderManageChannel, control))
+
+	go func() {
+		request := <-orderManageChannel
+		assertion.Equal("cancel", request)
+		control <- "stop"
+	}()
+
+	shutdownManager.Shutdown(time.Millisecond)
+	assertion.True(orderExecutor.CheckIsShutdown(&exchangeOrder, orderManageChannel, control))
+}
+
+func TestResumeDropsNotPlacedOrder(t *testing.T) {
+	assertion := assert.New(t)
+
+	binance := new(ExchangeOrderAPIMock)
+	orderRepository := new(OrderStorageMock)
+	lifecycleRepository := new(OrderLifecycleStorageMock)
+	timeService := new(TimeServiceMock)
+
+	orderExecutor := exchange.OrderExecutor{
+		CurrentBot:          &model.Bot{Id: 999, BotUuid: uuid.New().String(), Exchange: "binance"},
+		TimeService:         timeService,
+		Binance:             binance,
+		OrderRepository:     orderRepository,
+		LifecycleRepository: lifecycleRepository,
+		Formatter:           &utils.Formatter{},
+		Lock:                make(map[string]bool),
+		TradeLockMutex:      sync.RWMutex{},
+	}
+
+	lifecycleRepository.On("GetOrderLifecycle", "ETHUSDT", "BUY").Return(&model.OrderLifecycle{
+		Symbol:    "ETHUSDT",
+		Side:      "BUY",
+		Operation: model.OrderLifecycleOperationBuy,
+		State:     model.OrderLifecycleStatePending,
+		Price:     2200.00,
+		Quantity:  0.01,
+	})
+	lifecycleRepository.On("GetOrderLifecycle", "ETHUSDT", "SELL").Return(nil)
+	lifecycleRepository.On("DeleteOrderLifecycle", "ETHUSDT", "BUY").Once()
+	orderRepository.On("GetExchangeOrder", "ETHUSDT", "BUY").Return(nil)
+	binance.On("GetOpenedOrders").Return([]model.ExchangeOrder{
+		{OrderId: "1", Symbol: "BTCUSDT", Side: "BUY", Status: "NEW"},
+	}, nil)
+	timeService.On("GetNowUnix").Return(1700000000)
+
+	orderExecutor.Resume("ETHUSDT")
+
+	lifecycleRepository.AssertCalled(t, "DeleteOrderLifecycle", "ETHUSDT", "BUY")
+	lifecycleRepository.AssertNotCalled(t, "SaveOrderLifecycle", mock.Anything)
+	assertion.Equal(1, len(binance.Calls))
+}
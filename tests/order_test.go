@@ -44,3 +44,33 @@ func TestOrderExtraBudget(t *testing.T) {
 	extraBudget = order.GetAvailableExtraBudget(kline, false)
 	assertion.Equal(0.04999999999999716, extraBudget)
 }
+
+func TestSymbolQuoteAsset(t *testing.T) {
+	assertion := assert.New(t)
+
+	baseAsset, quoteAsset := model.SplitSymbol("BTCFDUSD")
+	assertion.Equal("BTC", baseAsset)
+	assertion.Equal("FDUSD", quoteAsset)
+
+	baseAsset, quoteAsset = model.SplitSymbol("ETHBTC")
+	assertion.Equal("ETH", baseAsset)
+	assertion.Equal("BTC", quoteAsset)
+
+	// order assets are resolved from trade limit, symbol is split only if they are unknown
+	order := model.Order{Symbol: "XUSDTEUR", BaseAsset: "XUSDT", QuoteAsset: "EUR"}
+	assertion.Equal("XUSDT", order.GetBaseAsset())
+	assertion.Equal("EUR", order.GetQuoteAsset())
+
+	// trade limit assets are unknown (cached order, deleted trade limit)
+	order = model.Order{Symbol: "ETHFDUSD"}
+	assertion.Equal("ETH", order.GetBaseAsset())
+	assertion.Equal("FDUSD", order.GetQuoteAsset())
+
+	tradeLimit := model.TradeLimit{Symbol: "PEPEUSDT"}
+	assertion.Equal("PEPE", tradeLimit.GetBaseAsset())
+	assertion.Equal("USDT", tradeLimit.GetQuoteAsset())
+
+	tradeLimit = model.TradeLimit{Symbol: "XUSDTEUR", BaseAsset: "XUSDT", QuoteAsset: "EUR"}
+	assertion.Equal("XUSDT", tradeLimit.GetBaseAsset())
+	assertion.Equal("EUR", tradeLimit.GetQuoteAsset())
+}
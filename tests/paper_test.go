@@ -0,0 +1,195 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"sync"
+	"testing"
+	"time"
+)
+
+func getPaperExchange(exchangeApi *ExchangeAPIMock) *client.Paper {
+	exchangeApi.On("GetExchangeData", []string{"ETHUSDT"}).Return(&model.ExchangeInfo{
+		Symbols: []model.ExchangeSymbol{
+			{
+				Symbol:     "ETHUSDT",
+				IsTrading:  true,
+				BaseAsset:  "ETH",
+				QuoteAsset: "USDT",
+			},
+		},
+	}, nil)
+
+	return &client.Paper{
+		Exchange:   exchangeApi,
+		FeePercent: 0.1,
+		Balances:   map[string]float64{"USDT": 1000.00},
+		Orders:     make(map[string]model.ExchangeOrder),
+		Symbols:    make(map[string]model.ExchangeSymbol),
+		Lock:       &sync.Mutex{},
+	}
+}
+
+func getPaperDepth(bid float64, ask float64) *model.OrderBook {
+	return &model.OrderBook{
+		Bids: [][2]model.Number{{{Value: bid}, {Value: 10}}},
+		Asks: [][2]model.Number{{{Value: ask}, {Value: 10}}},
+	}
+}
+
+func TestPaperLimitOrderFilledByMarket(t *testing.T) {
+	assertion := assert.New(t)
+
+	exchangeApi := new(ExchangeAPIMock)
+	paper := getPaperExchange(exchangeApi)
+	exchangeApi.On("GetDepth", "ETHUSDT", int64(5)).Return(getPaperDepth(1999.00, 2000.00))
+
+	order, err := paper.LimitOrder("ETHUSDT", 0.1, 2010.00, "BUY", "GTC")
+	assertion.Nil(err)
+	assertion.Equal(model.ExchangeOrderStatusFilled, order.Status)
+	assertion.Equal(2000.00, order.Price)
+	assertion.Equal(0.1, order.ExecutedQty)
+
+	status, _ := paper.GetAccountStatus()
+	balances := make(map[string]model.Balance)
+	for _, balance := range status.Balances {
+		balances[balance.Asset] = balance
+	}
+
+	assertion.InDelta(800.00, balances["USDT"].Free, 0.000001)
+	assertion.InDelta(0.0999, balances["ETH"].Free, 0.000001)
+
+	_, err = paper.LimitOrder("ETHUSDT", 0.1, 1990.00, "SELL", "GTC")
+	assertion.Equal("Account has insufficient balance for requested action.", err.Error())
+}
+
+func TestPaperLimitOrderWaitsForPrice(t *testing.T) {
+	assertion := assert.New(t)
+
+	exchangeApi := new(ExchangeAPIMock)
+	paper := getPaperExchange(exchangeApi)
+	exchangeApi.On("GetDepth", "ETHUSDT", int64(5)).Return(getPaperDepth(1999.00, 2000.00)).Twice()
+
+	order, err := paper.LimitOrder("ETHUSDT", 0.1, 1950.00, "BUY", "GTC")
+	assertion.Nil(err)
+	assertion.Equal(model.ExchangeOrderStatusNew, order.Status)
+
+	status, _ := paper.GetAccountStatus()
+	for _, balance := range status.Balances {
+		if balance.Asset == "USDT" {
+			assertion.InDelta(805.00, balance.Free, 0.000001)
+		}
+	}
+
+	exchangeApi.On("GetDepth", "ETHUSDT", int64(5)).Return(getPaperDepth(1940.00, 1945.00))
+	order, err = paper.QueryOrder("ETHUSDT", order.OrderId)
+	assertion.Nil(err)
+	assertion.Equal(model.ExchangeOrderStatusFilled, order.Status)
+	assertion.Equal(1945.00, order.Price)
+
+	opened, _ := paper.GetOpenedOrders()
+	assertion.Len(opened, 0)
+}
+
+func TestPaperLimitOrderCancel(t *testing.T) {
+	assertion := assert.New(t)
+
+	exchangeApi := new(ExchangeAPIMock)
+	paper := getPaperExchange(exchangeApi)
+	exchangeApi.On("GetDepth", "ETHUSDT", int64(5)).Return(getPaperDepth(1999.00, 2000.00))
+
+	order, _ := paper.LimitOrder("ETHUSDT", 0.1, 1950.00, "BUY", "GTC")
+	canceled, err := paper.CancelOrder("ETHUSDT", order.OrderId)
+	assertion.Nil(err)
+	assertion.Equal(model.ExchangeOrderStatusCanceled, canceled.Status)
+
+	expired, _ := paper.LimitOrder("ETHUSDT", 0.1, 1950.00, "BUY", "IOC")
+	assertion.Equal(model.ExchangeOrderStatusExpired, expired.Status)
+
+	status, _ := paper.GetAccountStatus()
+	for _, balance := range status.Balances {
+		if balance.Asset == "USDT" {
+			assertion.Equal(1000.00, balance.Free)
+			assertion.Equal(0.00, balance.Locked)
+		}
+	}
+}
+
+func TestPaperMarketOrders(t *testing.T) {
+	assertion := assert.New(t)
+
+	exchangeApi := new(ExchangeAPIMock)
+	paper := getPaperExchange(exchangeApi)
+	exchangeApi.On("GetDepth", "ETHUSDT", int64(5)).Return(getPaperDepth(1999.00, 2000.00))
+
+	order, err := paper.QuoteMarketOrder("ETHUSDT", 500.00, "BUY")
+	assertion.Nil(err)
+	assertion.Equal(model.ExchangeOrderStatusFilled, order.Status)
+	assertion.Equal(model.OrderTypeMarket, order.Type)
+	assertion.Equal(2000.00, order.Price)
+	assertion.Equal(0.25, order.ExecutedQty)
+	assertion.Equal(500.00, order.CummulativeQuoteQty)
+
+	order, err = paper.MarketOrder("ETHUSDT", 0.2, "SELL")
+	assertion.Nil(err)
+	assertion.Equal(model.ExchangeOrderStatusFilled, order.Status)
+	assertion.Equal(1999.00, order.Price)
+	assertion.InDelta(899.4002, paper.Balances["USDT"], 0.000001)
+	assertion.InDelta(0.04975, paper.Balances["ETH"], 0.000001)
+
+	_, err = paper.MarketOrder("ETHUSDT", 0.2, "SELL")
+	assertion.Equal("Account has insufficient balance for requested action.", err.Error())
+}
+
+func TestPaperPostOnlyOrder(t *testing.T) {
+	assertion := assert.New(t)
+
+	exchangeApi := new(ExchangeAPIMock)
+	paper := getPaperExchange(exchangeApi)
+	exchangeApi.On("GetDepth", "ETHUSDT", int64(5)).Return(getPaperDepth(1999.00, 2000.00))
+
+	_, err := paper.PostOnlyOrder("ETHUSDT", 0.1, 2000.00, "BUY")
+	assertion.Equal("Order would immediately match and take.", err.Error())
+
+	order, err := paper.PostOnlyOrder("ETHUSDT", 0.1, 1990.00, "BUY")
+	assertion.Nil(err)
+	assertion.Equal(model.ExchangeOrderStatusNew, order.Status)
+	assertion.Equal(model.OrderTypeLimitMaker, order.Type)
+}
+
+func TestPaperDepthIsNotLoadedUnderLock(t *testing.T) {
+	assertion := assert.New(t)
+
+	started := make(chan bool)
+	release := make(chan bool)
+	exchangeApi := new(ExchangeAPIMock)
+	paper := getPaperExchange(exchangeApi)
+	exchangeApi.On("GetDepth", "ETHUSDT", int64(5)).Run(func(args mock.Arguments) {
+		close(started)
+		<-release
+	}).Return(getPaperDepth(1999.00, 2000.00))
+
+	placed := make(chan model.ExchangeOrder)
+	go func() {
+		order, _ := paper.LimitOrder("ETHUSDT", 0.1, 2000.00, "BUY", "GTC")
+		placed <- order
+	}()
+	<-started
+
+	// depth request is in progress, account is still available
+	queried := make(chan bool)
+	go func() {
+		_, _ = paper.GetAccountStatus()
+		close(queried)
+	}()
+	select {
+	case <-queried:
+	case <-time.After(time.Second):
+		assertion.Fail("Paper account is blocked by depth request")
+	}
+
+	close(release)
+	assertion.Equal(model.ExchangeOrderStatusFilled, (<-placed).Status)
+}
@@ -353,3 +353,35 @@ func TestCalculateBuyPriceBySignal(t *testing.T) {
 	assertion.Equal(2080.00, priceModel.Price)
 	assertion.Nil(priceModel.Error)
 }
+
+func TestBestFrameBuyUsesDeepOrderBook(t *testing.T) {
+	assertion := assert.New(t)
+
+	profitService := new(ProfitServiceMock)
+	priceCalculator := exchange.PriceCalculator{
+		ProfitService: profitService,
+		Formatter:     &utils.Formatter{},
+	}
+
+	tradeLimit := model.TradeLimit{Symbol: "ETHUSDT", MinPrice: 0.01}
+	depth := model.OrderBookModel{Symbol: "ETHUSDT"}
+	for level := 0; level < 30; level++ {
+		price := 2000.00 - float64(level)
+		depth.Bids = append(depth.Bids, [2]model.Number{{Value: price}, {Value: 1.00}})
+		profitService.On("GetMinClosePrice", tradeLimit, price).Return(price + 50.00)
+	}
+
+	// only the level 25 can be closed within the frame
+	frame := model.Frame{Low: 1900.00, AvgLow: 1950.00, AvgHigh: 2025.00}
+
+	prices, err := priceCalculator.GetBestFrameBuy(tradeLimit, depth, frame)
+	assertion.Nil(err)
+	assertion.Equal([2]float64{1950.00, 1975.00}, prices)
+
+	_, err = priceCalculator.GetBestFrameBuy(tradeLimit, depth.Top(20), frame)
+	assertion.NotNil(err)
+
+	position, book := depth.GetBidPosition(1974.50)
+	assertion.Equal(25, position)
+	assertion.Equal(1975.00, book[0].Value)
+}
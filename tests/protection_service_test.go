@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"testing"
+)
+
+type protectionServiceMocks struct {
+	Binance         *ExchangeOrderAPIMock
+	ProtectionApi   *ExchangeProtectionAPIMock
+	OrderRepository *OrderStorageMock
+	CallbackManager *TelegramNotificatorMock
+}
+
+func getProtectionService(order model.Order, enabled bool) (*exchange.ProtectionService, protectionServiceMocks) {
+	mocks := protectionServiceMocks{
+		Binance:         new(ExchangeOrderAPIMock),
+		ProtectionApi:   new(ExchangeProtectionAPIMock),
+		OrderRepository: new(OrderStorageMock),
+		CallbackManager: new(TelegramNotificatorMock),
+	}
+	exchangeRepository := new(BaseTradeStorageMock)
+	profitService := new(ProfitServiceMock)
+	balanceService := new(BalanceServiceMock)
+	botService := new(BotServiceMock)
+	timeService := new(TimeServiceMock)
+
+	botService.On("GetBot").Return(model.Bot{RiskConfig: model.RiskConfig{ExchangeProtection: enabled}})
+	botService.On("UseSwapCapital").Return(false)
+	timeService.On("GetNowDateTimeString").Return("2024-05-10 12:00:00")
+	balanceService.On("GetAssetBalance", "BTC", false).Return(1.00, nil)
+	balanceService.On("InvalidateBalanceCache", mock.Anything).Return()
+	profitService.On("GetMinClosePrice", mock.Anything, 100.00).Return(103.00)
+	exchangeRepository.On("GetTradeLimit", "BTCUSDT").Return(model.TradeLimit{
+		Symbol:      "BTCUSDT",
+		MinPrice:    0.01,
+		MinQuantity: 0.0001,
+		MinNotional: 5.00,
+	}, nil)
+	mocks.OrderRepository.On("Find", order.Id).Return(order, nil)
+
+	return &exchange.ProtectionService{
+		Binance:            mocks.Binance,
+		ProtectionApi:      mocks.ProtectionApi,
+		OrderRepository:    mocks.OrderRepository,
+		ExchangeRepository: exchangeRepository,
+		ProfitService:      profitService,
+		BalanceService:     balanceService,
+		BotService:         botService,
+		CallbackManager:    mocks.CallbackManager,
+		Formatter:          &utils.Formatter{},
+		TimeService:        timeService,
+		CurrentBot:         &model.Bot{Id: 1, Exchange: "binance"},
+	}, mocks
+}
+
+func getProtectedOrder(protection *model.ProtectionOrder) model.Order {
+	return model.Order{
+		Id:               10,
+		Symbol:           "BTCUSDT",
+		BaseAsset:        "BTC",
+		QuoteAsset:       "USDT",
+		Price:            100.00,
+		Quantity:         1.00,
+		ExecutedQuantity: 1.00,
+		Status:           "opened",
+		Operation:        "BUY",
+		ProfitOptions: model.ProfitOptions{
+			{Index: 0, OptionValue: 1, OptionUnit: model.ProfitOptionUnitHour, OptionPercent: 3.00},
+			{Index: 1, OptionPercent: -5.00, OptionType: model.ProfitOptionTypeStopLoss},
+		},
+		Protection: protection,
+	}
+}
+
+func TestProtectionIsPlaced(t *testing.T) {
+	assertion := assert.New(t)
+
+	order := getProtectedOrder(nil)
+	protectionService, mocks := getProtectionService(order, true)
+
+	protection := model.ProtectionOrder{OrderListId: "1", OrderIds: []string{"2", "3"}, Quantity: 1.00, TakeProfitPrice: 103.00, StopPrice: 95.00}
+	mocks.ProtectionApi.On("ProtectionOrder", "BTCUSDT", 1.00, 103.00, 95.00).Return(protection, nil)
+	mocks.OrderRepository.On("Update", mock.Anything).Return(nil)
+
+	assertion.Nil(protectionService.Protect(order))
+	updated := mocks.OrderRepository.Calls[1].Arguments.Get(0).(model.Order)
+	assertion.Equal("1", updated.Protection.OrderListId)
+	assertion.Equal(95.00, updated.Protection.StopPrice)
+}
+
+func TestProtectionIsNotReplacedIfSame(t *testing.T) {
+	assertion := assert.New(t)
+
+	order := getProtectedOrder(&model.ProtectionOrder{OrderListId: "1", Quantity: 1.00, TakeProfitPrice: 103.00, StopPrice: 95.00})
+	protectionService, mocks := getProtectionService(order, true)
+
+	assertion.Nil(protectionService.Protect(order))
+	mocks.ProtectionApi.AssertNotCalled(t, "ProtectionOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mocks.ProtectionApi.AssertNotCalled(t, "CancelProtectionOrder", mock.Anything, mock.Anything)
+}
+
+func TestProtectionIsDisabled(t *testing.T) {
+	assertion := assert.New(t)
+
+	order := getProtectedOrder(nil)
+	protectionService, mocks := getProtectionService(order, false)
+
+	assertion.Nil(protectionService.Protect(order))
+	mocks.ProtectionApi.AssertNotCalled(t, "ProtectionOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProtectionStopLossExecution(t *testing.T) {
+	assertion := assert.New(t)
+
+	protection := model.ProtectionOrder{OrderListId: "1", OrderIds: []string{"2", "3"}, Quantity: 1.00, TakeProfitPrice: 103.00, StopPrice: 95.00}
+	order := getProtectedOrder(&protection)
+	protectionService, mocks := getProtectionService(order, true)
+
+	mocks.Binance.On("QueryOrder", "BTCUSDT", "2").Return(model.ExchangeOrder{OrderId: "2", Status: "EXPIRED"}, nil)
+	mocks.Binance.On("QueryOrder", "BTCUSDT", "3").Return(model.ExchangeOrder{
+		OrderId:     "3",
+		Symbol:      "BTCUSDT",
+		Status:      "FILLED",
+		Price:       94.90,
+		OrigQty:     1.00,
+		ExecutedQty: 1.00,
+	}, nil)
+	mocks.ProtectionApi.On("CancelProtectionOrder", "BTCUSDT", protection).Return(nil)
+	mocks.OrderRepository.On("Create", mock.Anything).Return(new(int64), nil)
+	mocks.OrderRepository.On("Update", mock.Anything).Return(nil)
+	mocks.CallbackManager.On("SellOrder", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	assertion.True(protectionService.CheckExecution(order))
+
+	sell := mocks.OrderRepository.Calls[0].Arguments.Get(0).(model.Order)
+	assertion.Equal(model.OrderCloseReasonStopLoss, *sell.CloseReason)
+	assertion.Equal(94.90, sell.Price)
+	assertion.Equal(int64(10), *sell.ClosesOrder)
+
+	closed := mocks.OrderRepository.Calls[1].Arguments.Get(0).(model.Order)
+	assertion.Equal("closed", closed.Status)
+	assertion.Nil(closed.Protection)
+}
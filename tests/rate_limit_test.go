@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/open-soft/go-crypto-bot/src/client"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimitGovernorClassBudget(t *testing.T) {
+	assertion := assert.New(t)
+
+	governor := client.RateLimitGovernor{
+		Interval:      time.Hour,
+		WeightLimit:   100,
+		OrderInterval: time.Hour,
+		OrderLimit:    2,
+	}
+
+	assertion.True(governor.TryAcquire(model.RateLimitClassMarket, 70))
+	assertion.False(governor.TryAcquire(model.RateLimitClassMarket, 1))
+	assertion.True(governor.TryAcquire(model.RateLimitClassAccount, 20))
+	assertion.False(governor.TryAcquire(model.RateLimitClassAccount, 1))
+	assertion.True(governor.TryAcquire(model.RateLimitClassOrder, 1))
+	assertion.True(governor.TryAcquire(model.RateLimitClassOrder, 1))
+	assertion.False(governor.TryAcquire(model.RateLimitClassOrder, 1))
+
+	usage := governor.GetUsage()
+	assertion.Equal(int64(92), usage.UsedWeight)
+	assertion.Equal(int64(2), usage.OrderCount)
+	assertion.Equal(92.00, usage.GetWeightPercent())
+}
+
+func TestRateLimitGovernorOrderPriority(t *testing.T) {
+	assertion := assert.New(t)
+
+	governor := client.RateLimitGovernor{
+		Interval:      time.Hour,
+		WeightLimit:   100,
+		OrderInterval: time.Millisecond * 300,
+		OrderLimit:    1,
+	}
+
+	// wait for the start of the order window
+	time.Sleep(time.Until(time.Now().Truncate(time.Millisecond * 300).Add(time.Millisecond * 300)))
+	assertion.True(governor.TryAcquire(model.RateLimitClassOrder, 1))
+
+	done := make(chan bool)
+	go func() {
+		governor.Acquire(model.RateLimitClassOrder, 1)
+		done <- true
+	}()
+
+	time.Sleep(time.Millisecond * 100)
+	assertion.Equal(int64(1), governor.GetUsage().GetQueuedCount())
+	assertion.False(governor.TryAcquire(model.RateLimitClassMarket, 1))
+
+	<-done
+	assertion.Equal(int64(0), governor.GetUsage().GetQueuedCount())
+	assertion.True(governor.TryAcquire(model.RateLimitClassMarket, 1))
+}
+
+func TestRateLimitGovernorBinanceRateLimits(t *testing.T) {
+	assertion := assert.New(t)
+
+	content, _ := os.ReadFile("example/binance_rate_limits.json")
+	var response model.BinanceRateLimitResponse
+	assertion.Nil(json.Unmarshal(content, &response))
+
+	governor := client.RateLimitGovernor{
+		Interval:      time.Hour,
+		WeightLimit:   1200,
+		OrderInterval: time.Hour,
+		OrderLimit:    10,
+	}
+	governor.UpdateBinanceRateLimits(response.RateLimits)
+
+	usage := governor.GetUsage()
+	assertion.Equal(int64(5850), usage.UsedWeight)
+	assertion.Equal(int64(6000), usage.WeightLimit)
+	assertion.Equal(int64(12), usage.OrderCount)
+	assertion.Equal(int64(50), usage.OrderLimit)
+
+	assertion.False(governor.TryAcquire(model.RateLimitClassMarket, 2))
+	assertion.True(governor.TryAcquire(model.RateLimitClassOrder, 1))
+
+	request := model.SocketRequest{Method: "depth", Params: map[string]any{"limit": int64(500)}}
+	class, cost := client.GetBinanceRequestClass(request)
+	assertion.Equal(model.RateLimitClassMarket, class)
+	assertion.Equal(int64(25), cost)
+}
+
+func TestRateLimitGovernorByBitHeaders(t *testing.T) {
+	assertion := assert.New(t)
+
+	governor := client.RateLimitGovernor{
+		Interval:    time.Hour,
+		WeightLimit: 600,
+		Classify:    client.GetByBitRequestClass,
+	}
+
+	headers := http.Header{}
+	headers.Set("X-Bapi-Limit", "20")
+	headers.Set("X-Bapi-Limit-Status", "0")
+	headers.Set("X-Bapi-Limit-Reset-Timestamp", strconv.FormatInt(time.Now().Add(time.Minute).UnixMilli(), 10))
+	governor.ReadHeaders("POST", "https://api.bybit.com/v5/order/create", headers)
+
+	assertion.False(governor.TryAcquire(model.RateLimitClassOrder, 1))
+	assertion.True(governor.TryAcquire(model.RateLimitClassMarket, 1))
+	assertion.True(governor.TryAcquire(model.RateLimitClassAccount, 1))
+}
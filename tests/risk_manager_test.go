@@ -0,0 +1,224 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"testing"
+	"time"
+)
+
+func getRiskManager(config model.RiskConfig, realizedProfit float64) (*exchange.RiskManager, *TelegramNotificatorMock) {
+	return getRiskManagerWithLimits(config, map[string]float64{"BTCUSDT": realizedProfit}, []model.TradeLimit{
+		{Symbol: "BTCUSDT"},
+		{Symbol: "ETHUSDT"},
+		{Symbol: "SOLUSDT"},
+	})
+}
+
+func getRiskManagerWithLimits(config model.RiskConfig, realizedProfit map[string]float64, tradeLimits []model.TradeLimit) (*exchange.RiskManager, *TelegramNotificatorMock) {
+	orderRepository := new(OrderCachedReaderMock)
+	profitRepository := new(OrderProfitReaderMock)
+	exchangeRepository := new(BaseTradeStorageMock)
+	balanceService := new(BalanceServiceMock)
+	botService := new(BotServiceMock)
+	callbackManager := new(TelegramNotificatorMock)
+	timeService := new(TimeServiceMock)
+
+	bot := model.Bot{Id: 1, BotUuid: "uuid", RiskConfig: config}
+	botService.On("GetBot").Return(bot)
+	botService.On("UseSwapCapital").Return(false)
+	timeService.On("GetNowUnix").Return(int(time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC).Unix()))
+	balanceService.On("GetAssetBalance", "USDT", true).Return(200.00, nil)
+	profitRepository.On("GetRealizedProfitSince", mock.Anything).Return(realizedProfit)
+	callbackManager.On("Notify", mock.Anything).Return()
+
+	balanceService.On("GetAssetBalance", "USDC", true).Return(100.00, nil)
+	exchangeRepository.On("GetCurrentKline", "USDCUSDT").Return(&model.KLine{Symbol: "USDCUSDT", Close: 0.99, UpdatedAt: time.Now().Unix()})
+	exchangeRepository.On("GetTradeLimits").Return(tradeLimits)
+	exchangeRepository.On("GetCurrentKline", "BTCUSDT").Return(&model.KLine{Symbol: "BTCUSDT", Close: 90.00})
+	exchangeRepository.On("GetCurrentKline", "ETHUSDT").Return(&model.KLine{Symbol: "ETHUSDT", Close: 110.00})
+	orderRepository.On("GetOpenedOrderCached", "BTCUSDT", "BUY").Return(&model.Order{
+		Symbol:           "BTCUSDT",
+		BaseAsset:        "BTC",
+		QuoteAsset:       "USDT",
+		Price:            100.00,
+		Quantity:         1.00,
+		ExecutedQuantity: 1.00,
+	})
+	orderRepository.On("GetOpenedOrderCached", "ETHUSDT", "BUY").Return(&model.Order{
+		Symbol:           "ETHUSDT",
+		BaseAsset:        "ETH",
+		QuoteAsset:       "USDT",
+		Price:            100.00,
+		Quantity:         1.00,
+		ExecutedQuantity: 1.00,
+	})
+	orderRepository.On("GetOpenedOrderCached", "SOLUSDT", "BUY").Return(nil)
+	orderRepository.On("GetOpenedOrderCached", "SOLUSDC", "BUY").Return(nil)
+
+	return &exchange.RiskManager{
+		OrderRepository:    orderRepository,
+		ProfitRepository:   profitRepository,
+		ExchangeRepository: exchangeRepository,
+		BalanceService:     balanceService,
+		CurrencyConverter: &exchange.CurrencyConverter{
+			ExchangeRepository: exchangeRepository,
+			BotService:         botService,
+		},
+		BotService:      botService,
+		CallbackManager: callbackManager,
+		TimeService:     timeService,
+	}, callbackManager
+}
+
+func TestRiskManagerStatus(t *testing.T) {
+	assertion := assert.New(t)
+
+	riskManager, _ := getRiskManager(model.RiskConfig{}, -5.00)
+	status := riskManager.GetStatus()
+
+	assertion.Equal(int64(2), status.Positions)
+	assertion.Equal(200.00, status.Exposure)
+	assertion.Equal(0.00, status.UnrealizedPnl)
+	assertion.Equal(-5.00, status.DailyPnl)
+	assertion.Equal(90.00, status.Assets["BTC"].Exposure)
+	assertion.Equal(-10.00, status.Assets["BTC"].UnrealizedPnl)
+	assertion.Equal(10.00, status.Assets["ETH"].UnrealizedPnl)
+	assertion.False(status.IsBuyPaused)
+	assertion.Nil(riskManager.CanBuy(model.TradeLimit{Symbol: "SOLUSDT"}, 50.00, false))
+}
+
+func TestRiskManagerLimits(t *testing.T) {
+	assertion := assert.New(t)
+
+	riskManager, _ := getRiskManager(model.RiskConfig{MaxPositions: 2}, 0.00)
+	assertion.Equal("Max positions 2 reached", riskManager.CanBuy(model.TradeLimit{Symbol: "SOLUSDT"}, 50.00, false).Error())
+	assertion.Nil(riskManager.CanBuy(model.TradeLimit{Symbol: "BTCUSDT"}, 50.00, true))
+
+	riskManager, _ = getRiskManager(model.RiskConfig{MaxExposure: 240.00}, 0.00)
+	assertion.Equal("Max exposure 240.00 USDT exceeded: 200.00 + 50.00 USDT", riskManager.CanBuy(model.TradeLimit{Symbol: "SOLUSDT"}, 50.00, false).Error())
+	assertion.Nil(riskManager.CanBuy(model.TradeLimit{Symbol: "SOLUSDT"}, 40.00, false))
+
+	riskManager, _ = getRiskManager(model.RiskConfig{MaxAssetPercent: 30.00}, 0.00)
+	assertion.Equal("Max BTC concentration 30.00% exceeded: 35.00%", riskManager.CanBuy(model.TradeLimit{Symbol: "BTCUSDT"}, 50.00, true).Error())
+	assertion.Nil(riskManager.CanBuy(model.TradeLimit{Symbol: "SOLUSDT"}, 50.00, false))
+}
+
+func TestRiskManagerLimitNotifiedOncePerDay(t *testing.T) {
+	assertion := assert.New(t)
+
+	riskManager, callbackManager := getRiskManager(model.RiskConfig{MaxPositions: 2}, 0.00)
+	assertion.NotNil(riskManager.CanBuy(model.TradeLimit{Symbol: "SOLUSDT"}, 50.00, false))
+	assertion.NotNil(riskManager.CanBuy(model.TradeLimit{Symbol: "SOLUSDT"}, 50.00, false))
+
+	callbackManager.AssertNumberOfCalls(t, "Notify", 1)
+	notification := callbackManager.Calls[0].Arguments.Get(0).(model.Notification)
+	assertion.Equal(model.NotificationEventRiskLimit, notification.Event)
+	assertion.Equal(model.RiskErrorCodeMaxPositions, notification.Code)
+	assertion.Equal("Max positions 2 reached", notification.Message)
+}
+
+func TestRiskManagerDailyLossBreaker(t *testing.T) {
+	assertion := assert.New(t)
+
+	riskManager, callbackManager := getRiskManager(model.RiskConfig{DailyLossLimit: 50.00}, -60.00)
+
+	err := riskManager.CanBuy(model.TradeLimit{Symbol: "SOLUSDT"}, 10.00, false)
+	assertion.Equal("Buying is paused, daily loss -60.00 USDT reached limit 50.00 USDT", err.Error())
+	assertion.NotNil(riskManager.CanBuy(model.TradeLimit{Symbol: "BTCUSDT"}, 10.00, true))
+	assertion.True(riskManager.GetStatus().IsBuyPaused)
+	callbackManager.AssertNumberOfCalls(t, "Notify", 1)
+	callbackManager.AssertCalled(t, "Notify", model.NewRiskLimitNotification(
+		model.Bot{Id: 1, BotUuid: "uuid", RiskConfig: model.RiskConfig{DailyLossLimit: 50.00}},
+		model.RiskErrorCodeDailyLoss,
+		"Daily loss -60.00 USDT reached limit 50.00 USDT, buying is paused until the end of the day",
+	))
+}
+
+func TestRiskManagerConvertsQuoteAssets(t *testing.T) {
+	assertion := assert.New(t)
+
+	riskManager, _ := getRiskManagerWithLimits(
+		model.RiskConfig{MaxExposure: 249.00},
+		map[string]float64{"BTCUSDT": -5.00, "SOLUSDC": 10.00},
+		[]model.TradeLimit{
+			{Symbol: "BTCUSDT"},
+			{Symbol: "ETHUSDT"},
+			{Symbol: "SOLUSDC", BaseAsset: "SOL", QuoteAsset: "USDC"},
+		},
+	)
+	status := riskManager.GetStatus()
+
+	assertion.Equal("USDT", status.ReportingCurrency)
+	assertion.InDelta(299.00, status.Balance, 0.000001)
+	assertion.InDelta(4.90, status.RealizedPnl, 0.000001)
+	assertion.Equal("Max exposure 249.00 USDT exceeded: 200.00 + 49.50 USDT", riskManager.CanBuy(model.TradeLimit{Symbol: "SOLUSDC", QuoteAsset: "USDC"}, 50.00, false).Error())
+}
+
+func TestRiskManagerRealizedProfitCache(t *testing.T) {
+	assertion := assert.New(t)
+
+	riskManager, _ := getRiskManager(model.RiskConfig{}, -5.00)
+	profitRepository := riskManager.ProfitRepository.(*OrderProfitReaderMock)
+
+	assertion.Equal(-5.00, riskManager.GetStatus().RealizedPnl)
+	riskManager.AddRealizedProfit("BTCUSDT", -10.00)
+	riskManager.AddRealizedProfit("ETHUSDT", 2.50)
+	status := riskManager.GetStatus()
+
+	assertion.Equal(-12.50, status.RealizedPnl)
+	assertion.Equal(-12.50, status.DailyPnl)
+	profitRepository.AssertNumberOfCalls(t, "GetRealizedProfitSince", 1)
+	profitRepository.AssertCalled(t, "GetRealizedProfitSince", time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC).Local().Format(time.DateTime))
+}
+
+func TestRiskManagerDayInLocation(t *testing.T) {
+	assertion := assert.New(t)
+
+	riskManager, _ := getRiskManager(model.RiskConfig{}, -5.00)
+	riskManager.Location = time.FixedZone("UTC+14", 14*3600)
+	profitRepository := riskManager.ProfitRepository.(*OrderProfitReaderMock)
+
+	assertion.Equal(-5.00, riskManager.GetStatus().RealizedPnl)
+	profitRepository.AssertCalled(t, "GetRealizedProfitSince", time.Date(2024, 5, 10, 10, 0, 0, 0, time.UTC).Local().Format(time.DateTime))
+}
+
+func TestRiskManagerUnconvertedAssetFailsClosed(t *testing.T) {
+	assertion := assert.New(t)
+
+	riskManager, _ := getRiskManagerWithLimits(
+		model.RiskConfig{MaxExposure: 1000.00},
+		map[string]float64{"BTCUSDT": -5.00},
+		[]model.TradeLimit{
+			{Symbol: "BTCUSDT"},
+			{Symbol: "SOLEUR", BaseAsset: "SOL", QuoteAsset: "EUR"},
+		},
+	)
+	exchangeRepository := riskManager.ExchangeRepository.(*BaseTradeStorageMock)
+	exchangeRepository.On("GetCurrentKline", "SOLEUR").Return(&model.KLine{Symbol: "SOLEUR", Close: 150.00})
+	exchangeRepository.On("GetCurrentKline", "EURUSDT").Return(nil)
+	exchangeRepository.On("GetCurrentKline", "USDTEUR").Return(nil)
+	priceApi := new(ExchangePriceAPIMock)
+	priceApi.On("GetKLinesCached", mock.Anything, "1m", int64(1)).Return([]model.KLine{})
+	riskManager.CurrencyConverter.(*exchange.CurrencyConverter).Binance = priceApi
+	riskManager.OrderRepository.(*OrderCachedReaderMock).On("GetOpenedOrderCached", "SOLEUR", "BUY").Return(&model.Order{
+		Symbol:           "SOLEUR",
+		BaseAsset:        "SOL",
+		QuoteAsset:       "EUR",
+		Price:            100.00,
+		Quantity:         1.00,
+		ExecutedQuantity: 1.00,
+	})
+	riskManager.BalanceService.(*BalanceServiceMock).On("GetAssetBalance", "EUR", true).Return(50.00, nil)
+
+	status := riskManager.GetStatus()
+	assertion.Equal(int64(2), status.Positions)
+	assertion.Equal(90.00, status.Exposure)
+	assertion.Equal([]string{"EUR"}, status.UnconvertedAssets)
+	assertion.Equal(
+		"Risk limits can't be checked, EUR can't be converted to USDT",
+		riskManager.CanBuy(model.TradeLimit{Symbol: "BTCUSDT"}, 10.00, false).Error(),
+	)
+}
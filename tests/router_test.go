@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/open-soft/go-crypto-bot/src/controller"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func createRouter(tokens string) *controller.Router {
+	router := &controller.Router{
+		Authenticator: &controller.ApiAuthenticator{
+			Tokens:         controller.ParseApiTokens(tokens),
+			AllowedOrigins: controller.ParseAllowedOrigins(""),
+		},
+		CurrentBot: &model.Bot{BotUuid: "uuid"},
+		Title:      "Test API",
+		Version:    "1.0.0",
+	}
+	router.Handle(controller.Route{Method: "DELETE", Path: "/order/{symbol}", Scope: model.ApiScopeTrading, Handler: func(w http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprintf(w, "delete %s", controller.PathParam(req, "symbol"))
+	}})
+	router.Handle(controller.Route{Method: "GET", Path: "/order/list", Scope: model.ApiScopeRead, Response: []model.Order{}, Handler: func(w http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprintf(w, "list")
+	}})
+	router.Handle(controller.Route{Method: "POST", Path: "/order", Scope: model.ApiScopeTrading, Request: model.ManualOrder{}, Handler: func(w http.ResponseWriter, req *http.Request) {
+		panic("unexpected")
+	}})
+
+	return router
+}
+
+func serve(router *controller.Router, method string, path string, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	// API without tokens is allowed from loopback only
+	req.RemoteAddr = "127.0.0.1:1234"
+	if token != "" {
+		req.Header.Set("X-Api-Token", token)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	return recorder
+}
+
+func decodeApiError(recorder *httptest.ResponseRecorder) model.ApiError {
+	var response model.ApiErrorResponse
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	return response.Error
+}
+
+func TestRouterPathParams(t *testing.T) {
+	assertion := assert.New(t)
+	router := createRouter("")
+
+	response := serve(router, "GET", "/api/v1/order/list?botUuid=uuid", "")
+	assertion.Equal(http.StatusOK, response.Code)
+	assertion.Equal("list", response.Body.String())
+	assertion.Equal("application/json", response.Header().Get("Content-Type"))
+	assertion.Equal("", response.Header().Get("Deprecation"))
+
+	response = serve(router, "DELETE", "/api/v1/order/BTCUSDT?botUuid=uuid", "")
+	assertion.Equal(http.StatusOK, response.Code)
+	assertion.Equal("delete BTCUSDT", response.Body.String())
+
+	// legacy path is still supported
+	response = serve(router, "DELETE", "/order/ETHUSDT?botUuid=uuid", "")
+	assertion.Equal(http.StatusOK, response.Code)
+	assertion.Equal("delete ETHUSDT", response.Body.String())
+	assertion.Equal("true", response.Header().Get("Deprecation"))
+}
+
+func TestRouterErrorEnvelope(t *testing.T) {
+	assertion := assert.New(t)
+	router := createRouter("read:secret-read")
+
+	response := serve(router, "GET", "/api/v1/order/BTCUSDT?botUuid=uuid", "secret-read")
+	assertion.Equal(http.StatusMethodNotAllowed, response.Code)
+	assertion.Equal("DELETE", response.Header().Get("Allow"))
+	assertion.Equal(http.StatusMethodNotAllowed, decodeApiError(response).Code)
+
+	response = serve(router, "GET", "/api/v1/unknown?botUuid=uuid", "secret-read")
+	assertion.Equal(http.StatusNotFound, response.Code)
+	assertion.Equal("Not found", decodeApiError(response).Message)
+
+	response = serve(router, "GET", "/api/v1/order/list?botUuid=wrong", "secret-read")
+	assertion.Equal(http.StatusForbidden, response.Code)
+
+	response = serve(router, "GET", "/api/v1/order/list?botUuid=uuid", "")
+	assertion.Equal(http.StatusUnauthorized, response.Code)
+	assertion.Equal("Unauthorized", decodeApiError(response).Message)
+
+	response = serve(router, "DELETE", "/api/v1/order/BTCUSDT?botUuid=uuid", "secret-read")
+	assertion.Equal(http.StatusForbidden, response.Code)
+
+	response = serve(router, "OPTIONS", "/api/v1/order/BTCUSDT", "")
+	assertion.Equal(http.StatusOK, response.Code)
+}
+
+func TestRouterPanicRecovery(t *testing.T) {
+	assertion := assert.New(t)
+	router := createRouter("")
+
+	response := serve(router, "POST", "/api/v1/order?botUuid=uuid", "")
+	assertion.Equal(http.StatusInternalServerError, response.Code)
+	assertion.Equal("Internal server error", decodeApiError(response).Message)
+}
+
+func TestRouterOpenApi(t *testing.T) {
+	assertion := assert.New(t)
+	router := createRouter("admin:secret-admin")
+
+	// documentation does not require token
+	response := serve(router, "GET", "/api/v1/openapi.json", "")
+	assertion.Equal(http.StatusOK, response.Code)
+
+	var document map[string]any
+	assertion.Nil(json.Unmarshal(response.Body.Bytes(), &document))
+	assertion.Equal("3.0.3", document["openapi"])
+
+	paths := document["paths"].(map[string]any)
+	assertion.Len(paths, 3)
+	assertion.Contains(paths, "/api/v1/order/{symbol}")
+	assertion.Contains(paths["/api/v1/order/list"], "get")
+	assertion.Contains(paths["/api/v1/order"], "post")
+
+	schemas := document["components"].(map[string]any)["schemas"].(map[string]any)
+	assertion.Contains(schemas, "Order")
+	assertion.Contains(schemas, "ManualOrder")
+	assertion.Contains(schemas, "ApiErrorResponse")
+}
+
+func TestRouterUnversionedRoute(t *testing.T) {
+	assertion := assert.New(t)
+	router := createRouter("")
+	metricsRoute := controller.Route{Method: "GET", Path: "/metrics", Unversioned: true, SkipBotUuid: true, Scope: model.ApiScopeRead, Handler: func(w http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprintf(w, "metrics")
+	}}
+	router.Handle(metricsRoute)
+
+	response := serve(router, "GET", "/metrics", "")
+	assertion.Equal(http.StatusOK, response.Code)
+	assertion.Equal("metrics", response.Body.String())
+	assertion.Equal("text/plain; charset=utf-8", response.Header().Get("Content-Type"))
+	assertion.Equal("", response.Header().Get("Deprecation"))
+
+	response = serve(router, "GET", "/api/v1/metrics?botUuid=uuid", "")
+	assertion.Equal(http.StatusNotFound, response.Code)
+
+	// token scope is still checked
+	protected := createRouter("read:secret-read")
+	protected.Handle(metricsRoute)
+	assertion.Equal(http.StatusUnauthorized, serve(protected, "GET", "/metrics", "").Code)
+	assertion.Equal(http.StatusOK, serve(protected, "GET", "/metrics", "secret-read").Code)
+}
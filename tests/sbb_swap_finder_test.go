@@ -212,7 +212,7 @@ func TestSwapSellBuyBuy(t *testing.T) {
 	}, nil)
 	swapRepoMock.On("GetSwapChainById", swapChain.Id).Return(swapChain, nil)
 
-	binanceMock.On("LimitOrder", "SOLGBP", 100.00, 58.53, "SELL", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "SOLGBP", 100.00, 58.53, "SELL", "GTC").Return(model.ExchangeOrder{
 		Status:              "NEW",
 		OrderId:             "19",
 		Symbol:              "SOLGBP",
@@ -222,7 +222,7 @@ func TestSwapSellBuyBuy(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 0.00,
 	}, nil)
-	binanceMock.On("QueryOrder", "SOLGBP", "19").Times(1).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "SOLGBP", "19").Times(1).Return(model.ExchangeOrder{
 		Status:              "PARTIALLY_FILLED",
 		OrderId:             "19",
 		ExecutedQty:         80.00,
@@ -232,7 +232,7 @@ func TestSwapSellBuyBuy(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 80 * 58.53,
 	}, nil)
-	binanceMock.On("QueryOrder", "SOLGBP", "19").Times(2).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "SOLGBP", "19").Times(2).Return(model.ExchangeOrder{
 		Status:              "FILLED",
 		OrderId:             "19",
 		ExecutedQty:         100.00,
@@ -246,7 +246,7 @@ func TestSwapSellBuyBuy(t *testing.T) {
 	gbpInitialBalance := 50.99
 	balanceServiceMock.On("GetAssetBalance", "GBP", false).Return(5856.00+gbpInitialBalance, nil)
 
-	binanceMock.On("LimitOrder", "ETHGBP", 3.2825, 1783.04, "BUY", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "ETHGBP", 3.2825, 1783.04, "BUY", "GTC").Return(model.ExchangeOrder{
 		Status:      "NEW",
 		OrderId:     "20",
 		Symbol:      "ETHGBP",
@@ -254,7 +254,7 @@ func TestSwapSellBuyBuy(t *testing.T) {
 		OrigQty:     3.2825,
 		Price:       1783.04,
 	}, nil)
-	binanceMock.On("QueryOrder", "ETHGBP", "20").Times(1).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "ETHGBP", "20").Times(1).Return(model.ExchangeOrder{
 		Status:      "PARTIALLY_FILLED",
 		OrderId:     "20",
 		Symbol:      "ETHGBP",
@@ -262,7 +262,7 @@ func TestSwapSellBuyBuy(t *testing.T) {
 		OrigQty:     3.2825,
 		Price:       1783.04,
 	}, nil)
-	binanceMock.On("QueryOrder", "ETHGBP", "20").Times(2).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "ETHGBP", "20").Times(2).Return(model.ExchangeOrder{
 		Status:      "FILLED",
 		OrderId:     "20",
 		Symbol:      "ETHGBP",
@@ -273,7 +273,7 @@ func TestSwapSellBuyBuy(t *testing.T) {
 	ethInitialBalance := 2.99
 	balanceServiceMock.On("GetAssetBalance", "ETH", false).Return(3.282+ethInitialBalance, nil)
 
-	binanceMock.On("LimitOrder", "SOLETH", 104.604, 0.03138, "BUY", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "SOLETH", 104.604, 0.03138, "BUY", "GTC").Return(model.ExchangeOrder{
 		Status:      "NEW",
 		OrderId:     "21",
 		Symbol:      "SOLETH",
@@ -281,7 +281,7 @@ func TestSwapSellBuyBuy(t *testing.T) {
 		OrigQty:     104.604,
 		Price:       0.03138,
 	}, nil)
-	binanceMock.On("QueryOrder", "SOLETH", "21").Times(1).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "SOLETH", "21").Times(1).Return(model.ExchangeOrder{
 		Status:      "PARTIALLY_FILLED",
 		OrderId:     "21",
 		Symbol:      "SOLETH",
@@ -289,7 +289,7 @@ func TestSwapSellBuyBuy(t *testing.T) {
 		OrigQty:     104.604,
 		Price:       0.03138,
 	}, nil)
-	binanceMock.On("QueryOrder", "SOLETH", "21").Times(2).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "SOLETH", "21").Times(2).Return(model.ExchangeOrder{
 		Status:      "FILLED",
 		OrderId:     "21",
 		Symbol:      "SOLETH",
@@ -560,7 +560,7 @@ func TestSwapSellBuyBuyRollback(t *testing.T) {
 	gbpInitialBalance := 50.99
 	balanceServiceMock.On("GetAssetBalance", "GBP", false).Return(5856.00+gbpInitialBalance, nil)
 
-	binanceMock.On("LimitOrder", "SOLGBP", 100.00, 58.53, "SELL", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "SOLGBP", 100.00, 58.53, "SELL", "GTC").Return(model.ExchangeOrder{
 		Status:              "NEW",
 		OrderId:             "19",
 		Symbol:              "SOLGBP",
@@ -570,7 +570,7 @@ func TestSwapSellBuyBuyRollback(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 0.00,
 	}, nil)
-	binanceMock.On("QueryOrder", "SOLGBP", "19").Times(2).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "SOLGBP", "19").Times(2).Return(model.ExchangeOrder{
 		Status:              "FILLED",
 		OrderId:             "19",
 		ExecutedQty:         100.00,
@@ -581,7 +581,7 @@ func TestSwapSellBuyBuyRollback(t *testing.T) {
 		CummulativeQuoteQty: 100 * 58.53,
 	}, nil)
 
-	binanceMock.On("LimitOrder", "ETHGBP", 3.4205, 1711.14, "BUY", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "ETHGBP", 3.4205, 1711.14, "BUY", "GTC").Return(model.ExchangeOrder{
 		Status:      "NEW",
 		OrderId:     "20",
 		Symbol:      "ETHGBP",
@@ -589,7 +589,7 @@ func TestSwapSellBuyBuyRollback(t *testing.T) {
 		OrigQty:     3.4205,
 		Price:       1711.14,
 	}, nil)
-	binanceMock.On("QueryOrder", "ETHGBP", "20").Times(1).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "ETHGBP", "20").Times(1).Return(model.ExchangeOrder{
 		Status:      "NEW",
 		OrderId:     "20",
 		Symbol:      "ETHGBP",
@@ -618,7 +618,7 @@ func TestSwapSellBuyBuyRollback(t *testing.T) {
 		MinPrice:    0.01,
 	}, nil)
 
-	binanceMock.On("CancelOrder", "ETHGBP", "20").Return(model.BinanceOrder{
+	binanceMock.On("CancelOrder", "ETHGBP", "20").Return(model.ExchangeOrder{
 		Status:      "CANCELED",
 		OrderId:     "20",
 		Symbol:      "ETHGBP",
@@ -627,7 +627,7 @@ func TestSwapSellBuyBuyRollback(t *testing.T) {
 		Price:       1711.14,
 	}, nil)
 
-	binanceMock.On("LimitOrder", "SOLGBP", 101.98, 57.39, "BUY", "IOC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "SOLGBP", 101.98, 57.39, "BUY", "IOC").Return(model.ExchangeOrder{
 		Status:      "FILLED",
 		OrderId:     "21",
 		Symbol:      "SOLGBP",
@@ -891,7 +891,7 @@ func TestSwapSellBuyBuyForceSwap(t *testing.T) {
 	gbpInitialBalance := 50.99
 	balanceServiceMock.On("GetAssetBalance", "GBP", false).Return(5856.00+gbpInitialBalance, nil)
 
-	binanceMock.On("LimitOrder", "SOLGBP", 100.00, 58.53, "SELL", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "SOLGBP", 100.00, 58.53, "SELL", "GTC").Return(model.ExchangeOrder{
 		Status:              "NEW",
 		OrderId:             "19",
 		Symbol:              "SOLGBP",
@@ -901,7 +901,7 @@ func TestSwapSellBuyBuyForceSwap(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 0.00,
 	}, nil)
-	binanceMock.On("QueryOrder", "SOLGBP", "19").Times(2).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "SOLGBP", "19").Times(2).Return(model.ExchangeOrder{
 		Status:              "FILLED",
 		OrderId:             "19",
 		ExecutedQty:         100.00,
@@ -912,7 +912,7 @@ func TestSwapSellBuyBuyForceSwap(t *testing.T) {
 		CummulativeQuoteQty: 100 * 58.53,
 	}, nil)
 
-	binanceMock.On("LimitOrder", "ETHGBP", 3.4205, 1711.14, "BUY", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "ETHGBP", 3.4205, 1711.14, "BUY", "GTC").Return(model.ExchangeOrder{
 		Status:      "NEW",
 		OrderId:     "20",
 		Symbol:      "ETHGBP",
@@ -920,7 +920,7 @@ func TestSwapSellBuyBuyForceSwap(t *testing.T) {
 		OrigQty:     3.4205,
 		Price:       1711.14,
 	}, nil)
-	binanceMock.On("QueryOrder", "ETHGBP", "20").Times(1).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "ETHGBP", "20").Times(1).Return(model.ExchangeOrder{
 		Status:      "FILLED",
 		OrderId:     "20",
 		Symbol:      "ETHGBP",
@@ -952,7 +952,7 @@ func TestSwapSellBuyBuyForceSwap(t *testing.T) {
 		MinPrice:    0.00001,
 	}, nil)
 
-	binanceMock.On("LimitOrder", "SOLETH", 109.002, 0.03138, "BUY", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "SOLETH", 109.002, 0.03138, "BUY", "GTC").Return(model.ExchangeOrder{
 		Status:      "NEW",
 		OrderId:     "21",
 		Symbol:      "SOLETH",
@@ -960,7 +960,7 @@ func TestSwapSellBuyBuyForceSwap(t *testing.T) {
 		OrigQty:     109.002,
 		Price:       0.03138,
 	}, nil)
-	binanceMock.On("QueryOrder", "SOLETH", "21").Times(3).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "SOLETH", "21").Times(3).Return(model.ExchangeOrder{
 		Status:      "NEW",
 		OrderId:     "21",
 		Symbol:      "SOLETH",
@@ -973,7 +973,7 @@ func TestSwapSellBuyBuyForceSwap(t *testing.T) {
 
 	timeServiceMock.On("WaitSeconds", int64(15)).Times(1)
 
-	binanceMock.On("CancelOrder", "SOLETH", "21").Return(model.BinanceOrder{
+	binanceMock.On("CancelOrder", "SOLETH", "21").Return(model.ExchangeOrder{
 		Status:      "CANCELED",
 		OrderId:     "21",
 		Symbol:      "SOLETH",
@@ -982,7 +982,7 @@ func TestSwapSellBuyBuyForceSwap(t *testing.T) {
 		Price:       0.03138,
 	}, nil)
 
-	binanceMock.On("LimitOrder", "SOLETH", 109.316, 0.03129, "BUY", "IOC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "SOLETH", 109.316, 0.03129, "BUY", "IOC").Return(model.ExchangeOrder{
 		Status:      "FILLED",
 		OrderId:     "21",
 		Symbol:      "SOLETH",
@@ -207,7 +207,7 @@ func TestSwapSellBuySell(t *testing.T) {
 	}, nil)
 	swapRepoMock.On("GetSwapChainById", swapChain.Id).Return(swapChain, nil)
 
-	binanceMock.On("LimitOrder", "ETHBTC", 100.00, 0.05355, "SELL", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "ETHBTC", 100.00, 0.05355, "SELL", "GTC").Return(model.ExchangeOrder{
 		Status:              "NEW",
 		OrderId:             "12",
 		Symbol:              "ETHBTC",
@@ -217,7 +217,7 @@ func TestSwapSellBuySell(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 0.00,
 	}, nil)
-	binanceMock.On("QueryOrder", "ETHBTC", "12").Times(1).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "ETHBTC", "12").Times(1).Return(model.ExchangeOrder{
 		Status:              "PARTIALLY_FILLED",
 		OrderId:             "12",
 		ExecutedQty:         80.00,
@@ -227,7 +227,7 @@ func TestSwapSellBuySell(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 80.00 * 0.05358,
 	}, nil)
-	binanceMock.On("QueryOrder", "ETHBTC", "12").Times(2).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "ETHBTC", "12").Times(2).Return(model.ExchangeOrder{
 		Status:              "FILLED",
 		OrderId:             "12",
 		ExecutedQty:         100.00,
@@ -241,7 +241,7 @@ func TestSwapSellBuySell(t *testing.T) {
 	btcInitialBalance := 1.3455
 	balanceServiceMock.On("GetAssetBalance", "BTC", false).Return(5.355+btcInitialBalance, nil)
 
-	binanceMock.On("LimitOrder", "XRPBTC", 372910.00, 0.00001436, "BUY", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "XRPBTC", 372910.00, 0.00001436, "BUY", "GTC").Return(model.ExchangeOrder{
 		Status:              "NEW",
 		OrderId:             "13",
 		Symbol:              "XRPBTC",
@@ -251,7 +251,7 @@ func TestSwapSellBuySell(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 0.00,
 	}, nil)
-	binanceMock.On("QueryOrder", "XRPBTC", "13").Times(1).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "XRPBTC", "13").Times(1).Return(model.ExchangeOrder{
 		Status:              "PARTIALLY_FILLED",
 		OrderId:             "13",
 		Symbol:              "XRPBTC",
@@ -261,7 +261,7 @@ func TestSwapSellBuySell(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 0.00001436 * 125210.00,
 	}, nil)
-	binanceMock.On("QueryOrder", "XRPBTC", "13").Times(2).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "XRPBTC", "13").Times(2).Return(model.ExchangeOrder{
 		Status:              "FILLED",
 		OrderId:             "13",
 		Symbol:              "XRPBTC",
@@ -275,7 +275,7 @@ func TestSwapSellBuySell(t *testing.T) {
 	xrpInitialBalance := 4000.00
 	balanceServiceMock.On("GetAssetBalance", "XRP", false).Return(372910.00+xrpInitialBalance, nil)
 
-	binanceMock.On("LimitOrder", "XRPETH", 372910.00, 0.000277, "SELL", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "XRPETH", 372910.00, 0.000277, "SELL", "GTC").Return(model.ExchangeOrder{
 		Status:              "NEW",
 		OrderId:             "14",
 		Symbol:              "XRPETH",
@@ -285,7 +285,7 @@ func TestSwapSellBuySell(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 0.00,
 	}, nil)
-	binanceMock.On("QueryOrder", "XRPETH", "14").Times(1).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "XRPETH", "14").Times(1).Return(model.ExchangeOrder{
 		Status:              "PARTIALLY_FILLED",
 		OrderId:             "14",
 		Symbol:              "XRPETH",
@@ -295,7 +295,7 @@ func TestSwapSellBuySell(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 0.000277 * 125210.00,
 	}, nil)
-	binanceMock.On("QueryOrder", "XRPETH", "14").Times(2).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "XRPETH", "14").Times(2).Return(model.ExchangeOrder{
 		Status:              "FILLED",
 		OrderId:             "14",
 		Symbol:              "XRPETH",
@@ -562,7 +562,7 @@ func TestSwapSellBuySellForceSwap(t *testing.T) {
 	}, nil)
 	swapRepoMock.On("GetSwapChainById", swapChain.Id).Return(swapChain, nil)
 
-	binanceMock.On("LimitOrder", "ETHBTC", 100.00, 0.05355, "SELL", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "ETHBTC", 100.00, 0.05355, "SELL", "GTC").Return(model.ExchangeOrder{
 		Status:              "NEW",
 		OrderId:             "12",
 		Symbol:              "ETHBTC",
@@ -572,7 +572,7 @@ func TestSwapSellBuySellForceSwap(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 0.00,
 	}, nil)
-	binanceMock.On("QueryOrder", "ETHBTC", "12").Times(1).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "ETHBTC", "12").Times(1).Return(model.ExchangeOrder{
 		Status:              "PARTIALLY_FILLED",
 		OrderId:             "12",
 		ExecutedQty:         80.00,
@@ -582,7 +582,7 @@ func TestSwapSellBuySellForceSwap(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 80.00 * 0.05355,
 	}, nil)
-	binanceMock.On("QueryOrder", "ETHBTC", "12").Times(2).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "ETHBTC", "12").Times(2).Return(model.ExchangeOrder{
 		Status:              "FILLED",
 		OrderId:             "12",
 		ExecutedQty:         100.00,
@@ -596,7 +596,7 @@ func TestSwapSellBuySellForceSwap(t *testing.T) {
 	btcInitialBalance := 1.3455
 	balanceServiceMock.On("GetAssetBalance", "BTC", false).Return(5.355+btcInitialBalance, nil)
 
-	binanceMock.On("LimitOrder", "XRPBTC", 372910.00, 0.00001436, "BUY", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "XRPBTC", 372910.00, 0.00001436, "BUY", "GTC").Return(model.ExchangeOrder{
 		Status:              "NEW",
 		OrderId:             "13",
 		Symbol:              "XRPBTC",
@@ -606,7 +606,7 @@ func TestSwapSellBuySellForceSwap(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 0.00,
 	}, nil)
-	binanceMock.On("QueryOrder", "XRPBTC", "13").Times(1).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "XRPBTC", "13").Times(1).Return(model.ExchangeOrder{
 		Status:              "PARTIALLY_FILLED",
 		OrderId:             "13",
 		Symbol:              "XRPBTC",
@@ -616,7 +616,7 @@ func TestSwapSellBuySellForceSwap(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 0.00001436 * 125210.00,
 	}, nil)
-	binanceMock.On("QueryOrder", "XRPBTC", "13").Times(2).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "XRPBTC", "13").Times(2).Return(model.ExchangeOrder{
 		Status:              "FILLED",
 		OrderId:             "13",
 		Symbol:              "XRPBTC",
@@ -630,7 +630,7 @@ func TestSwapSellBuySellForceSwap(t *testing.T) {
 	xrpInitialBalance := 4000.00
 	balanceServiceMock.On("GetAssetBalance", "XRP", false).Return(372910.00+xrpInitialBalance, nil)
 
-	binanceMock.On("LimitOrder", "XRPETH", 372910.00, 0.000277, "SELL", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "XRPETH", 372910.00, 0.000277, "SELL", "GTC").Return(model.ExchangeOrder{
 		Status:              "NEW",
 		OrderId:             "14",
 		Symbol:              "XRPETH",
@@ -640,7 +640,7 @@ func TestSwapSellBuySellForceSwap(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 0.00,
 	}, nil)
-	binanceMock.On("QueryOrder", "XRPETH", "14").Times(1).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "XRPETH", "14").Times(1).Return(model.ExchangeOrder{
 		Status:              "NEW",
 		OrderId:             "14",
 		Symbol:              "XRPETH",
@@ -650,7 +650,7 @@ func TestSwapSellBuySellForceSwap(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 0.00,
 	}, nil)
-	binanceMock.On("CancelOrder", "XRPETH", "14").Return(model.BinanceOrder{
+	binanceMock.On("CancelOrder", "XRPETH", "14").Return(model.ExchangeOrder{
 		Status:              "CANCELED",
 		OrderId:             "14",
 		Symbol:              "XRPETH",
@@ -660,7 +660,7 @@ func TestSwapSellBuySellForceSwap(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 0.00,
 	}, nil)
-	binanceMock.On("LimitOrder", "XRPETH", 372910.00, 0.0002784, "SELL", "IOC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "XRPETH", 372910.00, 0.0002784, "SELL", "IOC").Return(model.ExchangeOrder{
 		Status:              "FILLED",
 		OrderId:             "14",
 		Symbol:              "XRPETH",
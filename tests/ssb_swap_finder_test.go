@@ -213,7 +213,7 @@ func TestSwapSellSellBuy(t *testing.T) {
 	}, nil)
 	swapRepoMock.On("GetSwapChainById", swapChain.Id).Return(swapChain, nil)
 
-	binanceMock.On("LimitOrder", "SOLETH", 100.00, 0.03369, "SELL", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "SOLETH", 100.00, 0.03369, "SELL", "GTC").Return(model.ExchangeOrder{
 		Status:              "NEW",
 		OrderId:             "16",
 		Symbol:              "SOLETH",
@@ -223,7 +223,7 @@ func TestSwapSellSellBuy(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 00.00,
 	}, nil)
-	binanceMock.On("QueryOrder", "SOLETH", "16").Times(1).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "SOLETH", "16").Times(1).Return(model.ExchangeOrder{
 		Status:              "PARTIALLY_FILLED",
 		OrderId:             "16",
 		ExecutedQty:         80.00,
@@ -233,7 +233,7 @@ func TestSwapSellSellBuy(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 80 * 0.03369,
 	}, nil)
-	binanceMock.On("QueryOrder", "SOLETH", "16").Times(2).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "SOLETH", "16").Times(2).Return(model.ExchangeOrder{
 		Status:              "FILLED",
 		OrderId:             "16",
 		ExecutedQty:         100.00,
@@ -247,7 +247,7 @@ func TestSwapSellSellBuy(t *testing.T) {
 	ethInitialBalance := 2.99
 	balanceServiceMock.On("GetAssetBalance", "ETH", false).Return(3.369+ethInitialBalance, nil)
 
-	binanceMock.On("LimitOrder", "ETHGBP", 3.369, 1782.98, "SELL", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "ETHGBP", 3.369, 1782.98, "SELL", "GTC").Return(model.ExchangeOrder{
 		Status:              "NEW",
 		OrderId:             "17",
 		Symbol:              "ETHGBP",
@@ -257,7 +257,7 @@ func TestSwapSellSellBuy(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 0.00,
 	}, nil)
-	binanceMock.On("QueryOrder", "ETHGBP", "17").Times(1).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "ETHGBP", "17").Times(1).Return(model.ExchangeOrder{
 		Status:              "PARTIALLY_FILLED",
 		OrderId:             "17",
 		Symbol:              "ETHGBP",
@@ -267,7 +267,7 @@ func TestSwapSellSellBuy(t *testing.T) {
 		Side:                "SELL",
 		CummulativeQuoteQty: 1.272 * 1782.98,
 	}, nil)
-	binanceMock.On("QueryOrder", "ETHGBP", "17").Times(2).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "ETHGBP", "17").Times(2).Return(model.ExchangeOrder{
 		Status:              "FILLED",
 		OrderId:             "17",
 		Symbol:              "ETHGBP",
@@ -280,7 +280,7 @@ func TestSwapSellSellBuy(t *testing.T) {
 	gbpInitialBalance := 2300.99
 	balanceServiceMock.On("GetAssetBalance", "GBP", false).Return(6006.85962+gbpInitialBalance, nil)
 
-	binanceMock.On("LimitOrder", "SOLGBP", 114.35, 52.53, "BUY", "GTC").Return(model.BinanceOrder{
+	binanceMock.On("LimitOrder", "SOLGBP", 114.35, 52.53, "BUY", "GTC").Return(model.ExchangeOrder{
 		Status:              "NEW",
 		OrderId:             "18",
 		Symbol:              "SOLGBP",
@@ -290,7 +290,7 @@ func TestSwapSellSellBuy(t *testing.T) {
 		Side:                "BUY",
 		CummulativeQuoteQty: 0.00,
 	}, nil)
-	binanceMock.On("QueryOrder", "SOLGBP", "18").Times(1).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "SOLGBP", "18").Times(1).Return(model.ExchangeOrder{
 		Status:              "PARTIALLY_FILLED",
 		OrderId:             "18",
 		Symbol:              "SOLGBP",
@@ -300,7 +300,7 @@ func TestSwapSellSellBuy(t *testing.T) {
 		Side:                "BUY",
 		CummulativeQuoteQty: 12.00 * 52.53,
 	}, nil)
-	binanceMock.On("QueryOrder", "SOLGBP", "18").Times(2).Return(model.BinanceOrder{
+	binanceMock.On("QueryOrder", "SOLGBP", "18").Times(2).Return(model.ExchangeOrder{
 		Status:              "FILLED",
 		OrderId:             "18",
 		Symbol:              "SOLGBP",
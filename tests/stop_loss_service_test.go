@@ -0,0 +1,176 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"gitlab.com/open-soft/go-crypto-bot/src/utils"
+	"gitlab.com/open-soft/go-crypto-bot/src/validator"
+	"testing"
+)
+
+func getStopLossOrder(id int64, profitOptions model.ProfitOptions) model.Order {
+	return model.Order{
+		Id:               id,
+		Symbol:           "BTCUSDT",
+		BaseAsset:        "BTC",
+		QuoteAsset:       "USDT",
+		Price:            100.00,
+		Quantity:         1.00,
+		ExecutedQuantity: 1.00,
+		Operation:        "BUY",
+		Status:           "opened",
+		ProfitOptions:    profitOptions,
+	}
+}
+
+func TestStopLossTriggered(t *testing.T) {
+	assertion := assert.New(t)
+
+	botService := new(BotServiceMock)
+	botService.On("UseSwapCapital").Return(false)
+	stopLossService := exchange.StopLossService{
+		BotService: botService,
+	}
+
+	order := getStopLossOrder(1, model.ProfitOptions{
+		{Index: 0, OptionValue: 1, OptionUnit: model.ProfitOptionUnitHour, OptionPercent: 2.00},
+		{Index: 1, OptionType: model.ProfitOptionTypeStopLoss, OptionPercent: -5.00},
+	})
+
+	_, isTriggered := stopLossService.Check(order, 96.00)
+	assertion.False(isTriggered)
+
+	closeReason, isTriggered := stopLossService.Check(order, 95.00)
+	assertion.True(isTriggered)
+	assertion.Equal(model.OrderCloseReasonStopLoss, closeReason)
+}
+
+func TestTrailingStopTriggered(t *testing.T) {
+	assertion := assert.New(t)
+
+	botService := new(BotServiceMock)
+	botService.On("UseSwapCapital").Return(false)
+	stopLossService := exchange.StopLossService{
+		BotService: botService,
+	}
+
+	order := getStopLossOrder(1, model.ProfitOptions{
+		{Index: 0, OptionType: model.ProfitOptionTypeTrailingStop, OptionPercent: 2.00, ActivationPercent: 3.00},
+	})
+
+	// not activated, the highest profit is 2%
+	_, isTriggered := stopLossService.Check(order, 102.00)
+	assertion.False(isTriggered)
+	_, isTriggered = stopLossService.Check(order, 99.00)
+	assertion.False(isTriggered)
+
+	_, isTriggered = stopLossService.Check(order, 110.00)
+	assertion.False(isTriggered)
+	_, isTriggered = stopLossService.Check(order, 108.00)
+	assertion.False(isTriggered)
+	closeReason, isTriggered := stopLossService.Check(order, 107.80)
+	assertion.True(isTriggered)
+	assertion.Equal(model.OrderCloseReasonTrailingStop, closeReason)
+
+	// the highest price is tracked per position
+	_, isTriggered = stopLossService.Check(getStopLossOrder(2, order.ProfitOptions), 107.80)
+	assertion.False(isTriggered)
+}
+
+func TestStopLossIsIgnoredByMinProfitPercent(t *testing.T) {
+	assertion := assert.New(t)
+
+	profitService := exchange.ProfitService{}
+	order := getStopLossOrder(1, model.ProfitOptions{
+		{Index: 0, OptionType: model.ProfitOptionTypeStopLoss, OptionPercent: -5.00},
+		{Index: 1, OptionValue: 1, OptionUnit: model.ProfitOptionUnitHour, OptionPercent: 2.00},
+		{Index: 2, OptionType: model.ProfitOptionTypeTrailingStop, OptionPercent: 1.00},
+	})
+
+	assertion.Equal(model.Percent(2.00), profitService.GetMinProfitPercent(order))
+}
+
+func TestProfitOptionsValidatorStopOptions(t *testing.T) {
+	assertion := assert.New(t)
+
+	profitOptionsValidator := validator.ProfitOptionsValidator{}
+
+	assertion.Nil(profitOptionsValidator.Validate(model.ProfitOptions{
+		{Index: 0, OptionValue: 1, OptionUnit: model.ProfitOptionUnitHour, OptionPercent: 2.00},
+		{Index: 1, OptionType: model.ProfitOptionTypeStopLoss, OptionPercent: -5.00},
+		{Index: 2, OptionType: model.ProfitOptionTypeTrailingStop, OptionPercent: 1.00, ActivationPercent: 2.00},
+	}))
+	assertion.NotNil(profitOptionsValidator.Validate(model.ProfitOptions{
+		{Index: 0, OptionType: model.ProfitOptionTypeStopLoss, OptionPercent: 5.00},
+	}))
+	assertion.NotNil(profitOptionsValidator.Validate(model.ProfitOptions{
+		{Index: 0, OptionType: model.ProfitOptionTypeTrailingStop, OptionPercent: -1.00},
+	}))
+	assertion.NotNil(profitOptionsValidator.Validate(model.ProfitOptions{
+		{Index: 0, OptionType: "unknown", OptionPercent: 1.00},
+	}))
+}
+
+func TestSellOperationOnStopLoss(t *testing.T) {
+	orderRepository := new(OrderStorageMock)
+	exchangeRepository := new(BaseTradeStorageMock)
+	botService := new(BotServiceMock)
+	strategyFacade := new(StrategyFacadeMock)
+	priceCalculator := new(PriceCalculatorMock)
+	orderExecutor := new(OrderExecutorMock)
+	tradeFilterService := new(TradeFilterServiceMock)
+
+	botService.On("UseSwapCapital").Return(false)
+
+	maker := exchange.MakerService{
+		TradeFilterService: tradeFilterService,
+		OrderRepository:    orderRepository,
+		ExchangeRepository: exchangeRepository,
+		BotService:         botService,
+		StrategyFacade:     strategyFacade,
+		PriceCalculator:    priceCalculator,
+		OrderExecutor:      orderExecutor,
+		StopLossService: &exchange.StopLossService{
+			BotService: botService,
+		},
+		Formatter: &utils.Formatter{},
+		CurrentBot: &model.Bot{
+			Id: 1,
+		},
+		HoldScore: 80.00,
+	}
+
+	tradeLimit := model.TradeLimit{
+		Symbol:      "BTCUSDT",
+		MinPrice:    0.01,
+		MinQuantity: 0.01,
+	}
+	order := getStopLossOrder(1, model.ProfitOptions{
+		{Index: 0, OptionType: model.ProfitOptionTypeStopLoss, OptionPercent: -5.00},
+	})
+
+	orderRepository.On("GetExchangeOrder", "BTCUSDT", "SELL").Return(nil)
+	strategyFacade.On("Decide", "BTCUSDT").Return(model.FacadeResponse{
+		Sell: model.DecisionHighestPriorityScore,
+	}, nil)
+	orderRepository.On("GetOpenedOrderCached", "BTCUSDT", "BUY").Return(&order)
+	orderExecutor.On("ProcessSwap", order).Return(false)
+	exchangeRepository.On("GetTradeLimit", "BTCUSDT").Return(tradeLimit, nil)
+	exchangeRepository.On("GetCurrentKline", "BTCUSDT").Return(&model.KLine{
+		Symbol: "BTCUSDT",
+		Close:  90.00,
+	})
+	orderRepository.On("GetManualOrder", "BTCUSDT").Return(nil)
+	priceCalculator.On("GetDepth", "BTCUSDT", int64(exchange.LocalOrderBookDepth)).Return(model.OrderBookModel{
+		Asks: [][2]model.Number{{{Value: 90.10}, {Value: 1.00}}},
+		Bids: [][2]model.Number{{{Value: 89.90}, {Value: 1.00}}},
+	})
+	orderExecutor.On("CalculateSellQuantity", order).Return(1.00)
+	orderExecutor.On("Sell", tradeLimit, order, 89.90, 1.00, model.OrderCloseReasonStopLoss).Return(nil)
+
+	maker.Make("BTCUSDT")
+	orderExecutor.AssertNumberOfCalls(t, "Sell", 1)
+	tradeFilterService.AssertNumberOfCalls(t, "CanSell", 0)
+	priceCalculator.AssertNumberOfCalls(t, "CalculateSell", 0)
+}
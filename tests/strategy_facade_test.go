@@ -5,6 +5,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gitlab.com/open-soft/go-crypto-bot/src/model"
 	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/strategy"
 	"testing"
 	"time"
 )
@@ -207,3 +208,62 @@ func TestDropHoldForHighPriority(t *testing.T) {
 	assertion.Equal(999.99, result.Sell)
 	assertion.Equal(1.00, result.Buy)
 }
+
+func TestMinStrategyAgreementAndExplanation(t *testing.T) {
+	assertion := assert.New(t)
+
+	exchangeRepository := new(ExchangeTradeInfoMock)
+	decisionStorage := new(DecisionReadStorageMock)
+	orderStorage := new(OrderStorageMock)
+	botService := new(BotServiceMock)
+	registry := exchange.StrategyRegistry{}
+	_ = registry.Register(&strategy.MarketDepthStrategy{})
+	_ = registry.Register(&strategy.BaseKLineStrategy{})
+	_ = registry.Register(&strategy.OrderBasedStrategy{})
+
+	strategyFacade := exchange.StrategyFacade{
+		DecisionReadStorage: decisionStorage,
+		ExchangeRepository:  exchangeRepository,
+		OrderRepository:     orderStorage,
+		BotService:          botService,
+		StrategyRegistry:    &registry,
+		MinDecisions:        3.00,
+	}
+
+	orderStorage.On("GetManualOrder", "BTCUSDT").Return(nil)
+	decisionStorage.On("GetDecisions", "BTCUSDT").Return([]model.Decision{
+		{StrategyName: model.MarketDepthStrategyName, Score: 30.00, Operation: "BUY", Reason: "bid volume dominates", Params: model.DecisionParams{"bidVolume": 100.00, "askVolume": 5.00}},
+		{StrategyName: model.BaseKlineStrategyName, Score: 25.00, Operation: "BUY", Reason: "positive kline"},
+		{StrategyName: model.OrderBasedStrategyName, Score: 30.00, Operation: "SELL", Reason: "price is above position price"},
+	})
+	exchangeRepository.On("GetTradeLimit", "BTCUSDT").Return(model.TradeLimit{
+		Symbol:               "BTCUSDT",
+		MinStrategyAgreement: 2,
+		Strategies: model.StrategyOptions{
+			{Name: model.MarketDepthStrategyName, IsEnabled: true, Weight: 2},
+		},
+	}, nil)
+	exchangeRepository.On("GetCurrentKline", "BTCUSDT").Return(&model.KLine{
+		Symbol:    "BTCUSDT",
+		Close:     100.00,
+		UpdatedAt: time.Now().Unix(),
+	})
+
+	result, err := strategyFacade.Decide("BTCUSDT")
+	assertion.Nil(err)
+	assertion.Equal(85.00, result.Buy)
+	// only one strategy votes for SELL
+	assertion.Equal(0.00, result.Sell)
+	assertion.Equal(0.00, result.Hold)
+
+	assertion.Equal(int64(2), result.Explanation.MinAgreement)
+	assertion.Equal(int64(2), result.Explanation.BuyVotes)
+	assertion.Equal(int64(1), result.Explanation.SellVotes)
+	assertion.Equal(85.00, result.Explanation.Buy)
+	assertion.Len(result.Explanation.Strategies, 3)
+	assertion.Equal(model.MarketDepthStrategyName, result.Explanation.Strategies[0].StrategyName)
+	assertion.Equal(60.00, result.Explanation.Strategies[0].Score)
+	assertion.Equal(2.00, result.Explanation.Strategies[0].Weight)
+	assertion.Equal("bid volume dominates", result.Explanation.Strategies[0].Reason)
+	assertion.Equal(100.00, result.Explanation.Strategies[0].Params["bidVolume"])
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/strategy"
+	"testing"
+	"time"
+)
+
+type WrongInputStrategy struct {
+	strategy.MarketDepthStrategy
+}
+
+func (w *WrongInputStrategy) GetName() string {
+	return "wrong_input_strategy"
+}
+
+func (w *WrongInputStrategy) GetInput() string {
+	return model.StrategyInputKLine
+}
+
+func TestStrategyRegistryRegister(t *testing.T) {
+	assertion := assert.New(t)
+
+	registry := exchange.StrategyRegistry{}
+	assertion.Nil(registry.Register(&strategy.MarketDepthStrategy{}))
+	assertion.Nil(registry.Register(&strategy.BaseKLineStrategy{}))
+	assertion.ErrorContains(registry.Register(&strategy.MarketDepthStrategy{}), "Strategy market_depth_strategy is already registered")
+	assertion.ErrorContains(registry.Register(&WrongInputStrategy{}), "Strategy wrong_input_strategy can't consume kline input")
+
+	assertion.True(registry.Has(model.MarketDepthStrategyName))
+	assertion.False(registry.Has("wrong_input_strategy"))
+	assertion.Equal([]string{model.MarketDepthStrategyName, model.BaseKlineStrategyName}, registry.GetEnabledNames(nil))
+	assertion.Equal([]string{model.BaseKlineStrategyName}, registry.GetEnabledNames(&model.TradeLimit{
+		Strategies: model.StrategyOptions{
+			{Name: model.MarketDepthStrategyName, IsEnabled: false},
+		},
+	}))
+
+	kLineDecisions := registry.DecideKLine(model.KLine{Symbol: "BTCUSDT", Open: 100, Close: 101, High: 103, Low: 99})
+	assertion.Len(kLineDecisions, 1)
+	assertion.Equal(model.BaseKlineStrategyName, kLineDecisions[0].StrategyName)
+	assertion.Len(registry.DecideTrade(model.Trade{}), 0)
+}
+
+func TestStrategyFacadeWeightsAndDisabledStrategies(t *testing.T) {
+	assertion := assert.New(t)
+
+	exchangeRepository := new(ExchangeTradeInfoMock)
+	decisionStorage := new(DecisionReadStorageMock)
+	orderStorage := new(OrderStorageMock)
+	botService := new(BotServiceMock)
+	registry := exchange.StrategyRegistry{}
+	_ = registry.Register(&strategy.MarketDepthStrategy{})
+	_ = registry.Register(&strategy.BaseKLineStrategy{})
+	_ = registry.Register(&strategy.OrderBasedStrategy{})
+
+	strategyFacade := exchange.StrategyFacade{
+		DecisionReadStorage: decisionStorage,
+		ExchangeRepository:  exchangeRepository,
+		OrderRepository:     orderStorage,
+		BotService:          botService,
+		StrategyRegistry:    &registry,
+		MinDecisions:        3.00,
+	}
+
+	orderStorage.On("GetManualOrder", "BTCUSDT").Return(nil)
+	decisionStorage.On("GetDecisions", "BTCUSDT").Return([]model.Decision{
+		{StrategyName: model.MarketDepthStrategyName, Score: 20.00, Operation: "BUY"},
+		{StrategyName: model.BaseKlineStrategyName, Score: 50.00, Operation: "SELL"},
+		{StrategyName: model.OrderBasedStrategyName, Score: 30.00, Operation: "HOLD"},
+		{StrategyName: "unknown_strategy", Score: 100.00, Operation: "BUY"},
+	})
+	exchangeRepository.On("GetTradeLimit", "BTCUSDT").Return(model.TradeLimit{
+		Symbol: "BTCUSDT",
+		Strategies: model.StrategyOptions{
+			{Name: model.MarketDepthStrategyName, IsEnabled: true, Weight: 2.5},
+			{Name: model.BaseKlineStrategyName, IsEnabled: false},
+		},
+	}, nil)
+	exchangeRepository.On("GetCurrentKline", "BTCUSDT").Return(&model.KLine{
+		Symbol:    "BTCUSDT",
+		Close:     100.00,
+		UpdatedAt: time.Now().Unix(),
+	})
+
+	result, err := strategyFacade.Decide("BTCUSDT")
+	assertion.Nil(err)
+	assertion.Equal(50.00, result.Buy)
+	assertion.Equal(0.00, result.Sell)
+	assertion.Equal(30.00, result.Hold)
+}
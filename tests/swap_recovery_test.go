@@ -0,0 +1,252 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"testing"
+	"time"
+)
+
+func getStuckSwapAction() model.SwapAction {
+	oneId := "1001"
+	oneStatus := "FILLED"
+	twoId := "1002"
+	twoStatus := "NEW"
+	oneTimestamp := int64(1000)
+	twoTimestamp := int64(1100)
+
+	return model.SwapAction{
+		Id:                    15,
+		OrderId:               99,
+		SwapChainId:           3,
+		Asset:                 "ETH",
+		Status:                model.SwapActionStatusProcess,
+		StartTimestamp:        900,
+		StartQuantity:         1.00,
+		SwapOneSymbol:         "ETHBTC",
+		SwapOneExternalId:     &oneId,
+		SwapOneExternalStatus: &oneStatus,
+		SwapOneTimestamp:      &oneTimestamp,
+		SwapTwoSymbol:         "XRPBTC",
+		SwapTwoExternalId:     &twoId,
+		SwapTwoExternalStatus: &twoStatus,
+		SwapTwoTimestamp:      &twoTimestamp,
+		SwapThreeSymbol:       "XRPETH",
+	}
+}
+
+func TestSwapActionUnfinishedLeg(t *testing.T) {
+	assertion := assert.New(t)
+
+	action := getStuckSwapAction()
+	assertion.Equal(model.SwapLegTwo, action.GetUnfinishedLeg())
+	assertion.Equal("BTC", action.GetLegAsset(action.GetUnfinishedLeg()))
+	assertion.Equal(int64(1100), action.GetLastActivityTimestamp())
+
+	action.UpdateLegOrder(model.SwapLegTwo, model.ExchangeOrder{Status: model.ExchangeOrderStatusFilled, ExecutedQty: 500, Price: 0.00001})
+	assertion.Equal(model.SwapLegThree, action.GetUnfinishedLeg())
+	assertion.Equal("XRP", action.GetLegAsset(action.GetUnfinishedLeg()))
+	assertion.Equal(500.00, *action.SwapTwoQuantity)
+	assertion.Equal(int64(1100), action.GetLastActivityTimestamp())
+}
+
+func TestSwapRecoveryStuckListClearsCanceledLeg(t *testing.T) {
+	assertion := assert.New(t)
+
+	swapRepository := new(SwapRecoveryRepositoryMock)
+	binance := new(ExchangeOrderAPIMock)
+	balanceService := new(BalanceServiceMock)
+	timeService := new(TimeServiceMock)
+
+	action := getStuckSwapAction()
+	swapRepository.On("GetProcessingSwapActions").Return([]model.SwapAction{action})
+	swapRepository.On("UpdateSwapAction", mock.Anything).Return(nil)
+	timeService.On("GetNowDiffMinutes", mock.Anything).Return(45.00)
+	timeService.On("GetNowUnix").Return(2000)
+	balanceService.On("InvalidateBalanceCache", "BTC").Return()
+	binance.On("QueryOrder", "ETHBTC", "1001").Return(model.ExchangeOrder{
+		OrderId:     "1001",
+		Symbol:      "ETHBTC",
+		Status:      model.ExchangeOrderStatusFilled,
+		ExecutedQty: 1.00,
+		Price:       0.05,
+	}, nil)
+	binance.On("QueryOrder", "XRPBTC", "1002").Return(model.ExchangeOrder{
+		OrderId: "1002",
+		Symbol:  "XRPBTC",
+		Status:  model.ExchangeOrderStatusExpired,
+	}, nil)
+
+	recovery := exchange.SwapRecoveryService{
+		SwapRepository: swapRepository,
+		SwapExecutor:   &exchange.SwapExecutor{},
+		Binance:        binance,
+		BalanceService: balanceService,
+		TimeService:    timeService,
+		StuckMinutes:   exchange.SwapRecoveryStuckMinutes,
+	}
+
+	list := recovery.GetStuckList()
+	assertion.Len(list, 1)
+	assertion.Equal(model.SwapLegTwo, list[0].Leg)
+	assertion.Equal("BTC", list[0].Asset)
+	assertion.False(list[0].Processing)
+	assertion.Equal([]string{model.SwapRecoveryActionRetry, model.SwapRecoveryActionRollback}, list[0].AllowedActions)
+	assertion.Nil(swapRepository.swapAction.SwapTwoExternalId)
+	assertion.Len(swapRepository.decisions, 1)
+	assertion.Equal(model.SwapRecoveryActionReconcile, swapRepository.decisions[0].Action)
+	assertion.False(swapRepository.decisions[0].Manual)
+}
+
+func TestSwapRecoveryRollbackSwapOne(t *testing.T) {
+	assertion := assert.New(t)
+
+	swapRepository := new(SwapRecoveryRepositoryMock)
+	orderRepository := new(OrderStorageMock)
+	binance := new(ExchangeOrderAPIMock)
+	balanceService := new(BalanceServiceMock)
+	timeService := new(TimeServiceMock)
+
+	oneId := "1001"
+	oneStatus := "NEW"
+	action := model.SwapAction{
+		Id:                    16,
+		OrderId:               99,
+		Asset:                 "ETH",
+		Status:                model.SwapActionStatusProcess,
+		StartQuantity:         1.00,
+		SwapOneSymbol:         "ETHBTC",
+		SwapOneExternalId:     &oneId,
+		SwapOneExternalStatus: &oneStatus,
+		SwapTwoSymbol:         "XRPBTC",
+		SwapThreeSymbol:       "XRPETH",
+	}
+
+	newOrder := model.ExchangeOrder{OrderId: "1001", Symbol: "ETHBTC", Status: model.ExchangeOrderStatusNew, OrigQty: 1.00}
+	swapRepository.On("GetSwapActionById", int64(16)).Return(action, nil)
+	swapRepository.On("UpdateSwapAction", mock.Anything).Return(nil)
+	binance.On("QueryOrder", "ETHBTC", "1001").Return(newOrder, nil)
+	binance.On("CancelOrder", "ETHBTC", "1001").Return(model.ExchangeOrder{OrderId: "1001", Status: model.ExchangeOrderStatusCanceled}, nil)
+	orderRepository.On("Find", int64(99)).Return(model.Order{Id: 99, Swap: true}, nil)
+	orderRepository.On("Update", mock.Anything).Return(nil)
+	balanceService.On("InvalidateBalanceCache", mock.Anything).Return()
+	timeService.On("GetNowUnix").Return(2000)
+	timeService.On("GetNowDiffMinutes", mock.Anything).Return(45.00)
+
+	swapExecutor := &exchange.SwapExecutor{}
+	recovery := exchange.SwapRecoveryService{
+		SwapRepository:  swapRepository,
+		OrderRepository: orderRepository,
+		SwapExecutor:    swapExecutor,
+		Binance:         binance,
+		BalanceService:  balanceService,
+		TimeService:     timeService,
+		StuckMinutes:    exchange.SwapRecoveryStuckMinutes,
+	}
+
+	// swap executor is processing the action
+	assertion.True(swapExecutor.TryLockAction(16))
+	_, err := recovery.Rollback(16)
+	assertion.NotNil(err)
+	binance.AssertNotCalled(t, "CancelOrder", "ETHBTC", "1001")
+	swapExecutor.UnlockAction(16)
+
+	state, err := recovery.Rollback(16)
+	assertion.Nil(err)
+	assertion.Equal(model.SwapActionStatusCanceled, state.SwapAction.Status)
+	assertion.Equal(1.00, *state.SwapAction.EndQuantity)
+	assertion.Equal("CANCELED", *state.SwapAction.SwapOneExternalStatus)
+	assertion.False(orderRepository.Updated.Swap)
+	assertion.False(swapExecutor.IsActionLocked(16))
+	assertion.Len(swapRepository.decisions, 1)
+	assertion.Equal(model.SwapRecoveryActionRollback, swapRepository.decisions[0].Action)
+	assertion.True(swapRepository.decisions[0].Success)
+}
+
+func TestSwapRecoveryForceCompletesFilledSwap(t *testing.T) {
+	assertion := assert.New(t)
+
+	swapRepository := new(SwapRecoveryRepositoryMock)
+	orderRepository := new(OrderStorageMock)
+	binance := new(ExchangeOrderAPIMock)
+	balanceService := new(BalanceServiceMock)
+	timeService := new(TimeServiceMock)
+
+	action := getStuckSwapAction()
+	twoStatus := "FILLED"
+	threeId := "1003"
+	threeStatus := "NEW"
+	action.SwapTwoExternalStatus = &twoStatus
+	action.SwapThreeExternalId = &threeId
+	action.SwapThreeExternalStatus = &threeStatus
+
+	swapRepository.On("GetSwapActionById", int64(15)).Return(action, nil)
+	swapRepository.On("UpdateSwapAction", mock.Anything).Return(nil)
+	swapRepository.On("GetSwapChainById", int64(3)).Return(model.SwapChainEntity{Id: 3, Type: model.SwapTransitionTypeSellSellBuy}, nil)
+	binance.On("QueryOrder", "ETHBTC", "1001").Return(model.ExchangeOrder{OrderId: "1001", Status: model.ExchangeOrderStatusFilled, ExecutedQty: 1.00}, nil)
+	binance.On("QueryOrder", "XRPBTC", "1002").Return(model.ExchangeOrder{OrderId: "1002", Status: model.ExchangeOrderStatusFilled, ExecutedQty: 500}, nil)
+	binance.On("QueryOrder", "XRPETH", "1003").Return(model.ExchangeOrder{OrderId: "1003", Status: model.ExchangeOrderStatusFilled, ExecutedQty: 1.01}, nil)
+	orderRepository.On("Find", int64(99)).Return(model.Order{Id: 99, Swap: true}, nil)
+	orderRepository.On("Update", mock.Anything).Return(nil)
+	balanceService.On("InvalidateBalanceCache", mock.Anything).Return()
+	timeService.On("GetNowUnix").Return(2000)
+	timeService.On("GetNowDiffMinutes", mock.Anything).Return(45.00)
+
+	recovery := exchange.SwapRecoveryService{
+		SwapRepository:  swapRepository,
+		OrderRepository: orderRepository,
+		SwapExecutor:    &exchange.SwapExecutor{},
+		Binance:         binance,
+		BalanceService:  balanceService,
+		TimeService:     timeService,
+		StuckMinutes:    exchange.SwapRecoveryStuckMinutes,
+	}
+
+	// funds are not in the source asset anymore
+	_, err := recovery.Rollback(15)
+	assertion.NotNil(err)
+
+	state, err := recovery.Force(15)
+	assertion.Nil(err)
+	assertion.Equal(model.SwapLegFinished, state.Leg)
+	assertion.Equal(model.SwapActionStatusSuccess, state.SwapAction.Status)
+	assertion.Equal(1.01, *state.SwapAction.EndQuantity)
+	assertion.False(orderRepository.Updated.Swap)
+	assertion.Empty(state.AllowedActions)
+}
+
+func TestSwapRecoveryRefusedOnShutdown(t *testing.T) {
+	assertion := assert.New(t)
+
+	swapRepository := new(SwapRecoveryRepositoryMock)
+	binance := new(ExchangeOrderAPIMock)
+	swapRepository.On("GetSwapActionById", int64(15)).Return(getStuckSwapAction(), nil)
+
+	shutdownManager := service.ShutdownManager{}
+	swapExecutor := &exchange.SwapExecutor{}
+	recovery := exchange.SwapRecoveryService{
+		SwapRepository:  swapRepository,
+		OrderRepository: new(OrderStorageMock),
+		SwapExecutor:    swapExecutor,
+		Binance:         binance,
+		BalanceService:  new(BalanceServiceMock),
+		TimeService:     new(TimeServiceMock),
+		StuckMinutes:    exchange.SwapRecoveryStuckMinutes,
+		ShutdownManager: &shutdownManager,
+	}
+	assertion.True(shutdownManager.Shutdown(time.Second))
+
+	_, err := recovery.Retry(15)
+	assertion.NotNil(err)
+	_, err = recovery.Force(15)
+	assertion.NotNil(err)
+	_, err = recovery.Rollback(15)
+	assertion.NotNil(err)
+
+	assertion.False(swapExecutor.IsActionLocked(15))
+	binance.AssertNotCalled(t, "QueryOrder", mock.Anything, mock.Anything)
+	binance.AssertNotCalled(t, "CancelOrder", mock.Anything, mock.Anything)
+}
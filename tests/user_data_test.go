@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/open-soft/go-crypto-bot/src/model"
+	"gitlab.com/open-soft/go-crypto-bot/src/service/exchange"
+	"os"
+	"testing"
+)
+
+func TestBinanceExecutionReportToExchangeOrder(t *testing.T) {
+	assertion := assert.New(t)
+
+	content, _ := os.ReadFile("example/binance_execution_report.json")
+	var report model.BinanceExecutionReport
+	assertion.Nil(json.Unmarshal(content, &report))
+
+	order, err := report.ToExchangeOrder()
+	assertion.Nil(err)
+	assertion.Equal("4293153", order.OrderId)
+	assertion.Equal("ETHBTC", order.Symbol)
+	assertion.Equal(model.ExchangeOrderStatusPartiallyFilled, order.Status)
+	assertion.Equal(0.60, order.ExecutedQty)
+	assertion.Equal(1.00, order.OrigQty)
+	assertion.Equal(0.10264410, order.Price)
+	assertion.Equal(0.06158640, order.CummulativeQuoteQty)
+	assertion.Equal("", order.OrderListId)
+	assertion.Equal(0.40, report.LastExecutedQty)
+}
+
+func TestByBitWsWalletToBalances(t *testing.T) {
+	assertion := assert.New(t)
+
+	content, _ := os.ReadFile("example/bybit_ws_wallet.json")
+	var event model.ByBitWsWalletEvent
+	assertion.Nil(json.Unmarshal(content, &event))
+	assertion.Len(event.Data, 1)
+	assertion.Equal(model.ByBitAccountTypeUnified, event.Data[0].AccountType)
+
+	btc := event.Data[0].Coin[0].ToBalance()
+	assertion.Equal("BTC", btc.Asset)
+	assertion.InDelta(0.001, btc.Free, 0.00000001)
+	assertion.Equal(0.00002964, btc.Locked)
+
+	usdt := event.Data[0].Coin[1].ToBalance()
+	assertion.Equal(9300.50, usdt.Free)
+	assertion.Equal(47.37, usdt.Locked)
+}
+
+func TestUserDataProcessorOrder(t *testing.T) {
+	assertion := assert.New(t)
+
+	tracked := model.ExchangeOrder{OrderId: "100", Symbol: "ETHUSDT", Side: "BUY", Status: model.ExchangeOrderStatusNew, OrigQty: 1.00}
+	partial := model.ExchangeOrder{OrderId: "100", Symbol: "ETHUSDT", Side: "BUY", Status: model.ExchangeOrderStatusPartiallyFilled, OrigQty: 1.00, ExecutedQty: 0.50}
+	filled := model.ExchangeOrder{OrderId: "100", Symbol: "ETHUSDT", Side: "BUY", Status: model.ExchangeOrderStatusFilled, OrigQty: 1.00, ExecutedQty: 1.00}
+	foreign := model.ExchangeOrder{OrderId: "200", Symbol: "ETHUSDT", Side: "BUY", Status: model.ExchangeOrderStatusFilled, OrigQty: 2.00, ExecutedQty: 2.00}
+
+	orderRepository := new(OrderStorageMock)
+	orderRepository.On("GetExchangeOrder", "ETHUSDT", "BUY").Return(&tracked)
+	orderRepository.On("SetExchangeOrder", filled).Return()
+
+	processor := exchange.UserDataProcessor{OrderRepository: orderRepository}
+	assertion.False(processor.IsConnected())
+	processor.SetConnected(true)
+	assertion.True(processor.IsConnected())
+
+	processor.ProcessOrder(filled)
+	// stale update is ignored
+	processor.ProcessOrder(partial)
+	// orders which are not tracked by the bot are not saved
+	processor.ProcessOrder(foreign)
+
+	orderRepository.AssertNumberOfCalls(t, "SetExchangeOrder", 1)
+	assertion.Equal(filled, *processor.GetOrderUpdate("100"))
+	assertion.Equal(foreign, *processor.GetOrderUpdate("200"))
+	assertion.Nil(processor.GetOrderUpdate("300"))
+}
+
+func TestUserDataProcessorBalances(t *testing.T) {
+	balanceService := new(BalanceUpdaterMock)
+	balances := []model.Balance{{Asset: "USDT", Free: 100.00, Locked: 5.00}}
+	balanceService.On("UpdateBalances", balances).Return()
+
+	processor := exchange.UserDataProcessor{BalanceService: balanceService}
+	processor.ProcessBalances(balances)
+	processor.ProcessBalances([]model.Balance{})
+
+	balanceService.AssertNumberOfCalls(t, "UpdateBalances", 1)
+}